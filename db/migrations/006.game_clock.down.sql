@@ -0,0 +1,7 @@
+DROP TABLE game_move;
+DROP TABLE game;
+
+ALTER TABLE game_session
+    DROP COLUMN time_control_base_seconds,
+    DROP COLUMN time_control_increment_seconds,
+    DROP COLUMN time_control_delay;
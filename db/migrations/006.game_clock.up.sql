@@ -0,0 +1,42 @@
+ALTER TABLE game_session
+    ADD COLUMN time_control_base_seconds integer NOT NULL DEFAULT 0,
+    ADD COLUMN time_control_increment_seconds integer NOT NULL DEFAULT 0,
+    ADD COLUMN time_control_delay text NOT NULL DEFAULT 'fischer';
+
+CREATE TABLE game (
+       id uuid PRIMARY KEY NOT NULL,
+       session_id text NOT NULL,
+       white_id uuid NOT NULL,
+       black_id uuid NOT NULL,
+       initial_fen text NOT NULL,
+       fen text NOT NULL,
+       ply integer NOT NULL DEFAULT 0,
+       status text NOT NULL,
+       result text NOT NULL,
+       termination text NOT NULL,
+       time_control_base_seconds integer NOT NULL,
+       time_control_increment_seconds integer NOT NULL,
+       time_control_delay text NOT NULL,
+       white_remaining_ms bigint NOT NULL,
+       black_remaining_ms bigint NOT NULL,
+       turn_started_at timestamptz NOT NULL,
+       flag_at timestamptz,
+       created_at timestamptz NOT NULL,
+       ended_at timestamptz,
+
+       CONSTRAINT fk_game_session FOREIGN KEY (session_id) REFERENCES game_session(id)
+);
+
+CREATE INDEX ix_game_flag_at ON game (flag_at) WHERE status = 'started';
+
+CREATE TABLE game_move (
+       game_id uuid NOT NULL,
+       ply integer NOT NULL,
+       uci text NOT NULL,
+       san text NOT NULL,
+       remaining_ms bigint,
+       played_at timestamptz NOT NULL,
+
+       PRIMARY KEY (game_id, ply),
+       CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game(id)
+);
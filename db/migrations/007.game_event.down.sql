@@ -0,0 +1,3 @@
+DROP TABLE game_event;
+
+ALTER TABLE game DROP COLUMN version;
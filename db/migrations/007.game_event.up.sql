@@ -0,0 +1,12 @@
+ALTER TABLE game ADD COLUMN version integer NOT NULL DEFAULT 0;
+
+CREATE TABLE game_event (
+       game_id uuid NOT NULL,
+       version integer NOT NULL,
+       type text NOT NULL,
+       payload jsonb NOT NULL,
+       created_at timestamptz NOT NULL,
+
+       PRIMARY KEY (game_id, version),
+       CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game(id)
+);
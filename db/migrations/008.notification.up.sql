@@ -0,0 +1,9 @@
+CREATE TABLE notification (
+       id bigserial PRIMARY KEY,
+       user_id uuid NULL,
+       type text NOT NULL,
+       payload jsonb NOT NULL,
+       created_at timestamptz NOT NULL
+);
+
+CREATE INDEX ix_notification_user_id ON notification (user_id, id);
@@ -0,0 +1 @@
+DROP TABLE pubsub_message;
@@ -0,0 +1,8 @@
+CREATE TABLE pubsub_message (
+       id bigserial PRIMARY KEY,
+       topic text NOT NULL,
+       payload jsonb NOT NULL,
+       created_at timestamptz NOT NULL
+);
+
+CREATE INDEX ix_pubsub_message_created_at ON pubsub_message (created_at);
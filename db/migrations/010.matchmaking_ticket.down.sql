@@ -0,0 +1 @@
+DROP TABLE matchmaking_ticket;
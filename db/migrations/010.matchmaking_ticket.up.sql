@@ -0,0 +1,17 @@
+CREATE TABLE matchmaking_ticket (
+       id uuid PRIMARY KEY NOT NULL,
+       player_id uuid NOT NULL UNIQUE,
+       rating integer NOT NULL,
+       rating_range integer NOT NULL,
+       time_control_base_seconds integer NOT NULL,
+       time_control_increment_seconds integer NOT NULL,
+       time_control_delay text NOT NULL,
+       status text NOT NULL,
+       session_id text,
+       created_at timestamptz NOT NULL,
+       matched_at timestamptz,
+
+       CONSTRAINT fk_game_session FOREIGN KEY (session_id) REFERENCES game_session(id)
+);
+
+CREATE INDEX ix_matchmaking_ticket_waiting ON matchmaking_ticket (created_at) WHERE status = 'waiting';
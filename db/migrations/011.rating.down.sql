@@ -0,0 +1,6 @@
+DROP TABLE rating_history;
+DROP TABLE player_rating;
+
+ALTER TABLE matchmaking_ticket DROP COLUMN rated;
+ALTER TABLE game DROP COLUMN rated;
+ALTER TABLE game_session DROP COLUMN rated;
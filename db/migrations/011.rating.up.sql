@@ -0,0 +1,33 @@
+ALTER TABLE game_session ADD COLUMN rated boolean NOT NULL DEFAULT false;
+ALTER TABLE game ADD COLUMN rated boolean NOT NULL DEFAULT false;
+ALTER TABLE matchmaking_ticket ADD COLUMN rated boolean NOT NULL DEFAULT false;
+
+CREATE TABLE player_rating (
+       player_id uuid NOT NULL,
+       category text NOT NULL,
+       rating double precision NOT NULL,
+       deviation double precision NOT NULL,
+       volatility double precision NOT NULL,
+       games integer NOT NULL,
+       last_played_at timestamptz,
+
+       PRIMARY KEY (player_id, category)
+);
+
+CREATE TABLE rating_history (
+       player_id uuid NOT NULL,
+       category text NOT NULL,
+       game_id uuid NOT NULL,
+       opponent_id uuid NOT NULL,
+       score double precision NOT NULL,
+       rating_before double precision NOT NULL,
+       rating_after double precision NOT NULL,
+       deviation_before double precision NOT NULL,
+       deviation_after double precision NOT NULL,
+       played_at timestamptz NOT NULL,
+
+       PRIMARY KEY (player_id, game_id),
+       CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game(id)
+);
+
+CREATE INDEX ix_rating_history_player_category ON rating_history (player_id, category, played_at);
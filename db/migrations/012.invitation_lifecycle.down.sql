@@ -0,0 +1,10 @@
+DROP INDEX ix_session_invitation_expiry;
+DROP INDEX ix_session_invitation_inviter;
+DROP INDEX ix_session_invitation_invitee;
+DROP INDEX ux_session_invitation_pending;
+
+ALTER TABLE session_invitation DROP COLUMN responded_at;
+ALTER TABLE session_invitation DROP COLUMN expires_at;
+ALTER TABLE session_invitation DROP COLUMN status;
+
+ALTER TABLE game_session DROP COLUMN closed;
@@ -0,0 +1,13 @@
+ALTER TABLE game_session ADD COLUMN closed boolean NOT NULL DEFAULT false;
+
+ALTER TABLE session_invitation ADD COLUMN status text NOT NULL DEFAULT 'pending';
+ALTER TABLE session_invitation ADD COLUMN expires_at timestamptz;
+ALTER TABLE session_invitation ADD COLUMN responded_at timestamptz;
+
+UPDATE session_invitation SET expires_at = created_at + interval '1 day';
+ALTER TABLE session_invitation ALTER COLUMN expires_at SET NOT NULL;
+
+CREATE UNIQUE INDEX ux_session_invitation_pending ON session_invitation (session_id, invitee_id) WHERE status = 'pending';
+CREATE INDEX ix_session_invitation_invitee ON session_invitation (invitee_id, created_at);
+CREATE INDEX ix_session_invitation_inviter ON session_invitation (inviter_id, created_at);
+CREATE INDEX ix_session_invitation_expiry ON session_invitation (expires_at) WHERE status = 'pending';
@@ -0,0 +1,6 @@
+DROP TABLE invite_link_redemption;
+DROP TABLE invite_link;
+
+DROP INDEX auth.ix_user_email_lower;
+
+DROP TABLE email_invitation;
@@ -0,0 +1,43 @@
+CREATE TABLE email_invitation (
+       id uuid PRIMARY KEY NOT NULL,
+       session_id text NOT NULL,
+       inviter_id uuid NOT NULL,
+       email text NOT NULL,
+       status text NOT NULL,
+       invitation_id uuid,
+       created_at timestamptz NOT NULL,
+       expires_at timestamptz NOT NULL,
+       sent_at timestamptz,
+       responded_at timestamptz,
+
+       CONSTRAINT fk_game_session FOREIGN KEY (session_id) REFERENCES game_session(id),
+       CONSTRAINT fk_session_invitation FOREIGN KEY (invitation_id) REFERENCES session_invitation(id)
+);
+
+CREATE UNIQUE INDEX ux_email_invitation_pending ON email_invitation (session_id, email) WHERE status = 'pending';
+CREATE INDEX ix_email_invitation_pending_email ON email_invitation (email) WHERE status = 'pending';
+CREATE INDEX ix_email_invitation_unsent ON email_invitation (created_at) WHERE sent_at IS NULL;
+
+CREATE INDEX ix_user_email_lower ON auth.user (lower(email));
+
+CREATE TABLE invite_link (
+       id uuid PRIMARY KEY NOT NULL,
+       session_id text NOT NULL,
+       creator_id uuid NOT NULL,
+       code text NOT NULL UNIQUE,
+       max_uses integer NOT NULL,
+       uses integer NOT NULL DEFAULT 0,
+       created_at timestamptz NOT NULL,
+       expires_at timestamptz NOT NULL,
+
+       CONSTRAINT fk_game_session FOREIGN KEY (session_id) REFERENCES game_session(id)
+);
+
+CREATE TABLE invite_link_redemption (
+       link_id uuid NOT NULL,
+       user_id uuid NOT NULL,
+       redeemed_at timestamptz NOT NULL,
+
+       PRIMARY KEY (link_id, user_id),
+       CONSTRAINT fk_invite_link FOREIGN KEY (link_id) REFERENCES invite_link(id)
+);
@@ -0,0 +1,6 @@
+DROP INDEX ix_game_session_lobby;
+
+ALTER TABLE game_session DROP COLUMN created_at;
+ALTER TABLE game_session DROP COLUMN time_control_category;
+ALTER TABLE game_session DROP COLUMN variant;
+ALTER TABLE game_session DROP COLUMN visibility;
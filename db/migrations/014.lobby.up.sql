@@ -0,0 +1,18 @@
+ALTER TABLE game_session ADD COLUMN visibility text NOT NULL DEFAULT 'public';
+ALTER TABLE game_session ADD COLUMN variant text NOT NULL DEFAULT 'standard';
+ALTER TABLE game_session ADD COLUMN time_control_category text NOT NULL DEFAULT 'untimed';
+ALTER TABLE game_session ADD COLUMN created_at timestamptz NOT NULL DEFAULT now();
+
+UPDATE
+       game_session
+SET
+       time_control_category = CASE
+              WHEN time_control_base_seconds = 0 THEN 'untimed'
+              WHEN time_control_base_seconds + 40 * time_control_increment_seconds < 180 THEN 'bullet'
+              WHEN time_control_base_seconds + 40 * time_control_increment_seconds < 480 THEN 'blitz'
+              WHEN time_control_base_seconds + 40 * time_control_increment_seconds < 1500 THEN 'rapid'
+              ELSE 'classical'
+       END;
+
+CREATE INDEX ix_game_session_lobby ON game_session (created_at, id)
+       WHERE visibility = 'public' AND closed = false AND player_2_id IS NULL;
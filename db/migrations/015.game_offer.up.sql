@@ -0,0 +1,15 @@
+CREATE TABLE game_offer (
+       id uuid PRIMARY KEY NOT NULL,
+       game_id uuid NOT NULL,
+       type text NOT NULL,
+       offered_by uuid NOT NULL,
+       ply integer NOT NULL,
+       status text NOT NULL,
+       created_at timestamptz NOT NULL,
+       responded_at timestamptz,
+
+       CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game(id)
+);
+
+CREATE INDEX ix_game_offer_game ON game_offer (game_id, type);
+CREATE UNIQUE INDEX ux_game_offer_pending ON game_offer (game_id, type) WHERE status = 'pending';
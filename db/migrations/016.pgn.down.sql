@@ -0,0 +1,4 @@
+DROP TABLE imported_game;
+
+DROP INDEX ix_game_black_ended;
+DROP INDEX ix_game_white_ended;
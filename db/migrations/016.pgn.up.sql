@@ -0,0 +1,19 @@
+CREATE INDEX ix_game_white_ended ON game (white_id, ended_at, id) WHERE status = 'ended';
+CREATE INDEX ix_game_black_ended ON game (black_id, ended_at, id) WHERE status = 'ended';
+
+CREATE TABLE imported_game (
+       id uuid PRIMARY KEY NOT NULL,
+       owner_id uuid NOT NULL,
+       event text NOT NULL,
+       white text NOT NULL,
+       black text NOT NULL,
+       result text NOT NULL,
+       initial_fen text NOT NULL,
+       moves text NOT NULL,
+       pgn text NOT NULL,
+       created_at timestamptz NOT NULL,
+
+       CONSTRAINT fk_owner FOREIGN KEY (owner_id) REFERENCES auth.user(id)
+);
+
+CREATE INDEX ix_imported_game_owner ON imported_game (owner_id, created_at);
@@ -0,0 +1 @@
+ALTER TABLE game_session DROP COLUMN initial_fen;
@@ -0,0 +1 @@
+ALTER TABLE game_session ADD COLUMN initial_fen text NOT NULL DEFAULT 'rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1';
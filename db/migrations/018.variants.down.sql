@@ -0,0 +1,2 @@
+ALTER TABLE imported_game DROP COLUMN variant;
+ALTER TABLE game DROP COLUMN variant;
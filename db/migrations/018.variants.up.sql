@@ -0,0 +1,2 @@
+ALTER TABLE game ADD COLUMN variant text NOT NULL DEFAULT 'standard';
+ALTER TABLE imported_game ADD COLUMN variant text NOT NULL DEFAULT 'standard';
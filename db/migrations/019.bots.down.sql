@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bot_move;
+ALTER TABLE game_session DROP COLUMN bot_level;
+DELETE FROM auth.user WHERE id = '00000000-0000-4000-8000-00000000b071';
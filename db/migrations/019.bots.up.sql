@@ -0,0 +1,22 @@
+-- The computer opponent plays as this user. It is locked and its password
+-- hash matches no password, so nobody can log in as it.
+INSERT INTO auth.user (id, security_stamp, username, email, email_confirmed, password_hash, locked)
+VALUES (
+       '00000000-0000-4000-8000-00000000b071',
+       '00000000-0000-4000-8000-00000000b071',
+       'computer',
+       'computer@localhost.invalid',
+       true,
+       '!',
+       true
+);
+
+ALTER TABLE game_session ADD COLUMN bot_level integer NOT NULL DEFAULT 0;
+
+CREATE TABLE bot_move (
+       game_id uuid PRIMARY KEY NOT NULL,
+       ply integer NOT NULL,
+       claimed_until timestamptz NOT NULL,
+
+       CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game(id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS game_spectator;
+ALTER TABLE game_session DROP COLUMN spectator_delay_seconds;
+ALTER TABLE game_session DROP COLUMN spectator_delay_moves;
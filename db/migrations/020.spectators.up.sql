@@ -0,0 +1,13 @@
+ALTER TABLE game_session ADD COLUMN spectator_delay_moves integer NOT NULL DEFAULT 0;
+ALTER TABLE game_session ADD COLUMN spectator_delay_seconds integer NOT NULL DEFAULT 0;
+
+CREATE TABLE game_spectator (
+       session_id text NOT NULL,
+       user_id uuid NOT NULL,
+       last_seen_at timestamptz NOT NULL,
+
+       PRIMARY KEY (session_id, user_id),
+       CONSTRAINT fk_game_session FOREIGN KEY (session_id) REFERENCES game_session(id)
+);
+
+CREATE INDEX ix_game_spectator_last_seen ON game_spectator (session_id, last_seen_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS chat_report;
+DROP TABLE IF EXISTS chat_silence;
+DROP TABLE IF EXISTS chat_mute;
+DROP TABLE IF EXISTS chat_message;
+ALTER TABLE auth.user DROP COLUMN is_admin;
@@ -0,0 +1,52 @@
+ALTER TABLE auth.user ADD COLUMN is_admin boolean NOT NULL DEFAULT false;
+
+CREATE TABLE chat_message (
+       id uuid PRIMARY KEY NOT NULL,
+       session_id text NOT NULL,
+       channel text NOT NULL,
+       sender_id uuid NOT NULL,
+       body text NOT NULL,
+       -- Messages removed by a moderator are kept for the review of their reports.
+       removed boolean NOT NULL DEFAULT false,
+       created_at timestamptz NOT NULL,
+
+       CONSTRAINT fk_game_session FOREIGN KEY (session_id) REFERENCES game_session(id),
+       CONSTRAINT fk_sender FOREIGN KEY (sender_id) REFERENCES auth.user(id)
+);
+
+CREATE INDEX ix_chat_message_channel ON chat_message (session_id, channel, created_at, id);
+CREATE INDEX ix_chat_message_sender ON chat_message (sender_id, created_at);
+
+CREATE TABLE chat_mute (
+       user_id uuid NOT NULL,
+       muted_user_id uuid NOT NULL,
+       created_at timestamptz NOT NULL,
+
+       PRIMARY KEY (user_id, muted_user_id),
+       CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES auth.user(id),
+       CONSTRAINT fk_muted_user FOREIGN KEY (muted_user_id) REFERENCES auth.user(id)
+);
+
+CREATE TABLE chat_silence (
+       user_id uuid PRIMARY KEY NOT NULL,
+       silenced_until timestamptz NOT NULL,
+
+       CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES auth.user(id)
+);
+
+CREATE TABLE chat_report (
+       id uuid PRIMARY KEY NOT NULL,
+       message_id uuid NOT NULL,
+       reporter_id uuid NOT NULL,
+       reason text NOT NULL,
+       status text NOT NULL,
+       created_at timestamptz NOT NULL,
+       reviewed_by uuid,
+       reviewed_at timestamptz,
+
+       UNIQUE (message_id, reporter_id),
+       CONSTRAINT fk_chat_message FOREIGN KEY (message_id) REFERENCES chat_message(id),
+       CONSTRAINT fk_reporter FOREIGN KEY (reporter_id) REFERENCES auth.user(id)
+);
+
+CREATE INDEX ix_chat_report_pending ON chat_report (created_at, id) WHERE status = 'pending';
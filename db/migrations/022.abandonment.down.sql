@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ix_game_session_open_created_at;
+DROP INDEX IF EXISTS ix_game_turn_started_at;
+ALTER TABLE game DROP COLUMN claimable_at;
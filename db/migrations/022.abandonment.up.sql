@@ -0,0 +1,4 @@
+ALTER TABLE game ADD COLUMN claimable_at timestamptz;
+
+CREATE INDEX ix_game_turn_started_at ON game (turn_started_at) WHERE status = 'started';
+CREATE INDEX ix_game_session_open_created_at ON game_session (created_at) WHERE closed = false AND player_2_id IS NULL;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS auth.user_preferences;
+DROP TABLE IF EXISTS move_reminder;
+DROP TABLE IF EXISTS user_vacation;
+DROP INDEX IF EXISTS ix_game_move_due_at;
+ALTER TABLE game DROP COLUMN move_due_at;
+ALTER TABLE game DROP COLUMN time_control_days_per_move;
+ALTER TABLE matchmaking_ticket DROP COLUMN time_control_days_per_move;
+ALTER TABLE game_session DROP COLUMN time_control_days_per_move;
@@ -0,0 +1,32 @@
+ALTER TABLE game_session ADD COLUMN time_control_days_per_move integer NOT NULL DEFAULT 0;
+ALTER TABLE matchmaking_ticket ADD COLUMN time_control_days_per_move integer NOT NULL DEFAULT 0;
+ALTER TABLE game ADD COLUMN time_control_days_per_move integer NOT NULL DEFAULT 0;
+ALTER TABLE game ADD COLUMN move_due_at timestamptz;
+
+CREATE INDEX ix_game_move_due_at ON game (move_due_at) WHERE status = 'started';
+
+CREATE TABLE user_vacation (
+       id uuid PRIMARY KEY,
+       user_id uuid NOT NULL,
+       days integer NOT NULL,
+       starts_at timestamptz NOT NULL,
+       ends_at timestamptz NOT NULL,
+
+       CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES auth.user(id)
+);
+
+CREATE INDEX ix_user_vacation_user ON user_vacation (user_id, ends_at);
+
+CREATE TABLE move_reminder (
+       user_id uuid PRIMARY KEY,
+       sent_at timestamptz NOT NULL,
+
+       CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES auth.user(id)
+);
+
+CREATE TABLE auth.user_preferences (
+       user_id uuid PRIMARY KEY,
+       correspondence_reminders boolean NOT NULL DEFAULT true,
+
+       CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES auth.user (id)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS rematch_offer;
+DROP INDEX IF EXISTS ix_game_session_series;
+ALTER TABLE game_session DROP COLUMN series_id;
+DROP TABLE IF EXISTS game_series;
@@ -0,0 +1,31 @@
+CREATE TABLE game_series (
+       id uuid PRIMARY KEY NOT NULL,
+       player_1_id uuid NOT NULL,
+       player_2_id uuid NOT NULL,
+       created_at timestamptz NOT NULL
+);
+
+ALTER TABLE game_session ADD COLUMN series_id uuid REFERENCES game_series(id);
+
+CREATE INDEX ix_game_session_series ON game_session (series_id) WHERE series_id IS NOT NULL;
+
+CREATE TABLE rematch_offer (
+       id uuid PRIMARY KEY NOT NULL,
+       game_id uuid NOT NULL,
+       session_id text NOT NULL,
+       offered_by uuid NOT NULL,
+       offered_to uuid NOT NULL,
+       status text NOT NULL,
+       expires_at timestamptz NOT NULL,
+       created_at timestamptz NOT NULL,
+       rematch_session_id text,
+       responded_at timestamptz,
+
+       CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game(id),
+       CONSTRAINT fk_session FOREIGN KEY (session_id) REFERENCES game_session(id),
+       CONSTRAINT fk_rematch_session FOREIGN KEY (rematch_session_id) REFERENCES game_session(id)
+);
+
+CREATE INDEX ix_rematch_offer_game ON rematch_offer (game_id);
+CREATE INDEX ix_rematch_offer_pending_expires_at ON rematch_offer (expires_at) WHERE status = 'pending';
+CREATE UNIQUE INDEX ux_rematch_offer_pending ON rematch_offer (game_id) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tournament_pairing;
+DROP TABLE IF EXISTS tournament_player;
+DROP TABLE IF EXISTS tournament;
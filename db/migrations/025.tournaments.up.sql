@@ -0,0 +1,56 @@
+CREATE TABLE tournament (
+       id uuid PRIMARY KEY NOT NULL,
+       organizer_id uuid NOT NULL,
+       name text NOT NULL,
+       format text NOT NULL,
+       status text NOT NULL,
+       rounds integer NOT NULL,
+       current_round integer NOT NULL,
+       max_players integer NOT NULL,
+       rated boolean NOT NULL,
+       time_control_base_seconds integer NOT NULL,
+       time_control_increment_seconds integer NOT NULL,
+       time_control_delay text NOT NULL,
+       time_control_days_per_move integer NOT NULL,
+       time_control_category text NOT NULL,
+       created_at timestamptz NOT NULL,
+       started_at timestamptz,
+       ended_at timestamptz,
+
+       CONSTRAINT fk_organizer FOREIGN KEY (organizer_id) REFERENCES auth.user(id)
+);
+
+CREATE INDEX ix_tournament_status_created_at ON tournament (status, created_at, id);
+
+CREATE TABLE tournament_player (
+       tournament_id uuid NOT NULL,
+       player_id uuid NOT NULL,
+       rating integer NOT NULL,
+       withdrawn boolean NOT NULL,
+       registered_at timestamptz NOT NULL,
+
+       PRIMARY KEY (tournament_id, player_id),
+       CONSTRAINT fk_tournament FOREIGN KEY (tournament_id) REFERENCES tournament(id),
+       CONSTRAINT fk_player FOREIGN KEY (player_id) REFERENCES auth.user(id)
+);
+
+CREATE TABLE tournament_pairing (
+       id uuid PRIMARY KEY NOT NULL,
+       tournament_id uuid NOT NULL,
+       round integer NOT NULL,
+       board integer NOT NULL,
+       white_id uuid NOT NULL,
+       black_id uuid,
+       session_id text,
+       game_id uuid,
+       white_score double precision,
+       black_score double precision,
+       created_at timestamptz NOT NULL,
+
+       CONSTRAINT fk_tournament FOREIGN KEY (tournament_id) REFERENCES tournament(id),
+       CONSTRAINT fk_session FOREIGN KEY (session_id) REFERENCES game_session(id),
+       CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game(id)
+);
+
+CREATE UNIQUE INDEX ux_tournament_pairing_board ON tournament_pairing (tournament_id, round, board);
+CREATE UNIQUE INDEX ux_tournament_pairing_game ON tournament_pairing (game_id) WHERE game_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS arena_game;
+DROP TABLE IF EXISTS arena_player;
+DROP TABLE IF EXISTS arena;
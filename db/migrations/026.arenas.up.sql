@@ -0,0 +1,62 @@
+CREATE TABLE arena (
+       id uuid PRIMARY KEY NOT NULL,
+       organizer_id uuid NOT NULL,
+       name text NOT NULL,
+       status text NOT NULL,
+       rated boolean NOT NULL,
+       berserk boolean NOT NULL,
+       time_control_base_seconds integer NOT NULL,
+       time_control_increment_seconds integer NOT NULL,
+       time_control_delay text NOT NULL,
+       time_control_category text NOT NULL,
+       starts_at timestamptz NOT NULL,
+       ends_at timestamptz NOT NULL,
+       created_at timestamptz NOT NULL,
+       started_at timestamptz,
+       ended_at timestamptz,
+
+       CONSTRAINT fk_organizer FOREIGN KEY (organizer_id) REFERENCES auth.user(id)
+);
+
+CREATE INDEX ix_arena_status_starts_at ON arena (status, starts_at);
+
+CREATE TABLE arena_player (
+       arena_id uuid NOT NULL,
+       player_id uuid NOT NULL,
+       rating integer NOT NULL,
+       points integer NOT NULL,
+       streak integer NOT NULL,
+       games integer NOT NULL,
+       wins integer NOT NULL,
+       draws integer NOT NULL,
+       losses integer NOT NULL,
+       berserks integer NOT NULL,
+       color_balance integer NOT NULL,
+       last_opponent_id uuid,
+       paused boolean NOT NULL,
+       playing boolean NOT NULL,
+       joined_at timestamptz NOT NULL,
+
+       PRIMARY KEY (arena_id, player_id),
+       CONSTRAINT fk_arena FOREIGN KEY (arena_id) REFERENCES arena(id),
+       CONSTRAINT fk_player FOREIGN KEY (player_id) REFERENCES auth.user(id)
+);
+
+CREATE TABLE arena_game (
+       game_id uuid PRIMARY KEY NOT NULL,
+       arena_id uuid NOT NULL,
+       session_id text NOT NULL,
+       white_id uuid NOT NULL,
+       black_id uuid NOT NULL,
+       white_berserk boolean NOT NULL,
+       black_berserk boolean NOT NULL,
+       white_points integer,
+       black_points integer,
+       created_at timestamptz NOT NULL,
+
+       CONSTRAINT fk_arena FOREIGN KEY (arena_id) REFERENCES arena(id),
+       CONSTRAINT fk_session FOREIGN KEY (session_id) REFERENCES game_session(id),
+       CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES game(id)
+);
+
+CREATE INDEX ix_arena_game_arena ON arena_game (arena_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS user_block;
+DROP TABLE IF EXISTS user_follow;
+DROP TABLE IF EXISTS friendship;
+DROP TABLE IF EXISTS friend_request;
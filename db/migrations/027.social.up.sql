@@ -0,0 +1,45 @@
+CREATE TABLE friend_request (
+       id uuid PRIMARY KEY NOT NULL,
+       sender_id uuid NOT NULL,
+       recipient_id uuid NOT NULL,
+       created_at timestamptz NOT NULL,
+
+       CONSTRAINT fk_sender FOREIGN KEY (sender_id) REFERENCES auth.user(id),
+       CONSTRAINT fk_recipient FOREIGN KEY (recipient_id) REFERENCES auth.user(id)
+);
+
+-- Only one request can be pending between two users, whoever sent it.
+CREATE UNIQUE INDEX ux_friend_request_users ON friend_request (LEAST(sender_id, recipient_id), GREATEST(sender_id, recipient_id));
+CREATE INDEX ix_friend_request_recipient ON friend_request (recipient_id);
+
+CREATE TABLE friendship (
+       user_id uuid NOT NULL,
+       friend_id uuid NOT NULL,
+       created_at timestamptz NOT NULL,
+
+       PRIMARY KEY (user_id, friend_id),
+       CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES auth.user(id),
+       CONSTRAINT fk_friend FOREIGN KEY (friend_id) REFERENCES auth.user(id)
+);
+
+CREATE TABLE user_follow (
+       follower_id uuid NOT NULL,
+       followed_id uuid NOT NULL,
+       created_at timestamptz NOT NULL,
+
+       PRIMARY KEY (follower_id, followed_id),
+       CONSTRAINT fk_follower FOREIGN KEY (follower_id) REFERENCES auth.user(id),
+       CONSTRAINT fk_followed FOREIGN KEY (followed_id) REFERENCES auth.user(id)
+);
+
+CREATE TABLE user_block (
+       blocker_id uuid NOT NULL,
+       blocked_id uuid NOT NULL,
+       created_at timestamptz NOT NULL,
+
+       PRIMARY KEY (blocker_id, blocked_id),
+       CONSTRAINT fk_blocker FOREIGN KEY (blocker_id) REFERENCES auth.user(id),
+       CONSTRAINT fk_blocked FOREIGN KEY (blocked_id) REFERENCES auth.user(id)
+);
+
+CREATE INDEX ix_user_block_blocked ON user_block (blocked_id);
@@ -0,0 +1,12 @@
+-- Each instance reports the users it serves, so a user is online as long as one of
+-- the rows of any instance has not expired.
+CREATE TABLE user_presence (
+       user_id uuid NOT NULL,
+       instance_id uuid NOT NULL,
+       expires_at timestamptz NOT NULL,
+
+       PRIMARY KEY (user_id, instance_id),
+       CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES auth.user(id)
+);
+
+CREATE INDEX ix_user_presence_expires_at ON user_presence (expires_at);
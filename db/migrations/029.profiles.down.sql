@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS player_opening_record;
+DROP TABLE IF EXISTS player_streak;
+DROP TABLE IF EXISTS player_record;
+DROP TABLE IF EXISTS player_profile;
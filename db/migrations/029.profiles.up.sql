@@ -0,0 +1,41 @@
+CREATE TABLE player_profile (
+       user_id uuid PRIMARY KEY,
+       display_name text NOT NULL,
+       bio text NOT NULL,
+       country text NOT NULL,
+       avatar_updated_at timestamptz,
+       updated_at timestamptz NOT NULL,
+
+       CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES auth.user(id)
+);
+
+-- The stats are counted up as games end, each row holds the results of
+-- one color in one time control.
+CREATE TABLE player_record (
+       player_id uuid NOT NULL,
+       time_control text NOT NULL,
+       color text NOT NULL,
+       wins integer NOT NULL,
+       draws integer NOT NULL,
+       losses integer NOT NULL,
+
+       PRIMARY KEY (player_id, time_control, color)
+);
+
+CREATE TABLE player_streak (
+       player_id uuid PRIMARY KEY,
+       current_streak integer NOT NULL,
+       longest_streak integer NOT NULL
+);
+
+CREATE TABLE player_opening_record (
+       player_id uuid NOT NULL,
+       color text NOT NULL,
+       eco text NOT NULL,
+       name text NOT NULL,
+       wins integer NOT NULL,
+       draws integer NOT NULL,
+       losses integer NOT NULL,
+
+       PRIMARY KEY (player_id, color, eco, name)
+);
@@ -0,0 +1 @@
+DROP INDEX ix_email_invitation_inviter_created_at;
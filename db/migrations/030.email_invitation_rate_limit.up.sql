@@ -0,0 +1,2 @@
+-- Backs the limit on the email invitations each inviter can send in a window.
+CREATE INDEX ix_email_invitation_inviter_created_at ON email_invitation (inviter_id, created_at);
@@ -0,0 +1 @@
+UPDATE auth.user SET username = 'computer' WHERE id = '00000000-0000-4000-8000-00000000b071';
@@ -0,0 +1,3 @@
+-- Registration rejects usernames containing '@', so no one can take the computer
+-- opponent's username, nor could anyone have registered it before this migration.
+UPDATE auth.user SET username = '@computer' WHERE id = '00000000-0000-4000-8000-00000000b071';
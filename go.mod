@@ -8,6 +8,7 @@ require (
 	github.com/eskrenkovic/migrate-go v0.1.4
 	github.com/eskrenkovic/tql v0.5.0
 	github.com/google/uuid v1.6.0
+	github.com/gorilla/websocket v1.5.3
 	github.com/joho/godotenv v1.4.0
 	github.com/lib/pq v1.10.7
 	github.com/stretchr/testify v1.9.0
@@ -6,6 +6,8 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"strings"
+	"time"
 )
 
 const (
@@ -17,6 +19,30 @@ const (
 	EmailServerUsernameEnv = "EMAIL_SERVER_USERNAME"
 	EmailServerPasswordEnv = "EMAIL_SERVER_PASSWORD"
 	EmailServerSenderEnv   = "EMAIL_SERVER_SENDER"
+
+	UCIEnginePathEnv     = "UCI_ENGINE_PATH"
+	UCIEnginePoolSizeEnv = "UCI_ENGINE_POOL_SIZE"
+
+	ChatBannedWordsEnv            = "CHAT_BANNED_WORDS"
+	ChatRateLimitMessagesEnv      = "CHAT_RATE_LIMIT_MESSAGES"
+	ChatRateLimitWindowSecondsEnv = "CHAT_RATE_LIMIT_WINDOW_SECONDS"
+
+	AbandonmentInactiveSecondsEnv     = "ABANDONMENT_INACTIVE_SECONDS"
+	AbandonmentClaimGraceSecondsEnv   = "ABANDONMENT_CLAIM_GRACE_SECONDS"
+	AbandonmentStaleSessionSecondsEnv = "ABANDONMENT_STALE_SESSION_SECONDS"
+
+	AvatarsPathEnv = "AVATARS_PATH"
+)
+
+const (
+	defaultUCIEnginePoolSize = 2
+
+	defaultChatRateLimitMessages      = 5
+	defaultChatRateLimitWindowSeconds = 10
+
+	defaultAbandonmentInactiveSeconds     = 5 * 60
+	defaultAbandonmentClaimGraceSeconds   = 2 * 60
+	defaultAbandonmentStaleSessionSeconds = 24 * 60 * 60
 )
 
 type EmailConfiguration struct {
@@ -26,6 +52,36 @@ type EmailConfiguration struct {
 	Sender   string
 }
 
+// UCIEngineConfiguration is the external engine games are analysed with. Without
+// a Path no engine is configured and analysis is unavailable.
+type UCIEngineConfiguration struct {
+	Path string
+	// PoolSize is how many engine processes run at most.
+	PoolSize int
+}
+
+// ChatConfiguration moderates the chat. BannedWords are masked in messages and a
+// user can send RateLimitMessages in any RateLimitWindow.
+type ChatConfiguration struct {
+	BannedWords       []string
+	RateLimitMessages int
+	RateLimitWindow   time.Duration
+}
+
+// AbandonmentConfiguration decides when games count as abandoned. The side to move
+// is inactive after InactiveAfter without a move, and the opponent can claim the game
+// ClaimGrace later. Sessions nobody joined are closed after StaleSessionAfter.
+type AbandonmentConfiguration struct {
+	InactiveAfter     time.Duration
+	ClaimGrace        time.Duration
+	StaleSessionAfter time.Duration
+}
+
+// ProfilesConfiguration is where the uploaded avatars are stored.
+type ProfilesConfiguration struct {
+	AvatarsPath string
+}
+
 type Config struct {
 	Logger *slog.Logger
 
@@ -34,6 +90,14 @@ type Config struct {
 	MigrationsPath string
 
 	Email EmailConfiguration
+
+	UCIEngine UCIEngineConfiguration
+
+	Chat ChatConfiguration
+
+	Abandonment AbandonmentConfiguration
+
+	Profiles ProfilesConfiguration
 }
 
 func Load() (Config, error) {
@@ -51,6 +115,20 @@ func Load() (Config, error) {
 
 	migrationsPath := path.Join(rootPath, "db", "migrations")
 
+	uciEnginePath := env.GetString(UCIEnginePathEnv, "")
+	uciEnginePoolSize := env.GetInt(UCIEnginePoolSizeEnv, defaultUCIEnginePoolSize)
+
+	// Banned words are separated by commas.
+	chatBannedWords := strings.Split(env.GetString(ChatBannedWordsEnv, ""), ",")
+	chatRateLimitMessages := env.GetInt(ChatRateLimitMessagesEnv, defaultChatRateLimitMessages)
+	chatRateLimitWindowSeconds := env.GetInt(ChatRateLimitWindowSecondsEnv, defaultChatRateLimitWindowSeconds)
+
+	abandonmentInactiveSeconds := env.GetInt(AbandonmentInactiveSecondsEnv, defaultAbandonmentInactiveSeconds)
+	abandonmentClaimGraceSeconds := env.GetInt(AbandonmentClaimGraceSecondsEnv, defaultAbandonmentClaimGraceSeconds)
+	abandonmentStaleSessionSeconds := env.GetInt(AbandonmentStaleSessionSecondsEnv, defaultAbandonmentStaleSessionSeconds)
+
+	avatarsPath := env.GetString(AvatarsPathEnv, path.Join(rootPath, "data", "avatars"))
+
 	return Config{
 		Logger:         logger,
 		Port:           port,
@@ -62,5 +140,22 @@ func Load() (Config, error) {
 			Password: emailServerPassword,
 			Sender:   emailServerSender,
 		},
+		UCIEngine: UCIEngineConfiguration{
+			Path:     uciEnginePath,
+			PoolSize: uciEnginePoolSize,
+		},
+		Chat: ChatConfiguration{
+			BannedWords:       chatBannedWords,
+			RateLimitMessages: chatRateLimitMessages,
+			RateLimitWindow:   time.Duration(chatRateLimitWindowSeconds) * time.Second,
+		},
+		Abandonment: AbandonmentConfiguration{
+			InactiveAfter:     time.Duration(abandonmentInactiveSeconds) * time.Second,
+			ClaimGrace:        time.Duration(abandonmentClaimGraceSeconds) * time.Second,
+			StaleSessionAfter: time.Duration(abandonmentStaleSessionSeconds) * time.Second,
+		},
+		Profiles: ProfilesConfiguration{
+			AvatarsPath: avatarsPath,
+		},
 	}, nil
 }
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/uci"
+)
+
+// Classification grades a move by how much worse it is than the engine's best move.
+type Classification string
+
+const (
+	Best       Classification = "best"
+	Good       Classification = "good"
+	Inaccuracy Classification = "inaccuracy"
+	Mistake    Classification = "mistake"
+	Blunder    Classification = "blunder"
+)
+
+// The least centipawns a move has to lose to be graded as an inaccuracy, a mistake or a blunder.
+const (
+	InaccuracyLoss = 50
+	MistakeLoss    = 100
+	BlunderLoss    = 300
+)
+
+// evaluationCap bounds the evaluations a move's loss is measured with. Beyond it a
+// position is won anyway, so letting a mate in 3 become a mate in 5 or dropping a
+// piece in a winning position is not a blunder.
+const evaluationCap = 1000
+
+// Classify grades the move from the evaluations of the position before and after it,
+// both in centipawns from the moving side's point of view.
+func Classify(best bool, before, after int) Classification {
+	if best {
+		return Best
+	}
+
+	loss := capEvaluation(before) - capEvaluation(after)
+	switch {
+	case loss >= BlunderLoss:
+		return Blunder
+	case loss >= MistakeLoss:
+		return Mistake
+	case loss >= InaccuracyLoss:
+		return Inaccuracy
+	default:
+		return Good
+	}
+}
+
+func capEvaluation(value int) int {
+	return min(max(value, -evaluationCap), evaluationCap)
+}
+
+// Evaluation is an engine's evaluation from white's point of view, in centipawns
+// or, when Mate is set, as a forced mate in Mate moves, negative when black mates.
+type Evaluation struct {
+	Centipawns int
+	Mate       *int
+}
+
+// NewEvaluation turns the score, from the point of view of the side to move, to white's.
+func NewEvaluation(score uci.Score, turn chess.Color) Evaluation {
+	if turn == chess.Black {
+		score = score.Negate()
+	}
+
+	if score.IsMate() {
+		mate := score.Mate
+		return Evaluation{Mate: &mate}
+	}
+
+	return Evaluation{Centipawns: score.Centipawns}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/uci"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Classify(t *testing.T) {
+	tests := []struct {
+		name           string
+		best           bool
+		before         int
+		after          int
+		classification Classification
+	}{
+		{"best move", true, 50, -400, Best},
+		{"small loss", false, 30, 10, Good},
+		{"inaccuracy", false, 30, -20, Inaccuracy},
+		{"mistake", false, 30, -100, Mistake},
+		{"blunder", false, 30, -300, Blunder},
+		{"walking into mate", false, -20, -uci.Score{Mate: 1}.Value(), Blunder},
+		{"slower mate when winning", false, uci.Score{Mate: 3}.Value(), uci.Score{Mate: 5}.Value(), Good},
+		{"dropping material when winning", false, 1500, 1100, Good},
+		{"missing a mate", false, uci.Score{Mate: 2}.Value(), 600, Blunder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.classification, Classify(tt.best, tt.before, tt.after))
+		})
+	}
+}
+
+func Test_NewEvaluation_Is_From_Whites_Point_Of_View(t *testing.T) {
+	require.Equal(t, Evaluation{Centipawns: 40}, NewEvaluation(uci.Score{Centipawns: 40}, chess.White))
+	require.Equal(t, Evaluation{Centipawns: -40}, NewEvaluation(uci.Score{Centipawns: 40}, chess.Black))
+
+	mate := -2
+	require.Equal(t, Evaluation{Mate: &mate}, NewEvaluation(uci.Score{Mate: 2}, chess.Black))
+}
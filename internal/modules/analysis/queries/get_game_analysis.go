@@ -0,0 +1,332 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/analysis/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/uci"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultAnalysisDepth = 12
+	MaxAnalysisDepth     = 30
+	MaxAnalysisLines     = 5
+
+	// analysisMoveTime bounds the search of every position, whatever the depth.
+	analysisMoveTime = 2 * time.Second
+)
+
+// GetGameAnalysisQuery has the configured UCI engine annotate every move of a finished
+// game. Games of private sessions are only analysed for their players.
+type GetGameAnalysisQuery struct {
+	GameID uuid.UUID
+	UserID uuid.UUID
+	// Depth is how deep the engine searches each position, DefaultAnalysisDepth when not set.
+	Depth int
+	// Lines is how many of the best lines are reported for each position, 1 when not set.
+	Lines int
+}
+
+func (q GetGameAnalysisQuery) Validate() error {
+	if q.GameID == uuid.Nil {
+		return fmt.Errorf("invalid GameID - '%s'", q.GameID)
+	}
+
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	if q.Depth < 0 || q.Depth > MaxAnalysisDepth {
+		return fmt.Errorf("invalid Depth - '%d', expected 1 to %d", q.Depth, MaxAnalysisDepth)
+	}
+
+	if q.Lines < 0 || q.Lines > MaxAnalysisLines {
+		return fmt.Errorf("invalid Lines - '%d', expected 1 to %d", q.Lines, MaxAnalysisLines)
+	}
+
+	return nil
+}
+
+func (q GetGameAnalysisQuery) limits() uci.Limits {
+	depth := q.Depth
+	if depth == 0 {
+		depth = DefaultAnalysisDepth
+	}
+
+	return uci.Limits{Depth: depth, MoveTime: analysisMoveTime, MultiPV: max(q.Lines, 1)}
+}
+
+// AnalysisLine is one of the engine's best lines in a position, in SAN.
+type AnalysisLine struct {
+	Eval  domain.Evaluation
+	Moves []string
+}
+
+// MoveAnalysis annotates a move with the evaluation of the position after it and
+// the move the engine would have played instead.
+type MoveAnalysis struct {
+	Ply int
+	SAN string
+	UCI string
+	// Eval is the evaluation after the move, not set when the move ended the game.
+	Eval *domain.Evaluation
+	// BestMove and BestLines are the engine's choices in the position before the move.
+	BestMove       string
+	BestLines      []AnalysisLine
+	Classification domain.Classification
+}
+
+type GetGameAnalysisResponse struct {
+	GameID uuid.UUID
+	Depth  int
+	Moves  []MoveAnalysis
+}
+
+func HandleGetGameAnalysis(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	query := GetGameAnalysisQuery{GameID: gameID, UserID: core.Session(ctx).UserID}
+
+	if depth := r.URL.Query().Get("depth"); depth != "" {
+		if query.Depth, err = strconv.Atoi(depth); err != nil {
+			core.WriteBadRequest(w, r, fmt.Errorf("invalid format for query param 'depth'"))
+			return
+		}
+	}
+
+	if lines := r.URL.Query().Get("lines"); lines != "" {
+		if query.Lines, err = strconv.Atoi(lines); err != nil {
+			core.WriteBadRequest(w, r, fmt.Errorf("invalid format for query param 'lines'"))
+			return
+		}
+	}
+
+	response, err := mediator.Send[GetGameAnalysisQuery, GetGameAnalysisResponse](ctx, query)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetGameAnalysisQueryHandler struct {
+	db      *sql.DB
+	engines *uci.Pool
+}
+
+func NewGetGameAnalysisQueryHandler(db *sql.DB, engines *uci.Pool) *GetGameAnalysisQueryHandler {
+	return &GetGameAnalysisQueryHandler{db, engines}
+}
+
+func (h *GetGameAnalysisQueryHandler) Handle(
+	ctx context.Context,
+	request GetGameAnalysisQuery,
+) (GetGameAnalysisResponse, error) {
+	if !h.engines.Configured() {
+		return GetGameAnalysisResponse{}, core.NewCommandError(503, uci.ErrNotConfigured)
+	}
+
+	const query = `
+		SELECT
+			g.*
+		FROM
+			game g
+			JOIN game_session s ON s.id = g.session_id
+		WHERE
+			g.id = $1 AND (s.visibility = $2 OR g.white_id = $3 OR g.black_id = $3);`
+	game, err := tql.QueryFirst[gamesessiondomain.Game](
+		ctx,
+		h.db,
+		query,
+		request.GameID,
+		gamesessiondomain.Public,
+		request.UserID,
+	)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return GetGameAnalysisResponse{}, core.NewCommandError(404, err)
+	case err != nil:
+		return GetGameAnalysisResponse{}, core.NewCommandError(500, err)
+	}
+
+	if !game.IsOver() {
+		return GetGameAnalysisResponse{}, core.NewCommandError(409, gamesessiondomain.ErrGameNotOver)
+	}
+
+	// Engines only speak the variants UCI itself knows.
+	if game.Variant != gamesessiondomain.Standard && game.Variant != gamesessiondomain.Chess960 {
+		return GetGameAnalysisResponse{}, core.NewCommandError(
+			400,
+			fmt.Errorf("games of the variant '%s' cannot be analysed", game.Variant),
+		)
+	}
+
+	const movesQuery = `
+		SELECT
+			*
+		FROM
+			game_move
+		WHERE
+			game_id = $1
+		ORDER BY
+			ply;`
+	moves, err := tql.Query[gamesessiondomain.GameMove](ctx, h.db, movesQuery, game.ID)
+	if err != nil {
+		return GetGameAnalysisResponse{}, core.NewCommandError(500, err)
+	}
+
+	ucis := core.Map(moves, func(m gamesessiondomain.GameMove) string { return m.UCI })
+	positions, err := chess.Replay(game.Variant, game.InitialFEN, ucis)
+	if err != nil {
+		return GetGameAnalysisResponse{}, core.NewCommandError(500, err)
+	}
+
+	analyses, err := h.analyse(ctx, game, positions, ucis, request.limits())
+	if err != nil {
+		return GetGameAnalysisResponse{}, core.NewCommandError(502, err)
+	}
+
+	return GetGameAnalysisResponse{
+		GameID: game.ID,
+		Depth:  request.limits().Depth,
+		Moves:  annotateMoves(moves, positions, analyses),
+	}, nil
+}
+
+// annotateMoves annotates the moves with the analyses of the positions before and after them.
+func annotateMoves(
+	moves []gamesessiondomain.GameMove,
+	positions []chess.Position,
+	analyses []uci.Analysis,
+) []MoveAnalysis {
+	annotated := make([]MoveAnalysis, 0, len(moves))
+
+	for i, move := range moves {
+		before, after := positions[i], positions[i+1]
+
+		analysis := MoveAnalysis{
+			Ply: move.Ply,
+			SAN: move.SAN,
+			UCI: move.UCI,
+			// The value after the move is from the opponent's point of view.
+			Classification: domain.Classify(
+				move.UCI == analyses[i].BestMove,
+				positionValue(before, analyses[i]),
+				-positionValue(after, analyses[i+1]),
+			),
+		}
+
+		if lines := analyses[i+1].Lines; len(lines) > 0 {
+			eval := domain.NewEvaluation(lines[0].Score, after.Turn)
+			analysis.Eval = &eval
+		}
+
+		analysis.BestMove = sanMove(before, analyses[i].BestMove)
+		for _, line := range analyses[i].Lines {
+			analysis.BestLines = append(analysis.BestLines, AnalysisLine{
+				Eval:  domain.NewEvaluation(line.Score, before.Turn),
+				Moves: sanLine(before, line.PV),
+			})
+		}
+
+		annotated = append(annotated, analysis)
+	}
+
+	return annotated
+}
+
+// analyse searches every position of the game with one engine. Positions in which
+// the game is over are not searched and have no analysis.
+func (h *GetGameAnalysisQueryHandler) analyse(
+	ctx context.Context,
+	game gamesessiondomain.Game,
+	positions []chess.Position,
+	ucis []string,
+	limits uci.Limits,
+) ([]uci.Analysis, error) {
+	engine, err := h.engines.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer h.engines.Release(engine)
+
+	if err := engine.NewGame(ctx); err != nil {
+		return nil, err
+	}
+
+	analyses := make([]uci.Analysis, len(positions))
+	for i, position := range positions {
+		if result, _ := position.Outcome(); result != chess.NoResult {
+			continue
+		}
+
+		analyses[i], err = engine.Analyze(
+			ctx,
+			uci.Position{FEN: game.InitialFEN, Moves: ucis[:i], Chess960: game.Variant == gamesessiondomain.Chess960},
+			limits,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return analyses, nil
+}
+
+// positionValue is the value of the position in centipawns for the side to move.
+func positionValue(position chess.Position, analysis uci.Analysis) int {
+	if len(analysis.Lines) > 0 {
+		return analysis.Lines[0].Score.Value()
+	}
+
+	if _, termination := position.Outcome(); termination == chess.Checkmate {
+		return -uci.MateCentipawns
+	}
+
+	// The game ended in a draw.
+	return 0
+}
+
+// sanMove writes the engine's move in SAN, or as the engine wrote it if it is not legal.
+func sanMove(position chess.Position, move string) string {
+	m, err := chess.ParseUCI(move)
+	if err != nil || !position.IsLegal(m) {
+		return move
+	}
+	return position.SAN(m)
+}
+
+// sanLine writes the engine's line in SAN, up to the first move which is not legal.
+func sanLine(position chess.Position, moves []string) []string {
+	san := make([]string, 0, len(moves))
+	for _, move := range moves {
+		m, err := chess.ParseUCI(move)
+		if err != nil || !position.IsLegal(m) {
+			break
+		}
+
+		san = append(san, position.SAN(m))
+		position = position.PlayLegal(m)
+	}
+	return san
+}
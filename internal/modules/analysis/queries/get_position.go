@@ -0,0 +1,107 @@
+package queries
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+)
+
+// GetPositionQuery describes the position of a FEN. FENs which are malformed or
+// describe an impossible position fail validation with every problem listed.
+type GetPositionQuery struct {
+	FEN string
+	// Variant defaults to standard.
+	Variant chess.Variant
+}
+
+func (q GetPositionQuery) Validate() error {
+	if problems := chess.ValidateVariantFEN(q.variant(), q.FEN); len(problems) > 0 {
+		return core.ValidationError{ValidationErrors: problems}
+	}
+
+	return nil
+}
+
+func (q GetPositionQuery) variant() chess.Variant {
+	if q.Variant == "" {
+		return chess.Standard
+	}
+	return q.Variant
+}
+
+type PositionMove struct {
+	UCI string
+	SAN string
+}
+
+// PositionMaterial is counted in pawns, Balance is white's material minus black's.
+type PositionMaterial struct {
+	White   int
+	Black   int
+	Balance int
+}
+
+type GetPositionResponse struct {
+	// FEN is the normalized FEN of the position.
+	FEN         string
+	SideToMove  string
+	InCheck     bool
+	Checkmate   bool
+	Stalemate   bool
+	Result      chess.Result
+	Termination chess.Termination
+	LegalMoves  []PositionMove
+	Material    PositionMaterial
+}
+
+func HandleGetPosition(w http.ResponseWriter, r *http.Request) {
+	response, err := mediator.Send[GetPositionQuery, GetPositionResponse](
+		r.Context(),
+		GetPositionQuery{
+			FEN:     r.URL.Query().Get("fen"),
+			Variant: chess.Variant(r.URL.Query().Get("variant")),
+		},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetPositionQueryHandler struct{}
+
+func NewGetPositionQueryHandler() *GetPositionQueryHandler {
+	return &GetPositionQueryHandler{}
+}
+
+func (h *GetPositionQueryHandler) Handle(_ context.Context, request GetPositionQuery) (GetPositionResponse, error) {
+	position, err := chess.ParseVariantFEN(request.variant(), request.FEN)
+	if err != nil {
+		return GetPositionResponse{}, core.NewCommandError(400, err)
+	}
+
+	legalMoves := core.Map(position.LegalMoves(), func(m chess.Move) PositionMove {
+		return PositionMove{UCI: m.UCI(), SAN: position.SAN(m)}
+	})
+
+	result, termination := position.Outcome()
+	white, black := position.Material(chess.White), position.Material(chess.Black)
+
+	return GetPositionResponse{
+		FEN:         position.FEN(),
+		SideToMove:  position.Turn.String(),
+		InCheck:     position.InCheck(),
+		Checkmate:   termination == chess.Checkmate,
+		Stalemate:   termination == chess.Stalemate,
+		Result:      result,
+		Termination: termination,
+		LegalMoves:  legalMoves,
+		Material:    PositionMaterial{White: white, Black: black, Balance: white - black},
+	}, nil
+}
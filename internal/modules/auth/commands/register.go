@@ -22,8 +22,8 @@ type RegisterCommand struct {
 }
 
 func (c RegisterCommand) Validate() error {
-	if c.Username == "" {
-		return fmt.Errorf("invalid Username: '%s'", c.Username)
+	if err := domain.ValidateUsername(c.Username); err != nil {
+		return err
 	}
 
 	if c.Password == "" {
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/auth/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// UpdatePreferencesCommand replaces the user's preferences.
+type UpdatePreferencesCommand struct {
+	UserID                  uuid.UUID
+	CorrespondenceReminders bool
+}
+
+func (c UpdatePreferencesCommand) Validate() error {
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleUpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command, err := core.RequestBody[UpdatePreferencesCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	command.UserID = core.Session(ctx).UserID
+
+	response, err := mediator.Send[UpdatePreferencesCommand, domain.Preferences](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type UpdatePreferencesCommandHandler struct {
+	db *sql.DB
+}
+
+func NewUpdatePreferencesCommandHandler(db *sql.DB) *UpdatePreferencesCommandHandler {
+	return &UpdatePreferencesCommandHandler{db}
+}
+
+func (h *UpdatePreferencesCommandHandler) Handle(
+	ctx context.Context,
+	request UpdatePreferencesCommand,
+) (domain.Preferences, error) {
+	preferences := domain.Preferences{
+		UserID:                  request.UserID,
+		CorrespondenceReminders: request.CorrespondenceReminders,
+	}
+
+	const stmt = `
+		INSERT INTO
+			auth.user_preferences (user_id, correspondence_reminders)
+		VALUES
+			(:user_id, :correspondence_reminders)
+		ON CONFLICT (user_id) DO UPDATE SET
+			correspondence_reminders = EXCLUDED.correspondence_reminders;`
+	if _, err := tql.Exec(ctx, h.db, stmt, preferences); err != nil {
+		return domain.Preferences{}, core.NewCommandError(500, err)
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Preferences are the user's settings. Users who never changed them have the defaults.
+type Preferences struct {
+	UserID uuid.UUID `db:"user_id"`
+	// CorrespondenceReminders emails the user when moves of their correspondence games are due.
+	CorrespondenceReminders bool `db:"correspondence_reminders"`
+}
+
+func DefaultPreferences(userID uuid.UUID) Preferences {
+	return Preferences{
+		UserID:                  userID,
+		CorrespondenceReminders: true,
+	}
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +18,26 @@ type User struct {
 	EmailConfirmed            bool      `db:"email_confirmed"`
 	Locked                    bool      `db:"locked"`
 	UnsuccessfulLoginAttempts int       `db:"unsuccessful_login_attempts"`
+	// IsAdmin users moderate the site, such as the reported chat messages.
+	IsAdmin bool `db:"is_admin"`
+}
+
+// reservedUsernameMarker marks the usernames of the users created by the system,
+// such as the computer opponent, so no one can register them.
+const reservedUsernameMarker = "@"
+
+var ErrReservedUsername = fmt.Errorf("usernames cannot contain '%s'", reservedUsernameMarker)
+
+func ValidateUsername(username string) error {
+	if username == "" {
+		return fmt.Errorf("invalid Username: '%s'", username)
+	}
+
+	if strings.Contains(username, reservedUsernameMarker) {
+		return ErrReservedUsername
+	}
+
+	return nil
 }
 
 func RegisterUser(
@@ -25,6 +46,10 @@ func RegisterUser(
 	password string,
 	passwordHasher PasswordHasher,
 ) (User, error) {
+	if err := ValidateUsername(username); err != nil {
+		return User{}, err
+	}
+
 	passwordHash, err := passwordHasher.HashPassword(password)
 	if err != nil {
 		return User{}, err
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RegisterUser_Rejects_Reserved_Usernames(t *testing.T) {
+	// Arrange
+	hasher := NewPasswordHasher(sha256.New)
+
+	// Act
+	_, botErr := RegisterUser("@computer", "computer@example.com", uuid.NewString(), *hasher)
+	_, emailErr := RegisterUser("player@example.com", "player@example.com", uuid.NewString(), *hasher)
+	user, err := RegisterUser("computer", "computer@example.com", uuid.NewString(), *hasher)
+
+	// Assert
+	require.ErrorIs(t, botErr, ErrReservedUsername)
+	require.ErrorIs(t, emailErr, ErrReservedUsername)
+	require.NoError(t, err)
+	require.Equal(t, "computer", user.Username)
+}
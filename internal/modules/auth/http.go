@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/auth/domain"
@@ -44,8 +45,42 @@ func AuthenticationMiddleware(db *sql.DB) func(http.HandlerFunc) http.HandlerFun
 				return
 			}
 
-			authContext := context.WithValue(r.Context(), core.SessionContextKey, session)
+			contextSession := core.ContextSession{UserID: session.UserID}
+			authContext := context.WithValue(r.Context(), core.SessionContextKey, contextSession)
 			next.ServeHTTP(w, r.WithContext(authContext))
 		}
 	}
 }
+
+// AdminMiddleware only lets admins through. It runs after the AuthenticationMiddleware,
+// which puts the user in the context.
+func AdminMiddleware(db *sql.DB) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			const q = `
+				SELECT
+					is_admin
+				FROM
+					auth.user
+				WHERE
+					id = $1;`
+
+			isAdmin, err := tql.QueryFirst[bool](r.Context(), db, q, core.Session(r.Context()).UserID)
+			switch {
+			case err != nil && errors.Is(err, sql.ErrNoRows):
+				core.WriteUnauthorized(w, r, nil)
+				return
+			case err != nil:
+				core.WriteInternalServerError(w, r, nil)
+				return
+			}
+
+			if !isAdmin {
+				core.WriteCommandError(w, r, core.NewCommandError(http.StatusForbidden, fmt.Errorf("only admins can do this")))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/auth/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetPreferencesQuery returns the user's preferences, the defaults if they never changed them.
+type GetPreferencesQuery struct {
+	UserID uuid.UUID
+}
+
+func (q GetPreferencesQuery) Validate() error {
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return nil
+}
+
+func HandleGetPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	response, err := mediator.Send[GetPreferencesQuery, domain.Preferences](
+		ctx,
+		GetPreferencesQuery{UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetPreferencesQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetPreferencesQueryHandler(db *sql.DB) *GetPreferencesQueryHandler {
+	return &GetPreferencesQueryHandler{db}
+}
+
+func (h *GetPreferencesQueryHandler) Handle(ctx context.Context, request GetPreferencesQuery) (domain.Preferences, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			auth.user_preferences
+		WHERE
+			user_id = $1;`
+	preferences, err := tql.QueryFirst[domain.Preferences](ctx, h.db, query, request.UserID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return domain.DefaultPreferences(request.UserID), nil
+	case err != nil:
+		return domain.Preferences{}, core.NewCommandError(500, err)
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,44 @@
+package chat
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// SessionRole loads the session whose chat the user wants and the user's role in it.
+// Private sessions have no chat for users other than their players.
+func SessionRole(
+	ctx context.Context,
+	q tql.Querier,
+	sessionID string,
+	userID uuid.UUID,
+) (gamesessiondomain.Session, gamesessiondomain.Role, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			game_session
+		WHERE
+			id = $1;`
+	session, err := tql.QueryFirst[gamesessiondomain.Session](ctx, q, query, sessionID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return gamesessiondomain.Session{}, "", core.NewCommandError(404, err)
+	case err != nil:
+		return gamesessiondomain.Session{}, "", core.NewCommandError(500, err)
+	}
+
+	role, err := session.Role(userID)
+	if err != nil {
+		return gamesessiondomain.Session{}, "", core.NewCommandError(403, err)
+	}
+
+	return session, role, nil
+}
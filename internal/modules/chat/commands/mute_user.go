@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// MuteUserCommand hides the muted user's chat messages from the user, in every
+// session. The muted user is not told.
+type MuteUserCommand struct {
+	UserID      uuid.UUID
+	MutedUserID uuid.UUID
+}
+
+func (c MuteUserCommand) Validate() error {
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	if c.MutedUserID == uuid.Nil {
+		return fmt.Errorf("invalid MutedUserID - '%s'", c.MutedUserID)
+	}
+
+	if c.UserID == c.MutedUserID {
+		return fmt.Errorf("users cannot mute themselves")
+	}
+
+	return nil
+}
+
+func HandleMuteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command, err := core.RequestBody[MuteUserCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	command.UserID = core.Session(ctx).UserID
+
+	_, err = mediator.Send[MuteUserCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type MuteUserCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewMuteUserCommandHandler(db *sql.DB, clock core.Clock) *MuteUserCommandHandler {
+	return &MuteUserCommandHandler{db: db, clock: clock}
+}
+
+func (h *MuteUserCommandHandler) Handle(ctx context.Context, request MuteUserCommand) (core.Unit, error) {
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const userQuery = `SELECT id FROM auth.user WHERE id = $1;`
+		if _, err := tql.QueryFirst[uuid.UUID](ctx, tx, userQuery, request.MutedUserID); err != nil {
+			return err
+		}
+
+		const stmt = `
+			INSERT INTO
+				chat_mute (user_id, muted_user_id, created_at)
+			VALUES
+				($1, $2, $3)
+			ON CONFLICT DO NOTHING;`
+		if _, err := tql.Exec(ctx, tx, stmt, request.UserID, request.MutedUserID, h.clock.Now()); err != nil {
+			return err
+		}
+
+		return core.Publish(ctx, tx, domain.MutesTopic(request.UserID), request.MutedUserID)
+	}
+
+	err := core.Tx(ctx, h.db, txFn)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return core.Unit{}, core.NewCommandError(404, fmt.Errorf("user '%s' not found", request.MutedUserID))
+	case err != nil:
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
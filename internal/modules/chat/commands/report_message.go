@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chat"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// ReportMessageCommand puts a chat message in the admins' review queue. Users can
+// only report the messages they can read, once each.
+type ReportMessageCommand struct {
+	MessageID  uuid.UUID
+	ReporterID uuid.UUID
+	Reason     string
+}
+
+func (c ReportMessageCommand) Validate() error {
+	if c.MessageID == uuid.Nil {
+		return fmt.Errorf("invalid MessageID - '%s'", c.MessageID)
+	}
+
+	if c.ReporterID == uuid.Nil {
+		return fmt.Errorf("invalid ReporterID - '%s'", c.ReporterID)
+	}
+
+	return nil
+}
+
+type ReportMessageResponse struct {
+	ReportID uuid.UUID
+}
+
+func HandleReportMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	messageID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command, err := core.RequestBody[ReportMessageCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	command.MessageID = messageID
+	command.ReporterID = core.Session(ctx).UserID
+
+	response, err := mediator.Send[ReportMessageCommand, ReportMessageResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteResponse(w, r, http.StatusCreated, response)
+}
+
+type ReportMessageCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewReportMessageCommandHandler(db *sql.DB, clock core.Clock) *ReportMessageCommandHandler {
+	return &ReportMessageCommandHandler{db: db, clock: clock}
+}
+
+func (h *ReportMessageCommandHandler) Handle(
+	ctx context.Context,
+	request ReportMessageCommand,
+) (ReportMessageResponse, error) {
+	const messageQuery = `
+		SELECT
+			*
+		FROM
+			chat_message
+		WHERE
+			id = $1 AND removed = false;`
+	message, err := tql.QueryFirst[domain.Message](ctx, h.db, messageQuery, request.MessageID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return ReportMessageResponse{}, core.NewCommandError(404, err)
+	case err != nil:
+		return ReportMessageResponse{}, core.NewCommandError(500, err)
+	}
+
+	session, role, err := chat.SessionRole(ctx, h.db, message.SessionID, request.ReporterID)
+	if err != nil {
+		return ReportMessageResponse{}, err
+	}
+
+	if !message.Channel.CanRead(session, role) {
+		return ReportMessageResponse{}, core.NewCommandError(403, domain.ErrCannotReadChannel)
+	}
+
+	report, err := domain.NewReport(message, request.ReporterID, request.Reason, h.clock.Now())
+	if err != nil {
+		return ReportMessageResponse{}, core.NewCommandError(400, err)
+	}
+
+	const stmt = `
+		INSERT INTO
+			chat_report (id, message_id, reporter_id, reason, status, created_at)
+		VALUES
+			(:id, :message_id, :reporter_id, :reason, :status, :created_at)
+		ON CONFLICT (message_id, reporter_id) DO NOTHING;`
+	result, err := tql.Exec(ctx, h.db, stmt, report)
+	if err != nil {
+		return ReportMessageResponse{}, core.NewCommandError(500, err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return ReportMessageResponse{}, core.NewCommandError(500, err)
+	}
+
+	if inserted == 0 {
+		return ReportMessageResponse{}, core.NewCommandError(409, fmt.Errorf("message '%s' was already reported", message.ID))
+	}
+
+	return ReportMessageResponse{ReportID: report.ID}, nil
+}
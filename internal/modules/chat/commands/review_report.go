@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// ReviewReportCommand is an admin's decision on a reported message. Upholding a
+// report removes the message, closes the other reports of it and can silence
+// its sender in every chat for a while.
+type ReviewReportCommand struct {
+	ReportID uuid.UUID
+	AdminID  uuid.UUID
+	Decision domain.ReportStatus
+	// SilenceMinutes silences the sender of an upheld report, not at all when 0.
+	SilenceMinutes int
+}
+
+func (c ReviewReportCommand) Validate() error {
+	if c.ReportID == uuid.Nil {
+		return fmt.Errorf("invalid ReportID - '%s'", c.ReportID)
+	}
+
+	if c.AdminID == uuid.Nil {
+		return fmt.Errorf("invalid AdminID - '%s'", c.AdminID)
+	}
+
+	if c.Decision != domain.ReportUpheld && c.Decision != domain.ReportDismissed {
+		return fmt.Errorf("invalid Decision - '%s'", c.Decision)
+	}
+
+	if c.SilenceMinutes < 0 || c.silence() > domain.MaxSilence {
+		return fmt.Errorf("invalid SilenceMinutes - '%d'", c.SilenceMinutes)
+	}
+
+	if c.SilenceMinutes > 0 && c.Decision != domain.ReportUpheld {
+		return fmt.Errorf("only the senders of upheld reports can be silenced")
+	}
+
+	return nil
+}
+
+func (c ReviewReportCommand) silence() time.Duration {
+	return time.Duration(c.SilenceMinutes) * time.Minute
+}
+
+func HandleUpholdReport(w http.ResponseWriter, r *http.Request) {
+	handleReviewReport(w, r, domain.ReportUpheld)
+}
+
+func HandleDismissReport(w http.ResponseWriter, r *http.Request) {
+	handleReviewReport(w, r, domain.ReportDismissed)
+}
+
+func handleReviewReport(w http.ResponseWriter, r *http.Request, decision domain.ReportStatus) {
+	ctx := r.Context()
+
+	reportID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	var command ReviewReportCommand
+	if r.ContentLength != 0 {
+		if command, err = core.RequestBody[ReviewReportCommand](r); err != nil {
+			core.WriteBadRequest(w, r, err)
+			return
+		}
+	}
+
+	command.ReportID = reportID
+	command.AdminID = core.Session(ctx).UserID
+	command.Decision = decision
+
+	_, err = mediator.Send[ReviewReportCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type ReviewReportCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewReviewReportCommandHandler(db *sql.DB, clock core.Clock) *ReviewReportCommandHandler {
+	return &ReviewReportCommandHandler{db: db, clock: clock}
+}
+
+func (h *ReviewReportCommandHandler) Handle(ctx context.Context, request ReviewReportCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const reportQuery = `
+			SELECT
+				*
+			FROM
+				chat_report
+			WHERE
+				id = $1
+			FOR UPDATE;`
+		report, err := tql.QueryFirst[domain.Report](ctx, tx, reportQuery, request.ReportID)
+		if err != nil {
+			return err
+		}
+
+		if err := report.Review(request.Decision, request.AdminID, now); err != nil {
+			return core.NewCommandError(409, err)
+		}
+
+		if request.Decision == domain.ReportDismissed {
+			const stmt = `
+				UPDATE
+					chat_report
+				SET
+					status = :status,
+					reviewed_by = :reviewed_by,
+					reviewed_at = :reviewed_at
+				WHERE
+					id = :id;`
+			_, err := tql.Exec(ctx, tx, stmt, report)
+			return err
+		}
+
+		// Every pending report of the message is settled by removing it.
+		const reportsStmt = `
+			UPDATE
+				chat_report
+			SET
+				status = $1,
+				reviewed_by = $2,
+				reviewed_at = $3
+			WHERE
+				message_id = $4 AND status = $5;`
+		_, err = tql.Exec(ctx, tx, reportsStmt, report.Status, request.AdminID, now, report.MessageID, domain.ReportPending)
+		if err != nil {
+			return err
+		}
+
+		const messageStmt = `
+			UPDATE
+				chat_message
+			SET
+				removed = true
+			WHERE
+				id = $1
+			RETURNING
+				*;`
+		message, err := tql.QueryFirst[domain.Message](ctx, tx, messageStmt, report.MessageID)
+		if err != nil {
+			return err
+		}
+
+		if request.SilenceMinutes > 0 {
+			const silenceStmt = `
+				INSERT INTO
+					chat_silence (user_id, silenced_until)
+				VALUES
+					($1, $2)
+				ON CONFLICT (user_id) DO UPDATE SET
+					silenced_until = GREATEST(chat_silence.silenced_until, EXCLUDED.silenced_until);`
+			if _, err := tql.Exec(ctx, tx, silenceStmt, message.SenderID, now.Add(request.silence())); err != nil {
+				return err
+			}
+		}
+
+		return core.Publish(ctx, tx, domain.ChannelTopic(message.SessionID, message.Channel), message.RemovedEvent())
+	}
+
+	err := core.Tx(ctx, h.db, txFn)
+	var commandErr core.CommandError
+	switch {
+	case err != nil && errors.As(err, &commandErr):
+		return core.Unit{}, commandErr
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return core.Unit{}, core.NewCommandError(404, err)
+	case err != nil:
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chat"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// chatLockSpace is the first key of the advisory locks serializing the messages of
+// a sender, so concurrent messages cannot slip past the rate limit.
+const chatLockSpace int32 = 0x63686174
+
+// SendMessageCommand posts a message to a channel of the session's chat. The message
+// is delivered to the streams of the users reading the channel.
+type SendMessageCommand struct {
+	SessionID string
+	Channel   domain.Channel
+	SenderID  uuid.UUID
+	Body      string
+}
+
+func (c SendMessageCommand) Validate() error {
+	if c.SessionID == "" {
+		return fmt.Errorf("invalid SessionID - '%s'", c.SessionID)
+	}
+
+	if err := c.Channel.Validate(); err != nil {
+		return err
+	}
+
+	if c.SenderID == uuid.Nil {
+		return fmt.Errorf("invalid SenderID - '%s'", c.SenderID)
+	}
+
+	return nil
+}
+
+func HandleSendMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command, err := core.RequestBody[SendMessageCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	command.SessionID = r.PathValue("id")
+	command.Channel = domain.Channel(r.PathValue("channel"))
+	command.SenderID = core.Session(ctx).UserID
+
+	response, err := mediator.Send[SendMessageCommand, domain.Message](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteResponse(w, r, http.StatusCreated, response)
+}
+
+type SendMessageCommandHandler struct {
+	db     *sql.DB
+	clock  core.Clock
+	filter domain.WordFilter
+	limit  domain.RateLimit
+}
+
+func NewSendMessageCommandHandler(
+	db *sql.DB,
+	clock core.Clock,
+	filter domain.WordFilter,
+	limit domain.RateLimit,
+) *SendMessageCommandHandler {
+	return &SendMessageCommandHandler{db: db, clock: clock, filter: filter, limit: limit}
+}
+
+func (h *SendMessageCommandHandler) Handle(ctx context.Context, request SendMessageCommand) (domain.Message, error) {
+	now := h.clock.Now()
+
+	session, role, err := chat.SessionRole(ctx, h.db, request.SessionID, request.SenderID)
+	if err != nil {
+		return domain.Message{}, err
+	}
+
+	// Users cannot chat in the sessions of players they blocked or who blocked them.
+	for _, playerID := range []uuid.UUID{session.Player1ID, session.Player2ID} {
+		if playerID == uuid.Nil || playerID == request.SenderID {
+			continue
+		}
+
+		if err := social.CheckNotBlocked(ctx, h.db, request.SenderID, playerID); err != nil {
+			return domain.Message{}, err
+		}
+	}
+
+	if !request.Channel.CanWrite(role) {
+		return domain.Message{}, core.NewCommandError(403, domain.ErrCannotWriteChannel)
+	}
+
+	message, err := domain.NewMessage(request.SessionID, request.Channel, request.SenderID, request.Body, h.filter, now)
+	if err != nil {
+		return domain.Message{}, core.NewCommandError(400, err)
+	}
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const silenceQuery = `
+			SELECT
+				count(*)
+			FROM
+				chat_silence
+			WHERE
+				user_id = $1 AND silenced_until > $2;`
+		silenced, err := tql.QueryFirst[int](ctx, tx, silenceQuery, request.SenderID, now)
+		if err != nil {
+			return err
+		}
+
+		if silenced > 0 {
+			return core.NewCommandError(403, domain.ErrSilenced)
+		}
+
+		const lockStmt = `SELECT pg_advisory_xact_lock($1, hashtext($2));`
+		if _, err := tql.Exec(ctx, tx, lockStmt, chatLockSpace, request.SenderID.String()); err != nil {
+			return err
+		}
+
+		const sentQuery = `
+			SELECT
+				count(*)
+			FROM
+				chat_message
+			WHERE
+				sender_id = $1 AND created_at > $2;`
+		sent, err := tql.QueryFirst[int](ctx, tx, sentQuery, request.SenderID, h.limit.WindowStart(now))
+		if err != nil {
+			return err
+		}
+
+		if !h.limit.Allows(sent) {
+			return core.NewCommandError(429, domain.ErrRateLimited)
+		}
+
+		const stmt = `
+			INSERT INTO
+				chat_message (id, session_id, channel, sender_id, body, removed, created_at)
+			VALUES
+				(:id, :session_id, :channel, :sender_id, :body, :removed, :created_at);`
+		if _, err := tql.Exec(ctx, tx, stmt, message); err != nil {
+			return err
+		}
+
+		return core.Publish(ctx, tx, domain.ChannelTopic(message.SessionID, message.Channel), message.SentEvent())
+	}
+
+	err = core.Tx(ctx, h.db, txFn)
+	var commandErr core.CommandError
+	switch {
+	case err != nil && errors.As(err, &commandErr):
+		return domain.Message{}, commandErr
+	case err != nil:
+		return domain.Message{}, core.NewCommandError(500, err)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+type UnmuteUserCommand struct {
+	UserID      uuid.UUID
+	MutedUserID uuid.UUID
+}
+
+func (c UnmuteUserCommand) Validate() error {
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	if c.MutedUserID == uuid.Nil {
+		return fmt.Errorf("invalid MutedUserID - '%s'", c.MutedUserID)
+	}
+
+	return nil
+}
+
+func HandleUnmuteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	mutedUserID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := UnmuteUserCommand{UserID: core.Session(ctx).UserID, MutedUserID: mutedUserID}
+
+	_, err = mediator.Send[UnmuteUserCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type UnmuteUserCommandHandler struct {
+	db *sql.DB
+}
+
+func NewUnmuteUserCommandHandler(db *sql.DB) *UnmuteUserCommandHandler {
+	return &UnmuteUserCommandHandler{db}
+}
+
+func (h *UnmuteUserCommandHandler) Handle(ctx context.Context, request UnmuteUserCommand) (core.Unit, error) {
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const stmt = `
+			DELETE FROM
+				chat_mute
+			WHERE
+				user_id = $1 AND muted_user_id = $2;`
+		result, err := tql.Exec(ctx, tx, stmt, request.UserID, request.MutedUserID)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if deleted == 0 {
+			return core.NewCommandError(404, fmt.Errorf("user '%s' is not muted", request.MutedUserID))
+		}
+
+		return core.Publish(ctx, tx, domain.MutesTopic(request.UserID), request.MutedUserID)
+	}
+
+	err := core.Tx(ctx, h.db, txFn)
+	var commandErr core.CommandError
+	switch {
+	case err != nil && errors.As(err, &commandErr):
+		return core.Unit{}, commandErr
+	case err != nil:
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
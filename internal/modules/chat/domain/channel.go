@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCannotReadChannel  = errors.New("user cannot read the channel")
+	ErrCannotWriteChannel = errors.New("user cannot write to the channel")
+)
+
+// Channel is one of the chats of a game session.
+type Channel string
+
+const (
+	// PlayersChannel is private to the players of the session.
+	PlayersChannel Channel = "players"
+	// SpectatorsChannel is where the spectators talk. The players only read it once
+	// their game is over, so spectators cannot help them.
+	SpectatorsChannel Channel = "spectators"
+)
+
+func (c Channel) Validate() error {
+	switch c {
+	case PlayersChannel, SpectatorsChannel:
+		return nil
+	default:
+		return fmt.Errorf("invalid Channel - '%s'", c)
+	}
+}
+
+// CanRead reports whether a user with the role can read the channel of the session.
+func (c Channel) CanRead(session gamesessiondomain.Session, role gamesessiondomain.Role) bool {
+	switch c {
+	case PlayersChannel:
+		return role == gamesessiondomain.PlayerRole
+	case SpectatorsChannel:
+		return role == gamesessiondomain.SpectatorRole || !session.Active
+	default:
+		return false
+	}
+}
+
+// CanWrite reports whether a user with the role can write to the channel.
+func (c Channel) CanWrite(role gamesessiondomain.Role) bool {
+	switch c {
+	case PlayersChannel:
+		return role == gamesessiondomain.PlayerRole
+	case SpectatorsChannel:
+		return role == gamesessiondomain.SpectatorRole
+	default:
+		return false
+	}
+}
+
+// LiveChannel is the channel the user follows in the session's stream.
+func LiveChannel(role gamesessiondomain.Role) Channel {
+	if role == gamesessiondomain.PlayerRole {
+		return PlayersChannel
+	}
+	return SpectatorsChannel
+}
+
+// ChannelTopic is the pub/sub topic the messages of the session's channel are published to.
+func ChannelTopic(sessionID string, channel Channel) string {
+	return fmt.Sprintf("chat:%s:%s", sessionID, channel)
+}
+
+// MutesTopic is the pub/sub topic the changes of the user's mutes are published to,
+// so the user's streams stop showing the muted users' messages.
+func MutesTopic(userID uuid.UUID) string {
+	return fmt.Sprintf("chat:mutes:%s", userID)
+}
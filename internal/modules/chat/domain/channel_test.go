@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Channel_Access(t *testing.T) {
+	inProgress := gamesessiondomain.Session{Active: true}
+	over := gamesessiondomain.Session{Active: false}
+
+	player, spectator := gamesessiondomain.PlayerRole, gamesessiondomain.SpectatorRole
+
+	// The players' channel is private to the players.
+	require.True(t, PlayersChannel.CanRead(inProgress, player))
+	require.True(t, PlayersChannel.CanWrite(player))
+	require.False(t, PlayersChannel.CanRead(over, spectator))
+	require.False(t, PlayersChannel.CanWrite(spectator))
+
+	// Players read the spectators only once the game is over, and never write to them.
+	require.True(t, SpectatorsChannel.CanRead(inProgress, spectator))
+	require.True(t, SpectatorsChannel.CanWrite(spectator))
+	require.False(t, SpectatorsChannel.CanRead(inProgress, player))
+	require.True(t, SpectatorsChannel.CanRead(over, player))
+	require.False(t, SpectatorsChannel.CanWrite(player))
+}
+
+func Test_NewMessage_Validates_Body(t *testing.T) {
+	filter := NewWordFilter([]string{"darn"})
+
+	message, err := NewMessage("session", PlayersChannel, [16]byte{1}, "  darn  ", filter, testNow)
+	require.NoError(t, err)
+	require.Equal(t, "****", message.Body)
+
+	_, err = NewMessage("session", PlayersChannel, [16]byte{1}, "   ", filter, testNow)
+	require.Error(t, err)
+
+	long := make([]rune, MaxMessageLength+1)
+	for i := range long {
+		long[i] = 'ž'
+	}
+	_, err = NewMessage("session", PlayersChannel, [16]byte{1}, string(long), filter, testNow)
+	require.Error(t, err)
+}
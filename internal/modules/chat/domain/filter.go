@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WordFilter masks the banned words in chat messages. Words are matched whole and
+// regardless of case, so "class" is not masked for "ass".
+type WordFilter struct {
+	banned map[string]struct{}
+}
+
+func NewWordFilter(words []string) WordFilter {
+	banned := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" {
+			banned[word] = struct{}{}
+		}
+	}
+
+	return WordFilter{banned: banned}
+}
+
+// Mask replaces every letter of the banned words in the text with '*'.
+func (f WordFilter) Mask(text string) string {
+	if len(f.banned) == 0 {
+		return text
+	}
+
+	runes := []rune(text)
+	isWordRune := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+
+	for start := 0; start < len(runes); {
+		if !isWordRune(runes[start]) {
+			start++
+			continue
+		}
+
+		end := start
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+
+		if f.IsBanned(string(runes[start:end])) {
+			for i := start; i < end; i++ {
+				runes[i] = '*'
+			}
+		}
+
+		start = end
+	}
+
+	return string(runes)
+}
+
+func (f WordFilter) IsBanned(word string) bool {
+	_, banned := f.banned[strings.ToLower(word)]
+	return banned
+}
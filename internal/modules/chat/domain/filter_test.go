@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WordFilter_Masks_Banned_Words(t *testing.T) {
+	filter := NewWordFilter([]string{"darn", " Heck ", ""})
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"no banned words", "good game", "good game"},
+		{"banned word", "darn it", "**** it"},
+		{"any case", "DARN, heck!", "****, ****!"},
+		{"whole words only", "darnation checkmate", "darnation checkmate"},
+		{"unicode letters", "čašćenje heck", "čašćenje ****"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, filter.Mask(tt.text))
+		})
+	}
+}
+
+func Test_WordFilter_Without_Words_Keeps_Text(t *testing.T) {
+	require.Equal(t, "anything goes", NewWordFilter(nil).Mask("anything goes"))
+}
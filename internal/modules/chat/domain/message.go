@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const MaxMessageLength = 500
+
+var ErrSilenced = errors.New("user is silenced in chat")
+
+// Message is a chat message in a channel of a game session.
+type Message struct {
+	ID        uuid.UUID `db:"id"`
+	SessionID string    `db:"session_id"`
+	Channel   Channel   `db:"channel"`
+	SenderID  uuid.UUID `db:"sender_id"`
+	Body      string    `db:"body"`
+	// Removed messages were taken down by a moderator and are no longer shown.
+	Removed   bool      `db:"removed"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// NewMessage writes the message with the filter's words masked.
+func NewMessage(
+	sessionID string,
+	channel Channel,
+	senderID uuid.UUID,
+	body string,
+	filter WordFilter,
+	now time.Time,
+) (Message, error) {
+	body = strings.TrimSpace(body)
+
+	if body == "" {
+		return Message{}, fmt.Errorf("invalid Body - message is empty")
+	}
+
+	if utf8.RuneCountInString(body) > MaxMessageLength {
+		return Message{}, fmt.Errorf("invalid Body - longer than %d characters", MaxMessageLength)
+	}
+
+	return Message{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		Channel:   channel,
+		SenderID:  senderID,
+		Body:      filter.Mask(body),
+		CreatedAt: now,
+	}, nil
+}
+
+const (
+	MessageSentEvent    = "chat_message"
+	MessageRemovedEvent = "chat_message_removed"
+)
+
+// ChannelEvent is published to the channel's topic and sent to the streams as is.
+type ChannelEvent struct {
+	Type    string  `json:"type"`
+	Payload Message `json:"payload"`
+}
+
+func (m Message) SentEvent() ChannelEvent {
+	return ChannelEvent{Type: MessageSentEvent, Payload: m}
+}
+
+func (m Message) RemovedEvent() ChannelEvent {
+	m.Body = ""
+	m.Removed = true
+	return ChannelEvent{Type: MessageRemovedEvent, Payload: m}
+}
+
+// ParseChannelEvent reads an event published to a channel's topic.
+func ParseChannelEvent(message []byte) (ChannelEvent, error) {
+	var event ChannelEvent
+	err := json.Unmarshal(message, &event)
+	return event, err
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrRateLimited = errors.New("too many chat messages, slow down")
+
+// RateLimit is how many messages a user can send in any Window, over all channels.
+type RateLimit struct {
+	Messages int
+	Window   time.Duration
+}
+
+// Allows reports whether one more message can be sent after the sent ones in the window.
+func (l RateLimit) Allows(sentInWindow int) bool {
+	return l.Messages <= 0 || sentInWindow < l.Messages
+}
+
+// WindowStart is the start of the window ending at now.
+func (l RateLimit) WindowStart(now time.Time) time.Time {
+	return now.Add(-l.Window)
+}
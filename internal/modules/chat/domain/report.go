@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxReportReasonLength = 500
+	MaxSilence            = 30 * 24 * time.Hour
+)
+
+var (
+	ErrReportNotPending    = errors.New("report was already reviewed")
+	ErrCannotReportOwnChat = errors.New("users cannot report their own messages")
+)
+
+type ReportStatus string
+
+const (
+	// ReportPending reports wait in the review queue.
+	ReportPending ReportStatus = "pending"
+	// ReportUpheld reports had their message removed.
+	ReportUpheld ReportStatus = "upheld"
+	// ReportDismissed reports were found to be unfounded.
+	ReportDismissed ReportStatus = "dismissed"
+)
+
+// Report is a user's report of a chat message for the admins to review.
+type Report struct {
+	ID         uuid.UUID    `db:"id"`
+	MessageID  uuid.UUID    `db:"message_id"`
+	ReporterID uuid.UUID    `db:"reporter_id"`
+	Reason     string       `db:"reason"`
+	Status     ReportStatus `db:"status"`
+	CreatedAt  time.Time    `db:"created_at"`
+	ReviewedBy *uuid.UUID   `db:"reviewed_by"`
+	ReviewedAt *time.Time   `db:"reviewed_at"`
+}
+
+func NewReport(message Message, reporterID uuid.UUID, reason string, now time.Time) (Report, error) {
+	if message.SenderID == reporterID {
+		return Report{}, ErrCannotReportOwnChat
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > MaxReportReasonLength {
+		return Report{}, fmt.Errorf("invalid Reason - expected 1 to %d characters", MaxReportReasonLength)
+	}
+
+	return Report{
+		ID:         uuid.New(),
+		MessageID:  message.ID,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Status:     ReportPending,
+		CreatedAt:  now,
+	}, nil
+}
+
+// Review closes the pending report with the admin's decision.
+func (r *Report) Review(status ReportStatus, adminID uuid.UUID, now time.Time) error {
+	if r.Status != ReportPending {
+		return ErrReportNotPending
+	}
+
+	if status != ReportUpheld && status != ReportDismissed {
+		return fmt.Errorf("invalid Status - '%s'", status)
+	}
+
+	r.Status = status
+	r.ReviewedBy = &adminID
+	r.ReviewedAt = &now
+
+	return nil
+}
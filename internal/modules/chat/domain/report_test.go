@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func Test_NewReport_Rejects_Own_Messages(t *testing.T) {
+	message := Message{ID: uuid.New(), SenderID: uuid.New()}
+
+	_, err := NewReport(message, message.SenderID, "spam", testNow)
+	require.ErrorIs(t, err, ErrCannotReportOwnChat)
+
+	_, err = NewReport(message, uuid.New(), " ", testNow)
+	require.Error(t, err)
+}
+
+func Test_Report_Review(t *testing.T) {
+	// Arrange
+	report, err := NewReport(Message{ID: uuid.New(), SenderID: uuid.New()}, uuid.New(), "insults", testNow)
+	require.NoError(t, err)
+	adminID := uuid.New()
+
+	// Act
+	err = report.Review(ReportUpheld, adminID, testNow.Add(time.Hour))
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, ReportUpheld, report.Status)
+	require.Equal(t, adminID, *report.ReviewedBy)
+	require.ErrorIs(t, report.Review(ReportDismissed, adminID, testNow), ErrReportNotPending)
+}
+
+func Test_RateLimit_Allows(t *testing.T) {
+	limit := RateLimit{Messages: 2, Window: 10 * time.Second}
+
+	require.True(t, limit.Allows(1))
+	require.False(t, limit.Allows(2))
+	require.True(t, RateLimit{}.Allows(100))
+	require.Equal(t, testNow.Add(-10*time.Second), limit.WindowStart(testNow))
+}
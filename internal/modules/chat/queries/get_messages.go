@@ -0,0 +1,140 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chat"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetMessagesQuery pages through the history of a channel of the session's chat,
+// newest first. Removed messages and those of the users the user muted are left out.
+type GetMessagesQuery struct {
+	SessionID string
+	Channel   domain.Channel
+	UserID    uuid.UUID
+	Page      core.PageRequest
+}
+
+func (q GetMessagesQuery) Validate() error {
+	if q.SessionID == "" {
+		return fmt.Errorf("invalid SessionID - '%s'", q.SessionID)
+	}
+
+	if err := q.Channel.Validate(); err != nil {
+		return err
+	}
+
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return q.Page.Validate()
+}
+
+type messageCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func HandleGetMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, err := core.ParsePageRequest(r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	query := GetMessagesQuery{
+		SessionID: r.PathValue("id"),
+		Channel:   domain.Channel(r.PathValue("channel")),
+		UserID:    core.Session(ctx).UserID,
+		Page:      page,
+	}
+
+	response, err := mediator.Send[GetMessagesQuery, core.Page[domain.Message]](ctx, query)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetMessagesQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetMessagesQueryHandler(db *sql.DB) *GetMessagesQueryHandler {
+	return &GetMessagesQueryHandler{db}
+}
+
+func (h *GetMessagesQueryHandler) Handle(ctx context.Context, request GetMessagesQuery) (core.Page[domain.Message], error) {
+	session, role, err := chat.SessionRole(ctx, h.db, request.SessionID, request.UserID)
+	if err != nil {
+		return core.Page[domain.Message]{}, err
+	}
+
+	if !request.Channel.CanRead(session, role) {
+		return core.Page[domain.Message]{}, core.NewCommandError(403, domain.ErrCannotReadChannel)
+	}
+
+	// Without a cursor the page starts after the newest message.
+	cursor := messageCursor{CreatedAt: time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if request.Page.Cursor != "" {
+		if cursor, err = core.DecodeCursor[messageCursor](request.Page.Cursor); err != nil {
+			return core.Page[domain.Message]{}, core.NewCommandError(400, err)
+		}
+	}
+
+	const query = `
+		SELECT
+			m.*
+		FROM
+			chat_message m
+		WHERE
+			m.session_id = $1
+			AND m.channel = $2
+			AND m.removed = false
+			AND (m.created_at, m.id) < ($3, $4)
+			AND NOT EXISTS (
+				SELECT 1 FROM chat_mute u WHERE u.user_id = $5 AND u.muted_user_id = m.sender_id
+			)
+		ORDER BY
+			m.created_at DESC, m.id DESC
+		LIMIT
+			$6;`
+	messages, err := tql.Query[domain.Message](
+		ctx,
+		h.db,
+		query,
+		request.SessionID,
+		request.Channel,
+		cursor.CreatedAt,
+		cursor.ID,
+		request.UserID,
+		request.Page.Limit+1,
+	)
+	if err != nil {
+		return core.Page[domain.Message]{}, core.NewCommandError(500, err)
+	}
+
+	page, err := core.NewPage(messages, request.Page.Limit, func(m domain.Message) messageCursor {
+		return messageCursor{CreatedAt: m.CreatedAt, ID: m.ID}
+	})
+	if err != nil {
+		return core.Page[domain.Message]{}, core.NewCommandError(500, err)
+	}
+
+	return page, nil
+}
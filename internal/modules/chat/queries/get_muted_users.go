@@ -0,0 +1,72 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetMutedUsersQuery lists the users whose chat messages the user does not see.
+type GetMutedUsersQuery struct {
+	UserID uuid.UUID
+}
+
+func (q GetMutedUsersQuery) Validate() error {
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return nil
+}
+
+func HandleGetMutedUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	response, err := mediator.Send[GetMutedUsersQuery, []uuid.UUID](
+		ctx,
+		GetMutedUsersQuery{UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetMutedUsersQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetMutedUsersQueryHandler(db *sql.DB) *GetMutedUsersQueryHandler {
+	return &GetMutedUsersQueryHandler{db}
+}
+
+func (h *GetMutedUsersQueryHandler) Handle(ctx context.Context, request GetMutedUsersQuery) ([]uuid.UUID, error) {
+	const query = `
+		SELECT
+			muted_user_id
+		FROM
+			chat_mute
+		WHERE
+			user_id = $1
+		ORDER BY
+			created_at;`
+	muted, err := tql.Query[uuid.UUID](ctx, h.db, query, request.UserID)
+	if err != nil {
+		return nil, core.NewCommandError(500, err)
+	}
+
+	if muted == nil {
+		muted = []uuid.UUID{}
+	}
+
+	return muted, nil
+}
@@ -0,0 +1,122 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetReportQueueQuery pages through the pending reports for the admins to review,
+// oldest first.
+type GetReportQueueQuery struct {
+	Page core.PageRequest
+}
+
+func (q GetReportQueueQuery) Validate() error {
+	return q.Page.Validate()
+}
+
+// QueuedReport is a pending report with the message it is about.
+type QueuedReport struct {
+	ReportID    uuid.UUID      `db:"report_id"`
+	ReporterID  uuid.UUID      `db:"reporter_id"`
+	Reason      string         `db:"reason"`
+	ReportedAt  time.Time      `db:"reported_at"`
+	MessageID   uuid.UUID      `db:"message_id"`
+	SessionID   string         `db:"session_id"`
+	Channel     domain.Channel `db:"channel"`
+	SenderID    uuid.UUID      `db:"sender_id"`
+	MessageBody string         `db:"message_body"`
+	SentAt      time.Time      `db:"sent_at"`
+}
+
+type reportCursor struct {
+	ReportedAt time.Time
+	ReportID   uuid.UUID
+}
+
+func HandleGetReportQueue(w http.ResponseWriter, r *http.Request) {
+	page, err := core.ParsePageRequest(r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	response, err := mediator.Send[GetReportQueueQuery, core.Page[QueuedReport]](r.Context(), GetReportQueueQuery{Page: page})
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetReportQueueQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetReportQueueQueryHandler(db *sql.DB) *GetReportQueueQueryHandler {
+	return &GetReportQueueQueryHandler{db}
+}
+
+func (h *GetReportQueueQueryHandler) Handle(ctx context.Context, request GetReportQueueQuery) (core.Page[QueuedReport], error) {
+	var cursor reportCursor
+	if request.Page.Cursor != "" {
+		decoded, err := core.DecodeCursor[reportCursor](request.Page.Cursor)
+		if err != nil {
+			return core.Page[QueuedReport]{}, core.NewCommandError(400, err)
+		}
+		cursor = decoded
+	}
+
+	const query = `
+		SELECT
+			r.id AS report_id,
+			r.reporter_id,
+			r.reason,
+			r.created_at AS reported_at,
+			m.id AS message_id,
+			m.session_id,
+			m.channel,
+			m.sender_id,
+			m.body AS message_body,
+			m.created_at AS sent_at
+		FROM
+			chat_report r
+			JOIN chat_message m ON m.id = r.message_id
+		WHERE
+			r.status = $1 AND (r.created_at, r.id) > ($2, $3)
+		ORDER BY
+			r.created_at, r.id
+		LIMIT
+			$4;`
+	reports, err := tql.Query[QueuedReport](
+		ctx,
+		h.db,
+		query,
+		domain.ReportPending,
+		cursor.ReportedAt,
+		cursor.ReportID,
+		request.Page.Limit+1,
+	)
+	if err != nil {
+		return core.Page[QueuedReport]{}, core.NewCommandError(500, err)
+	}
+
+	page, err := core.NewPage(reports, request.Page.Limit, func(r QueuedReport) reportCursor {
+		return reportCursor{ReportedAt: r.ReportedAt, ReportID: r.ReportID}
+	})
+	if err != nil {
+		return core.Page[QueuedReport]{}, core.NewCommandError(500, err)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,357 @@
+package chess
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const StartingFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+type Color uint8
+
+const (
+	White Color = iota
+	Black
+)
+
+func (c Color) Other() Color {
+	return c ^ 1
+}
+
+func (c Color) String() string {
+	if c == White {
+		return "white"
+	}
+	return "black"
+}
+
+type PieceType uint8
+
+const (
+	NoPieceType PieceType = iota
+	Pawn
+	Knight
+	Bishop
+	Rook
+	Queen
+	King
+)
+
+type Piece struct {
+	Type  PieceType
+	Color Color
+}
+
+func (p Piece) IsEmpty() bool {
+	return p.Type == NoPieceType
+}
+
+const pieceLetters = " pnbrqk"
+
+// Letter returns the FEN letter of the piece, upper case for white.
+func (p Piece) Letter() byte {
+	l := pieceLetters[p.Type]
+	if p.Color == White {
+		return l - ('a' - 'A')
+	}
+	return l
+}
+
+func pieceFromLetter(l byte) (Piece, bool) {
+	color := Black
+	if l >= 'A' && l <= 'Z' {
+		color = White
+		l += 'a' - 'A'
+	}
+
+	i := strings.IndexByte(pieceLetters, l)
+	if i < 1 {
+		return Piece{}, false
+	}
+
+	return Piece{Type: PieceType(i), Color: color}, true
+}
+
+// Square is an index into the board where a1 is 0 and h8 is 63.
+type Square int8
+
+const NoSquare Square = -1
+
+func NewSquare(file, rank int) Square {
+	return Square(rank*8 + file)
+}
+
+func (s Square) File() int {
+	return int(s) % 8
+}
+
+func (s Square) Rank() int {
+	return int(s) / 8
+}
+
+// IsLight reports whether the square is a light square.
+func (s Square) IsLight() bool {
+	return (s.File()+s.Rank())%2 == 1
+}
+
+func (s Square) String() string {
+	if s == NoSquare {
+		return "-"
+	}
+	return string([]byte{byte('a' + s.File()), byte('1' + s.Rank())})
+}
+
+func ParseSquare(s string) (Square, error) {
+	if len(s) != 2 || s[0] < 'a' || s[0] > 'h' || s[1] < '1' || s[1] > '8' {
+		return NoSquare, fmt.Errorf("invalid square - '%s'", s)
+	}
+
+	return NewSquare(int(s[0]-'a'), int(s[1]-'1')), nil
+}
+
+type CastlingRights uint8
+
+const (
+	WhiteKingside CastlingRights = 1 << iota
+	WhiteQueenside
+	BlackKingside
+	BlackQueenside
+
+	NoCastling CastlingRights = 0
+)
+
+func (c CastlingRights) String() string {
+	if c == NoCastling {
+		return "-"
+	}
+
+	var b strings.Builder
+	for i, l := range "KQkq" {
+		if c&(1<<i) != 0 {
+			b.WriteRune(l)
+		}
+	}
+	return b.String()
+}
+
+// Position is a complete description of a chess position, equivalent to a FEN record.
+type Position struct {
+	Variant        Variant
+	Board          [64]Piece
+	Turn           Color
+	Castling       CastlingRights
+	EnPassant      Square
+	HalfmoveClock  int
+	FullmoveNumber int
+	// Checks counts the checks given by each colour, which only matter in Three-check.
+	Checks [2]int
+
+	// castlingRooks are the squares of the rooks castling is with, in the order of
+	// castlingSides, which only differ from the standard ones in Chess960.
+	castlingRooks [4]Square
+}
+
+func StartingPosition() Position {
+	p, err := ParseFEN(StartingFEN)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// ParseFEN parses the FEN of a standard chess position.
+func ParseFEN(fen string) (Position, error) {
+	return ParseVariantFEN(Standard, fen)
+}
+
+// ParseVariantFEN parses the FEN of a position of the variant. Three-check positions
+// may have a seventh field with the checks given by each side, as in "+1+0".
+func ParseVariantFEN(variant Variant, fen string) (Position, error) {
+	p, errs := parseFEN(variant, fen)
+	if len(errs) > 0 {
+		return Position{}, errs[0]
+	}
+	return p, nil
+}
+
+// parseFEN parses every field of the FEN, collecting the problems of all of them.
+func parseFEN(variant Variant, fen string) (Position, []error) {
+	if err := variant.Validate(); err != nil {
+		return Position{}, []error{err}
+	}
+
+	fields := strings.Fields(fen)
+	if len(fields) != 6 && (variant != ThreeCheck || len(fields) != 7) {
+		return Position{}, []error{fmt.Errorf("invalid FEN - expected 6 fields got %d", len(fields))}
+	}
+
+	p := Position{Variant: variant, EnPassant: NoSquare}
+
+	var errs []error
+	placementErr := p.parsePiecePlacement(fields[0])
+	if placementErr != nil {
+		errs = append(errs, placementErr)
+	}
+
+	switch fields[1] {
+	case "w":
+		p.Turn = White
+	case "b":
+		p.Turn = Black
+	default:
+		errs = append(errs, fmt.Errorf("invalid FEN side to move - '%s'", fields[1]))
+	}
+
+	// Chess960 castling rights depend on where the rooks are, which is unknown
+	// when the piece placement is invalid.
+	if placementErr == nil || variant != Chess960 {
+		if err := p.parseCastling(fields[2]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if fields[3] != "-" {
+		sq, err := ParseSquare(fields[3])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid FEN en passant square - '%s'", fields[3]))
+		}
+		p.EnPassant = sq
+	}
+
+	halfmoveClock, err := strconv.Atoi(fields[4])
+	if err != nil || halfmoveClock < 0 {
+		errs = append(errs, fmt.Errorf("invalid FEN halfmove clock - '%s'", fields[4]))
+	}
+	p.HalfmoveClock = halfmoveClock
+
+	fullmoveNumber, err := strconv.Atoi(fields[5])
+	if err != nil || fullmoveNumber < 1 {
+		errs = append(errs, fmt.Errorf("invalid FEN fullmove number - '%s'", fields[5]))
+	}
+	p.FullmoveNumber = fullmoveNumber
+
+	if len(fields) == 7 {
+		if err := p.parseChecks(fields[6]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return p, errs
+}
+
+// parseChecks reads the checks given by white and black in a Three-check FEN.
+func (p *Position) parseChecks(field string) error {
+	invalid := fmt.Errorf("invalid FEN checks - '%s'", field)
+
+	parts := strings.Split(field, "+")
+	if len(parts) != 3 || parts[0] != "" {
+		return invalid
+	}
+
+	for i, part := range parts[1:] {
+		checks, err := strconv.Atoi(part)
+		if err != nil || checks < 0 || checks > ThreeCheckLimit {
+			return invalid
+		}
+		p.Checks[i] = checks
+	}
+
+	return nil
+}
+
+func (p *Position) parsePiecePlacement(placement string) error {
+	ranks := strings.Split(placement, "/")
+	if len(ranks) != 8 {
+		return fmt.Errorf("invalid FEN piece placement - expected 8 ranks got %d", len(ranks))
+	}
+
+	for i, rank := range ranks {
+		r := 7 - i
+		f := 0
+		for j := 0; j < len(rank); j++ {
+			c := rank[j]
+			if c >= '1' && c <= '8' {
+				f += int(c - '0')
+				continue
+			}
+
+			piece, ok := pieceFromLetter(c)
+			if !ok {
+				return fmt.Errorf("invalid FEN piece - '%c'", c)
+			}
+
+			if f > 7 {
+				return fmt.Errorf("invalid FEN rank %d - too many squares", r+1)
+			}
+
+			p.Board[NewSquare(f, r)] = piece
+			f++
+		}
+
+		if f != 8 {
+			return fmt.Errorf("invalid FEN rank %d - expected 8 squares got %d", r+1, f)
+		}
+	}
+
+	return nil
+}
+
+func (p Position) FEN() string {
+	var b strings.Builder
+
+	for r := 7; r >= 0; r-- {
+		empty := 0
+		for f := 0; f < 8; f++ {
+			piece := p.Board[NewSquare(f, r)]
+			if piece.IsEmpty() {
+				empty++
+				continue
+			}
+
+			if empty > 0 {
+				b.WriteByte(byte('0' + empty))
+				empty = 0
+			}
+			b.WriteByte(piece.Letter())
+		}
+
+		if empty > 0 {
+			b.WriteByte(byte('0' + empty))
+		}
+
+		if r > 0 {
+			b.WriteByte('/')
+		}
+	}
+
+	turn := "w"
+	if p.Turn == Black {
+		turn = "b"
+	}
+
+	fen := fmt.Sprintf(
+		"%s %s %s %s %d %d",
+		b.String(),
+		turn,
+		p.castlingField(),
+		p.EnPassant,
+		p.HalfmoveClock,
+		p.FullmoveNumber,
+	)
+
+	if p.Variant == ThreeCheck {
+		fen += fmt.Sprintf(" +%d+%d", p.Checks[White], p.Checks[Black])
+	}
+
+	return fen
+}
+
+func (p Position) KingSquare(c Color) Square {
+	for sq, piece := range p.Board {
+		if piece.Type == King && piece.Color == c {
+			return Square(sq)
+		}
+	}
+	return NoSquare
+}
@@ -0,0 +1,262 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+)
+
+// castlingSide is one of the four ways to castle, in the bit order of CastlingRights.
+type castlingSide struct {
+	right    CastlingRights
+	color    Color
+	kingside bool
+}
+
+var castlingSides = [4]castlingSide{
+	{WhiteKingside, White, true},
+	{WhiteQueenside, White, false},
+	{BlackKingside, Black, true},
+	{BlackQueenside, Black, false},
+}
+
+// standardCastlingRooks are the squares of the rooks castling is with in standard
+// chess, in the order of castlingSides. The kings start on the e file.
+var standardCastlingRooks = [4]Square{7, 0, 63, 56}
+
+const standardKingFile = 4
+
+func backRank(c Color) int {
+	if c == White {
+		return 0
+	}
+	return 7
+}
+
+// kingTo is the square castling puts the king on, which is the same in every variant.
+func (s castlingSide) kingTo() Square {
+	if s.kingside {
+		return NewSquare(6, backRank(s.color))
+	}
+	return NewSquare(2, backRank(s.color))
+}
+
+// rookTo is the square castling puts the rook on, which is the same in every variant.
+func (s castlingSide) rookTo() Square {
+	if s.kingside {
+		return NewSquare(5, backRank(s.color))
+	}
+	return NewSquare(3, backRank(s.color))
+}
+
+// castlingKing returns the square of the king the side can castle with. The king
+// has to be on its starting square, which in Chess960 is anywhere on the back rank.
+func (p Position) castlingKing(side castlingSide) Square {
+	king := p.KingSquare(side.color)
+	if king == NoSquare || king.Rank() != backRank(side.color) {
+		return NoSquare
+	}
+
+	if p.Variant != Chess960 && king.File() != standardKingFile {
+		return NoSquare
+	}
+
+	return king
+}
+
+// castlingRook returns the rook the side can castle with, when the side still has the
+// right to castle and the king and rook stand where castling starts from.
+func (p Position) castlingRook(i int) (Square, bool) {
+	side := castlingSides[i]
+	if p.Castling&side.right == 0 {
+		return NoSquare, false
+	}
+
+	king := p.castlingKing(side)
+	rook := p.castlingRooks[i]
+	if king == NoSquare || p.Board[rook] != (Piece{Rook, side.color}) {
+		return NoSquare, false
+	}
+
+	if side.kingside != (rook.File() > king.File()) {
+		return NoSquare, false
+	}
+
+	return rook, true
+}
+
+// castling returns the index of the side the move castles to. Castling is written as
+// the king moving two squares in standard chess and as the king taking its own rook
+// in Chess960, where the king may move a single square or not at all.
+func (p Position) castling(m Move) (int, bool) {
+	piece := p.Board[m.From]
+	if piece.Type != King {
+		return 0, false
+	}
+
+	for i, side := range castlingSides {
+		if side.color != piece.Color || p.Castling&side.right == 0 {
+			continue
+		}
+
+		if p.Variant == Chess960 {
+			if m.To == p.castlingRooks[i] {
+				return i, true
+			}
+			continue
+		}
+
+		if m.From.File() == standardKingFile && m.To == side.kingTo() {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func (p Position) appendCastlingMoves(moves []Move, from Square) []Move {
+CastlingLoop:
+	for i, side := range castlingSides {
+		if side.color != p.Turn || p.castlingKing(side) != from {
+			continue
+		}
+
+		rook, ok := p.castlingRook(i)
+		if !ok {
+			continue
+		}
+
+		// Every square the king and rook cross or land on has to be empty,
+		// apart from the squares of the king and rook themselves.
+		rank := backRank(side.color)
+		lo := min(from.File(), rook.File(), side.kingTo().File(), side.rookTo().File())
+		hi := max(from.File(), rook.File(), side.kingTo().File(), side.rookTo().File())
+		for f := lo; f <= hi; f++ {
+			sq := NewSquare(f, rank)
+			if sq != from && sq != rook && !p.Board[sq].IsEmpty() {
+				continue CastlingLoop
+			}
+		}
+
+		// The king cannot castle out of, through or into check.
+		lo, hi = min(from.File(), side.kingTo().File()), max(from.File(), side.kingTo().File())
+		for f := lo; f <= hi; f++ {
+			if p.IsAttacked(NewSquare(f, rank), p.Turn.Other()) {
+				continue CastlingLoop
+			}
+		}
+
+		to := side.kingTo()
+		if p.Variant == Chess960 {
+			to = rook
+		}
+
+		moves = append(moves, Move{From: from, To: to})
+	}
+	return moves
+}
+
+// parseCastling reads the castling rights of the FEN. Chess960 positions take both
+// the KQkq letters, which castle with the outermost rook on their side of the king,
+// and the files of the rooks for the other rooks (X-FEN and Shredder-FEN).
+func (p *Position) parseCastling(field string) error {
+	p.castlingRooks = standardCastlingRooks
+
+	if field == "-" {
+		return nil
+	}
+
+	invalid := fmt.Errorf("invalid FEN castling rights - '%s'", field)
+
+	for _, c := range field {
+		i := strings.IndexRune("KQkq", c)
+		if i >= 0 {
+			p.Castling |= castlingSides[i].right
+			if p.Variant == Chess960 {
+				rook, ok := p.outermostRook(castlingSides[i])
+				if !ok {
+					return invalid
+				}
+				p.castlingRooks[i] = rook
+			}
+			continue
+		}
+
+		color, file := White, int(c-'A')
+		if c >= 'a' && c <= 'h' {
+			color, file = Black, int(c-'a')
+		}
+
+		if p.Variant != Chess960 || file < 0 || file > 7 {
+			return invalid
+		}
+
+		king := p.KingSquare(color)
+		if king == NoSquare || king.Rank() != backRank(color) || king.File() == file {
+			return invalid
+		}
+
+		i = 2 * int(color)
+		if file < king.File() {
+			i++
+		}
+
+		p.Castling |= castlingSides[i].right
+		p.castlingRooks[i] = NewSquare(file, backRank(color))
+	}
+
+	return nil
+}
+
+// outermostRook finds the rook furthest from the king on the side's half of the back rank.
+func (p Position) outermostRook(side castlingSide) (Square, bool) {
+	king := p.KingSquare(side.color)
+	if king == NoSquare || king.Rank() != backRank(side.color) {
+		return NoSquare, false
+	}
+
+	rank := backRank(side.color)
+	if side.kingside {
+		for f := 7; f > king.File(); f-- {
+			if p.Board[NewSquare(f, rank)] == (Piece{Rook, side.color}) {
+				return NewSquare(f, rank), true
+			}
+		}
+	} else {
+		for f := 0; f < king.File(); f++ {
+			if p.Board[NewSquare(f, rank)] == (Piece{Rook, side.color}) {
+				return NewSquare(f, rank), true
+			}
+		}
+	}
+
+	return NoSquare, false
+}
+
+// castlingField writes the castling rights of the FEN, using the files of the rooks
+// in Chess960 only for rooks which are not the outermost on their side.
+func (p Position) castlingField() string {
+	if p.Variant != Chess960 || p.Castling == NoCastling {
+		return p.Castling.String()
+	}
+
+	var b strings.Builder
+	for i, side := range castlingSides {
+		if p.Castling&side.right == 0 {
+			continue
+		}
+
+		rook := p.castlingRooks[i]
+		if outermost, ok := p.outermostRook(side); ok && outermost == rook {
+			b.WriteByte("KQkq"[i])
+			continue
+		}
+
+		letter := byte('A' + rook.File())
+		if side.color == Black {
+			letter = byte('a' + rook.File())
+		}
+		b.WriteByte(letter)
+	}
+
+	return b.String()
+}
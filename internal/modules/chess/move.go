@@ -0,0 +1,358 @@
+package chess
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrIllegalMove = errors.New("illegal move")
+
+type Move struct {
+	From      Square
+	To        Square
+	Promotion PieceType
+}
+
+func (m Move) UCI() string {
+	s := m.From.String() + m.To.String()
+	if m.Promotion != NoPieceType {
+		s += string(pieceLetters[m.Promotion])
+	}
+	return s
+}
+
+func (m Move) String() string {
+	return m.UCI()
+}
+
+func ParseUCI(s string) (Move, error) {
+	if len(s) != 4 && len(s) != 5 {
+		return Move{}, fmt.Errorf("invalid UCI move - '%s'", s)
+	}
+
+	from, err := ParseSquare(s[0:2])
+	if err != nil {
+		return Move{}, fmt.Errorf("invalid UCI move - '%s'", s)
+	}
+
+	to, err := ParseSquare(s[2:4])
+	if err != nil {
+		return Move{}, fmt.Errorf("invalid UCI move - '%s'", s)
+	}
+
+	m := Move{From: from, To: to}
+	if len(s) == 5 {
+		i := strings.IndexByte("nbrq", s[4])
+		if i < 0 {
+			return Move{}, fmt.Errorf("invalid UCI promotion - '%s'", s)
+		}
+		m.Promotion = Knight + PieceType(i)
+	}
+
+	return m, nil
+}
+
+type direction struct {
+	file, rank int
+}
+
+var (
+	knightDirections = []direction{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+	bishopDirections = []direction{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+	rookDirections   = []direction{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+	kingDirections   = append(append([]direction{}, bishopDirections...), rookDirections...)
+
+	promotionPieces = []PieceType{Queen, Rook, Bishop, Knight}
+)
+
+func (s Square) offset(d direction) (Square, bool) {
+	f, r := s.File()+d.file, s.Rank()+d.rank
+	if f < 0 || f > 7 || r < 0 || r > 7 {
+		return NoSquare, false
+	}
+	return NewSquare(f, r), true
+}
+
+func pawnDirection(c Color) int {
+	if c == White {
+		return 1
+	}
+	return -1
+}
+
+// IsAttacked reports whether any piece of the given color attacks the square.
+func (p Position) IsAttacked(sq Square, by Color) bool {
+	if sq == NoSquare {
+		return false
+	}
+
+	for _, df := range []int{-1, 1} {
+		from, ok := sq.offset(direction{df, -pawnDirection(by)})
+		if ok && p.Board[from] == (Piece{Pawn, by}) {
+			return true
+		}
+	}
+
+	for _, d := range knightDirections {
+		from, ok := sq.offset(d)
+		if ok && p.Board[from] == (Piece{Knight, by}) {
+			return true
+		}
+	}
+
+	for _, d := range kingDirections {
+		from, ok := sq.offset(d)
+		if ok && p.Board[from] == (Piece{King, by}) {
+			return true
+		}
+	}
+
+	if p.slidingAttack(sq, by, bishopDirections, Bishop) {
+		return true
+	}
+
+	return p.slidingAttack(sq, by, rookDirections, Rook)
+}
+
+func (p Position) slidingAttack(sq Square, by Color, directions []direction, slider PieceType) bool {
+	for _, d := range directions {
+		for from, ok := sq.offset(d); ok; from, ok = from.offset(d) {
+			piece := p.Board[from]
+			if piece.IsEmpty() {
+				continue
+			}
+
+			if piece.Color == by && (piece.Type == slider || piece.Type == Queen) {
+				return true
+			}
+			break
+		}
+	}
+	return false
+}
+
+func (p Position) InCheck() bool {
+	return p.IsAttacked(p.KingSquare(p.Turn), p.Turn.Other())
+}
+
+// LegalMoves returns all the moves the side to move can legally play.
+func (p Position) LegalMoves() []Move {
+	pseudoLegal := p.pseudoLegalMoves()
+	moves := make([]Move, 0, len(pseudoLegal))
+
+	for _, m := range pseudoLegal {
+		next := p.apply(m)
+		if next.IsAttacked(next.KingSquare(p.Turn), p.Turn.Other()) {
+			continue
+		}
+		moves = append(moves, m)
+	}
+
+	return moves
+}
+
+func (p Position) IsLegal(m Move) bool {
+	for _, legal := range p.LegalMoves() {
+		if legal == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Play returns the position after the move is played, or ErrIllegalMove
+// if the move cannot be played in the position.
+func (p Position) Play(m Move) (Position, error) {
+	if !p.IsLegal(m) {
+		return Position{}, fmt.Errorf("%w - '%s'", ErrIllegalMove, m)
+	}
+	return p.apply(m), nil
+}
+
+// PlayLegal returns the position after a move taken from LegalMoves, without
+// checking the move is legal again. It is meant for searches, which play every
+// legal move of the positions they visit.
+func (p Position) PlayLegal(m Move) Position {
+	return p.apply(m)
+}
+
+// IsCapture reports whether the move takes a piece, including en passant.
+func (p Position) IsCapture(m Move) bool {
+	if _, ok := p.castling(m); ok {
+		return false
+	}
+	return !p.Board[m.To].IsEmpty() || (p.Board[m.From].Type == Pawn && m.To == p.EnPassant)
+}
+
+func (p Position) pseudoLegalMoves() []Move {
+	moves := make([]Move, 0, 64)
+
+	for i, piece := range p.Board {
+		if piece.IsEmpty() || piece.Color != p.Turn {
+			continue
+		}
+
+		from := Square(i)
+		switch piece.Type {
+		case Pawn:
+			moves = p.appendPawnMoves(moves, from)
+		case Knight:
+			moves = p.appendStepMoves(moves, from, knightDirections)
+		case Bishop:
+			moves = p.appendSlidingMoves(moves, from, bishopDirections)
+		case Rook:
+			moves = p.appendSlidingMoves(moves, from, rookDirections)
+		case Queen:
+			moves = p.appendSlidingMoves(moves, from, kingDirections)
+		case King:
+			moves = p.appendStepMoves(moves, from, kingDirections)
+			moves = p.appendCastlingMoves(moves, from)
+		}
+	}
+
+	return moves
+}
+
+func (p Position) appendPawnMoves(moves []Move, from Square) []Move {
+	dir := pawnDirection(p.Turn)
+	startRank, lastRank := 1, 7
+	if p.Turn == Black {
+		startRank, lastRank = 6, 0
+	}
+
+	appendPawnMove := func(to Square) {
+		if to.Rank() != lastRank {
+			moves = append(moves, Move{From: from, To: to})
+			return
+		}
+		for _, promotion := range promotionPieces {
+			moves = append(moves, Move{From: from, To: to, Promotion: promotion})
+		}
+	}
+
+	if to, ok := from.offset(direction{0, dir}); ok && p.Board[to].IsEmpty() {
+		appendPawnMove(to)
+
+		if from.Rank() == startRank {
+			if to, ok := to.offset(direction{0, dir}); ok && p.Board[to].IsEmpty() {
+				appendPawnMove(to)
+			}
+		}
+	}
+
+	for _, df := range []int{-1, 1} {
+		to, ok := from.offset(direction{df, dir})
+		if !ok {
+			continue
+		}
+
+		target := p.Board[to]
+		if (!target.IsEmpty() && target.Color != p.Turn) || to == p.EnPassant {
+			appendPawnMove(to)
+		}
+	}
+
+	return moves
+}
+
+func (p Position) appendStepMoves(moves []Move, from Square, directions []direction) []Move {
+	for _, d := range directions {
+		to, ok := from.offset(d)
+		if !ok {
+			continue
+		}
+
+		target := p.Board[to]
+		if target.IsEmpty() || target.Color != p.Turn {
+			moves = append(moves, Move{From: from, To: to})
+		}
+	}
+	return moves
+}
+
+func (p Position) appendSlidingMoves(moves []Move, from Square, directions []direction) []Move {
+	for _, d := range directions {
+		for to, ok := from.offset(d); ok; to, ok = to.offset(d) {
+			target := p.Board[to]
+			if target.IsEmpty() {
+				moves = append(moves, Move{From: from, To: to})
+				continue
+			}
+
+			if target.Color != p.Turn {
+				moves = append(moves, Move{From: from, To: to})
+			}
+			break
+		}
+	}
+	return moves
+}
+
+// apply plays the move without checking whether it is legal.
+func (p Position) apply(m Move) Position {
+	next := p
+	piece := p.Board[m.From]
+	captured := p.Board[m.To]
+
+	next.EnPassant = NoSquare
+	next.HalfmoveClock++
+
+	if i, ok := p.castling(m); ok {
+		side := castlingSides[i]
+		next.Board[m.From] = Piece{}
+		next.Board[p.castlingRooks[i]] = Piece{}
+		next.Board[side.kingTo()] = piece
+		next.Board[side.rookTo()] = Piece{Rook, piece.Color}
+	} else {
+		if piece.Type == Pawn || !captured.IsEmpty() {
+			next.HalfmoveClock = 0
+		}
+
+		if piece.Type == Pawn {
+			if m.To == p.EnPassant {
+				next.Board[NewSquare(m.To.File(), m.From.Rank())] = Piece{}
+			}
+
+			if m.To.Rank()-m.From.Rank() == 2*pawnDirection(p.Turn) {
+				next.setEnPassant(m.To, Square(int(m.From)+8*pawnDirection(p.Turn)))
+			}
+		}
+
+		next.Board[m.From] = Piece{}
+		next.Board[m.To] = piece
+		if m.Promotion != NoPieceType {
+			next.Board[m.To] = Piece{Type: m.Promotion, Color: piece.Color}
+		}
+	}
+
+	for i, side := range castlingSides {
+		rook := p.castlingRooks[i]
+		if (piece.Type == King && piece.Color == side.color) || m.From == rook || m.To == rook {
+			next.Castling &^= side.right
+		}
+	}
+
+	next.Turn = p.Turn.Other()
+	if p.Turn == Black {
+		next.FullmoveNumber++
+	}
+
+	if p.Variant == ThreeCheck && next.InCheck() {
+		next.Checks[p.Turn]++
+	}
+
+	return next
+}
+
+// setEnPassant only records the en passant square when an enemy pawn
+// is in place to capture, so equal positions compare equal.
+func (p *Position) setEnPassant(pawn Square, sq Square) {
+	for _, df := range []int{-1, 1} {
+		adjacent, ok := pawn.offset(direction{df, 0})
+		if ok && p.Board[adjacent] == (Piece{Pawn, p.Turn.Other()}) {
+			p.EnPassant = sq
+			return
+		}
+	}
+}
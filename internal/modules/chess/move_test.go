@@ -0,0 +1,128 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func perft(p Position, depth int) int {
+	if depth == 0 {
+		return 1
+	}
+
+	moves := p.LegalMoves()
+	if depth == 1 {
+		return len(moves)
+	}
+
+	nodes := 0
+	for _, m := range moves {
+		nodes += perft(p.apply(m), depth-1)
+	}
+	return nodes
+}
+
+func Test_LegalMoves_Perft(t *testing.T) {
+	tests := []struct {
+		name  string
+		fen   string
+		depth int
+		nodes int
+	}{
+		{"starting position", StartingFEN, 3, 8902},
+		{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", 3, 97862},
+		{"en passant and promotions", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", 4, 43238},
+		{"castling and checks", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", 3, 9467},
+		{"promotion into check", "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", 3, 62379},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p, err := ParseFEN(tt.fen)
+			require.NoError(t, err)
+
+			// Act
+			nodes := perft(p, tt.depth)
+
+			// Assert
+			require.Equal(t, tt.nodes, nodes)
+		})
+	}
+}
+
+func Test_ParseFEN_Round_Trips(t *testing.T) {
+	// Arrange
+	fen := "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1"
+
+	// Act
+	p, err := ParseFEN(fen)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, fen, p.FEN())
+}
+
+func Test_SAN_Disambiguates_By_File(t *testing.T) {
+	// Arrange
+	p, err := ParseFEN("6k1/5ppp/8/8/8/8/8/R3R1K1 w - - 0 1")
+	require.NoError(t, err)
+
+	move, err := ParseUCI("a1d1")
+	require.NoError(t, err)
+
+	// Act
+	san := p.SAN(move)
+
+	// Assert
+	require.Equal(t, "Rad1", san)
+}
+
+func Test_Outcome_Detects_Checkmate(t *testing.T) {
+	// Arrange
+	p := StartingPosition()
+	for _, uci := range []string{"f2f3", "e7e5", "g2g4", "d8h4"} {
+		m, err := ParseUCI(uci)
+		require.NoError(t, err)
+
+		p, err = p.Play(m)
+		require.NoError(t, err)
+	}
+
+	// Act
+	result, termination := p.Outcome()
+
+	// Assert
+	require.Equal(t, BlackWins, result)
+	require.Equal(t, Checkmate, termination)
+}
+
+func Test_HasMatingMaterial(t *testing.T) {
+	tests := []struct {
+		name     string
+		fen      string
+		color    Color
+		expected bool
+	}{
+		{"lone king", "8/8/4k3/8/8/8/3QK3/8 w - - 0 1", Black, false},
+		{"knight against lone king", "8/8/4k3/8/8/8/3NK3/8 w - - 0 1", White, false},
+		{"knight against pieces", "8/8/4k3/4p3/8/8/3NK3/8 w - - 0 1", White, true},
+		{"rook", "8/8/4k3/8/8/8/3RK3/8 w - - 0 1", White, true},
+		{"bishop pair", "8/8/4k3/8/8/8/2BBK3/8 w - - 0 1", White, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p, err := ParseFEN(tt.fen)
+			require.NoError(t, err)
+
+			// Act
+			hasMatingMaterial := p.HasMatingMaterial(tt.color)
+
+			// Assert
+			require.Equal(t, tt.expected, hasMatingMaterial)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package chess
+
+type Result string
+
+const (
+	NoResult  Result = "*"
+	WhiteWins Result = "1-0"
+	BlackWins Result = "0-1"
+	Draw      Result = "1/2-1/2"
+)
+
+func Win(c Color) Result {
+	if c == White {
+		return WhiteWins
+	}
+	return BlackWins
+}
+
+type Termination string
+
+const (
+	NoTermination        Termination = ""
+	Checkmate            Termination = "checkmate"
+	Stalemate            Termination = "stalemate"
+	InsufficientMaterial Termination = "insufficient_material"
+	Timeout              Termination = "timeout"
+	Resignation          Termination = "resignation"
+	Agreement            Termination = "agreement"
+	ThreefoldRepetition  Termination = "threefold_repetition"
+	FiftyMoveRule        Termination = "fifty_move_rule"
+	// Abandonment ends games the opponent of the player who left claimed.
+	Abandonment Termination = "abandonment"
+	// Aborted games were left before both players moved and have no result.
+	Aborted Termination = "aborted"
+	// KingInTheCenter ends King of the Hill games.
+	KingInTheCenter Termination = "king_in_the_center"
+	// ThreeChecks ends Three-check games.
+	ThreeChecks Termination = "three_checks"
+)
+
+// hill are the central squares which win King of the Hill games.
+var hill = [...]Square{27, 28, 35, 36}
+
+// Outcome returns the result of the position if the game is over by the rules
+// alone, or NoResult if the game can continue.
+func (p Position) Outcome() (Result, Termination) {
+	if result, termination := p.VariantOutcome(); result != NoResult {
+		return result, termination
+	}
+
+	if len(p.LegalMoves()) == 0 {
+		if p.InCheck() {
+			return Win(p.Turn.Other()), Checkmate
+		}
+		return Draw, Stalemate
+	}
+
+	if p.IsInsufficientMaterial() {
+		return Draw, InsufficientMaterial
+	}
+
+	return NoResult, NoTermination
+}
+
+// VariantOutcome returns the result of the position if the game is won by the
+// rules of its variant, which are reached by the move before the position and
+// come before checkmate and stalemate. Standard games return NoResult.
+func (p Position) VariantOutcome() (Result, Termination) {
+	switch p.Variant {
+	case KingOfTheHill:
+		for _, sq := range hill {
+			if piece := p.Board[sq]; piece.Type == King {
+				return Win(piece.Color), KingInTheCenter
+			}
+		}
+	case ThreeCheck:
+		for _, c := range []Color{White, Black} {
+			if p.Checks[c] >= ThreeCheckLimit {
+				return Win(c), ThreeChecks
+			}
+		}
+	}
+
+	return NoResult, NoTermination
+}
+
+// IsInsufficientMaterial reports whether neither side can ever deliver mate,
+// which is the case for K v K, K+minor v K and bishops all on the same colour.
+// A king can always walk to the hill, and any piece can give checks, so those
+// variants only draw for lack of material with bare kings.
+func (p Position) IsInsufficientMaterial() bool {
+	switch p.Variant {
+	case KingOfTheHill:
+		return false
+	case ThreeCheck:
+		for _, piece := range p.Board {
+			if !piece.IsEmpty() && piece.Type != King {
+				return false
+			}
+		}
+		return true
+	}
+
+	var minors, knights int
+	var lightBishops, darkBishops bool
+
+	for i, piece := range p.Board {
+		switch piece.Type {
+		case Pawn, Rook, Queen:
+			return false
+		case Knight:
+			minors++
+			knights++
+		case Bishop:
+			minors++
+			if Square(i).IsLight() {
+				lightBishops = true
+			} else {
+				darkBishops = true
+			}
+		}
+	}
+
+	if minors <= 1 {
+		return true
+	}
+
+	return knights == 0 && !(lightBishops && darkBishops)
+}
+
+// HasMatingMaterial reports whether the color could deliver mate by any sequence of moves.
+// This decides whether running out of time loses or draws.
+func (p Position) HasMatingMaterial(c Color) bool {
+	switch p.Variant {
+	case KingOfTheHill:
+		return true
+	case ThreeCheck:
+		for _, piece := range p.Board {
+			if piece.Color == c && !piece.IsEmpty() && piece.Type != King {
+				return true
+			}
+		}
+		return false
+	}
+
+	var knights, otherPieces int
+	var lightBishops, darkBishops bool
+	var opponentHasBlockers bool
+
+	for i, piece := range p.Board {
+		if piece.IsEmpty() || piece.Type == King {
+			continue
+		}
+
+		// A lone minor piece can only mate when the opponent's
+		// own pieces block their king in.
+		if piece.Color != c {
+			opponentHasBlockers = true
+			continue
+		}
+
+		switch piece.Type {
+		case Knight:
+			knights++
+		case Bishop:
+			if Square(i).IsLight() {
+				lightBishops = true
+			} else {
+				darkBishops = true
+			}
+		default:
+			otherPieces++
+		}
+	}
+
+	switch {
+	case otherPieces > 0:
+		return true
+	case knights > 1:
+		return true
+	case knights == 1 && (lightBishops || darkBishops):
+		return true
+	case lightBishops && darkBishops:
+		return true
+	case knights == 1 || lightBishops || darkBishops:
+		return opponentHasBlockers
+	default:
+		return false
+	}
+}
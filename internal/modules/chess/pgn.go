@@ -0,0 +1,279 @@
+package chess
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SevenTagRoster are the tags every PGN game has, in the order they are exported in.
+var SevenTagRoster = []string{"Event", "Site", "Date", "Round", "White", "Black", "Result"}
+
+type PGNTag struct {
+	Name  string
+	Value string
+}
+
+// PGNGame is a game in Portable Game Notation. Moves is the main line,
+// variations hang off the moves they are alternatives to.
+type PGNGame struct {
+	Tags   []PGNTag
+	Moves  []PGNMove
+	Result Result
+}
+
+// PGNMove is a move with its annotations. UCI is only known once the game is resolved.
+type PGNMove struct {
+	SAN string
+	UCI string
+	// NAGs are the Numeric Annotation Glyphs, '!' and '?' suffixes are read as their NAGs.
+	NAGs []int
+	// CommentsBefore only occur before the first move of a line.
+	CommentsBefore []string
+	Comments       []string
+	// Variations are lines played instead of the move.
+	Variations [][]PGNMove
+}
+
+// Tag returns the value of the tag, empty when the game does not have it.
+func (g PGNGame) Tag(name string) string {
+	for _, tag := range g.Tags {
+		if tag.Name == name {
+			return tag.Value
+		}
+	}
+	return ""
+}
+
+// SetTag replaces the value of the tag or adds the tag.
+func (g *PGNGame) SetTag(name, value string) {
+	for i, tag := range g.Tags {
+		if tag.Name == name {
+			g.Tags[i].Value = value
+			return
+		}
+	}
+	g.Tags = append(g.Tags, PGNTag{Name: name, Value: value})
+}
+
+// InitialFEN is the position the game starts from, set with the FEN tag.
+func (g PGNGame) InitialFEN() string {
+	if fen := g.Tag("FEN"); fen != "" {
+		return fen
+	}
+	return StartingFEN
+}
+
+// Variant is the variant the game is played in, set with the Variant tag.
+func (g PGNGame) Variant() (Variant, error) {
+	return ParsePGNVariant(g.Tag("Variant"))
+}
+
+// Resolve plays the game's moves and variations by the rules, which fills in their
+// UCI and rewrites their SAN in its canonical form. The Result must agree with how
+// the main line ends when it ends by the rules.
+func (g *PGNGame) Resolve() error {
+	variant, err := g.Variant()
+	if err != nil {
+		return err
+	}
+
+	position, err := ParseVariantFEN(variant, g.InitialFEN())
+	if err != nil {
+		return err
+	}
+
+	if position.KingSquare(White) == NoSquare || position.KingSquare(Black) == NoSquare {
+		return fmt.Errorf("invalid FEN - both sides need a king")
+	}
+
+	final, err := resolveLine(position, g.Moves)
+	if err != nil {
+		return err
+	}
+
+	if result, _ := final.Outcome(); result != NoResult && g.Result != NoResult && g.Result != result {
+		return fmt.Errorf("result '%s' does not match the final position, which is '%s'", g.Result, result)
+	}
+
+	return nil
+}
+
+func resolveLine(position Position, line []PGNMove) (Position, error) {
+	for i := range line {
+		for _, variation := range line[i].Variations {
+			if _, err := resolveLine(position, variation); err != nil {
+				return Position{}, err
+			}
+		}
+
+		move, err := position.ParseSAN(line[i].SAN)
+		if err != nil {
+			return Position{}, fmt.Errorf("move %s: %w", moveNumber(position), err)
+		}
+
+		line[i].SAN = position.SAN(move)
+		line[i].UCI = move.UCI()
+		position = position.apply(move)
+	}
+
+	return position, nil
+}
+
+func moveNumber(p Position) string {
+	if p.Turn == White {
+		return fmt.Sprintf("%d.", p.FullmoveNumber)
+	}
+	return fmt.Sprintf("%d...", p.FullmoveNumber)
+}
+
+// MainLine returns the UCI moves of the main line of a resolved game.
+func (g PGNGame) MainLine() []string {
+	moves := make([]string, 0, len(g.Moves))
+	for _, move := range g.Moves {
+		moves = append(moves, move.UCI)
+	}
+	return moves
+}
+
+// pgnLineLength is the length export format lines are wrapped at.
+const pgnLineLength = 79
+
+// String formats the game in the PGN export format: the Seven Tag Roster first,
+// then the other tags and the movetext wrapped at 80 columns.
+func (g PGNGame) String() string {
+	var b strings.Builder
+
+	for _, name := range SevenTagRoster {
+		value := g.Tag(name)
+		switch {
+		case name == "Result":
+			value = string(g.Result)
+		case value == "":
+			value = "?"
+		}
+		writeTag(&b, name, value)
+	}
+
+	for _, tag := range g.Tags {
+		if !slices.Contains(SevenTagRoster, tag.Name) {
+			writeTag(&b, tag.Name, tag.Value)
+		}
+	}
+
+	b.WriteByte('\n')
+
+	tokens := g.movetextTokens()
+	tokens = append(tokens, string(g.Result))
+
+	length := 0
+	for _, token := range tokens {
+		if length > 0 && length+1+len(token) > pgnLineLength {
+			b.WriteByte('\n')
+			length = 0
+		}
+
+		if length > 0 {
+			b.WriteByte(' ')
+			length++
+		}
+
+		b.WriteString(token)
+		length += len(token)
+	}
+
+	b.WriteString("\n\n")
+
+	return b.String()
+}
+
+// WritePGN writes the games in the PGN export format.
+func WritePGN(w io.Writer, games ...PGNGame) error {
+	for _, game := range games {
+		if _, err := io.WriteString(w, game.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTag(b *strings.Builder, name, value string) {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	fmt.Fprintf(b, "[%s \"%s\"]\n", name, value)
+}
+
+func (g PGNGame) movetextTokens() []string {
+	variant, err := g.Variant()
+	if err != nil {
+		variant = Standard
+	}
+
+	position, err := ParseVariantFEN(variant, g.InitialFEN())
+	if err != nil {
+		position = StartingPosition()
+	}
+
+	return lineTokens(nil, g.Moves, position.Turn, position.FullmoveNumber)
+}
+
+// lineTokens formats the line. Move numbers are written before white's moves, and
+// before black's moves when they follow a comment, a variation or start the line.
+func lineTokens(tokens []string, line []PGNMove, turn Color, fullmove int) []string {
+	numbered := false
+
+	for _, move := range line {
+		for _, comment := range move.CommentsBefore {
+			tokens = append(tokens, commentToken(comment))
+			numbered = false
+		}
+
+		// Move numbers share the token of their move, so lines never break between them.
+		switch {
+		case turn == White:
+			tokens = append(tokens, strconv.Itoa(fullmove)+". "+move.SAN)
+		case !numbered:
+			tokens = append(tokens, strconv.Itoa(fullmove)+"... "+move.SAN)
+		default:
+			tokens = append(tokens, move.SAN)
+		}
+		numbered = true
+
+		for _, nag := range move.NAGs {
+			tokens = append(tokens, "$"+strconv.Itoa(nag))
+		}
+
+		for _, comment := range move.Comments {
+			tokens = append(tokens, commentToken(comment))
+			numbered = false
+		}
+
+		for _, variation := range move.Variations {
+			variationTokens := lineTokens(nil, variation, turn, fullmove)
+			if len(variationTokens) == 0 {
+				continue
+			}
+
+			variationTokens[0] = "(" + variationTokens[0]
+			variationTokens[len(variationTokens)-1] += ")"
+			tokens = append(tokens, variationTokens...)
+			numbered = false
+		}
+
+		if turn == Black {
+			fullmove++
+		}
+		turn = turn.Other()
+	}
+
+	return tokens
+}
+
+// commentToken keeps comments on a single token, so they are not wrapped
+// inside, and drops the closing braces comments cannot contain.
+func commentToken(comment string) string {
+	comment = strings.ReplaceAll(comment, "}", "")
+	return "{" + strings.Join(strings.Fields(comment), " ") + "}"
+}
@@ -0,0 +1,362 @@
+package chess
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxPGNVariationDepth caps how deep variations can be nested.
+const MaxPGNVariationDepth = 32
+
+// suffixNAGs are the NAGs of the move suffix annotations.
+var suffixNAGs = map[string]int{"!": 1, "?": 2, "!!": 3, "??": 4, "!?": 5, "?!": 6}
+
+// ParsePGN parses the games of a PGN file. Only the syntax is checked, the moves
+// are checked against the rules once the games are resolved.
+func ParsePGN(pgn string) ([]PGNGame, error) {
+	p := pgnParser{input: pgn, line: 1}
+
+	var games []PGNGame
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return games, nil
+		}
+
+		game, err := p.game()
+		if err != nil {
+			return nil, fmt.Errorf("pgn line %d: %w", p.line, err)
+		}
+
+		games = append(games, game)
+	}
+}
+
+type pgnParser struct {
+	input string
+	pos   int
+	line  int
+}
+
+func (p *pgnParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *pgnParser) peek() byte {
+	return p.input[p.pos]
+}
+
+func (p *pgnParser) next() byte {
+	c := p.input[p.pos]
+	p.pos++
+	if c == '\n' {
+		p.line++
+	}
+	return c
+}
+
+// skipSpace skips whitespace and the lines escaped with '%'.
+func (p *pgnParser) skipSpace() {
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == '%' && (p.pos == 0 || p.input[p.pos-1] == '\n'):
+			p.skipLine()
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.next()
+		default:
+			return
+		}
+	}
+}
+
+func (p *pgnParser) skipLine() {
+	for !p.eof() && p.next() != '\n' {
+	}
+}
+
+func (p *pgnParser) game() (PGNGame, error) {
+	game := PGNGame{Result: NoResult}
+
+	for {
+		p.skipSpace()
+		if p.eof() || p.peek() != '[' {
+			break
+		}
+
+		tag, err := p.tag()
+		if err != nil {
+			return PGNGame{}, err
+		}
+		game.Tags = append(game.Tags, tag)
+	}
+
+	if result := Result(game.Tag("Result")); isResult(string(result)) {
+		game.Result = result
+	}
+
+	moves, result, err := p.movetext(0)
+	if err != nil {
+		return PGNGame{}, err
+	}
+
+	game.Moves = moves
+	if result != "" {
+		game.Result = result
+		game.SetTag("Result", string(result))
+	}
+
+	return game, nil
+}
+
+func (p *pgnParser) tag() (PGNTag, error) {
+	p.next() // [
+	p.skipSpace()
+
+	start := p.pos
+	for !p.eof() && isSymbolChar(p.peek()) {
+		p.next()
+	}
+
+	name := p.input[start:p.pos]
+	if name == "" {
+		return PGNTag{}, fmt.Errorf("missing tag name")
+	}
+
+	p.skipSpace()
+	if p.eof() || p.next() != '"' {
+		return PGNTag{}, fmt.Errorf("missing value of tag '%s'", name)
+	}
+
+	var value strings.Builder
+	for {
+		if p.eof() {
+			return PGNTag{}, fmt.Errorf("unterminated value of tag '%s'", name)
+		}
+
+		c := p.next()
+		if c == '"' {
+			break
+		}
+
+		if c == '\\' && !p.eof() && (p.peek() == '"' || p.peek() == '\\') {
+			c = p.next()
+		}
+
+		if c == '\n' {
+			return PGNTag{}, fmt.Errorf("unterminated value of tag '%s'", name)
+		}
+
+		value.WriteByte(c)
+	}
+
+	p.skipSpace()
+	if p.eof() || p.next() != ']' {
+		return PGNTag{}, fmt.Errorf("unterminated tag '%s'", name)
+	}
+
+	return PGNTag{Name: name, Value: value.String()}, nil
+}
+
+// movetext parses the moves of the main line when depth is 0, otherwise those of a
+// variation up to its closing parenthesis. The main line ends at the game termination
+// marker, which is returned, or at the tags of the next game.
+func (p *pgnParser) movetext(depth int) ([]PGNMove, Result, error) {
+	var (
+		moves   []PGNMove
+		pending []string
+	)
+
+	last := func() *PGNMove {
+		if len(moves) == 0 {
+			return nil
+		}
+		return &moves[len(moves)-1]
+	}
+
+	end := func() []PGNMove {
+		if len(pending) > 0 && last() != nil {
+			last().Comments = append(last().Comments, pending...)
+		}
+		return moves
+	}
+
+	for {
+		p.skipSpace()
+
+		if p.eof() {
+			if depth > 0 {
+				return nil, "", fmt.Errorf("unterminated variation")
+			}
+			return end(), "", nil
+		}
+
+		switch c := p.peek(); {
+		case c == '[':
+			if depth > 0 {
+				return nil, "", fmt.Errorf("unterminated variation")
+			}
+			return end(), "", nil
+
+		case c == '{':
+			comment, err := p.braceComment()
+			if err != nil {
+				return nil, "", err
+			}
+
+			if last() == nil {
+				pending = append(pending, comment)
+			} else {
+				last().Comments = append(last().Comments, comment)
+			}
+
+		case c == ';':
+			p.next()
+			start := p.pos
+			p.skipLine()
+			comment := strings.TrimSpace(p.input[start:p.pos])
+
+			if last() == nil {
+				pending = append(pending, comment)
+			} else {
+				last().Comments = append(last().Comments, comment)
+			}
+
+		case c == '(':
+			p.next()
+			if last() == nil {
+				return nil, "", fmt.Errorf("variation before any move")
+			}
+
+			if depth+1 > MaxPGNVariationDepth {
+				return nil, "", fmt.Errorf("variations nested deeper than %d", MaxPGNVariationDepth)
+			}
+
+			variation, _, err := p.movetext(depth + 1)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if len(variation) == 0 {
+				return nil, "", fmt.Errorf("empty variation")
+			}
+
+			last().Variations = append(last().Variations, variation)
+
+		case c == ')':
+			p.next()
+			if depth == 0 {
+				return nil, "", fmt.Errorf("unexpected ')'")
+			}
+			return end(), "", nil
+
+		case c == '$':
+			p.next()
+			start := p.pos
+			for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+				p.next()
+			}
+
+			nag, err := strconv.Atoi(p.input[start:p.pos])
+			if err != nil || nag > 255 {
+				return nil, "", fmt.Errorf("invalid NAG '$%s'", p.input[start:p.pos])
+			}
+
+			if err := appendNAG(last(), nag); err != nil {
+				return nil, "", err
+			}
+
+		case c == '!' || c == '?':
+			start := p.pos
+			for !p.eof() && (p.peek() == '!' || p.peek() == '?') {
+				p.next()
+			}
+
+			nag, found := suffixNAGs[p.input[start:p.pos]]
+			if !found {
+				return nil, "", fmt.Errorf("invalid annotation '%s'", p.input[start:p.pos])
+			}
+
+			if err := appendNAG(last(), nag); err != nil {
+				return nil, "", err
+			}
+
+		case c == '.':
+			p.next()
+
+		case isSymbolChar(c):
+			start := p.pos
+			for !p.eof() && isSymbolChar(p.peek()) {
+				p.next()
+			}
+			symbol := p.input[start:p.pos]
+
+			switch {
+			case isResult(symbol):
+				if depth > 0 {
+					return nil, "", fmt.Errorf("game termination marker '%s' inside a variation", symbol)
+				}
+				return end(), Result(symbol), nil
+
+			case isMoveNumber(symbol):
+				// Move numbers only help the reader, the moves alternate regardless.
+
+			default:
+				moves = append(moves, PGNMove{SAN: symbol, CommentsBefore: pending})
+				pending = nil
+			}
+
+		default:
+			return nil, "", fmt.Errorf("unexpected '%c'", c)
+		}
+	}
+}
+
+func (p *pgnParser) braceComment() (string, error) {
+	p.next() // {
+
+	start := p.pos
+	for {
+		if p.eof() {
+			return "", fmt.Errorf("unterminated comment")
+		}
+
+		if p.next() == '}' {
+			return strings.TrimSpace(p.input[start : p.pos-1]), nil
+		}
+	}
+}
+
+func appendNAG(move *PGNMove, nag int) error {
+	if move == nil {
+		return fmt.Errorf("annotation before any move")
+	}
+
+	move.NAGs = append(move.NAGs, nag)
+	return nil
+}
+
+func isSymbolChar(c byte) bool {
+	return c >= 'a' && c <= 'z' ||
+		c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' ||
+		strings.IndexByte("_+#=:-/*", c) >= 0
+}
+
+func isResult(symbol string) bool {
+	switch Result(symbol) {
+	case WhiteWins, BlackWins, Draw, NoResult:
+		return true
+	default:
+		return false
+	}
+}
+
+func isMoveNumber(symbol string) bool {
+	for i := 0; i < len(symbol); i++ {
+		if symbol[i] < '0' || symbol[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,238 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const annotatedPGN = `[Event "Casual game"]
+[Site "?"]
+[Date "2024.01.02"]
+[Round "-"]
+[White "Anderssen, Adolf"]
+[Black "Kieseritzky, Lionel"]
+[Result "1-0"]
+[Annotator "The \"Immortal\" editor"]
+
+{The Immortal Game} 1. e4 e5 2. f4 exf4 3. Bc4 Qh4+ 4. Kf1 b5?! (4... Nf6 $1 {is
+the modern choice} 5. Nc3 (5. d3)) 5. Bxb5 Nf6 6. Nf3 Qh6 7. d3 Nh5 8. Nh4 Qg5
+9. Nf5 c6 10. g4 Nf6 11. Rg1 cxb5 12. h4 Qg6 13. h5 Qg5 14. Qf3 Ng8 15. Bxf4 Qf6
+16. Nc3 Bc5 17. Nd5 Qxb2 18. Bd6 Bxg1 ; White gives up both rooks
+19. e5 Qxa1+ 20. Ke2 Na6 21. Nxg7+ Kd8 22. Qf6+ Nxf6 23. Be7# 1-0
+`
+
+func Test_ParsePGN_Reads_Tags_Moves_And_Annotations(t *testing.T) {
+	// Act
+	games, err := ParsePGN(annotatedPGN)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, games, 1)
+
+	game := games[0]
+	require.Equal(t, "Anderssen, Adolf", game.Tag("White"))
+	require.Equal(t, `The "Immortal" editor`, game.Tag("Annotator"))
+	require.Equal(t, WhiteWins, game.Result)
+	require.Len(t, game.Moves, 45)
+
+	require.Equal(t, []string{"The Immortal Game"}, game.Moves[0].CommentsBefore)
+
+	b5 := game.Moves[7]
+	require.Equal(t, "b5", b5.SAN)
+	require.Equal(t, []int{6}, b5.NAGs)
+	require.Len(t, b5.Variations, 1)
+
+	variation := b5.Variations[0]
+	require.Equal(t, "Nf6", variation[0].SAN)
+	require.Equal(t, []int{1}, variation[0].NAGs)
+	require.Equal(t, []string{"is\nthe modern choice"}, variation[0].Comments)
+	require.Equal(t, "d3", variation[1].Variations[0][0].SAN)
+
+	require.Equal(t, []string{"White gives up both rooks"}, game.Moves[35].Comments)
+
+	require.NoError(t, game.Resolve())
+	require.Equal(t, "d6e7", game.Moves[44].UCI)
+	require.Equal(t, "Be7#", game.Moves[44].SAN)
+}
+
+func Test_ParsePGN_Reads_Several_Games(t *testing.T) {
+	pgn := "[Event \"First\"]\n\n1. e4 e5 *\n\n[Event \"Second\"]\n\n1. d4 d5 2. c4 1/2-1/2\n[Event \"Third\"]\n1. c4"
+
+	games, err := ParsePGN(pgn)
+
+	require.NoError(t, err)
+	require.Len(t, games, 3)
+	require.Equal(t, "Second", games[1].Tag("Event"))
+	require.Equal(t, Draw, games[1].Result)
+	require.Len(t, games[1].Moves, 3)
+	require.Equal(t, NoResult, games[2].Result)
+}
+
+func Test_ParsePGN_Rejects_Malformed_PGN(t *testing.T) {
+	tests := []string{
+		`[Event "unterminated`,
+		`[Event "x"`,
+		`1. e4 (e5`,
+		`1. e4 e5)`,
+		`1. e4 {comment`,
+		`$1 1. e4`,
+		`(1. e4)`,
+		`1. e4 ()`,
+		`1. e4 $300`,
+		`1. e4 !!!`,
+		`1. e4 (1... e5 1-0)`,
+		`1. e4 @`,
+	}
+
+	for _, pgn := range tests {
+		_, err := ParsePGN(pgn)
+		require.Error(t, err, pgn)
+	}
+}
+
+func Test_PGNGame_Resolve_Rejects_Illegal_Moves(t *testing.T) {
+	tests := []struct {
+		name string
+		pgn  string
+	}{
+		{"illegal main line move", "1. e4 e5 2. Ke3 *"},
+		{"illegal variation move", "1. e4 e5 (1... Nf3) *"},
+		{"result contradicting mate", "1. f3 e5 2. g4 Qh4# 1-0"},
+		{"missing king", "[FEN \"8/8/8/8/8/8/8/4K3 w - - 0 1\"]\n\n1. Kd1 *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			games, err := ParsePGN(tt.pgn)
+			require.NoError(t, err)
+			require.Error(t, games[0].Resolve())
+		})
+	}
+}
+
+func Test_PGNGame_String_Writes_Export_Format(t *testing.T) {
+	// Arrange
+	games, err := ParsePGN(`[White "a"] [Black "b"] [TimeControl "180+2"]
+1. e4 {[%clk 0:03:00]} e5 $2 (1... c5 2. Nf3) 2. Nf3 1-0`)
+	require.NoError(t, err)
+
+	// Act
+	pgn := games[0].String()
+
+	// Assert
+	expected := `[Event "?"]
+[Site "?"]
+[Date "?"]
+[Round "?"]
+[White "a"]
+[Black "b"]
+[Result "1-0"]
+[TimeControl "180+2"]
+
+1. e4 {[%clk 0:03:00]} 1... e5 $2 (1... c5 2. Nf3) 2. Nf3 1-0
+
+`
+	require.Equal(t, expected, pgn)
+}
+
+func Test_PGNGame_String_Numbers_Moves_From_FEN(t *testing.T) {
+	game := PGNGame{
+		Tags:   []PGNTag{{"FEN", "4k3/8/8/8/8/8/8/4K2R b K - 0 30"}, {"SetUp", "1"}},
+		Moves:  []PGNMove{{SAN: "Kd7"}, {SAN: "O-O"}},
+		Result: NoResult,
+	}
+
+	require.NoError(t, game.Resolve())
+	require.Contains(t, game.String(), "\n30... Kd7 31. O-O *\n")
+}
+
+func Test_ParseSAN(t *testing.T) {
+	position, err := ParseFEN("r3k2r/1P6/8/8/8/2N3N1/8/R3K2R w KQkq - 0 1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		san string
+		uci string
+	}{
+		{"O-O", "e1g1"},
+		{"0-0-0", "e1c1"},
+		{"Nce4", "c3e4"},
+		{"Nc3e4", "c3e4"},
+		{"bxa8=Q+", "b7a8q"},
+		{"bxa8N", "b7a8n"},
+		{"b8=R!", "b7b8r"},
+		{"Rad1", "a1d1"},
+	}
+
+	for _, tt := range tests {
+		move, err := position.ParseSAN(tt.san)
+		require.NoError(t, err, tt.san)
+		require.Equal(t, tt.uci, move.UCI(), tt.san)
+	}
+
+	for _, san := range []string{"Ne4", "b8", "Ke3", "Qd1", "", "x", "Nc3xe44", "a9=Q"} {
+		_, err := position.ParseSAN(san)
+		require.Error(t, err, san)
+	}
+}
+
+func FuzzParsePGN(f *testing.F) {
+	f.Add(annotatedPGN)
+	f.Add("[Event \"x\"]\n1. e4 e5 (1... c5 {Sicilian} 2. Nf3 $1) 2. Nf3 Nc6 *")
+	f.Add("1. e4 ; comment\n% escaped\n1... e5 2. Qh5?? Nc6 3. Bc4 Nf6 4. Qxf7# 1-0")
+	f.Add("[FEN \"4k3/8/8/8/8/8/8/4K2R w K - 0 1\"]\n1. O-O Kd7 1/2-1/2")
+
+	f.Fuzz(func(t *testing.T, pgn string) {
+		games, err := ParsePGN(pgn)
+		if err != nil {
+			return
+		}
+
+		// The export format parses back to games which export the same.
+		exported := ""
+		for _, game := range games {
+			exported += game.String()
+		}
+
+		reparsed, err := ParsePGN(exported)
+		require.NoError(t, err, exported)
+		require.Len(t, reparsed, len(games))
+
+		reexported := ""
+		for _, game := range reparsed {
+			reexported += game.String()
+		}
+		require.Equal(t, exported, reexported)
+
+		for _, game := range games {
+			_ = game.Resolve()
+		}
+	})
+}
+
+func FuzzParseSAN(f *testing.F) {
+	for _, san := range []string{"e4", "Nf3", "O-O", "exd5", "e8=Q", "Qh4#", "Raxd1+"} {
+		f.Add(StartingFEN, san)
+	}
+	f.Add("r3k2r/1P6/8/8/8/2N3N1/8/R3K2R w KQkq - 0 1", "bxa8=Q")
+
+	f.Fuzz(func(t *testing.T, fen string, san string) {
+		position, err := ParseFEN(fen)
+		if err != nil || position.KingSquare(White) == NoSquare || position.KingSquare(Black) == NoSquare {
+			return
+		}
+
+		move, err := position.ParseSAN(san)
+		if err != nil {
+			return
+		}
+
+		// Whatever parses is legal and parses back from its canonical SAN.
+		require.True(t, position.IsLegal(move))
+
+		canonical, err := position.ParseSAN(position.SAN(move))
+		require.NoError(t, err)
+		require.Equal(t, move, canonical)
+	})
+}
@@ -0,0 +1,69 @@
+package chess
+
+import "strings"
+
+// RepetitionKey identifies the position for the repetition rules. Positions are
+// the same when the same pieces are on the same squares with the same side to move,
+// castling rights and en passant capture available, the move counters do not count.
+// In Three-check the checks given are part of the position too.
+func (p Position) RepetitionKey() string {
+	fields := strings.Fields(p.FEN())
+	key := strings.Join(fields[:4], " ")
+	if p.Variant == ThreeCheck {
+		key += " " + fields[6]
+	}
+	return key
+}
+
+// IsFiftyMoveDraw reports whether fifty moves were made by each side without
+// a pawn move or a capture, after which either player can claim a draw.
+func (p Position) IsFiftyMoveDraw() bool {
+	return p.HalfmoveClock >= 100
+}
+
+// Replay plays the UCI moves from the initial position and returns every position
+// of the game, the initial one first and the current one last.
+func Replay(variant Variant, initialFEN string, moves []string) ([]Position, error) {
+	position, err := ParseVariantFEN(variant, initialFEN)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]Position, 0, len(moves)+1)
+	positions = append(positions, position)
+
+	for _, uci := range moves {
+		move, err := ParseUCI(uci)
+		if err != nil {
+			return nil, err
+		}
+
+		position, err = position.Play(move)
+		if err != nil {
+			return nil, err
+		}
+
+		positions = append(positions, position)
+	}
+
+	return positions, nil
+}
+
+// IsThreefoldRepetition reports whether the last of the positions of a game
+// occurred at least three times, after which either player can claim a draw.
+func IsThreefoldRepetition(positions []Position) bool {
+	if len(positions) == 0 {
+		return false
+	}
+
+	current := positions[len(positions)-1].RepetitionKey()
+
+	occurrences := 0
+	for _, position := range positions {
+		if position.RepetitionKey() == current {
+			occurrences++
+		}
+	}
+
+	return occurrences >= 3
+}
@@ -0,0 +1,52 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IsThreefoldRepetition(t *testing.T) {
+	// Arrange
+	knightDance := []string{"g1f3", "g8f6", "f3g1", "f6g8", "g1f3", "g8f6", "f3g1", "f6g8"}
+
+	// Act
+	twice, err := Replay(Standard, StartingFEN, knightDance[:4])
+	require.NoError(t, err)
+
+	thrice, err := Replay(Standard, StartingFEN, knightDance)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, thrice, len(knightDance)+1)
+	require.False(t, IsThreefoldRepetition(twice))
+	require.True(t, IsThreefoldRepetition(thrice))
+}
+
+func Test_RepetitionKey_Ignores_Move_Counters(t *testing.T) {
+	first, err := ParseFEN("4k3/8/8/8/8/8/8/4K2R w K - 0 1")
+	require.NoError(t, err)
+
+	later, err := ParseFEN("4k3/8/8/8/8/8/8/4K2R w K - 12 30")
+	require.NoError(t, err)
+
+	withoutCastling, err := ParseFEN("4k3/8/8/8/8/8/8/4K2R w - - 0 1")
+	require.NoError(t, err)
+
+	require.Equal(t, first.RepetitionKey(), later.RepetitionKey())
+	require.NotEqual(t, first.RepetitionKey(), withoutCastling.RepetitionKey())
+}
+
+func Test_IsFiftyMoveDraw(t *testing.T) {
+	position, err := ParseFEN("4k3/8/8/8/8/8/8/4K2R w K - 99 80")
+	require.NoError(t, err)
+	require.False(t, position.IsFiftyMoveDraw())
+
+	position.HalfmoveClock = 100
+	require.True(t, position.IsFiftyMoveDraw())
+}
+
+func Test_Replay_Rejects_Illegal_Moves(t *testing.T) {
+	_, err := Replay(Standard, StartingFEN, []string{"e2e4", "e2e4"})
+	require.ErrorIs(t, err, ErrIllegalMove)
+}
@@ -0,0 +1,197 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SAN returns the move in Standard Algebraic Notation. The move is expected to be legal.
+func (p Position) SAN(m Move) string {
+	piece := p.Board[m.From]
+
+	var b strings.Builder
+
+	castling, isCastling := p.castling(m)
+
+	switch {
+	case isCastling && castlingSides[castling].kingside:
+		b.WriteString("O-O")
+
+	case isCastling:
+		b.WriteString("O-O-O")
+
+	case piece.Type == Pawn:
+		if m.From.File() != m.To.File() {
+			b.WriteByte(byte('a' + m.From.File()))
+			b.WriteByte('x')
+		}
+		b.WriteString(m.To.String())
+
+		if m.Promotion != NoPieceType {
+			b.WriteByte('=')
+			b.WriteByte(Piece{Type: m.Promotion}.Letter())
+		}
+
+	default:
+		b.WriteByte(Piece{Type: piece.Type}.Letter())
+		b.WriteString(p.disambiguation(m))
+		if !p.Board[m.To].IsEmpty() {
+			b.WriteByte('x')
+		}
+		b.WriteString(m.To.String())
+	}
+
+	next := p.apply(m)
+	if next.InCheck() {
+		if len(next.LegalMoves()) == 0 {
+			b.WriteByte('#')
+		} else {
+			b.WriteByte('+')
+		}
+	}
+
+	return b.String()
+}
+
+func (p Position) disambiguation(m Move) string {
+	piece := p.Board[m.From]
+
+	var ambiguous, sameFile, sameRank bool
+	for _, other := range p.LegalMoves() {
+		if other.To != m.To || other.From == m.From || p.Board[other.From] != piece {
+			continue
+		}
+
+		ambiguous = true
+		sameFile = sameFile || other.From.File() == m.From.File()
+		sameRank = sameRank || other.From.Rank() == m.From.Rank()
+	}
+
+	switch {
+	case !ambiguous:
+		return ""
+	case !sameFile:
+		return m.From.String()[:1]
+	case !sameRank:
+		return m.From.String()[1:]
+	default:
+		return m.From.String()
+	}
+}
+
+// ParseSAN returns the legal move written in Standard Algebraic Notation. Check and
+// annotation suffixes are ignored, castling may be written with zeros, promotions
+// without the '=' and moves may be disambiguated more than needed.
+func (p Position) ParseSAN(san string) (Move, error) {
+	s := strings.TrimRight(san, "+#!?")
+
+	switch s {
+	case "O-O", "0-0":
+		return p.castlingMove(san, true)
+	case "O-O-O", "0-0-0":
+		return p.castlingMove(san, false)
+	}
+
+	promotion := NoPieceType
+	if i := strings.IndexByte(s, '='); i >= 0 {
+		if i != len(s)-2 {
+			return Move{}, fmt.Errorf("invalid SAN move - '%s'", san)
+		}
+		promotion = promotionPiece(s[i+1])
+		if promotion == NoPieceType {
+			return Move{}, fmt.Errorf("invalid SAN promotion - '%s'", san)
+		}
+		s = s[:i]
+	} else if len(s) >= 3 && s[0] >= 'a' && s[0] <= 'h' && strings.ContainsRune("18", rune(s[len(s)-2])) {
+		if piece := promotionPiece(s[len(s)-1]); piece != NoPieceType {
+			promotion = piece
+			s = s[:len(s)-1]
+		}
+	}
+
+	pieceType := Pawn
+	if len(s) > 0 {
+		if i := strings.IndexByte("NBRQK", s[0]); i >= 0 {
+			pieceType = Knight + PieceType(i)
+			s = s[1:]
+		}
+	}
+
+	if len(s) < 2 {
+		return Move{}, fmt.Errorf("invalid SAN move - '%s'", san)
+	}
+
+	to, err := ParseSquare(s[len(s)-2:])
+	if err != nil {
+		return Move{}, fmt.Errorf("invalid SAN move - '%s'", san)
+	}
+
+	from := strings.TrimSuffix(s[:len(s)-2], "x")
+	if len(from) > 2 {
+		return Move{}, fmt.Errorf("invalid SAN move - '%s'", san)
+	}
+
+	fromFile, fromRank := -1, -1
+	for i := 0; i < len(from); i++ {
+		switch c := from[i]; {
+		case c >= 'a' && c <= 'h' && fromFile < 0:
+			fromFile = int(c - 'a')
+		case c >= '1' && c <= '8' && fromRank < 0:
+			fromRank = int(c - '1')
+		default:
+			return Move{}, fmt.Errorf("invalid SAN move - '%s'", san)
+		}
+	}
+
+	// Pawns only leave their file to capture, and the capturing file is always written.
+	if pieceType == Pawn && fromFile < 0 {
+		fromFile = to.File()
+	}
+
+	var matches []Move
+	for _, m := range p.LegalMoves() {
+		_, isCastling := p.castling(m)
+
+		switch {
+		case isCastling, p.Board[m.From].Type != pieceType, m.To != to, m.Promotion != promotion:
+		case fromFile >= 0 && m.From.File() != fromFile:
+		case fromRank >= 0 && m.From.Rank() != fromRank:
+		default:
+			matches = append(matches, m)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Move{}, fmt.Errorf("%w - '%s'", ErrIllegalMove, san)
+	case 1:
+		return matches[0], nil
+	default:
+		return Move{}, fmt.Errorf("ambiguous SAN move - '%s'", san)
+	}
+}
+
+func (p Position) castlingMove(san string, kingside bool) (Move, error) {
+	for _, m := range p.LegalMoves() {
+		if i, ok := p.castling(m); ok && castlingSides[i].kingside == kingside {
+			return m, nil
+		}
+	}
+
+	return Move{}, fmt.Errorf("%w - '%s'", ErrIllegalMove, san)
+}
+
+func promotionPiece(letter byte) PieceType {
+	switch letter {
+	case 'N', 'n':
+		return Knight
+	case 'B', 'b':
+		return Bishop
+	case 'R', 'r':
+		return Rook
+	case 'Q', 'q':
+		return Queen
+	default:
+		return NoPieceType
+	}
+}
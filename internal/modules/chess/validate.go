@@ -0,0 +1,117 @@
+package chess
+
+import "fmt"
+
+// pieceValues are the conventional values of the pieces in pawns.
+var pieceValues = [...]int{NoPieceType: 0, Pawn: 1, Knight: 3, Bishop: 3, Rook: 5, Queen: 9, King: 0}
+
+// Material sums the values of the colour's pieces in pawns.
+func (p Position) Material(c Color) int {
+	material := 0
+	for _, piece := range p.Board {
+		if piece.Color == c {
+			material += pieceValues[piece.Type]
+		}
+	}
+	return material
+}
+
+// ValidateFEN lists every problem of the FEN, first those of its syntax and then,
+// once it parses, those of the position which make it impossible in a game.
+func ValidateFEN(fen string) []error {
+	return ValidateVariantFEN(Standard, fen)
+}
+
+// ValidateVariantFEN lists every problem of the FEN of a position of the variant.
+func ValidateVariantFEN(variant Variant, fen string) []error {
+	p, errs := parseFEN(variant, fen)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return p.problems()
+}
+
+func (p Position) problems() []error {
+	var errs []error
+
+	kingsFound := true
+	for _, c := range []Color{White, Black} {
+		counts := map[PieceType]int{}
+
+		for i, piece := range p.Board {
+			if piece.IsEmpty() || piece.Color != c {
+				continue
+			}
+			counts[piece.Type]++
+
+			if piece.Type == Pawn && (Square(i).Rank() == 0 || Square(i).Rank() == 7) {
+				errs = append(errs, fmt.Errorf("invalid FEN position - %s pawn on %s", c, Square(i)))
+			}
+		}
+
+		kings, pawns := counts[King], counts[Pawn]
+		if kings != 1 {
+			errs = append(errs, fmt.Errorf("invalid FEN position - %s has %d kings", c, kings))
+			kingsFound = false
+		}
+
+		if pawns > 8 {
+			errs = append(errs, fmt.Errorf("invalid FEN position - %s has %d pawns", c, pawns))
+		}
+
+		// Pieces beyond the starting ones can only come from promoted pawns.
+		extraPieces := max(counts[Queen]-1, 0) + max(counts[Rook]-2, 0) +
+			max(counts[Bishop]-2, 0) + max(counts[Knight]-2, 0)
+		if pawns <= 8 && pawns+extraPieces > 8 {
+			errs = append(errs, fmt.Errorf("invalid FEN position - %s has more promoted pieces than missing pawns", c))
+		}
+	}
+
+	if kingsFound && p.IsAttacked(p.KingSquare(p.Turn.Other()), p.Turn) {
+		errs = append(errs, fmt.Errorf("invalid FEN position - %s is in check but it is %s's turn", p.Turn.Other(), p.Turn))
+	}
+
+	for i, side := range castlingSides {
+		if p.Castling&side.right == 0 {
+			continue
+		}
+
+		if _, ok := p.castlingRook(i); !ok {
+			errs = append(errs, fmt.Errorf("invalid FEN castling rights - '%s' needs the king and rook on their squares", side.right))
+		}
+	}
+
+	if p.EnPassant != NoSquare {
+		if err := p.enPassantProblem(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// enPassantProblem checks the en passant square is behind a pawn of the side
+// which is not to move, that could have just advanced two squares.
+func (p Position) enPassantProblem() error {
+	rank := 5
+	if p.Turn == Black {
+		rank = 2
+	}
+
+	invalid := fmt.Errorf("invalid FEN en passant square - '%s' does not follow a double pawn push", p.EnPassant)
+
+	if p.EnPassant.Rank() != rank {
+		return invalid
+	}
+
+	direction := pawnDirection(p.Turn.Other())
+	pawn := Square(int(p.EnPassant) + 8*direction)
+	origin := Square(int(p.EnPassant) - 8*direction)
+
+	if p.Board[pawn] != (Piece{Pawn, p.Turn.Other()}) || !p.Board[p.EnPassant].IsEmpty() || !p.Board[origin].IsEmpty() {
+		return invalid
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateFEN(t *testing.T) {
+	tests := []struct {
+		name     string
+		fen      string
+		problems []string
+	}{
+		{"starting position", StartingFEN, nil},
+		{"en passant after double push", "rnbqkbnr/ppp1pppp/8/8/3pP3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 3", nil},
+		{
+			"every field invalid",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNX x KQkx e9 -1 0",
+			[]string{
+				"invalid FEN piece - 'X'",
+				"invalid FEN side to move - 'x'",
+				"invalid FEN castling rights - 'KQkx'",
+				"invalid FEN en passant square - 'e9'",
+				"invalid FEN halfmove clock - '-1'",
+				"invalid FEN fullmove number - '0'",
+			},
+		},
+		{
+			"missing fields",
+			"8/8/8/8/8/8/8/8 w",
+			[]string{"invalid FEN - expected 6 fields got 2"},
+		},
+		{
+			"kings and pawns",
+			"P3k2k/8/8/8/8/8/8/8 w - - 0 1",
+			[]string{
+				"invalid FEN position - white pawn on a8",
+				"invalid FEN position - white has 0 kings",
+				"invalid FEN position - black has 2 kings",
+			},
+		},
+		{
+			"side not to move in check",
+			"4k3/8/8/8/8/8/4Q3/4K3 w - - 0 1",
+			[]string{"invalid FEN position - black is in check but it is white's turn"},
+		},
+		{
+			"too many promoted pieces",
+			"4k3/8/8/8/8/PPPPPPPP/8/QQ2K3 w - - 0 1",
+			[]string{"invalid FEN position - white has more promoted pieces than missing pawns"},
+		},
+		{
+			"castling without rook",
+			"r3k3/8/8/8/8/8/8/4K2R w KQq - 0 1",
+			[]string{"invalid FEN castling rights - 'Q' needs the king and rook on their squares"},
+		},
+		{
+			"en passant without pawn",
+			"4k3/8/8/8/8/8/8/4K3 b - e3 0 1",
+			[]string{"invalid FEN en passant square - 'e3' does not follow a double pawn push"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var problems []string
+			for _, err := range ValidateFEN(tt.fen) {
+				problems = append(problems, err.Error())
+			}
+
+			require.Equal(t, tt.problems, problems)
+		})
+	}
+}
+
+func Test_Position_Material(t *testing.T) {
+	position, err := ParseFEN("4k3/pp6/8/8/8/8/1Q6/R3K3 w Q - 0 1")
+	require.NoError(t, err)
+
+	require.Equal(t, 14, position.Material(White))
+	require.Equal(t, 2, position.Material(Black))
+	require.Equal(t, 39, StartingPosition().Material(Black))
+}
@@ -0,0 +1,120 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Variant is the set of rules a game is played by.
+type Variant string
+
+const (
+	Standard Variant = "standard"
+	// Chess960 starts from one of 960 shuffled back ranks. Castling puts the king
+	// and rook on the squares they end on in standard chess.
+	Chess960 Variant = "chess960"
+	// KingOfTheHill is won by bringing the king to one of the four central squares.
+	KingOfTheHill Variant = "king_of_the_hill"
+	// ThreeCheck is won by giving check three times.
+	ThreeCheck Variant = "three_check"
+)
+
+// ThreeCheckLimit is the number of checks which wins a Three-check game.
+const ThreeCheckLimit = 3
+
+func (v Variant) Validate() error {
+	switch v {
+	case Standard, Chess960, KingOfTheHill, ThreeCheck:
+		return nil
+	default:
+		return fmt.Errorf("invalid Variant - '%s'", v)
+	}
+}
+
+var pgnVariantNames = map[Variant]string{
+	Standard:      "Standard",
+	Chess960:      "Chess960",
+	KingOfTheHill: "King of the Hill",
+	ThreeCheck:    "Three-check",
+}
+
+// PGNName is the name of the variant in the PGN Variant tag.
+func (v Variant) PGNName() string {
+	return pgnVariantNames[v]
+}
+
+// ParsePGNVariant reads the PGN Variant tag, where standard chess is also
+// written as an empty tag and the variants go by a few different names.
+func ParsePGNVariant(name string) (Variant, error) {
+	normalized := strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(name))
+
+	switch normalized {
+	case "", "standard", "chess":
+		return Standard, nil
+	case "chess960", "fischerandom", "fischerrandom", "960":
+		return Chess960, nil
+	case "kingofthehill", "koth":
+		return KingOfTheHill, nil
+	case "threecheck", "3check":
+		return ThreeCheck, nil
+	default:
+		return "", fmt.Errorf("unsupported PGN variant - '%s'", name)
+	}
+}
+
+// Chess960Positions is the number of Chess960 starting positions.
+const Chess960Positions = 960
+
+// Chess960StandardIndex is the index of the standard starting position.
+const Chess960StandardIndex = 518
+
+// chess960Knights are the pairs of the five free squares the knights take.
+var chess960Knights = [10][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+
+// Chess960FEN returns the FEN of the Chess960 starting position with the index
+// in the Scharnagl numbering, where 518 is the standard starting position.
+func Chess960FEN(index int) (string, error) {
+	if index < 0 || index >= Chess960Positions {
+		return "", fmt.Errorf("invalid Chess960 index - '%d'", index)
+	}
+
+	var rank [8]byte
+	n := index
+
+	// The bishops go on opposite coloured squares, the light one on the b, d, f or h file.
+	rank[2*(n%4)+1] = 'b'
+	n /= 4
+	rank[2*(n%4)] = 'b'
+	n /= 4
+
+	place := func(piece byte, nth int) {
+		for f := range rank {
+			if rank[f] != 0 {
+				continue
+			}
+			if nth == 0 {
+				rank[f] = piece
+				return
+			}
+			nth--
+		}
+	}
+
+	place('q', n%6)
+	n /= 6
+
+	// Placing the second knight after the first shifts its free square by one.
+	knights := chess960Knights[n]
+	place('n', knights[0])
+	place('n', knights[1]-1)
+
+	// The king always stands between the rooks.
+	place('r', 0)
+	place('k', 0)
+	place('r', 0)
+
+	black := string(rank[:])
+	white := strings.ToUpper(black)
+
+	return fmt.Sprintf("%s/pppppppp/8/8/8/8/PPPPPPPP/%s w KQkq - 0 1", black, white), nil
+}
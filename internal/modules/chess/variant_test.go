@@ -0,0 +1,227 @@
+package chess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Chess960FEN(t *testing.T) {
+	// Act
+	standard, err := Chess960FEN(Chess960StandardIndex)
+	require.NoError(t, err)
+
+	first, err := Chess960FEN(0)
+	require.NoError(t, err)
+
+	_, err = Chess960FEN(Chess960Positions)
+
+	// Assert
+	require.Equal(t, StartingFEN, standard)
+	require.Equal(t, "bbqnnrkr/pppppppp/8/8/8/8/PPPPPPPP/BBQNNRKR w KQkq - 0 1", first)
+	require.Error(t, err)
+}
+
+func Test_Chess960FEN_Generates_Every_Position_Once(t *testing.T) {
+	seen := map[string]bool{}
+
+	for i := range Chess960Positions {
+		// Act
+		fen, err := Chess960FEN(i)
+		require.NoError(t, err)
+
+		// Assert
+		require.Empty(t, ValidateVariantFEN(Chess960, fen), fen)
+		require.False(t, seen[fen], fen)
+		seen[fen] = true
+	}
+}
+
+func Test_Chess960_Perft(t *testing.T) {
+	// Arrange
+	p, err := ParseVariantFEN(Chess960, "bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9")
+	require.NoError(t, err)
+
+	// Act
+	nodes := perft(p, 3)
+
+	// Assert
+	require.Equal(t, 12189, nodes)
+}
+
+func Test_Chess960_Castling(t *testing.T) {
+	tests := []struct {
+		name  string
+		fen   string
+		san   string
+		uci   string
+		after string
+	}{
+		{
+			"king next to the rook",
+			"4k3/8/8/8/8/8/8/1R4KR w KQ - 0 1",
+			"O-O",
+			"g1h1",
+			"4k3/8/8/8/8/8/8/1R3RK1 b - - 1 1",
+		},
+		{
+			"king crossing the board",
+			"4k3/8/8/8/8/8/8/1R4KR w KQ - 0 1",
+			"O-O-O",
+			"g1b1",
+			"4k3/8/8/8/8/8/8/2KR3R b - - 1 1",
+		},
+		{
+			"inner rook",
+			"4k3/8/8/8/8/8/8/RR2K3 w B - 0 1",
+			"O-O-O",
+			"e1b1",
+			"4k3/8/8/8/8/8/8/R1KR4 b - - 1 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p, err := ParseVariantFEN(Chess960, tt.fen)
+			require.NoError(t, err)
+			require.Equal(t, tt.fen, p.FEN())
+
+			// Act
+			move, err := p.ParseSAN(tt.san)
+			require.NoError(t, err)
+
+			next, err := p.Play(move)
+			require.NoError(t, err)
+
+			// Assert
+			require.Equal(t, tt.uci, move.UCI())
+			require.Equal(t, tt.san, p.SAN(move))
+			require.Equal(t, tt.after, next.FEN())
+		})
+	}
+}
+
+func Test_ParseVariantFEN_Shredder_Castling(t *testing.T) {
+	// Act
+	p, err := ParseVariantFEN(Chess960, "rk5r/8/8/8/8/8/8/RK5R w HAha - 0 1")
+	require.NoError(t, err)
+
+	_, standardErr := ParseFEN("rk5r/8/8/8/8/8/8/RK5R w HAha - 0 1")
+
+	// Assert
+	require.Equal(t, "rk5r/8/8/8/8/8/8/RK5R w KQkq - 0 1", p.FEN())
+	require.Error(t, standardErr)
+}
+
+func Test_Chess960_Castling_Blocked_By_Attack(t *testing.T) {
+	// Arrange
+	p, err := ParseVariantFEN(Chess960, "4kr2/8/8/8/8/8/8/1R4KR w KQ - 0 1")
+	require.NoError(t, err)
+
+	// Act
+	_, kingside := p.ParseSAN("O-O")
+	_, queenside := p.ParseSAN("O-O-O")
+
+	// Assert
+	// Only the king's path has to be safe, the rook may cross the attacked f1.
+	require.NoError(t, kingside)
+	require.ErrorIs(t, queenside, ErrIllegalMove)
+}
+
+func Test_KingOfTheHill_Outcome(t *testing.T) {
+	// Arrange
+	p, err := ParseVariantFEN(KingOfTheHill, "4k3/8/8/8/8/4K3/8/8 w - - 0 1")
+	require.NoError(t, err)
+
+	// Act
+	result, termination := p.Outcome()
+	next, err := p.Play(Move{From: NewSquare(4, 2), To: NewSquare(4, 3)})
+	require.NoError(t, err)
+	nextResult, nextTermination := next.Outcome()
+
+	// Assert
+	require.Equal(t, NoResult, result)
+	require.Equal(t, NoTermination, termination)
+	require.Equal(t, WhiteWins, nextResult)
+	require.Equal(t, KingInTheCenter, nextTermination)
+	require.True(t, p.HasMatingMaterial(Black))
+}
+
+func Test_ThreeCheck_Counts_Checks(t *testing.T) {
+	// Arrange
+	p, err := ParseVariantFEN(ThreeCheck, "4k3/8/8/8/8/8/8/4K2R w - - 0 1 +2+0")
+	require.NoError(t, err)
+
+	_, standardErr := ParseFEN("4k3/8/8/8/8/8/8/4K2R w - - 0 1 +2+0")
+
+	// Act
+	move, err := p.ParseSAN("Rh8+")
+	require.NoError(t, err)
+	next := p.apply(move)
+	result, termination := next.Outcome()
+
+	// Assert
+	require.Error(t, standardErr)
+	require.Equal(t, [2]int{3, 0}, next.Checks)
+	require.Equal(t, "4k2R/8/8/8/8/8/8/4K3 b - - 1 1 +3+0", next.FEN())
+	require.Equal(t, WhiteWins, result)
+	require.Equal(t, ThreeChecks, termination)
+	require.False(t, next.HasMatingMaterial(Black))
+}
+
+func Test_ThreeCheck_RepetitionKey_Includes_Checks(t *testing.T) {
+	// Arrange
+	first, err := ParseVariantFEN(ThreeCheck, "4k3/8/8/8/8/8/8/4K2R w - - 0 1 +0+0")
+	require.NoError(t, err)
+
+	second, err := ParseVariantFEN(ThreeCheck, "4k3/8/8/8/8/8/8/4K2R w - - 0 1 +1+0")
+	require.NoError(t, err)
+
+	// Assert
+	require.NotEqual(t, first.RepetitionKey(), second.RepetitionKey())
+}
+
+func Test_ParsePGNVariant(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant Variant
+	}{
+		{"", Standard},
+		{"Chess960", Chess960},
+		{"Fischerandom", Chess960},
+		{"King of the Hill", KingOfTheHill},
+		{"Three-check", ThreeCheck},
+	}
+
+	for _, tt := range tests {
+		// Act
+		variant, err := ParsePGNVariant(tt.name)
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, tt.variant, variant)
+	}
+
+	_, err := ParsePGNVariant("Crazyhouse")
+	require.Error(t, err)
+}
+
+func Test_PGNGame_Resolve_Chess960(t *testing.T) {
+	// Arrange
+	pgn := `[Variant "Chess960"]
+[SetUp "1"]
+[FEN "4k3/8/8/8/8/8/8/1R4KR w KQ - 0 1"]
+
+1. O-O-O *`
+
+	games, err := ParsePGN(pgn)
+	require.NoError(t, err)
+
+	// Act
+	err = games[0].Resolve()
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []string{"g1b1"}, games[0].MainLine())
+}
@@ -0,0 +1,101 @@
+package core
+
+import "sync"
+
+// Broker fans out messages published on a topic to the subscribers of that topic
+// within this process. Publishing never blocks - a subscriber which does not keep
+// up and fills its buffer is dropped, its channel is closed and it is expected to
+// resubscribe and catch up from storage.
+type Broker struct {
+	mu            sync.Mutex
+	subscriptions map[string]map[*Subscription]struct{}
+}
+
+type Subscription struct {
+	topic    string
+	messages chan []byte
+	dropped  bool
+}
+
+// Messages is closed when the subscription is dropped or cancelled.
+func (s *Subscription) Messages() <-chan []byte {
+	return s.messages
+}
+
+func NewBroker() *Broker {
+	return &Broker{subscriptions: make(map[string]map[*Subscription]struct{})}
+}
+
+func (b *Broker) Subscribe(topic string, buffer int) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := &Subscription{topic: topic, messages: make(chan []byte, buffer)}
+
+	if _, found := b.subscriptions[topic]; !found {
+		b.subscriptions[topic] = make(map[*Subscription]struct{})
+	}
+	b.subscriptions[topic][s] = struct{}{}
+
+	return s
+}
+
+func (b *Broker) Unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remove(s)
+}
+
+// Dropped reports whether the subscription was dropped because it fell behind.
+func (b *Broker) Dropped(s *Subscription) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return s.dropped
+}
+
+func (b *Broker) Publish(topic string, message []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subscriptions[topic] {
+		select {
+		case s.messages <- message:
+		default:
+			s.dropped = true
+			b.remove(s)
+		}
+	}
+}
+
+// DropAll drops every subscription, used when messages might have been missed.
+func (b *Broker) DropAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subscriptions := range b.subscriptions {
+		for s := range subscriptions {
+			s.dropped = true
+			b.remove(s)
+		}
+	}
+}
+
+func (b *Broker) remove(s *Subscription) {
+	subscriptions, found := b.subscriptions[s.topic]
+	if !found {
+		return
+	}
+
+	if _, found := subscriptions[s]; !found {
+		return
+	}
+
+	delete(subscriptions, s)
+	close(s.messages)
+
+	if len(subscriptions) == 0 {
+		delete(b.subscriptions, s.topic)
+	}
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Broker_Publish_Delivers_To_Topic_Subscribers(t *testing.T) {
+	// Arrange
+	broker := NewBroker()
+	subscription := broker.Subscribe("topic", 1)
+	other := broker.Subscribe("other", 1)
+
+	// Act
+	broker.Publish("topic", []byte("message"))
+
+	// Assert
+	require.Equal(t, []byte("message"), <-subscription.Messages())
+	require.Len(t, other.Messages(), 0)
+}
+
+func Test_Broker_Publish_Drops_Slow_Subscriber(t *testing.T) {
+	// Arrange
+	broker := NewBroker()
+	subscription := broker.Subscribe("topic", 1)
+
+	// Act
+	broker.Publish("topic", []byte("first"))
+	broker.Publish("topic", []byte("second"))
+
+	// Assert
+	require.True(t, broker.Dropped(subscription))
+
+	message, ok := <-subscription.Messages()
+	require.True(t, ok)
+	require.Equal(t, []byte("first"), message)
+
+	_, ok = <-subscription.Messages()
+	require.False(t, ok)
+}
+
+func Test_Broker_DropAll_Drops_Every_Subscription(t *testing.T) {
+	// Arrange
+	broker := NewBroker()
+	subscription := broker.Subscribe("topic", 1)
+	other := broker.Subscribe("other", 1)
+
+	// Act
+	broker.DropAll()
+
+	// Assert
+	require.True(t, broker.Dropped(subscription))
+	require.True(t, broker.Dropped(other))
+
+	_, ok := <-subscription.Messages()
+	require.False(t, ok)
+
+	broker.Publish("topic", []byte("message"))
+	_, ok = <-subscription.Messages()
+	require.False(t, ok)
+}
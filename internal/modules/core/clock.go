@@ -0,0 +1,28 @@
+package core
+
+import "time"
+
+// Clock is the source of the current time for the handlers which
+// need to be tested at specific points in time.
+type Clock interface {
+	Now() time.Time
+}
+
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// FixedClock is a Clock which only moves when told to.
+type FixedClock struct {
+	Time time.Time
+}
+
+func (c *FixedClock) Now() time.Time {
+	return c.Time
+}
+
+func (c *FixedClock) Advance(d time.Duration) {
+	c.Time = c.Time.Add(d)
+}
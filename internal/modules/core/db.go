@@ -9,6 +9,13 @@ import (
 
 type TransactionOption func(*sql.TxOptions)
 
+type txContextKey string
+
+const beforeCommitKey txContextKey = "before_commit"
+
+// beforeCommitHooks are the hooks of a transaction, run in the order they were added.
+type beforeCommitHooks []func(context.Context, *sql.Tx) error
+
 func WithIsolationLevel(isolationLevel sql.IsolationLevel) TransactionOption {
 	return func(opts *sql.TxOptions) {
 		opts.Isolation = isolationLevel
@@ -32,6 +39,9 @@ func Tx(
 		return err
 	}
 
+	hooks := &beforeCommitHooks{}
+	ctx = context.WithValue(ctx, beforeCommitKey, hooks)
+
 	defer func() {
 		if r := recover(); r != nil {
 			err = errors.Join(fmt.Errorf("transaction panicked with: %v", r), err)
@@ -43,6 +53,10 @@ func Tx(
 	}()
 
 	err = transaction(ctx, tx)
+	if err == nil {
+		err = hooks.run(ctx, tx)
+	}
+
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			return errors.Join(rollbackErr, err)
@@ -62,3 +76,27 @@ func Tx(
 
 	return err
 }
+
+// BeforeCommit adds a hook run as the last statements of the transaction started
+// by Tx, right before it commits. It is for work which should hold its locks for
+// as short as possible, regardless of where in the transaction it was requested.
+func BeforeCommit(ctx context.Context, hook func(context.Context, *sql.Tx) error) error {
+	hooks, ok := ctx.Value(beforeCommitKey).(*beforeCommitHooks)
+	if !ok {
+		return fmt.Errorf("before commit hooks can only be added inside a transaction")
+	}
+
+	*hooks = append(*hooks, hook)
+	return nil
+}
+
+func (h *beforeCommitHooks) run(ctx context.Context, tx *sql.Tx) error {
+	// Indexed since a hook can add hooks of its own.
+	for i := 0; i < len(*h); i++ {
+		if err := (*h)[i](ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
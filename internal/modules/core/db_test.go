@@ -0,0 +1,17 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BeforeCommit_Requires_A_Transaction(t *testing.T) {
+	// Act
+	err := BeforeCommit(context.Background(), func(context.Context, *sql.Tx) error { return nil })
+
+	// Assert
+	require.Error(t, err)
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Job is a unit of background work which is run periodically
+// for as long as the server is running.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(context.Context) error
+}
+
+func (j Job) Start(ctx context.Context, logger *slog.Logger) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.Run(ctx); err != nil {
+				logger.Error("background job failed", "job", j.Name, "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// PageRequest asks for the page of a list following the Cursor, the first page
+// when the Cursor is empty. Cursors are opaque to clients, each list query
+// decides what its cursors hold with EncodeCursor and DecodeCursor.
+type PageRequest struct {
+	Cursor string
+	Limit  int
+}
+
+// ParsePageRequest reads the 'cursor' and 'limit' query params.
+func ParsePageRequest(r *http.Request) (PageRequest, error) {
+	query := r.URL.Query()
+
+	page := PageRequest{Cursor: query.Get("cursor"), Limit: DefaultPageLimit}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			return PageRequest{}, fmt.Errorf("invalid format for query param 'limit'")
+		}
+		page.Limit = parsed
+	}
+
+	return page, nil
+}
+
+func (p PageRequest) Validate() error {
+	if p.Limit < 1 || p.Limit > MaxPageLimit {
+		return fmt.Errorf("invalid Limit - '%d'", p.Limit)
+	}
+
+	return nil
+}
+
+// Page is a page of a list. NextCursor is empty on the last page.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+}
+
+// NewPage makes the page out of the items queried with a limit of one more than
+// the page's limit, the extra item only tells there is a next page. The next
+// cursor is the one of the last item on the page.
+func NewPage[T any, C any](items []T, limit int, cursor func(T) C) (Page[T], error) {
+	if items == nil {
+		items = []T{}
+	}
+
+	if len(items) <= limit {
+		return Page[T]{Items: items}, nil
+	}
+
+	items = items[:limit]
+
+	next, err := EncodeCursor(cursor(items[len(items)-1]))
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	return Page[T]{Items: items, NextCursor: next}, nil
+}
+
+func EncodeCursor[C any](cursor C) (string, error) {
+	serialized, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(serialized), nil
+}
+
+func DecodeCursor[C any](cursor string) (C, error) {
+	var decoded C
+
+	serialized, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return decoded, fmt.Errorf("invalid Cursor - '%s'", cursor)
+	}
+
+	if err := json.Unmarshal(serialized, &decoded); err != nil {
+		return decoded, fmt.Errorf("invalid Cursor - '%s'", cursor)
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCursor struct {
+	Rating int
+	ID     string
+}
+
+func Test_NewPage_Returns_Next_Cursor_When_More_Items(t *testing.T) {
+	// Arrange
+	items := []testCursor{{1, "a"}, {2, "b"}, {3, "c"}}
+
+	// Act
+	page, err := NewPage(items, 2, func(c testCursor) testCursor { return c })
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, items[:2], page.Items)
+
+	cursor, err := DecodeCursor[testCursor](page.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, testCursor{2, "b"}, cursor)
+}
+
+func Test_NewPage_Returns_No_Cursor_On_Last_Page(t *testing.T) {
+	page, err := NewPage([]testCursor{{1, "a"}}, 2, func(c testCursor) testCursor { return c })
+	require.NoError(t, err)
+	require.Empty(t, page.NextCursor)
+
+	empty, err := NewPage[testCursor](nil, 2, func(c testCursor) testCursor { return c })
+	require.NoError(t, err)
+	require.NotNil(t, empty.Items)
+}
+
+func Test_DecodeCursor_Rejects_Invalid_Cursor(t *testing.T) {
+	_, err := DecodeCursor[testCursor]("not a cursor")
+	require.Error(t, err)
+
+	_, err = DecodeCursor[testCursor]("bm90IGpzb24")
+	require.Error(t, err)
+}
+
+func Test_ParsePageRequest_Reads_Query_Params(t *testing.T) {
+	page, err := ParsePageRequest(httptest.NewRequest("GET", "/lobby?cursor=abc&limit=5", nil))
+	require.NoError(t, err)
+	require.Equal(t, PageRequest{Cursor: "abc", Limit: 5}, page)
+
+	page, err = ParsePageRequest(httptest.NewRequest("GET", "/lobby", nil))
+	require.NoError(t, err)
+	require.Equal(t, DefaultPageLimit, page.Limit)
+
+	_, err = ParsePageRequest(httptest.NewRequest("GET", "/lobby?limit=x", nil))
+	require.Error(t, err)
+
+	require.Error(t, PageRequest{Limit: MaxPageLimit + 1}.Validate())
+}
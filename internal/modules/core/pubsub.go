@@ -0,0 +1,197 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/lib/pq"
+)
+
+const (
+	// pubSubChannel is the Postgres channel every API instance listens on.
+	pubSubChannel = "pubsub"
+
+	// maxNotifyPayload is kept below the 8000 byte limit Postgres puts on NOTIFY
+	// payloads. Larger messages are stored and only their ID is sent.
+	maxNotifyPayload = 7500
+
+	// pubSubRetention is how long the stored messages are kept for the
+	// listeners to fetch them.
+	pubSubRetention = time.Hour
+
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+	listenerPingInterval = 90 * time.Second
+)
+
+type envelope struct {
+	Topic   string          `json:"topic"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	// ID refers to the stored message when the payload was too large to be sent.
+	ID int64 `json:"id,omitempty"`
+}
+
+// Publish sends the message to the subscribers of the topic on every API instance.
+//
+// It must be called inside the transaction which stores the changes the message
+// is about, Postgres delivers it only if and when the transaction commits.
+func Publish[T any](ctx context.Context, tx *sql.Tx, topic string, message T) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	notification, err := json.Marshal(envelope{Topic: topic, Payload: payload})
+	if err != nil {
+		return err
+	}
+
+	if len(notification) > maxNotifyPayload {
+		const stmt = `
+			INSERT INTO
+				pubsub_message (topic, payload, created_at)
+			VALUES
+				($1, $2, $3)
+			RETURNING
+				id;`
+		id, err := tql.QueryFirst[int64](ctx, tx, stmt, topic, string(payload), time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		if notification, err = json.Marshal(envelope{Topic: topic, ID: id}); err != nil {
+			return err
+		}
+	}
+
+	_, err = tql.Exec(ctx, tx, `SELECT pg_notify($1, $2);`, pubSubChannel, string(notification))
+	return err
+}
+
+// PubSub delivers the messages published by any API instance to the
+// subscribers on this instance.
+//
+// Messages are not persisted for the subscribers, anything sent while the
+// listener is disconnected is lost. When the listener reconnects all the
+// subscriptions are dropped, as if they fell behind, and the subscribers
+// are expected to resync from storage.
+type PubSub struct {
+	db          *sql.DB
+	databaseURL string
+	broker      *Broker
+	logger      *slog.Logger
+}
+
+func NewPubSub(db *sql.DB, databaseURL string, logger *slog.Logger) *PubSub {
+	return &PubSub{
+		db:          db,
+		databaseURL: databaseURL,
+		broker:      NewBroker(),
+		logger:      logger,
+	}
+}
+
+// Subscribe returns a subscription to the JSON messages published on the topic.
+func (p *PubSub) Subscribe(topic string, buffer int) *Subscription {
+	return p.broker.Subscribe(topic, buffer)
+}
+
+func (p *PubSub) Unsubscribe(s *Subscription) {
+	p.broker.Unsubscribe(s)
+}
+
+// Dropped reports whether the subscription was dropped because it fell behind
+// or because messages might have been missed during a reconnect.
+func (p *PubSub) Dropped(s *Subscription) bool {
+	return p.broker.Dropped(s)
+}
+
+// Start listens for messages until the context is cancelled.
+func (p *PubSub) Start(ctx context.Context) {
+	listener := pq.NewListener(
+		p.databaseURL,
+		minReconnectInterval,
+		maxReconnectInterval,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				p.logger.Error("pubsub listener connection error", "error", err)
+			}
+		},
+	)
+	defer listener.Close()
+
+	if err := listener.Listen(pubSubChannel); err != nil {
+		p.logger.Error("pubsub failed to listen", "error", err)
+		return
+	}
+
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			go func() {
+				_ = listener.Ping()
+			}()
+
+		case n := <-listener.Notify:
+			if n == nil {
+				// The connection was re-established, anything sent while it was down is lost.
+				p.broker.DropAll()
+				continue
+			}
+
+			if err := p.deliver(ctx, n.Extra); err != nil {
+				p.logger.Error("pubsub failed to deliver message", "error", err)
+			}
+		}
+	}
+}
+
+func (p *PubSub) deliver(ctx context.Context, notification string) error {
+	var e envelope
+	if err := json.Unmarshal([]byte(notification), &e); err != nil {
+		return fmt.Errorf("invalid message '%s': %w", notification, err)
+	}
+
+	payload := []byte(e.Payload)
+
+	if e.ID != 0 {
+		const query = `
+			SELECT
+				payload
+			FROM
+				pubsub_message
+			WHERE
+				id = $1;`
+		stored, err := tql.QueryFirst[string](ctx, p.db, query, e.ID)
+		if err != nil {
+			return err
+		}
+
+		payload = []byte(stored)
+	}
+
+	p.broker.Publish(e.Topic, payload)
+	return nil
+}
+
+// Cleanup deletes the stored messages which were already delivered.
+func (p *PubSub) Cleanup(ctx context.Context) error {
+	const stmt = `
+		DELETE FROM
+			pubsub_message
+		WHERE
+			created_at < $1;`
+	_, err := tql.Exec(ctx, p.db, stmt, time.Now().UTC().Add(-pubSubRetention))
+	return err
+}
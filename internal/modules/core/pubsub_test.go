@@ -0,0 +1,34 @@
+package core
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PubSub_Delivers_Inline_Payload_To_Topic_Subscribers(t *testing.T) {
+	// Arrange
+	pubSub := NewPubSub(nil, "", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	subscription := pubSub.Subscribe("topic", 1)
+
+	// Act
+	err := pubSub.deliver(context.Background(), `{"topic":"topic","payload":{"value":1}}`)
+
+	// Assert
+	require.NoError(t, err)
+	require.JSONEq(t, `{"value":1}`, string(<-subscription.Messages()))
+}
+
+func Test_PubSub_Returns_Error_For_Invalid_Message(t *testing.T) {
+	// Arrange
+	pubSub := NewPubSub(nil, "", slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Act
+	err := pubSub.deliver(context.Background(), "not json")
+
+	// Assert
+	require.Error(t, err)
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 
 	"github.com/eskrenkovic/mediator-go"
@@ -25,6 +26,16 @@ func (e ValidationError) Error() string {
 	return b.String()
 }
 
+// MarshalJSON lists the messages of the errors, which would otherwise marshal to empty objects.
+func (e ValidationError) MarshalJSON() ([]byte, error) {
+	messages := make([]string, 0, len(e.ValidationErrors))
+	for _, err := range e.ValidationErrors {
+		messages = append(messages, err.Error())
+	}
+
+	return json.Marshal(struct{ ValidationErrors []string }{messages})
+}
+
 type RequestValidationBehavior struct{}
 
 func (b *RequestValidationBehavior) Handle(
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidationError_Marshals_Every_Message(t *testing.T) {
+	// Arrange
+	err := NewCommandError(400, ValidationError{
+		ValidationErrors: []error{errors.New("first problem"), errors.New("second problem")},
+	})
+
+	// Act
+	serialized, marshalErr := json.Marshal(err)
+
+	// Assert
+	require.NoError(t, marshalErr)
+	require.JSONEq(
+		t,
+		`{"Payload":{"ValidationErrors":["first problem","second problem"]},"StatusCode":400,"Reason":null}`,
+		string(serialized),
+	)
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+var ErrWorkerPoolFull = errors.New("worker pool is full")
+
+// Task is a unit of work run by a WorkerPool.
+type Task func(context.Context) error
+
+// WorkerPool runs tasks on a fixed number of workers. It is meant for CPU bound
+// work, which would starve the HTTP handlers if it ran on as many goroutines as
+// there are requests for it. Submitting never blocks, tasks are refused once the
+// workers are busy and the queue is full.
+type WorkerPool struct {
+	workers int
+	tasks   chan Task
+	// slots holds a token for every task submitted and not yet finished.
+	slots  chan struct{}
+	logger *slog.Logger
+}
+
+func NewWorkerPool(workers int, queueSize int, logger *slog.Logger) *WorkerPool {
+	return &WorkerPool{
+		workers: workers,
+		tasks:   make(chan Task, workers+queueSize),
+		slots:   make(chan struct{}, workers+queueSize),
+		logger:  logger,
+	}
+}
+
+// Submit queues the task, or returns ErrWorkerPoolFull when there is no room for it.
+func (p *WorkerPool) Submit(task Task) error {
+	select {
+	case p.slots <- struct{}{}:
+		p.tasks <- task
+		return nil
+	default:
+		return ErrWorkerPoolFull
+	}
+}
+
+// Available is the number of tasks which can be submitted right now.
+func (p *WorkerPool) Available() int {
+	return cap(p.slots) - len(p.slots)
+}
+
+// Start runs the workers until the context is cancelled. The tasks get the
+// context, so they are cancelled with it.
+func (p *WorkerPool) Start(ctx context.Context) {
+	done := make(chan struct{})
+
+	for range p.workers {
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case task := <-p.tasks:
+					p.run(ctx, task)
+				}
+			}
+		}()
+	}
+
+	for range p.workers {
+		<-done
+	}
+}
+
+// run runs the task, a panicking task is logged as failed instead of taking the
+// process down, and its slot is freed either way.
+func (p *WorkerPool) run(ctx context.Context, task Task) {
+	defer func() { <-p.slots }()
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.Error("worker pool task panicked", "panic", r)
+		}
+	}()
+
+	if err := task(ctx); err != nil {
+		p.logger.Error("worker pool task failed", "error", err)
+	}
+}
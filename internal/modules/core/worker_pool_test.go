@@ -0,0 +1,106 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WorkerPool_Runs_Submitted_Tasks(t *testing.T) {
+	// Arrange
+	pool := NewWorkerPool(2, 2, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go pool.Start(ctx)
+
+	ran := make(chan int, 3)
+
+	// Act
+	for i := range 3 {
+		require.NoError(t, pool.Submit(func(context.Context) error {
+			ran <- i
+			return nil
+		}))
+	}
+
+	// Assert
+	results := []int{<-ran, <-ran, <-ran}
+	require.ElementsMatch(t, []int{0, 1, 2}, results)
+}
+
+func Test_WorkerPool_Refuses_Tasks_When_Full(t *testing.T) {
+	// Arrange
+	pool := NewWorkerPool(1, 1, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go pool.Start(ctx)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	block := func(context.Context) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	require.NoError(t, pool.Submit(block))
+	<-started
+	require.NoError(t, pool.Submit(func(context.Context) error { return nil }))
+
+	// Act
+	err := pool.Submit(func(context.Context) error { return nil })
+
+	// Assert
+	require.ErrorIs(t, err, ErrWorkerPoolFull)
+	require.Equal(t, 0, pool.Available())
+
+	close(release)
+	require.Eventually(t, func() bool { return pool.Available() == 2 }, time.Second, time.Millisecond)
+}
+
+func Test_WorkerPool_Keeps_Running_After_Task_Error(t *testing.T) {
+	// Arrange
+	pool := NewWorkerPool(1, 0, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go pool.Start(ctx)
+
+	ran := make(chan struct{})
+	require.NoError(t, pool.Submit(func(context.Context) error { return errors.New("failed") }))
+	require.Eventually(t, func() bool { return pool.Available() == 1 }, time.Second, time.Millisecond)
+
+	// Act
+	require.NoError(t, pool.Submit(func(context.Context) error {
+		close(ran)
+		return nil
+	}))
+
+	// Assert
+	<-ran
+}
+
+func Test_WorkerPool_Keeps_Running_After_Task_Panic(t *testing.T) {
+	// Arrange
+	pool := NewWorkerPool(1, 0, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go pool.Start(ctx)
+
+	ran := make(chan struct{})
+	require.NoError(t, pool.Submit(func(context.Context) error { panic("failed") }))
+	require.Eventually(t, func() bool { return pool.Available() == 1 }, time.Second, time.Millisecond)
+
+	// Act
+	require.NoError(t, pool.Submit(func(context.Context) error {
+		close(ran)
+		return nil
+	}))
+
+	// Assert
+	<-ran
+}
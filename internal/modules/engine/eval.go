@@ -0,0 +1,165 @@
+package engine
+
+import "github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+// pieceValues are the values of the pieces in centipawns.
+var pieceValues = [...]int{
+	chess.NoPieceType: 0,
+	chess.Pawn:        100,
+	chess.Knight:      320,
+	chess.Bishop:      330,
+	chess.Rook:        500,
+	chess.Queen:       900,
+	chess.King:        0,
+}
+
+// The piece-square tables reward pieces for standing on good squares. They are
+// written from white's side with the eighth rank first, as the board is drawn.
+var (
+	pawnTable = [64]int{
+		0, 0, 0, 0, 0, 0, 0, 0,
+		50, 50, 50, 50, 50, 50, 50, 50,
+		10, 10, 20, 30, 30, 20, 10, 10,
+		5, 5, 10, 25, 25, 10, 5, 5,
+		0, 0, 0, 20, 20, 0, 0, 0,
+		5, -5, -10, 0, 0, -10, -5, 5,
+		5, 10, 10, -20, -20, 10, 10, 5,
+		0, 0, 0, 0, 0, 0, 0, 0,
+	}
+	knightTable = [64]int{
+		-50, -40, -30, -30, -30, -30, -40, -50,
+		-40, -20, 0, 0, 0, 0, -20, -40,
+		-30, 0, 10, 15, 15, 10, 0, -30,
+		-30, 5, 15, 20, 20, 15, 5, -30,
+		-30, 0, 15, 20, 20, 15, 0, -30,
+		-30, 5, 10, 15, 15, 10, 5, -30,
+		-40, -20, 0, 5, 5, 0, -20, -40,
+		-50, -40, -30, -30, -30, -30, -40, -50,
+	}
+	bishopTable = [64]int{
+		-20, -10, -10, -10, -10, -10, -10, -20,
+		-10, 0, 0, 0, 0, 0, 0, -10,
+		-10, 0, 5, 10, 10, 5, 0, -10,
+		-10, 5, 5, 10, 10, 5, 5, -10,
+		-10, 0, 10, 10, 10, 10, 0, -10,
+		-10, 10, 10, 10, 10, 10, 10, -10,
+		-10, 5, 0, 0, 0, 0, 5, -10,
+		-20, -10, -10, -10, -10, -10, -10, -20,
+	}
+	rookTable = [64]int{
+		0, 0, 0, 0, 0, 0, 0, 0,
+		5, 10, 10, 10, 10, 10, 10, 5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		0, 0, 0, 5, 5, 0, 0, 0,
+	}
+	queenTable = [64]int{
+		-20, -10, -10, -5, -5, -10, -10, -20,
+		-10, 0, 0, 0, 0, 0, 0, -10,
+		-10, 0, 5, 5, 5, 5, 0, -10,
+		-5, 0, 5, 5, 5, 5, 0, -5,
+		0, 0, 5, 5, 5, 5, 0, -5,
+		-10, 5, 5, 5, 5, 5, 0, -10,
+		-10, 0, 5, 0, 0, 0, 0, -10,
+		-20, -10, -10, -5, -5, -10, -10, -20,
+	}
+	// The king hides behind its pawns while there are pieces to attack it,
+	// and comes to the centre once they are traded.
+	kingMiddlegameTable = [64]int{
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-20, -30, -30, -40, -40, -30, -30, -20,
+		-10, -20, -20, -20, -20, -20, -20, -10,
+		20, 20, 0, 0, 0, 0, 20, 20,
+		20, 30, 10, 0, 0, 10, 30, 20,
+	}
+	kingEndgameTable = [64]int{
+		-50, -40, -30, -20, -20, -30, -40, -50,
+		-30, -20, -10, 0, 0, -10, -20, -30,
+		-30, -10, 20, 30, 30, 20, -10, -30,
+		-30, -10, 30, 40, 40, 30, -10, -30,
+		-30, -10, 30, 40, 40, 30, -10, -30,
+		-30, -10, 20, 30, 30, 20, -10, -30,
+		-30, -30, 0, 0, 0, 0, -30, -30,
+		-50, -30, -30, -30, -30, -30, -30, -50,
+	}
+)
+
+var pieceTables = [...]*[64]int{
+	chess.Pawn:   &pawnTable,
+	chess.Knight: &knightTable,
+	chess.Bishop: &bishopTable,
+	chess.Rook:   &rookTable,
+	chess.Queen:  &queenTable,
+}
+
+// phaseWeights measure how far the game is from the endgame by the pieces left,
+// the full set of pieces of both sides weighs maxPhase.
+var phaseWeights = [...]int{chess.Knight: 1, chess.Bishop: 1, chess.Rook: 2, chess.Queen: 4, chess.King: 0}
+
+const maxPhase = 24
+
+// hillBonus rewards a King of the Hill king for its distance from the nearest central square.
+var hillBonus = [...]int{0, 250, 100, 40}
+
+// checkBonus rewards a Three-check side for the checks it gave.
+var checkBonus = [...]int{0, 150, 450, 0}
+
+// tableIndex maps the square to the piece-square tables, mirrored for black.
+func tableIndex(sq chess.Square, c chess.Color) int {
+	if c == chess.White {
+		return (7-sq.Rank())*8 + sq.File()
+	}
+	return sq.Rank()*8 + sq.File()
+}
+
+// Evaluate scores the position in centipawns from the side to move's point of view.
+func Evaluate(p chess.Position) int {
+	var score [2]int
+	var kings [2]chess.Square
+	phase := 0
+
+	for i, piece := range p.Board {
+		if piece.IsEmpty() {
+			continue
+		}
+
+		sq := chess.Square(i)
+		if piece.Type == chess.King {
+			kings[piece.Color] = sq
+			continue
+		}
+
+		score[piece.Color] += pieceValues[piece.Type] + pieceTables[piece.Type][tableIndex(sq, piece.Color)]
+		phase += phaseWeights[piece.Type]
+	}
+
+	phase = min(phase, maxPhase)
+	for _, c := range []chess.Color{chess.White, chess.Black} {
+		i := tableIndex(kings[c], c)
+		score[c] += (kingMiddlegameTable[i]*phase + kingEndgameTable[i]*(maxPhase-phase)) / maxPhase
+
+		switch p.Variant {
+		case chess.KingOfTheHill:
+			if d := hillDistance(kings[c]); d < len(hillBonus) {
+				score[c] += hillBonus[d]
+			}
+		case chess.ThreeCheck:
+			score[c] += checkBonus[min(p.Checks[c], len(checkBonus)-1)]
+		}
+	}
+
+	return score[p.Turn] - score[p.Turn.Other()]
+}
+
+// hillDistance counts the king moves from the square to the nearest central square.
+func hillDistance(sq chess.Square) int {
+	fileDistance := max(3-sq.File(), sq.File()-4, 0)
+	rankDistance := max(3-sq.Rank(), sq.Rank()-4, 0)
+	return max(fileDistance, rankDistance)
+}
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+)
+
+// Level is the strength the engine plays at, from MinLevel to MaxLevel.
+type Level int
+
+const (
+	MinLevel Level = 1
+	MaxLevel Level = 8
+)
+
+// levelLimits weaken the lower levels by searching shallower and playing
+// moves which are not the best.
+var levelLimits = [...]Limits{
+	1: {Depth: 1, Nodes: 1_000, MoveTime: 100 * time.Millisecond, Randomness: 400},
+	2: {Depth: 2, Nodes: 5_000, MoveTime: 200 * time.Millisecond, Randomness: 200},
+	3: {Depth: 2, Nodes: 20_000, MoveTime: 300 * time.Millisecond, Randomness: 100},
+	4: {Depth: 3, Nodes: 50_000, MoveTime: 500 * time.Millisecond, Randomness: 50},
+	5: {Depth: 4, Nodes: 150_000, MoveTime: time.Second, Randomness: 20},
+	6: {Depth: 5, Nodes: 400_000, MoveTime: 2 * time.Second},
+	7: {Depth: 6, Nodes: 1_000_000, MoveTime: 3 * time.Second},
+	8: {Depth: MaxDepth, Nodes: 3_000_000, MoveTime: 5 * time.Second},
+}
+
+func (l Level) Validate() error {
+	if l < MinLevel || l > MaxLevel {
+		return fmt.Errorf("invalid Level - '%d', expected %d to %d", l, MinLevel, MaxLevel)
+	}
+	return nil
+}
+
+// Limits are the search limits of the level.
+func (l Level) Limits() Limits {
+	return levelLimits[min(max(l, MinLevel), MaxLevel)]
+}
+
+// ForClock shortens the move time so the engine does not lose on time, spending
+// a share of the remaining time and most of the increment on each move. Untimed
+// games, with no remaining time, keep the limits.
+func (l Limits) ForClock(remaining, increment time.Duration) Limits {
+	if remaining <= 0 {
+		return l
+	}
+
+	budget := max(remaining/30+increment*3/4, 10*time.Millisecond)
+	if l.MoveTime == 0 || budget < l.MoveTime {
+		l.MoveTime = budget
+	}
+
+	return l
+}
@@ -0,0 +1,398 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+)
+
+var ErrNoLegalMoves = errors.New("position has no legal moves")
+
+const (
+	infinity  = 1_000_000
+	mateScore = 100_000
+	// mateBound separates mate scores, which count the plies to mate, from evaluations.
+	mateBound = mateScore - 1000
+
+	// MaxDepth is the deepest a search goes, in plies.
+	MaxDepth = 64
+
+	// stopCheckInterval is how many nodes are searched between checking the limits.
+	stopCheckInterval = 1024
+)
+
+// Limits bound a search. Unset limits do not bound it, the depth is
+// bounded by MaxDepth when no other limit is set.
+type Limits struct {
+	Depth    int
+	Nodes    int64
+	MoveTime time.Duration
+	// Randomness is how many centipawns worse than the best move a move may be
+	// to be played instead, making weaker levels vary and blunder.
+	Randomness int
+}
+
+// Result is the move the search settled on with its score in centipawns from
+// the side to move's point of view, and the depth it was found at.
+type Result struct {
+	Move  chess.Move
+	Score int
+	Depth int
+	Nodes int64
+	// PV is the principal variation, the moves both sides are expected to play.
+	PV []chess.Move
+}
+
+// IsMate reports whether the score is a forced mate, for either side.
+func (r Result) IsMate() bool {
+	return r.Score > mateBound || r.Score < -mateBound
+}
+
+type searcher struct {
+	ctx      context.Context
+	limits   Limits
+	deadline time.Time
+	table    *table
+	nodes    int64
+	stopped  bool
+	// path holds the hashes of the positions leading to the one searched,
+	// the game's own positions first, to score repetitions as draws.
+	path []uint64
+}
+
+// Search looks for the best move in the position with iterative deepening, searching
+// one ply deeper each iteration until a limit is hit. An iteration cut short by a
+// limit is only used for its first moves, the result of the last complete one stands.
+// History are the positions of the game before the position, for repetitions.
+func Search(ctx context.Context, position chess.Position, limits Limits, history ...chess.Position) (Result, error) {
+	moves := position.LegalMoves()
+	if len(moves) == 0 {
+		return Result{}, ErrNoLegalMoves
+	}
+
+	maxDepth := MaxDepth
+	if limits.Depth > 0 {
+		maxDepth = min(limits.Depth, MaxDepth)
+	}
+
+	s := &searcher{ctx: ctx, limits: limits, table: newTable()}
+	if limits.MoveTime > 0 {
+		s.deadline = time.Now().Add(limits.MoveTime)
+	}
+
+	for _, p := range history {
+		s.path = append(s.path, hash(p))
+	}
+
+	var result Result
+	for depth := 1; depth <= maxDepth; depth++ {
+		scores := s.root(position, moves, depth)
+		if s.stopped && depth > 1 {
+			break
+		}
+
+		// The best move so far is searched first in the next iteration.
+		slices.SortStableFunc(moves, func(a, b chess.Move) int { return scores[b] - scores[a] })
+
+		result = Result{
+			Move:  s.pick(moves, scores),
+			Score: scores[moves[0]],
+			Depth: depth,
+		}
+
+		if s.stopped || result.IsMate() {
+			break
+		}
+	}
+
+	result.Nodes = s.nodes
+	result.PV = s.pv(position, result.Move)
+
+	return result, nil
+}
+
+// root scores the moves of the root position. Without randomness only the best
+// move's score is exact, the others are bounds. With randomness every move is
+// searched with the full window, so the moves to pick from are scored exactly.
+func (s *searcher) root(position chess.Position, moves []chess.Move, depth int) map[chess.Move]int {
+	scores := make(map[chess.Move]int, len(moves))
+	rootHash := hash(position)
+	s.path = append(s.path, rootHash)
+	defer func() { s.path = s.path[:len(s.path)-1] }()
+
+	alpha := -infinity
+	best := moves[0]
+	for _, m := range moves {
+		window := alpha
+		if s.limits.Randomness > 0 {
+			window = -infinity
+		}
+
+		score := -s.negamax(position.PlayLegal(m), depth-1, 1, -infinity, -window)
+		if s.stopped {
+			// Moves not searched this iteration keep their place behind the searched ones.
+			for _, rest := range moves {
+				if _, ok := scores[rest]; !ok {
+					scores[rest] = -infinity
+				}
+			}
+			break
+		}
+
+		scores[m] = score
+		if score > alpha {
+			alpha, best = score, m
+		}
+	}
+
+	s.table.store(rootHash, depth, alpha, exactBound, best)
+	return scores
+}
+
+// pick returns the best of the sorted moves, or with randomness a random move
+// of those scored within the randomness of the best.
+func (s *searcher) pick(moves []chess.Move, scores map[chess.Move]int) chess.Move {
+	if s.limits.Randomness <= 0 {
+		return moves[0]
+	}
+
+	best := scores[moves[0]]
+	candidates := 1
+	for candidates < len(moves) && scores[moves[candidates]] >= best-s.limits.Randomness {
+		candidates++
+	}
+
+	return moves[rand.IntN(candidates)]
+}
+
+func (s *searcher) negamax(p chess.Position, depth, ply, alpha, beta int) int {
+	if s.stop() {
+		return 0
+	}
+
+	if result, _ := p.VariantOutcome(); result != chess.NoResult {
+		// Variant wins are reached by the move before, the side to move lost.
+		return -mateScore + ply
+	}
+
+	h := hash(p)
+	if p.IsFiftyMoveDraw() || p.IsInsufficientMaterial() || s.repeated(h) {
+		return 0
+	}
+
+	if depth <= 0 || ply >= MaxDepth {
+		return s.quiesce(p, ply, alpha, beta)
+	}
+
+	var ttMove chess.Move
+	if e, ok := s.table.probe(h); ok {
+		ttMove = e.move
+		if int(e.depth) >= depth {
+			score := fromTable(int(e.score), ply)
+			switch {
+			case e.bound == exactBound:
+				return score
+			case e.bound == lowerBound && score >= beta:
+				return score
+			case e.bound == upperBound && score <= alpha:
+				return score
+			}
+		}
+	}
+
+	moves := p.LegalMoves()
+	if len(moves) == 0 {
+		if p.InCheck() {
+			return -mateScore + ply
+		}
+		return 0
+	}
+
+	orderMoves(p, moves, ttMove)
+
+	s.path = append(s.path, h)
+	defer func() { s.path = s.path[:len(s.path)-1] }()
+
+	alphaOrig := alpha
+	best, bestMove := -infinity, moves[0]
+	for _, m := range moves {
+		score := -s.negamax(p.PlayLegal(m), depth-1, ply+1, -beta, -alpha)
+		if s.stopped {
+			return 0
+		}
+
+		if score > best {
+			best, bestMove = score, m
+		}
+		alpha = max(alpha, score)
+		if alpha >= beta {
+			break
+		}
+	}
+
+	b := exactBound
+	switch {
+	case best <= alphaOrig:
+		b = upperBound
+	case best >= beta:
+		b = lowerBound
+	}
+	s.table.store(h, depth, toTable(best, ply), b, bestMove)
+
+	return best
+}
+
+// quiesce only searches captures and promotions until the position is quiet,
+// so the evaluation is never taken in the middle of an exchange. The side to
+// move may stand pat instead, unless it is in check and has to get out of it.
+func (s *searcher) quiesce(p chess.Position, ply, alpha, beta int) int {
+	if s.stop() {
+		return 0
+	}
+
+	if result, _ := p.VariantOutcome(); result != chess.NoResult {
+		return -mateScore + ply
+	}
+
+	moves := p.LegalMoves()
+	inCheck := p.InCheck()
+	if len(moves) == 0 {
+		if inCheck {
+			return -mateScore + ply
+		}
+		return 0
+	}
+
+	if ply >= MaxDepth {
+		return Evaluate(p)
+	}
+
+	if !inCheck {
+		standPat := Evaluate(p)
+		if standPat >= beta {
+			return standPat
+		}
+		alpha = max(alpha, standPat)
+
+		moves = slices.DeleteFunc(moves, func(m chess.Move) bool {
+			return !p.IsCapture(m) && m.Promotion == chess.NoPieceType
+		})
+	}
+
+	orderMoves(p, moves, chess.Move{})
+
+	for _, m := range moves {
+		score := -s.quiesce(p.PlayLegal(m), ply+1, -beta, -alpha)
+		if s.stopped {
+			return 0
+		}
+
+		if score >= beta {
+			return score
+		}
+		alpha = max(alpha, score)
+	}
+
+	return alpha
+}
+
+// stop reports whether a limit was hit, checking them every few nodes.
+func (s *searcher) stop() bool {
+	s.nodes++
+
+	if s.stopped || s.nodes%stopCheckInterval != 0 {
+		return s.stopped
+	}
+
+	switch {
+	case s.ctx.Err() != nil:
+		s.stopped = true
+	case s.limits.Nodes > 0 && s.nodes >= s.limits.Nodes:
+		s.stopped = true
+	case !s.deadline.IsZero() && time.Now().After(s.deadline):
+		s.stopped = true
+	}
+
+	return s.stopped
+}
+
+// repeated reports whether the position occurred before with the same side to move.
+func (s *searcher) repeated(h uint64) bool {
+	for i := len(s.path) - 2; i >= 0; i -= 2 {
+		if s.path[i] == h {
+			return true
+		}
+	}
+	return false
+}
+
+// pv follows the best moves stored in the table from the position.
+func (s *searcher) pv(p chess.Position, first chess.Move) []chess.Move {
+	pv := []chess.Move{first}
+	seen := map[uint64]bool{hash(p): true}
+	p = p.PlayLegal(first)
+
+	for len(pv) < MaxDepth {
+		h := hash(p)
+		e, ok := s.table.probe(h)
+		if !ok || seen[h] || !p.IsLegal(e.move) {
+			break
+		}
+
+		seen[h] = true
+		pv = append(pv, e.move)
+		p = p.PlayLegal(e.move)
+	}
+
+	return pv
+}
+
+// Mate scores are stored relative to the position they are stored for,
+// and read back relative to the root.
+func toTable(score, ply int) int {
+	switch {
+	case score > mateBound:
+		return score + ply
+	case score < -mateBound:
+		return score - ply
+	default:
+		return score
+	}
+}
+
+func fromTable(score, ply int) int {
+	switch {
+	case score > mateBound:
+		return score - ply
+	case score < -mateBound:
+		return score + ply
+	default:
+		return score
+	}
+}
+
+// orderMoves sorts the moves most promising first, so the search cuts off sooner:
+// the table's best move, then captures of the most valuable pieces by the least
+// valuable ones, then promotions.
+func orderMoves(p chess.Position, moves []chess.Move, ttMove chess.Move) {
+	priority := func(m chess.Move) int {
+		switch {
+		case m == ttMove:
+			return infinity
+		case p.IsCapture(m):
+			victim := pieceValues[p.Board[m.To].Type]
+			if victim == 0 {
+				victim = pieceValues[chess.Pawn] // en passant
+			}
+			return 10*victim - pieceValues[p.Board[m.From].Type] + pieceValues[m.Promotion]
+		default:
+			return pieceValues[m.Promotion]
+		}
+	}
+
+	slices.SortStableFunc(moves, func(a, b chess.Move) int { return priority(b) - priority(a) })
+}
@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/stretchr/testify/require"
+)
+
+func search(t *testing.T, variant chess.Variant, fen string, limits Limits) Result {
+	position, err := chess.ParseVariantFEN(variant, fen)
+	require.NoError(t, err)
+
+	result, err := Search(context.Background(), position, limits)
+	require.NoError(t, err)
+
+	return result
+}
+
+func Test_Search_Finds_Best_Move(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant chess.Variant
+		fen     string
+		depth   int
+		move    string
+	}{
+		{"back rank mate", chess.Standard, "6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1", 2, "a1a8"},
+		{"queen sacrifice mating in three", chess.Standard, "r1b3kr/ppp1Bp1p/1b6/n2P4/2p3q1/2Q2N2/P4PPP/RN2R1K1 w - - 1 1", 5, "c3h8"},
+		{"takes hanging queen", chess.Standard, "4k3/8/8/3q4/8/8/3R4/4K3 w - - 0 1", 2, "d2d5"},
+		{"walks to the hill", chess.KingOfTheHill, "4k3/8/8/8/8/4K3/8/8 w - - 0 1", 1, "e3d4"},
+		{"gives the third check", chess.ThreeCheck, "4k3/8/8/8/8/8/8/R3K3 w - - 0 1 +2+0", 1, "a1a8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := search(t, tt.variant, tt.fen, Limits{Depth: tt.depth})
+
+			// Assert
+			require.Equal(t, tt.move, result.Move.UCI())
+		})
+	}
+}
+
+func Test_Search_Scores_Mate(t *testing.T) {
+	// Act
+	result := search(t, chess.Standard, "6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1", Limits{Depth: 4})
+
+	// Assert
+	require.True(t, result.IsMate())
+	require.Equal(t, mateScore-1, result.Score)
+	require.Equal(t, []string{"a1a8"}, uciMoves(result.PV))
+}
+
+func Test_Search_Avoids_Mate(t *testing.T) {
+	// Act
+	// Re8 mates unless black makes room for the king or covers the back rank.
+	result := search(t, chess.Standard, "6k1/5ppp/8/8/8/8/r7/1K2R3 b - - 0 1", Limits{Depth: 3})
+
+	// Assert
+	require.False(t, result.IsMate())
+}
+
+func Test_Search_Respects_Limits(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits
+	}{
+		{"depth", Limits{Depth: 2}},
+		{"nodes", Limits{Nodes: 2 * stopCheckInterval}},
+		{"move time", Limits{MoveTime: 50 * time.Millisecond}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			started := time.Now()
+
+			// Act
+			result := search(t, chess.Standard, chess.StartingFEN, tt.limits)
+
+			// Assert
+			require.Less(t, time.Since(started), 5*time.Second)
+			require.NotZero(t, result.Move)
+			if tt.limits.Depth > 0 {
+				require.Equal(t, tt.limits.Depth, result.Depth)
+			}
+			if tt.limits.Nodes > 0 {
+				require.LessOrEqual(t, result.Nodes, tt.limits.Nodes)
+			}
+		})
+	}
+}
+
+func Test_Search_Stops_When_Context_Is_Cancelled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	result, err := Search(ctx, chess.StartingPosition(), Limits{})
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, chess.StartingPosition().IsLegal(result.Move))
+}
+
+func Test_Search_Returns_Error_Without_Legal_Moves(t *testing.T) {
+	// Arrange
+	position, err := chess.ParseFEN("7k/5Q2/6K1/8/8/8/8/8 b - - 0 1")
+	require.NoError(t, err)
+
+	// Act
+	_, err = Search(context.Background(), position, Limits{Depth: 1})
+
+	// Assert
+	require.ErrorIs(t, err, ErrNoLegalMoves)
+}
+
+func Test_Search_Randomness_Only_Plays_Moves_Within_Margin(t *testing.T) {
+	// Arrange
+	// Taking the queen is worth far more than any margin, so it is always played.
+	fen := "4k3/8/8/3q4/8/8/3R4/4K3 w - - 0 1"
+
+	for range 10 {
+		// Act
+		result := search(t, chess.Standard, fen, Limits{Depth: 2, Randomness: 100})
+
+		// Assert
+		require.Equal(t, "d2d5", result.Move.UCI())
+	}
+}
+
+func Test_Search_Repetitions_Only_Match_The_Same_Side_To_Move(t *testing.T) {
+	// Arrange
+	// The path ends with the parent of the position searched, which has the other side to move.
+	s := &searcher{path: []uint64{1, 2, 3, 4}}
+
+	// Assert
+	require.True(t, s.repeated(3))
+	require.True(t, s.repeated(1))
+	require.False(t, s.repeated(4))
+	require.False(t, s.repeated(2))
+}
+
+func Test_Search_Repeats_Position_When_Losing(t *testing.T) {
+	// Arrange
+	// White is a rook against a queen, so repeating the moves for a draw is best.
+	positions, err := chess.Replay(chess.Standard, "6k1/8/8/7q/8/8/8/R5K1 w - - 0 1", []string{"a1a2", "g8h8", "a2a1", "h8g8"})
+	require.NoError(t, err)
+
+	position, history := positions[len(positions)-1], positions[:len(positions)-1]
+
+	// Act
+	result, err := Search(context.Background(), position, Limits{Depth: 2}, history...)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "a1a2", result.Move.UCI())
+	require.Equal(t, 0, result.Score)
+}
+
+func Test_Evaluate_Is_Symmetric(t *testing.T) {
+	require.Equal(t, 0, Evaluate(chess.StartingPosition()))
+
+	white, err := chess.ParseFEN("4k3/8/8/8/8/8/8/R3K3 w - - 0 1")
+	require.NoError(t, err)
+	black, err := chess.ParseFEN("r3k3/8/8/8/8/8/8/4K3 b - - 0 1")
+	require.NoError(t, err)
+
+	require.Equal(t, Evaluate(white), Evaluate(black))
+	require.Greater(t, Evaluate(white), pieceValues[chess.Rook]/2)
+}
+
+func Test_Level_Limits(t *testing.T) {
+	require.NoError(t, MinLevel.Validate())
+	require.NoError(t, MaxLevel.Validate())
+	require.Error(t, Level(0).Validate())
+	require.Error(t, (MaxLevel + 1).Validate())
+
+	require.Greater(t, MaxLevel.Limits().Depth, MinLevel.Limits().Depth)
+	require.Equal(t, 2*time.Second, Limits{}.ForClock(60*time.Second, 0).MoveTime)
+	require.Equal(t, 100*time.Millisecond, MinLevel.Limits().ForClock(time.Hour, 0).MoveTime)
+	require.Equal(t, MinLevel.Limits(), MinLevel.Limits().ForClock(0, 0))
+}
+
+func uciMoves(moves []chess.Move) []string {
+	ucis := make([]string, 0, len(moves))
+	for _, m := range moves {
+		ucis = append(ucis, m.UCI())
+	}
+	return ucis
+}
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"math/rand/v2"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+)
+
+// The Zobrist keys hash a position by XOR-ing a random key for each of its features.
+// They are generated from a fixed seed, so hashes are the same on every run.
+var (
+	pieceKeys     [2][7][64]uint64
+	turnKey       uint64
+	castlingKeys  [16]uint64
+	enPassantKeys [8]uint64
+	checkKeys     [2][chess.ThreeCheckLimit + 1]uint64
+)
+
+func init() {
+	r := rand.New(rand.NewPCG(0x5eed, 0xc4e55))
+
+	for c := range pieceKeys {
+		for t := range pieceKeys[c] {
+			for sq := range pieceKeys[c][t] {
+				pieceKeys[c][t][sq] = r.Uint64()
+			}
+		}
+	}
+
+	turnKey = r.Uint64()
+
+	for i := range castlingKeys {
+		castlingKeys[i] = r.Uint64()
+	}
+
+	for i := range enPassantKeys {
+		enPassantKeys[i] = r.Uint64()
+	}
+
+	for c := range checkKeys {
+		for i := range checkKeys[c] {
+			checkKeys[c][i] = r.Uint64()
+		}
+	}
+}
+
+// hash is the Zobrist hash of the position, equal for the positions the
+// repetition rules treat as equal.
+func hash(p chess.Position) uint64 {
+	var h uint64
+
+	for i, piece := range p.Board {
+		if !piece.IsEmpty() {
+			h ^= pieceKeys[piece.Color][piece.Type][i]
+		}
+	}
+
+	if p.Turn == chess.Black {
+		h ^= turnKey
+	}
+
+	h ^= castlingKeys[p.Castling]
+
+	if p.EnPassant != chess.NoSquare {
+		h ^= enPassantKeys[p.EnPassant.File()]
+	}
+
+	if p.Variant == chess.ThreeCheck {
+		for c := range checkKeys {
+			h ^= checkKeys[c][min(p.Checks[c], chess.ThreeCheckLimit)]
+		}
+	}
+
+	return h
+}
+
+type bound uint8
+
+const (
+	exactBound bound = iota
+	// lowerBound scores failed high, the position is worth at least the score.
+	lowerBound
+	// upperBound scores failed low, the position is worth at most the score.
+	upperBound
+)
+
+type entry struct {
+	hash  uint64
+	move  chess.Move
+	score int32
+	depth int8
+	bound bound
+}
+
+// table is the transposition table, which remembers the positions searched so
+// positions reached by different move orders are only searched once. Entries are
+// replaced when a position with the same index is searched at least as deep.
+type table struct {
+	entries []entry
+	mask    uint64
+}
+
+// tableSize is the number of entries, a power of two.
+const tableSize = 1 << 16
+
+func newTable() *table {
+	return &table{entries: make([]entry, tableSize), mask: tableSize - 1}
+}
+
+func (t *table) probe(h uint64) (entry, bool) {
+	e := t.entries[h&t.mask]
+	return e, e.hash == h && e.depth > 0
+}
+
+func (t *table) store(h uint64, depth int, score int, b bound, move chess.Move) {
+	e := &t.entries[h&t.mask]
+	if e.hash == h && int(e.depth) > depth {
+		return
+	}
+
+	*e = entry{hash: h, move: move, score: int32(score), depth: int8(depth), bound: b}
+}
@@ -57,3 +57,21 @@ func MustGetURL(key string) *url.URL {
 
 	return u
 }
+
+// GetString returns the value of the environment variable, or the fallback when it is not set.
+func GetString(key string, fallback string) string {
+	if val, found := os.LookupEnv(key); found {
+		return val
+	}
+
+	return fallback
+}
+
+// GetInt returns the value of the environment variable, or the fallback when it is not set.
+func GetInt(key string, fallback int) int {
+	if _, found := os.LookupEnv(key); !found {
+		return fallback
+	}
+
+	return MustGetInt(key)
+}
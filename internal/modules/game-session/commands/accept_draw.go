@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// AcceptDrawCommand accepts the opponent's draw offer, which ends the game in a draw.
+type AcceptDrawCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c AcceptDrawCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+func HandleAcceptDraw(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := AcceptDrawCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[AcceptDrawCommand, GameActionResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type AcceptDrawCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewAcceptDrawCommandHandler(db *sql.DB, clock core.Clock) *AcceptDrawCommandHandler {
+	return &AcceptDrawCommandHandler{db: db, clock: clock}
+}
+
+func (h *AcceptDrawCommandHandler) Handle(ctx context.Context, request AcceptDrawCommand) (GameActionResponse, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		offer, err := getPendingGameOffer(ctx, tx, game.ID, domain.DrawOffer)
+		if err != nil {
+			return err
+		}
+
+		if err := offer.Accept(*game, request.PlayerID, now); err != nil {
+			return err
+		}
+
+		if err := answerGameOffer(ctx, tx, game, offer, now); err != nil {
+			return err
+		}
+
+		if err := game.AgreeDraw(now); err != nil {
+			return err
+		}
+
+		if err := recordGameOver(ctx, tx, game, now); err != nil {
+			return err
+		}
+
+		if err := updateGame(ctx, tx, *game); err != nil {
+			return err
+		}
+
+		return notifyGameOffer(ctx, tx, *game, offer.OfferedBy, offer, notificationsdomain.GameOfferAnsweredNotification, now)
+	}
+
+	game, err := runGameAction(ctx, h.db, request.SessionID, now, action)
+	if err != nil {
+		return GameActionResponse{}, err
+	}
+
+	return newGameActionResponse(game), nil
+}
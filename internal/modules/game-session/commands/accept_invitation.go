@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// AcceptInvitationCommand seats the invitee in the session and starts the game.
+type AcceptInvitationCommand struct {
+	InvitationID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (c AcceptInvitationCommand) Validate() error {
+	if c.InvitationID == uuid.Nil {
+		return fmt.Errorf("invalid InvitationID - '%s'", c.InvitationID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+type AcceptInvitationResponse struct {
+	SessionURL string
+	GameID     uuid.UUID
+}
+
+func HandleAcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	invitationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := AcceptInvitationCommand{
+		InvitationID: invitationID,
+		UserID:       core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[AcceptInvitationCommand, AcceptInvitationResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type AcceptInvitationCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewAcceptInvitationCommandHandler(db *sql.DB, clock core.Clock) *AcceptInvitationCommandHandler {
+	return &AcceptInvitationCommandHandler{db, clock}
+}
+
+func (h *AcceptInvitationCommandHandler) Handle(
+	ctx context.Context,
+	request AcceptInvitationCommand,
+) (AcceptInvitationResponse, error) {
+	var (
+		session domain.Session
+		game    domain.Game
+	)
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		// The session is locked before the invitation, in the same order as
+		// joining and closing the session lock them.
+		const sessionQuery = `
+			SELECT
+				s.*
+			FROM
+				game_session s
+			INNER JOIN
+				session_invitation i ON i.session_id = s.id
+			WHERE
+				i.id = $1
+			FOR UPDATE OF s;`
+		var err error
+		session, err = tql.QueryFirst[domain.Session](ctx, tx, sessionQuery, request.InvitationID)
+		if err != nil {
+			return err
+		}
+
+		invitation, err := getInvitationForUpdate(ctx, tx, request.InvitationID)
+		if err != nil {
+			return err
+		}
+
+		if err := social.CheckNotBlocked(ctx, tx, invitation.InviterID, invitation.InviteeID); err != nil {
+			return err
+		}
+
+		now := h.clock.Now()
+
+		if err := invitation.Accept(request.UserID, &session, now); err != nil {
+			return err
+		}
+
+		if err := updateInvitation(ctx, tx, invitation); err != nil {
+			return err
+		}
+
+		game, err = startSessionGame(ctx, tx, session, now)
+		if err != nil {
+			return err
+		}
+
+		if err := withdrawSessionInvitations(ctx, tx, session.ID, now); err != nil {
+			return err
+		}
+
+		if err := notifyGameStarted(ctx, tx, session, game, now); err != nil {
+			return err
+		}
+
+		return notifyLobbySessionClosed(ctx, tx, session.ID, now)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return AcceptInvitationResponse{}, invitationCommandError(err)
+	}
+
+	return AcceptInvitationResponse{
+		SessionURL: path.Join("/game-sessions", session.ID),
+		GameID:     game.ID,
+	}, nil
+}
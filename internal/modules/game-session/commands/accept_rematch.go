@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"path"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// AcceptRematchCommand accepts the opponent's rematch offer. The rematch starts right
+// away in a new session with the same settings and the colors swapped, and continues
+// the series of games between the players.
+type AcceptRematchCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c AcceptRematchCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+type AcceptRematchResponse struct {
+	SessionID string
+	GameID    uuid.UUID
+	SeriesID  uuid.UUID
+}
+
+func HandleAcceptRematch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := AcceptRematchCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[AcceptRematchCommand, AcceptRematchResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	location := path.Join(r.Host, "game-sessions", response.SessionID)
+	core.WriteResponse(w, r, http.StatusCreated, response, core.WithHeader("Location", location))
+}
+
+type AcceptRematchCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewAcceptRematchCommandHandler(db *sql.DB, clock core.Clock) *AcceptRematchCommandHandler {
+	return &AcceptRematchCommandHandler{db: db, clock: clock}
+}
+
+func (h *AcceptRematchCommandHandler) Handle(ctx context.Context, request AcceptRematchCommand) (AcceptRematchResponse, error) {
+	now := h.clock.Now()
+
+	var response AcceptRematchResponse
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		offer, err := getPendingRematchOffer(ctx, tx, game.ID)
+		if err != nil {
+			return err
+		}
+
+		const sessionQuery = `
+			SELECT
+				*
+			FROM
+				game_session
+			WHERE
+				id = $1
+			FOR UPDATE;`
+		session, err := tql.QueryFirst[domain.Session](ctx, tx, sessionQuery, request.SessionID)
+		if err != nil {
+			return err
+		}
+
+		// The series starts with the first rematch, taking in the game it rematches.
+		if session.SeriesID == nil {
+			series := domain.NewSeries(*game, now)
+
+			const seriesStmt = `
+				INSERT INTO
+					game_series (id, player_1_id, player_2_id, created_at)
+				VALUES
+					(:id, :player_1_id, :player_2_id, :created_at);`
+			if _, err := tql.Exec(ctx, tx, seriesStmt, series); err != nil {
+				return err
+			}
+
+			session.SeriesID = &series.ID
+
+			const sessionStmt = `
+				UPDATE
+					game_session
+				SET
+					series_id = :series_id
+				WHERE
+					id = :id;`
+			if _, err := tql.Exec(ctx, tx, sessionStmt, session); err != nil {
+				return err
+			}
+		}
+
+		rematch := session.Rematch(*game, *session.SeriesID, now)
+
+		if err := offer.Accept(request.PlayerID, rematch.ID, now); err != nil {
+			return err
+		}
+
+		if err := social.CheckNotBlocked(ctx, tx, offer.OfferedBy, offer.OfferedTo); err != nil {
+			return err
+		}
+
+		if err := insertSession(ctx, tx, rematch); err != nil {
+			return err
+		}
+
+		if err := updateRematchOffer(ctx, tx, offer); err != nil {
+			return err
+		}
+
+		rematchGame, err := startSessionGame(ctx, tx, rematch, now)
+		if err != nil {
+			return err
+		}
+
+		if err := notifyGameStarted(ctx, tx, rematch, rematchGame, now); err != nil {
+			return err
+		}
+
+		response = AcceptRematchResponse{
+			SessionID: rematch.ID,
+			GameID:    rematchGame.ID,
+			SeriesID:  *rematch.SeriesID,
+		}
+
+		return notifyRematchOffer(ctx, tx, offer.OfferedBy, offer, notificationsdomain.RematchAnsweredNotification, now)
+	}
+
+	if _, err := runGameAction(ctx, h.db, request.SessionID, now, action); err != nil {
+		return AcceptRematchResponse{}, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// AcceptTakebackCommand accepts the opponent's takeback request, which returns
+// the game to the position before the opponent's last move.
+type AcceptTakebackCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c AcceptTakebackCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+func HandleAcceptTakeback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := AcceptTakebackCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[AcceptTakebackCommand, GameActionResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type AcceptTakebackCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewAcceptTakebackCommandHandler(db *sql.DB, clock core.Clock) *AcceptTakebackCommandHandler {
+	return &AcceptTakebackCommandHandler{db: db, clock: clock}
+}
+
+func (h *AcceptTakebackCommandHandler) Handle(ctx context.Context, request AcceptTakebackCommand) (GameActionResponse, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		offer, err := getPendingGameOffer(ctx, tx, game.ID, domain.TakebackOffer)
+		if err != nil {
+			return err
+		}
+
+		if err := offer.Accept(*game, request.PlayerID, now); err != nil {
+			return err
+		}
+
+		if err := answerGameOffer(ctx, tx, game, offer, now); err != nil {
+			return err
+		}
+
+		moves, err := getGameMoves(ctx, tx, game.ID)
+		if err != nil {
+			return err
+		}
+
+		ply, err := game.TakeBack(offer.OfferedBy, moves, now)
+		if err != nil {
+			return err
+		}
+
+		const stmt = `
+			DELETE FROM
+				game_move
+			WHERE
+				game_id = $1 AND ply > $2;`
+		if _, err := tql.Exec(ctx, tx, stmt, game.ID, ply); err != nil {
+			return err
+		}
+
+		payload := domain.TakebackPayload{
+			Ply:   game.Ply,
+			FEN:   game.FEN,
+			Clock: game.Clock(),
+		}
+		if err := recordGameEvent(ctx, tx, game, domain.TakebackEvent, payload, now); err != nil {
+			return err
+		}
+
+		if err := updateGame(ctx, tx, *game); err != nil {
+			return err
+		}
+
+		return notifyGameOffer(ctx, tx, *game, offer.OfferedBy, offer, notificationsdomain.GameOfferAnsweredNotification, now)
+	}
+
+	game, err := runGameAction(ctx, h.db, request.SessionID, now, action)
+	if err != nil {
+		return GameActionResponse{}, err
+	}
+
+	return newGameActionResponse(game), nil
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// BerserkCommand halves the player's clock in an arena game allowing it, in return
+// for an extra point if they win. It is only possible before the player's first move.
+type BerserkCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c BerserkCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+func HandleBerserk(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := BerserkCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[BerserkCommand, GameActionResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type BerserkCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewBerserkCommandHandler(db *sql.DB, clock core.Clock) *BerserkCommandHandler {
+	return &BerserkCommandHandler{db: db, clock: clock}
+}
+
+func (h *BerserkCommandHandler) Handle(ctx context.Context, request BerserkCommand) (GameActionResponse, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		if err := game.Berserk(request.PlayerID, now); err != nil {
+			return err
+		}
+
+		if err := tournaments.Berserk(ctx, tx, game.ID, request.PlayerID); err != nil {
+			return err
+		}
+
+		payload := domain.BerserkPayload{PlayerID: request.PlayerID, Clock: game.Clock()}
+		if err := recordGameEvent(ctx, tx, game, domain.BerserkEvent, payload, now); err != nil {
+			return err
+		}
+
+		return updateGame(ctx, tx, *game)
+	}
+
+	game, err := runGameAction(ctx, h.db, request.SessionID, now, action)
+	if err != nil {
+		return GameActionResponse{}, err
+	}
+
+	return newGameActionResponse(game), nil
+}
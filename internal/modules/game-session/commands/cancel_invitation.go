@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// CancelInvitationCommand is the inviter taking back an invitation.
+type CancelInvitationCommand struct {
+	InvitationID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (c CancelInvitationCommand) Validate() error {
+	if c.InvitationID == uuid.Nil {
+		return fmt.Errorf("invalid InvitationID - '%s'", c.InvitationID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleCancelInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	invitationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := CancelInvitationCommand{
+		InvitationID: invitationID,
+		UserID:       core.Session(ctx).UserID,
+	}
+
+	if _, err := mediator.Send[CancelInvitationCommand, core.Unit](ctx, command); err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type CancelInvitationCommandHandler struct {
+	db *sql.DB
+}
+
+func NewCancelInvitationCommandHandler(db *sql.DB) *CancelInvitationCommandHandler {
+	return &CancelInvitationCommandHandler{db}
+}
+
+func (h *CancelInvitationCommandHandler) Handle(
+	ctx context.Context,
+	request CancelInvitationCommand,
+) (core.Unit, error) {
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		invitation, err := getInvitationForUpdate(ctx, tx, request.InvitationID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		if err := invitation.Cancel(request.UserID, now); err != nil {
+			return err
+		}
+
+		if err := updateInvitation(ctx, tx, invitation); err != nil {
+			return err
+		}
+
+		notification, err := newInvitationClosedNotification(
+			invitation.InviteeID,
+			notificationsdomain.InvitationCancelledNotification,
+			invitation,
+			now,
+		)
+		if err != nil {
+			return err
+		}
+
+		return notifications.Notify(ctx, tx, notification)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, invitationCommandError(err)
+	}
+
+	return core.Unit{}, nil
+}
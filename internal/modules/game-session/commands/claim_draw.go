@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// ClaimDrawCommand ends the game in a draw by threefold repetition or
+// the fifty-move rule, whichever applies.
+type ClaimDrawCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c ClaimDrawCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+func HandleClaimDraw(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := ClaimDrawCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[ClaimDrawCommand, GameActionResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type ClaimDrawCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewClaimDrawCommandHandler(db *sql.DB, clock core.Clock) *ClaimDrawCommandHandler {
+	return &ClaimDrawCommandHandler{db: db, clock: clock}
+}
+
+func (h *ClaimDrawCommandHandler) Handle(ctx context.Context, request ClaimDrawCommand) (GameActionResponse, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		moves, err := getGameMoves(ctx, tx, game.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := game.ClaimDraw(request.PlayerID, moves, now); err != nil {
+			return err
+		}
+
+		if err := recordGameOver(ctx, tx, game, now); err != nil {
+			return err
+		}
+
+		if err := updateGame(ctx, tx, *game); err != nil {
+			return err
+		}
+
+		return notifyGameEnded(ctx, tx, *game, request.PlayerID, now)
+	}
+
+	game, err := runGameAction(ctx, h.db, request.SessionID, now, action)
+	if err != nil {
+		return GameActionResponse{}, err
+	}
+
+	return newGameActionResponse(game), nil
+}
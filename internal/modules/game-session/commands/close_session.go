@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
 
 	"github.com/eskrenkovic/mediator-go"
 	"github.com/eskrenkovic/tql"
@@ -57,31 +59,49 @@ func (h *CloseSessionCommandHandler) Handle(
 	request CloseSessionCommand,
 ) (core.Unit, error) {
 	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const query = `
+			SELECT
+				*
+			FROM
+				game_session
+			WHERE
+				id = $1 AND owner_id = $2
+			FOR UPDATE;`
+		session, err := tql.QueryFirst[domain.Session](ctx, tx, query, request.SessionID, request.UserID)
+		if err != nil {
+			return err
+		}
+
+		// Sessions with a game in progress end with the game.
+		if !session.IsOpen() {
+			return core.NewCommandError(409, fmt.Errorf("session '%s' is not open", session.ID))
+		}
+
 		const stmt = `
 			UPDATE
 				game_session
 			SET
-				active = false
+				closed = true
 			WHERE
-				id = $1 AND owner_id == $2;`
+				id = $1;`
+		if _, err := tql.Exec(ctx, tx, stmt, session.ID); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
 
-		if _, err := tql.Exec(ctx, tx, stmt, request.SessionID, request.UserID); err != nil {
+		if err := withdrawSessionInvitations(ctx, tx, session.ID, now); err != nil {
 			return err
 		}
 
-		const invitationStmt = `
-			UPDATE
-				session_invitations
-			SET
-				active = false
-			WHERE
-				session_id = $1;`
-		_, err := tql.Exec(ctx, tx, invitationStmt, request.SessionID)
-		return err
+		return notifyLobbySessionClosed(ctx, tx, session.ID, now)
 	}
 
 	err := core.Tx(ctx, h.db, txFn)
+	var commandErr core.CommandError
 	switch {
+	case err != nil && errors.As(err, &commandErr):
+		return core.Unit{}, commandErr
 	case err != nil && errors.Is(err, sql.ErrNoRows):
 		return core.Unit{}, core.NewCommandError(404, err)
 	case err != nil:
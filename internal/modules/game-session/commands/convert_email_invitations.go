@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// ConvertEmailInvitationsCommand turns the email invitations whose invitee has since
+// registered and confirmed the email into invitations. It is run periodically by a background job.
+type ConvertEmailInvitationsCommand struct{}
+
+type ConvertEmailInvitationsCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewConvertEmailInvitationsCommandHandler(db *sql.DB, clock core.Clock) *ConvertEmailInvitationsCommandHandler {
+	return &ConvertEmailInvitationsCommandHandler{db: db, clock: clock}
+}
+
+func (h *ConvertEmailInvitationsCommandHandler) Handle(
+	ctx context.Context,
+	_ ConvertEmailInvitationsCommand,
+) (core.Unit, error) {
+	now := h.clock.Now()
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const query = `
+			SELECT
+				e.*
+			FROM
+				email_invitation e
+			WHERE
+				e.status = $1
+				AND e.expires_at > $2
+				AND EXISTS (SELECT 1 FROM auth.user u WHERE lower(u.email) = e.email AND u.email_confirmed = true)
+			ORDER BY
+				e.created_at
+			LIMIT
+				100
+			FOR UPDATE OF e SKIP LOCKED;`
+		emailInvitations, err := tql.Query[domain.EmailInvitation](ctx, tx, query, domain.InvitationPending, now)
+		if err != nil {
+			return err
+		}
+
+		for _, emailInvitation := range emailInvitations {
+			if err := convertEmailInvitation(ctx, tx, emailInvitation, now); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
+
+func convertEmailInvitation(ctx context.Context, tx *sql.Tx, emailInvitation domain.EmailInvitation, now time.Time) error {
+	const inviteeQuery = `
+		SELECT
+			id
+		FROM
+			auth.user
+		WHERE
+			lower(email) = $1 AND email_confirmed = true;`
+	inviteeID, err := tql.QueryFirst[uuid.UUID](ctx, tx, inviteeQuery, emailInvitation.Email)
+	if err != nil {
+		return err
+	}
+
+	// The session is normally locked before its invitations, so a session
+	// being joined or closed is skipped instead of waited for.
+	const sessionQuery = `
+		SELECT
+			*
+		FROM
+			game_session
+		WHERE
+			id = $1
+		FOR UPDATE SKIP LOCKED;`
+	session, err := tql.QueryFirst[domain.Session](ctx, tx, sessionQuery, emailInvitation.SessionID)
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	withdrawn := emailInvitation
+	if err := withdrawn.Withdraw(now); err != nil {
+		return err
+	}
+
+	// Blocked users cannot invite each other, no matter when the email invitation was sent.
+	blocked, err := social.Blocked(ctx, tx, emailInvitation.InviterID, inviteeID)
+	if err != nil {
+		return err
+	}
+
+	if blocked {
+		return updateEmailInvitation(ctx, tx, withdrawn)
+	}
+
+	invitation, err := emailInvitation.Convert(session, inviteeID, now)
+	switch {
+	case err != nil && (errors.Is(err, domain.ErrSessionNotOpen) || errors.Is(err, domain.ErrCannotInviteSelf)):
+		return updateEmailInvitation(ctx, tx, withdrawn)
+	case err != nil:
+		return err
+	}
+
+	inserted, err := insertInvitation(ctx, tx, invitation)
+	if err != nil {
+		return err
+	}
+
+	// The player was invited directly in the meantime.
+	if !inserted {
+		return updateEmailInvitation(ctx, tx, withdrawn)
+	}
+
+	return updateEmailInvitation(ctx, tx, emailInvitation)
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/engine"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// CreateBotGameCommand starts a game against the computer, which takes the second
+// seat and plays black. The session is private and its games are never rated.
+type CreateBotGameCommand struct {
+	OwnerID     uuid.UUID
+	Level       engine.Level
+	TimeControl domain.TimeControl
+	Variant     domain.Variant
+	InitialFEN  string
+	// Chess960Index picks the Chess960 starting position, a random one when not set.
+	Chess960Index *int
+}
+
+func (c CreateBotGameCommand) Validate() error {
+	if err := c.Level.Validate(); err != nil {
+		return err
+	}
+
+	return c.session().Validate()
+}
+
+// session is the command creating the bot game's session.
+func (c CreateBotGameCommand) session() CreateSessionCommand {
+	return CreateSessionCommand{
+		OwnerID:       c.OwnerID,
+		Name:          fmt.Sprintf("Computer level %d", c.Level),
+		TimeControl:   c.TimeControl,
+		Visibility:    domain.Private,
+		Variant:       c.Variant,
+		InitialFEN:    c.InitialFEN,
+		Chess960Index: c.Chess960Index,
+	}
+}
+
+type CreateBotGameResponse struct {
+	SessionID string
+	GameID    uuid.UUID
+}
+
+func HandleCreateBotGame(w http.ResponseWriter, r *http.Request) {
+	command, err := core.RequestBody[CreateBotGameCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+	command.OwnerID = core.Session(r.Context()).UserID
+
+	response, err := mediator.Send[CreateBotGameCommand, CreateBotGameResponse](r.Context(), command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteResponse(w, r, http.StatusCreated, response)
+}
+
+type CreateBotGameCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewCreateBotGameCommandHandler(db *sql.DB, clock core.Clock) *CreateBotGameCommandHandler {
+	return &CreateBotGameCommandHandler{db, clock}
+}
+
+func (h *CreateBotGameCommandHandler) Handle(
+	ctx context.Context,
+	request CreateBotGameCommand,
+) (CreateBotGameResponse, error) {
+	now := h.clock.Now()
+
+	session, err := newSession(request.session(), now)
+	if err != nil {
+		return CreateBotGameResponse{}, err
+	}
+	session.Player2ID = domain.BotUserID
+	session.BotLevel = int(request.Level)
+
+	var game domain.Game
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		if err := insertSession(ctx, tx, session); err != nil {
+			return err
+		}
+
+		game, err = startSessionGame(ctx, tx, session, now)
+		if err != nil {
+			return err
+		}
+
+		return notifyGameStarted(ctx, tx, session, game, now)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return CreateBotGameResponse{}, core.NewCommandError(500, err)
+	}
+
+	return CreateBotGameResponse{SessionID: session.ID, GameID: game.ID}, nil
+}
@@ -3,21 +3,31 @@ package commands
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
+	"path"
+	"time"
 
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
 
 	"github.com/eskrenkovic/mediator-go"
 	"github.com/eskrenkovic/tql"
 	"github.com/google/uuid"
 )
 
+// CreateSessionInvitationCommand invites a player by exactly one of their ID,
+// username or email. Emails always get an email invitation, which becomes an
+// invitation once an account with the email is registered and confirmed, so the
+// response does not tell whether the email has an account.
 type CreateSessionInvitationCommand struct {
-	SessionID string
-	InviterID uuid.UUID
-	InviteeID uuid.UUID
+	SessionID       string
+	InviterID       uuid.UUID
+	InviteeID       uuid.UUID
+	InviteeUsername string
+	InviteeEmail    string
 }
 
 func (c CreateSessionInvitationCommand) Validate() error {
@@ -29,29 +39,62 @@ func (c CreateSessionInvitationCommand) Validate() error {
 		return fmt.Errorf("invalid InviterID - '%s'", c.InviterID)
 	}
 
-	if c.InviteeID == uuid.Nil {
-		return fmt.Errorf("invalid InviteeID - '%s'", c.InviteeID)
+	invitees := 0
+	for _, set := range []bool{c.InviteeID != uuid.Nil, c.InviteeUsername != "", c.InviteeEmail != ""} {
+		if set {
+			invitees++
+		}
+	}
+
+	if invitees != 1 {
+		return fmt.Errorf("exactly one of InviteeID, InviteeUsername and InviteeEmail is required")
+	}
+
+	if c.InviteeEmail != "" {
+		if _, err := domain.NormalizeEmail(c.InviteeEmail); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// CreateSessionInvitationResponse holds the InvitationID when the invitee was given
+// by ID or username, otherwise the EmailInvitationID.
+type CreateSessionInvitationResponse struct {
+	InvitationID      uuid.UUID
+	EmailInvitationID uuid.UUID
+}
+
 func HandleCreateSessionInvitation(w http.ResponseWriter, r *http.Request) {
 	command, err := core.RequestBody[CreateSessionInvitationCommand](r)
 	if err != nil {
 		core.WriteBadRequest(w, r, err)
+		return
 	}
 	command.SessionID = r.PathValue("id")
+	command.InviterID = core.Session(r.Context()).UserID // only the logged-in user can invite on their behalf
 
-	_, err = mediator.Send[CreateSessionInvitationCommand, core.Unit](r.Context(), command)
+	response, err := mediator.Send[CreateSessionInvitationCommand, CreateSessionInvitationResponse](r.Context(), command)
 	if err != nil {
 		core.WriteCommandError(w, r, err)
 		return
 	}
 
-	core.WriteOK(w, r, nil)
+	// Email invitations only become invitations once the invitee has a confirmed account.
+	if response.InvitationID == uuid.Nil {
+		core.WriteResponse(w, r, http.StatusAccepted, response)
+		return
+	}
+
+	location := path.Join(r.Host, "invitations", response.InvitationID.String())
+	core.WriteCreated(w, r, location)
 }
 
+// emailInvitationLockSpace is the first key of the advisory locks serializing the
+// email invitations of each inviter.
+const emailInvitationLockSpace int32 = 0x656d6169
+
 type CreateSessionInvitationCommandHandler struct {
 	db *sql.DB
 }
@@ -63,19 +106,144 @@ func NewCreateSessionInvitationCommandHandler(db *sql.DB) *CreateSessionInvitati
 func (h *CreateSessionInvitationCommandHandler) Handle(
 	ctx context.Context,
 	request CreateSessionInvitationCommand,
-) (core.Unit, error) {
-	invitation := domain.SessionInvitation{
-		ID:        uuid.New(),
-		SessionID: request.SessionID,
-		InviterID: request.InviterID,
-		InviteeID: request.InviteeID,
+) (CreateSessionInvitationResponse, error) {
+	var response CreateSessionInvitationResponse
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		// Locking the session keeps it from being joined or closed while the invitation is sent.
+		const sessionQuery = `
+			SELECT
+				*
+			FROM
+				game_session
+			WHERE
+				id = $1
+			FOR UPDATE;`
+		session, err := tql.QueryFirst[domain.Session](ctx, tx, sessionQuery, request.SessionID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		if request.InviteeEmail != "" {
+			emailInvitation, err := createEmailInvitation(ctx, tx, session, request, now)
+			if err != nil {
+				return err
+			}
+
+			response.EmailInvitationID = emailInvitation.ID
+			return nil
+		}
+
+		inviteeID, err := findInvitee(ctx, tx, request)
+		switch {
+		case err != nil && errors.Is(err, sql.ErrNoRows):
+			return core.NewCommandError(404, fmt.Errorf("invitee not found"))
+		case err != nil:
+			return err
+		}
+
+		if err := social.CheckNotBlocked(ctx, tx, request.InviterID, inviteeID); err != nil {
+			return err
+		}
+
+		invitation, err := domain.NewSessionInvitation(session, request.InviterID, inviteeID, now)
+		if err != nil {
+			return err
+		}
+
+		inserted, err := insertInvitation(ctx, tx, invitation)
+		if err != nil {
+			return err
+		}
+
+		if !inserted {
+			return core.NewCommandError(409, fmt.Errorf("invitee '%s' already has a pending invitation", inviteeID))
+		}
+
+		response.InvitationID = invitation.ID
+		return nil
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return CreateSessionInvitationResponse{}, invitationCommandError(err)
+	}
+
+	return response, nil
+}
+
+// findInvitee returns the ID of the user invited by ID or username, sql.ErrNoRows when there is none.
+func findInvitee(ctx context.Context, tx *sql.Tx, request CreateSessionInvitationCommand) (uuid.UUID, error) {
+	if request.InviteeID != uuid.Nil {
+		const query = `SELECT id FROM auth.user WHERE id = $1;`
+		return tql.QueryFirst[uuid.UUID](ctx, tx, query, request.InviteeID)
+	}
+
+	const query = `SELECT id FROM auth.user WHERE username = $1;`
+	return tql.QueryFirst[uuid.UUID](ctx, tx, query, request.InviteeUsername)
+}
+
+// createEmailInvitation invites the email whether it has an account or not. The
+// accounts are only looked up by the job converting the email invitations, so
+// neither the response nor a block gives away who registered with the email.
+func createEmailInvitation(
+	ctx context.Context,
+	tx *sql.Tx,
+	session domain.Session,
+	request CreateSessionInvitationCommand,
+	now time.Time,
+) (domain.EmailInvitation, error) {
+	emailInvitation, err := domain.NewEmailInvitation(session, request.InviterID, request.InviteeEmail, now)
+	if err != nil {
+		return domain.EmailInvitation{}, err
+	}
+
+	// Serializes the inviter's email invitations, so concurrent ones cannot exceed the limit.
+	const lockStmt = `SELECT pg_advisory_xact_lock($1, hashtext($2));`
+	if _, err := tql.Exec(ctx, tx, lockStmt, emailInvitationLockSpace, request.InviterID.String()); err != nil {
+		return domain.EmailInvitation{}, err
+	}
+
+	const sentQuery = `
+		SELECT
+			count(*)
+		FROM
+			email_invitation
+		WHERE
+			inviter_id = $1 AND created_at > $2;`
+	sent, err := tql.QueryFirst[int](ctx, tx, sentQuery, request.InviterID, domain.EmailInvitationWindowStart(now))
+	if err != nil {
+		return domain.EmailInvitation{}, err
 	}
 
+	if err := domain.CheckEmailInvitationLimit(sent); err != nil {
+		return domain.EmailInvitation{}, err
+	}
+
+	return emailInvitation, insertEmailInvitation(ctx, tx, emailInvitation)
+}
+
+func insertEmailInvitation(ctx context.Context, tx *sql.Tx, invitation domain.EmailInvitation) error {
 	const stmt = `
 		INSERT INTO
-			session_invitations (id, session_id, inviter_id, invitee_id, created_at)
+			email_invitation (id, session_id, inviter_id, email, status, created_at, expires_at)
 		VALUES
-			(:id, :session_id, :inviter_id, :invitee_id);`
-	_, err := tql.Exec(ctx, h.db, stmt, invitation)
-	return core.Unit{}, err
+			(:id, :session_id, :inviter_id, :email, :status, :created_at, :expires_at)
+		ON CONFLICT DO NOTHING;`
+	result, err := tql.Exec(ctx, tx, stmt, invitation)
+	if err != nil {
+		return err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if inserted == 0 {
+		return core.NewCommandError(409, fmt.Errorf("'%s' already has a pending invitation", invitation.Email))
+	}
+
+	return nil
 }
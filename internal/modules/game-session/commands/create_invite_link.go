@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// CreateInviteLinkCommand creates a link with a short join code for the session,
+// which the owner can share with anyone. MaxUses defaults to 1 and TTLSeconds
+// to domain.DefaultInviteLinkTTL.
+type CreateInviteLinkCommand struct {
+	SessionID  string
+	CreatorID  uuid.UUID
+	MaxUses    int
+	TTLSeconds int
+}
+
+func (c CreateInviteLinkCommand) Validate() error {
+	if c.SessionID == "" {
+		return fmt.Errorf("invalid SessionID - '%s'", c.SessionID)
+	}
+
+	if c.CreatorID == uuid.Nil {
+		return fmt.Errorf("invalid CreatorID - '%s'", c.CreatorID)
+	}
+
+	if c.MaxUses < 0 || c.MaxUses > domain.MaxInviteLinkUses {
+		return fmt.Errorf("invalid MaxUses - '%d'", c.MaxUses)
+	}
+
+	if c.TTLSeconds < 0 || time.Duration(c.TTLSeconds)*time.Second > domain.MaxInviteLinkTTL {
+		return fmt.Errorf("invalid TTLSeconds - '%d'", c.TTLSeconds)
+	}
+
+	return nil
+}
+
+type CreateInviteLinkResponse struct {
+	Code      string
+	ExpiresAt time.Time
+}
+
+func HandleCreateInviteLink(w http.ResponseWriter, r *http.Request) {
+	command, err := core.RequestBody[CreateInviteLinkCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+	command.SessionID = r.PathValue("id")
+	command.CreatorID = core.Session(r.Context()).UserID
+
+	response, err := mediator.Send[CreateInviteLinkCommand, CreateInviteLinkResponse](r.Context(), command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	location := path.Join(r.Host, "invite-links", response.Code)
+	core.WriteCreated(w, r, location)
+}
+
+type CreateInviteLinkCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewCreateInviteLinkCommandHandler(db *sql.DB, clock core.Clock) *CreateInviteLinkCommandHandler {
+	return &CreateInviteLinkCommandHandler{db: db, clock: clock}
+}
+
+func (h *CreateInviteLinkCommandHandler) Handle(
+	ctx context.Context,
+	request CreateInviteLinkCommand,
+) (CreateInviteLinkResponse, error) {
+	maxUses := request.MaxUses
+	if maxUses == 0 {
+		maxUses = 1
+	}
+
+	ttl := time.Duration(request.TTLSeconds) * time.Second
+	if ttl == 0 {
+		ttl = domain.DefaultInviteLinkTTL
+	}
+
+	var link domain.InviteLink
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const sessionQuery = `
+			SELECT
+				*
+			FROM
+				game_session
+			WHERE
+				id = $1
+			FOR UPDATE;`
+		session, err := tql.QueryFirst[domain.Session](ctx, tx, sessionQuery, request.SessionID)
+		if err != nil {
+			return err
+		}
+
+		link, err = domain.NewInviteLink(session, request.CreatorID, maxUses, ttl, h.clock.Now())
+		if err != nil {
+			return err
+		}
+
+		// Codes are random, a collision fails the request rather than being retried.
+		const stmt = `
+			INSERT INTO
+				invite_link (id, session_id, creator_id, code, max_uses, uses, created_at, expires_at)
+			VALUES
+				(:id, :session_id, :creator_id, :code, :max_uses, :uses, :created_at, :expires_at);`
+		_, err = tql.Exec(ctx, tx, stmt, link)
+		return err
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return CreateInviteLinkResponse{}, invitationCommandError(err)
+	}
+
+	return CreateInviteLinkResponse{Code: link.Code, ExpiresAt: link.ExpiresAt}, nil
+}
@@ -4,20 +4,38 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math/rand/v2"
 	"net/http"
 	"path"
+	"time"
 
 	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
 
 	"github.com/eskrenkovic/tql"
 	"github.com/google/uuid"
 )
 
 type CreateSessionCommand struct {
-	OwnerID uuid.UUID
-	Name    string
+	OwnerID     uuid.UUID
+	Name        string
+	TimeControl domain.TimeControl
+	// Rated games count towards the players' ratings, casual games do not.
+	Rated bool
+	// Visibility defaults to public, Variant to standard.
+	Visibility domain.Visibility
+	Variant    domain.Variant
+	// InitialFEN sets up a custom starting position, such as a teaching position.
+	// Games from custom positions cannot be rated.
+	InitialFEN string
+	// Chess960Index picks the Chess960 starting position, a random one when not set.
+	Chess960Index *int
+	// SpectatorDelay holds back the game from its spectators, by moves, seconds or both.
+	SpectatorDelay domain.SpectatorDelay
 }
 
 func (c CreateSessionCommand) Validate() error {
@@ -29,24 +47,80 @@ func (c CreateSessionCommand) Validate() error {
 		return fmt.Errorf("invalid Name - '%s'", c.Name)
 	}
 
+	if err := c.TimeControl.Validate(); err != nil {
+		return err
+	}
+
+	if c.Rated && !c.TimeControl.IsTimed() && !c.TimeControl.IsCorrespondence() {
+		return fmt.Errorf("untimed games cannot be rated")
+	}
+
+	if c.Visibility != "" {
+		if err := c.Visibility.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Variant != "" {
+		if err := c.Variant.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Chess960Index != nil {
+		if c.Variant != domain.Chess960 {
+			return fmt.Errorf("Chess960Index is only valid for chess960 sessions")
+		}
+
+		if *c.Chess960Index < 0 || *c.Chess960Index >= chess.Chess960Positions {
+			return fmt.Errorf("invalid Chess960Index - '%d'", *c.Chess960Index)
+		}
+
+		if c.InitialFEN != "" {
+			return fmt.Errorf("Chess960Index and InitialFEN cannot both be set")
+		}
+	}
+
+	if err := c.SpectatorDelay.Validate(); err != nil {
+		return err
+	}
+
+	if c.InitialFEN != "" {
+		if err := domain.ValidateInitialFEN(c.variant(), c.InitialFEN); err != nil {
+			return err
+		}
+
+		if c.Rated && c.InitialFEN != chess.StartingFEN {
+			return fmt.Errorf("games from custom positions cannot be rated")
+		}
+	}
+
 	return nil
 }
 
+func (c CreateSessionCommand) variant() domain.Variant {
+	if c.Variant == "" {
+		return domain.Standard
+	}
+	return c.Variant
+}
+
 type CreateSessionResponse struct {
 	SessionID string
 }
 
 func HandleCreateGameSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	command, err := core.RequestBody[CreateSessionCommand](r)
 	if err != nil {
 		core.WriteBadRequest(w, r, err)
 		return
 	}
 
-	response, err := mediator.Send[CreateSessionCommand, CreateSessionResponse](
-		r.Context(),
-		command,
-	)
+	command.OwnerID = core.Session(ctx).UserID
+
+	response, err := mediator.Send[CreateSessionCommand, CreateSessionResponse](ctx, command)
 	if err != nil {
 		core.WriteCommandError(w, r, err)
 		return
@@ -68,20 +142,152 @@ func (h *CreateSessionCommandHandler) Handle(
 	ctx context.Context,
 	request CreateSessionCommand,
 ) (CreateSessionResponse, error) {
-	session := domain.Session{
-		ID:      uuid.NewString(),
-		OwnerID: request.OwnerID,
-		Name:    request.Name,
+	now := time.Now().UTC()
+
+	session, err := newSession(request, now)
+	if err != nil {
+		return CreateSessionResponse{}, err
 	}
 
-	const stmt = `
-		INSERT INTO
-			game_session (id, owner_id, name)
-		VALUES
-			(:id, :owner_id, :name);`
-	if _, err := tql.Exec(ctx, h.db, stmt, session); err != nil {
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		if err := insertSession(ctx, tx, session); err != nil {
+			return err
+		}
+
+		// Private sessions are kept out of the lobby.
+		if session.Visibility == domain.Private {
+			return nil
+		}
+
+		notification, err := notificationsdomain.NewLobbyNotification(
+			notificationsdomain.LobbySessionOpenedNotification,
+			notificationsdomain.LobbySessionOpenedPayload{
+				SessionID:                   session.ID,
+				Name:                        session.Name,
+				OwnerID:                     session.OwnerID,
+				Rated:                       session.Rated,
+				Variant:                     string(session.Variant),
+				TimeControlBaseSeconds:      session.TimeControlBaseSeconds,
+				TimeControlIncrementSeconds: session.TimeControlIncrementSeconds,
+			},
+			now,
+		)
+		if err != nil {
+			return err
+		}
+
+		return notifications.Notify(ctx, tx, notification)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
 		return CreateSessionResponse{}, err
 	}
 
 	return CreateSessionResponse{SessionID: session.ID}, nil
 }
+
+// newSession sets up the session the command describes, with the owner in the first seat.
+func newSession(request CreateSessionCommand, now time.Time) (domain.Session, error) {
+	timeControl := request.TimeControl
+	if timeControl.Delay == "" {
+		timeControl.Delay = domain.FischerDelay
+	}
+
+	visibility := request.Visibility
+	if visibility == "" {
+		visibility = domain.Public
+	}
+
+	variant := request.variant()
+
+	initialFEN := chess.StartingFEN
+	switch {
+	case request.InitialFEN != "":
+		position, err := chess.ParseVariantFEN(variant, request.InitialFEN)
+		if err != nil {
+			return domain.Session{}, core.NewCommandError(400, err)
+		}
+		initialFEN = position.FEN()
+
+	case variant == domain.Chess960:
+		index := rand.IntN(chess.Chess960Positions)
+		if request.Chess960Index != nil {
+			index = *request.Chess960Index
+		}
+
+		fen, err := chess.Chess960FEN(index)
+		if err != nil {
+			return domain.Session{}, core.NewCommandError(400, err)
+		}
+		initialFEN = fen
+	}
+
+	// The owner takes the first seat and plays white.
+	return domain.Session{
+		ID:                          uuid.NewString(),
+		OwnerID:                     request.OwnerID,
+		Player1ID:                   request.OwnerID,
+		Name:                        request.Name,
+		Rated:                       request.Rated,
+		Visibility:                  visibility,
+		Variant:                     variant,
+		InitialFEN:                  initialFEN,
+		TimeControlBaseSeconds:      timeControl.BaseSeconds,
+		TimeControlIncrementSeconds: timeControl.IncrementSeconds,
+		TimeControlDelay:            timeControl.Delay,
+		TimeControlDaysPerMove:      timeControl.DaysPerMove,
+		TimeControlCategory:         timeControl.Category(),
+		SpectatorDelayMoves:         request.SpectatorDelay.Moves,
+		SpectatorDelaySeconds:       request.SpectatorDelay.Seconds,
+		CreatedAt:                   now,
+	}, nil
+}
+
+func insertSession(ctx context.Context, tx *sql.Tx, session domain.Session) error {
+	const stmt = `
+		INSERT INTO
+			game_session (
+				id,
+				owner_id,
+				player_1_id,
+				name,
+				rated,
+				visibility,
+				variant,
+				initial_fen,
+				bot_level,
+				spectator_delay_moves,
+				spectator_delay_seconds,
+				time_control_base_seconds,
+				time_control_increment_seconds,
+				time_control_delay,
+				time_control_days_per_move,
+				time_control_category,
+				series_id,
+				created_at
+			)
+		VALUES
+			(
+				:id,
+				:owner_id,
+				:player_1_id,
+				:name,
+				:rated,
+				:visibility,
+				:variant,
+				:initial_fen,
+				:bot_level,
+				:spectator_delay_moves,
+				:spectator_delay_seconds,
+				:time_control_base_seconds,
+				:time_control_increment_seconds,
+				:time_control_delay,
+				:time_control_days_per_move,
+				:time_control_category,
+				:series_id,
+				:created_at
+			);`
+
+	_, err := tql.Exec(ctx, tx, stmt, session)
+	return err
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// DeclineDrawCommand declines the opponent's draw offer.
+type DeclineDrawCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c DeclineDrawCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+func HandleDeclineDraw(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := DeclineDrawCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[DeclineDrawCommand, GameActionResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type DeclineDrawCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewDeclineDrawCommandHandler(db *sql.DB, clock core.Clock) *DeclineDrawCommandHandler {
+	return &DeclineDrawCommandHandler{db: db, clock: clock}
+}
+
+func (h *DeclineDrawCommandHandler) Handle(ctx context.Context, request DeclineDrawCommand) (GameActionResponse, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		return declineGameOffer(ctx, tx, game, domain.DrawOffer, request.PlayerID, now)
+	}
+
+	game, err := runGameAction(ctx, h.db, request.SessionID, now, action)
+	if err != nil {
+		return GameActionResponse{}, err
+	}
+
+	return newGameActionResponse(game), nil
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// DeclineInvitationCommand is the invitee turning down an invitation.
+type DeclineInvitationCommand struct {
+	InvitationID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (c DeclineInvitationCommand) Validate() error {
+	if c.InvitationID == uuid.Nil {
+		return fmt.Errorf("invalid InvitationID - '%s'", c.InvitationID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleDeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	invitationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := DeclineInvitationCommand{
+		InvitationID: invitationID,
+		UserID:       core.Session(ctx).UserID,
+	}
+
+	if _, err := mediator.Send[DeclineInvitationCommand, core.Unit](ctx, command); err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type DeclineInvitationCommandHandler struct {
+	db *sql.DB
+}
+
+func NewDeclineInvitationCommandHandler(db *sql.DB) *DeclineInvitationCommandHandler {
+	return &DeclineInvitationCommandHandler{db}
+}
+
+func (h *DeclineInvitationCommandHandler) Handle(
+	ctx context.Context,
+	request DeclineInvitationCommand,
+) (core.Unit, error) {
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		invitation, err := getInvitationForUpdate(ctx, tx, request.InvitationID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		if err := invitation.Decline(request.UserID, now); err != nil {
+			return err
+		}
+
+		if err := updateInvitation(ctx, tx, invitation); err != nil {
+			return err
+		}
+
+		notification, err := newInvitationClosedNotification(
+			invitation.InviterID,
+			notificationsdomain.InvitationDeclinedNotification,
+			invitation,
+			now,
+		)
+		if err != nil {
+			return err
+		}
+
+		return notifications.Notify(ctx, tx, notification)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, invitationCommandError(err)
+	}
+
+	return core.Unit{}, nil
+}
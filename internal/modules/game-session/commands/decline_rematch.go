@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// DeclineRematchCommand declines the opponent's rematch offer.
+type DeclineRematchCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c DeclineRematchCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+func HandleDeclineRematch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := DeclineRematchCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	if _, err := mediator.Send[DeclineRematchCommand, core.Unit](ctx, command); err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type DeclineRematchCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewDeclineRematchCommandHandler(db *sql.DB, clock core.Clock) *DeclineRematchCommandHandler {
+	return &DeclineRematchCommandHandler{db: db, clock: clock}
+}
+
+func (h *DeclineRematchCommandHandler) Handle(ctx context.Context, request DeclineRematchCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		offer, err := getPendingRematchOffer(ctx, tx, game.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := offer.Decline(request.PlayerID, now); err != nil {
+			return err
+		}
+
+		if err := updateRematchOffer(ctx, tx, offer); err != nil {
+			return err
+		}
+
+		return notifyRematchOffer(ctx, tx, offer.OfferedBy, offer, notificationsdomain.RematchAnsweredNotification, now)
+	}
+
+	if _, err := runGameAction(ctx, h.db, request.SessionID, now, action); err != nil {
+		return core.Unit{}, err
+	}
+
+	return core.Unit{}, nil
+}
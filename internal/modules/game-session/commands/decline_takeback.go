@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// DeclineTakebackCommand declines the opponent's takeback request.
+type DeclineTakebackCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c DeclineTakebackCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+func HandleDeclineTakeback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := DeclineTakebackCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[DeclineTakebackCommand, GameActionResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type DeclineTakebackCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewDeclineTakebackCommandHandler(db *sql.DB, clock core.Clock) *DeclineTakebackCommandHandler {
+	return &DeclineTakebackCommandHandler{db: db, clock: clock}
+}
+
+func (h *DeclineTakebackCommandHandler) Handle(ctx context.Context, request DeclineTakebackCommand) (GameActionResponse, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		return declineGameOffer(ctx, tx, game, domain.TakebackOffer, request.PlayerID, now)
+	}
+
+	game, err := runGameAction(ctx, h.db, request.SessionID, now, action)
+	if err != nil {
+		return GameActionResponse{}, err
+	}
+
+	return newGameActionResponse(game), nil
+}
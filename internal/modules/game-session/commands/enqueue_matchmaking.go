@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"path"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings"
+	ratingsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+type EnqueueMatchmakingCommand struct {
+	PlayerID    uuid.UUID
+	TimeControl domain.TimeControl
+	// RatingRange is how far from their own rating the player accepts opponents
+	// initially, it widens the longer the player waits.
+	RatingRange int
+	Rated       bool
+}
+
+func (c EnqueueMatchmakingCommand) Validate() error {
+	if c.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", c.PlayerID)
+	}
+
+	if c.RatingRange < 0 || c.RatingRange > domain.MaxRatingRange {
+		return fmt.Errorf("invalid RatingRange - '%d'", c.RatingRange)
+	}
+
+	if err := c.TimeControl.Validate(); err != nil {
+		return err
+	}
+
+	if c.Rated && !c.TimeControl.IsTimed() && !c.TimeControl.IsCorrespondence() {
+		return fmt.Errorf("untimed games cannot be rated")
+	}
+
+	return nil
+}
+
+type EnqueueMatchmakingResponse struct {
+	TicketID uuid.UUID
+}
+
+func HandleEnqueueMatchmaking(w http.ResponseWriter, r *http.Request) {
+	command, err := core.RequestBody[EnqueueMatchmakingCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+	command.PlayerID = core.Session(r.Context()).UserID
+
+	_, err = mediator.Send[EnqueueMatchmakingCommand, EnqueueMatchmakingResponse](r.Context(), command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteCreated(w, r, path.Join(r.Host, "matchmaking", "queue"))
+}
+
+type EnqueueMatchmakingCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewEnqueueMatchmakingCommandHandler(db *sql.DB, clock core.Clock) *EnqueueMatchmakingCommandHandler {
+	return &EnqueueMatchmakingCommandHandler{db, clock}
+}
+
+func (h *EnqueueMatchmakingCommandHandler) Handle(
+	ctx context.Context,
+	request EnqueueMatchmakingCommand,
+) (EnqueueMatchmakingResponse, error) {
+	category := ratingsdomain.Category(request.TimeControl.Category())
+
+	rating, err := ratings.CurrentRating(ctx, h.db, request.PlayerID, category)
+	if err != nil {
+		return EnqueueMatchmakingResponse{}, core.NewCommandError(500, err)
+	}
+
+	ticket, err := domain.NewMatchmakingTicket(
+		request.PlayerID,
+		int(math.Round(rating.Rating)),
+		request.RatingRange,
+		request.TimeControl,
+		request.Rated,
+		h.clock.Now(),
+	)
+	if err != nil {
+		return EnqueueMatchmakingResponse{}, core.NewCommandError(400, err)
+	}
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		// The ticket of the previous match is replaced.
+		const deleteStmt = `
+			DELETE FROM
+				matchmaking_ticket
+			WHERE
+				player_id = $1 AND status = $2;`
+		if _, err := tql.Exec(ctx, tx, deleteStmt, ticket.PlayerID, domain.TicketMatched); err != nil {
+			return err
+		}
+
+		const stmt = `
+			INSERT INTO
+				matchmaking_ticket (
+					id,
+					player_id,
+					rating,
+					rating_range,
+					rated,
+					time_control_base_seconds,
+					time_control_increment_seconds,
+					time_control_delay,
+					time_control_days_per_move,
+					status,
+					session_id,
+					created_at,
+					matched_at
+				)
+			VALUES
+				(
+					:id,
+					:player_id,
+					:rating,
+					:rating_range,
+					:rated,
+					:time_control_base_seconds,
+					:time_control_increment_seconds,
+					:time_control_delay,
+					:time_control_days_per_move,
+					:status,
+					:session_id,
+					:created_at,
+					:matched_at
+				)
+			ON CONFLICT (player_id) DO NOTHING;`
+		result, err := tql.Exec(ctx, tx, stmt, ticket)
+		if err != nil {
+			return err
+		}
+
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if inserted == 0 {
+			return core.NewCommandError(409, fmt.Errorf("player '%s' is already in the queue", ticket.PlayerID))
+		}
+
+		return nil
+	}
+
+	err = core.Tx(ctx, h.db, txFn)
+	var commandErr core.CommandError
+	switch {
+	case err != nil && errors.As(err, &commandErr):
+		return EnqueueMatchmakingResponse{}, commandErr
+	case err != nil:
+		return EnqueueMatchmakingResponse{}, core.NewCommandError(500, err)
+	}
+
+	return EnqueueMatchmakingResponse{TicketID: ticket.ID}, nil
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// ExpireInvitationsCommand expires the pending invitations which were not answered
+// in time, and the email invitations whose invitee did not register in time. It is run periodically by a background job.
+type ExpireInvitationsCommand struct{}
+
+type ExpireInvitationsCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewExpireInvitationsCommandHandler(db *sql.DB, clock core.Clock) *ExpireInvitationsCommandHandler {
+	return &ExpireInvitationsCommandHandler{db: db, clock: clock}
+}
+
+func (h *ExpireInvitationsCommandHandler) Handle(ctx context.Context, _ ExpireInvitationsCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		// Skip the invitations being answered or expired by another instance,
+		// the ones answered meanwhile are no longer pending on the next run.
+		const query = `
+			SELECT
+				*
+			FROM
+				session_invitation
+			WHERE
+				status = $1 AND expires_at <= $2
+			ORDER BY
+				expires_at
+			LIMIT
+				100
+			FOR UPDATE SKIP LOCKED;`
+		invitations, err := tql.Query[domain.SessionInvitation](ctx, tx, query, domain.InvitationPending, now)
+		if err != nil {
+			return err
+		}
+
+		expired := make([]notificationsdomain.Notification, 0, 2*len(invitations))
+		for _, invitation := range invitations {
+			if err := invitation.Expire(now); err != nil {
+				return err
+			}
+
+			if err := updateInvitation(ctx, tx, invitation); err != nil {
+				return err
+			}
+
+			for _, userID := range []uuid.UUID{invitation.InviterID, invitation.InviteeID} {
+				notification, err := newInvitationClosedNotification(
+					userID,
+					notificationsdomain.InvitationExpiredNotification,
+					invitation,
+					now,
+				)
+				if err != nil {
+					return err
+				}
+
+				expired = append(expired, notification)
+			}
+		}
+
+		const emailQuery = `
+			SELECT
+				*
+			FROM
+				email_invitation
+			WHERE
+				status = $1 AND expires_at <= $2
+			ORDER BY
+				expires_at
+			LIMIT
+				100
+			FOR UPDATE SKIP LOCKED;`
+		emailInvitations, err := tql.Query[domain.EmailInvitation](ctx, tx, emailQuery, domain.InvitationPending, now)
+		if err != nil {
+			return err
+		}
+
+		for _, emailInvitation := range emailInvitations {
+			if err := emailInvitation.Expire(now); err != nil {
+				return err
+			}
+
+			if err := updateEmailInvitation(ctx, tx, emailInvitation); err != nil {
+				return err
+			}
+		}
+
+		return notifications.Notify(ctx, tx, expired...)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// ExpireRematchOffersCommand expires the rematch offers which were not answered in time
+// and lets both players know. It is run periodically by a background job.
+type ExpireRematchOffersCommand struct{}
+
+type ExpireRematchOffersCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewExpireRematchOffersCommandHandler(db *sql.DB, clock core.Clock) *ExpireRematchOffersCommandHandler {
+	return &ExpireRematchOffersCommandHandler{db: db, clock: clock}
+}
+
+func (h *ExpireRematchOffersCommandHandler) Handle(ctx context.Context, _ ExpireRematchOffersCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		// Skip the offers being answered or expired by another instance,
+		// the ones answered meanwhile are no longer pending on the next run.
+		const query = `
+			SELECT
+				*
+			FROM
+				rematch_offer
+			WHERE
+				status = $1 AND expires_at <= $2
+			ORDER BY
+				expires_at
+			LIMIT
+				100
+			FOR UPDATE SKIP LOCKED;`
+		offers, err := tql.Query[domain.RematchOffer](ctx, tx, query, domain.OfferPending, now)
+		if err != nil {
+			return err
+		}
+
+		expired := make([]notificationsdomain.Notification, 0, 2*len(offers))
+		for _, offer := range offers {
+			if err := offer.Expire(now); err != nil {
+				return err
+			}
+
+			if err := updateRematchOffer(ctx, tx, offer); err != nil {
+				return err
+			}
+
+			for _, userID := range []uuid.UUID{offer.OfferedBy, offer.OfferedTo} {
+				notification, err := newRematchOfferNotification(
+					userID,
+					notificationsdomain.RematchAnsweredNotification,
+					offer,
+					now,
+				)
+				if err != nil {
+					return err
+				}
+
+				expired = append(expired, notification)
+			}
+		}
+
+		return notifications.Notify(ctx, tx, expired...)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,430 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles"
+	profilesdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings"
+	ratingsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments"
+	tournamentsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/tql"
+)
+
+// startSessionGame seats the players of the session and starts a new game for them.
+func startSessionGame(
+	ctx context.Context,
+	tx *sql.Tx,
+	session domain.Session,
+	now time.Time,
+) (domain.Game, error) {
+	game, err := domain.StartGame(session, now)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	if err := postponeForVacation(ctx, tx, &game, now); err != nil {
+		return domain.Game{}, err
+	}
+
+	payload := domain.GameStartedPayload{
+		GameID:  game.ID,
+		WhiteID: game.WhiteID,
+		BlackID: game.BlackID,
+		FEN:     game.FEN,
+		Clock:   game.Clock(),
+	}
+	event, err := game.RecordEvent(domain.GameStartedEvent, payload, now)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	const gameStmt = `
+		INSERT INTO
+			game (
+				id,
+				session_id,
+				white_id,
+				black_id,
+				variant,
+				initial_fen,
+				fen,
+				ply,
+				status,
+				result,
+				termination,
+				version,
+				rated,
+				time_control_base_seconds,
+				time_control_increment_seconds,
+				time_control_delay,
+				time_control_days_per_move,
+				white_remaining_ms,
+				black_remaining_ms,
+				turn_started_at,
+				flag_at,
+				move_due_at,
+				created_at,
+				ended_at
+			)
+		VALUES
+			(
+				:id,
+				:session_id,
+				:white_id,
+				:black_id,
+				:variant,
+				:initial_fen,
+				:fen,
+				:ply,
+				:status,
+				:result,
+				:termination,
+				:version,
+				:rated,
+				:time_control_base_seconds,
+				:time_control_increment_seconds,
+				:time_control_delay,
+				:time_control_days_per_move,
+				:white_remaining_ms,
+				:black_remaining_ms,
+				:turn_started_at,
+				:flag_at,
+				:move_due_at,
+				:created_at,
+				:ended_at
+			);`
+	if _, err := tql.Exec(ctx, tx, gameStmt, game); err != nil {
+		return domain.Game{}, err
+	}
+
+	if err := insertGameEvent(ctx, tx, event); err != nil {
+		return domain.Game{}, err
+	}
+
+	session.GameID = game.ID
+	session.Active = true
+
+	const sessionStmt = `
+		UPDATE
+			game_session
+		SET
+			player_1_id = :player_1_id,
+			player_2_id = :player_2_id,
+			game_id = :game_id,
+			active = :active
+		WHERE
+			id = :id;`
+	if _, err := tql.Exec(ctx, tx, sessionStmt, session); err != nil {
+		return domain.Game{}, err
+	}
+
+	return game, nil
+}
+
+// StartPairedGame opens the session of players paired by another module, such as
+// a tournament, and starts their game right away.
+func StartPairedGame(ctx context.Context, tx *sql.Tx, session domain.Session, now time.Time) (domain.Game, error) {
+	if err := insertSession(ctx, tx, session); err != nil {
+		return domain.Game{}, err
+	}
+
+	game, err := startSessionGame(ctx, tx, session, now)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	if err := notifyGameStarted(ctx, tx, session, game, now); err != nil {
+		return domain.Game{}, err
+	}
+
+	return game, nil
+}
+
+// notifyGameStarted tells both players their game has started.
+func notifyGameStarted(
+	ctx context.Context,
+	tx *sql.Tx,
+	session domain.Session,
+	game domain.Game,
+	now time.Time,
+) error {
+	payload := notificationsdomain.GameStartedPayload{
+		SessionID: session.ID,
+		GameID:    game.ID,
+		WhiteID:   game.WhiteID,
+		BlackID:   game.BlackID,
+	}
+
+	whiteNotification, err := notificationsdomain.NewUserNotification(
+		game.WhiteID,
+		notificationsdomain.GameStartedNotification,
+		payload,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	blackNotification, err := notificationsdomain.NewUserNotification(
+		game.BlackID,
+		notificationsdomain.GameStartedNotification,
+		payload,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	return notifications.Notify(ctx, tx, whiteNotification, blackNotification)
+}
+
+// notifyLobbySessionClosed removes the session from the lobby.
+func notifyLobbySessionClosed(ctx context.Context, tx *sql.Tx, sessionID string, now time.Time) error {
+	notification, err := notificationsdomain.NewLobbyNotification(
+		notificationsdomain.LobbySessionClosedNotification,
+		notificationsdomain.LobbySessionClosedPayload{SessionID: sessionID},
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	return notifications.Notify(ctx, tx, notification)
+}
+
+func updateGame(ctx context.Context, tx *sql.Tx, game domain.Game) error {
+	const stmt = `
+		UPDATE
+			game
+		SET
+			fen = :fen,
+			ply = :ply,
+			status = :status,
+			result = :result,
+			termination = :termination,
+			version = :version,
+			white_remaining_ms = :white_remaining_ms,
+			black_remaining_ms = :black_remaining_ms,
+			turn_started_at = :turn_started_at,
+			flag_at = :flag_at,
+			move_due_at = :move_due_at,
+			claimable_at = :claimable_at,
+			ended_at = :ended_at
+		WHERE
+			id = :id;`
+	if _, err := tql.Exec(ctx, tx, stmt, game); err != nil {
+		return err
+	}
+
+	if !game.IsOver() {
+		return nil
+	}
+
+	const sessionStmt = `
+		UPDATE
+			game_session
+		SET
+			active = false
+		WHERE
+			id = $1;`
+	_, err := tql.Exec(ctx, tx, sessionStmt, game.SessionID)
+	return err
+}
+
+// postponeForVacation makes the move of a correspondence game due after the vacation
+// of the player to move, when they are on one.
+func postponeForVacation(ctx context.Context, tx *sql.Tx, game *domain.Game, now time.Time) error {
+	if game.MoveDueAt == nil || game.IsOver() {
+		return nil
+	}
+
+	playerID, err := game.PlayerToMove()
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		SELECT
+			*
+		FROM
+			user_vacation
+		WHERE
+			user_id = $1 AND starts_at <= $2 AND ends_at > $2;`
+	vacation, err := tql.QueryFirst[domain.Vacation](ctx, tx, query, playerID, now)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		return err
+	}
+
+	game.PostponeMove(vacation.Remaining(now))
+	return nil
+}
+
+func insertGameMove(ctx context.Context, tx *sql.Tx, move domain.GameMove) error {
+	const stmt = `
+		INSERT INTO
+			game_move (game_id, ply, uci, san, remaining_ms, played_at)
+		VALUES
+			(:game_id, :ply, :uci, :san, :remaining_ms, :played_at);`
+	_, err := tql.Exec(ctx, tx, stmt, move)
+	return err
+}
+
+// recordGameOver appends the game over event and rates the game if the game has ended.
+func recordGameOver(ctx context.Context, tx *sql.Tx, game *domain.Game, now time.Time) error {
+	if !game.IsOver() {
+		return nil
+	}
+
+	if err := rateGame(ctx, tx, *game); err != nil {
+		return err
+	}
+
+	if err := recordTournamentGame(ctx, tx, *game); err != nil {
+		return err
+	}
+
+	if err := recordPlayerStats(ctx, tx, *game); err != nil {
+		return err
+	}
+
+	// Offers cannot be answered once the game is over.
+	if err := expireGameOffers(ctx, tx, game, now); err != nil {
+		return err
+	}
+
+	payload := domain.GameOverPayload{
+		Result:      game.Result,
+		Termination: game.Termination,
+		Clock:       game.Clock(),
+	}
+	return recordGameEvent(ctx, tx, game, domain.GameOverEvent, payload, now)
+}
+
+// recordGameEvent appends the event to the game's log. The game's version
+// is bumped, so the game still needs to be updated by the caller.
+func recordGameEvent(
+	ctx context.Context,
+	tx *sql.Tx,
+	game *domain.Game,
+	eventType domain.GameEventType,
+	payload any,
+	now time.Time,
+) error {
+	event, err := game.RecordEvent(eventType, payload, now)
+	if err != nil {
+		return err
+	}
+
+	return insertGameEvent(ctx, tx, event)
+}
+
+func rateGame(ctx context.Context, tx *sql.Tx, game domain.Game) error {
+	if !game.Rated || game.EndedAt == nil {
+		return nil
+	}
+
+	var whiteScore float64
+	switch game.Result {
+	case chess.WhiteWins:
+		whiteScore = 1
+	case chess.Draw:
+		whiteScore = 0.5
+	case chess.BlackWins:
+		whiteScore = 0
+	default:
+		// Aborted games are not rated.
+		return nil
+	}
+
+	return ratings.RecordGame(ctx, tx, ratingsdomain.RatedGame{
+		GameID:     game.ID,
+		WhiteID:    game.WhiteID,
+		BlackID:    game.BlackID,
+		Category:   ratingsdomain.Category(game.RatingCategory()),
+		WhiteScore: whiteScore,
+		PlayedAt:   *game.EndedAt,
+	})
+}
+
+// recordTournamentGame scores the game in its tournament, when it was played for one.
+func recordTournamentGame(ctx context.Context, tx *sql.Tx, game domain.Game) error {
+	result := tournamentsdomain.GameResult{GameID: game.ID, Plies: game.Ply, EndedAt: *game.EndedAt}
+	switch game.Result {
+	case chess.WhiteWins:
+		result.WhiteScore = 1
+	case chess.Draw:
+		result.WhiteScore, result.BlackScore = 0.5, 0.5
+	case chess.BlackWins:
+		result.BlackScore = 1
+	default:
+		result.Aborted = true
+	}
+
+	// Aborted games score nothing for either player.
+	return tournaments.RecordGame(ctx, tx, result)
+}
+
+// recordPlayerStats counts the game in its players' stats. Only games from the
+// standard starting position are classified by their opening.
+func recordPlayerStats(ctx context.Context, tx *sql.Tx, game domain.Game) error {
+	finished := profilesdomain.FinishedGame{
+		WhiteID:     game.WhiteID,
+		BlackID:     game.BlackID,
+		TimeControl: string(game.TimeControl().Category()),
+		Result:      game.Result,
+	}
+
+	if (game.Variant == "" || game.Variant == domain.Standard) && game.InitialFEN == chess.StartingFEN {
+		const query = `
+			SELECT
+				san
+			FROM
+				game_move
+			WHERE
+				game_id = $1 AND ply <= $2
+			ORDER BY
+				ply;`
+		moves, err := tql.Query[string](ctx, tx, query, game.ID, profilesdomain.OpeningPlies)
+		if err != nil {
+			return err
+		}
+		finished.Moves = moves
+	}
+
+	return profiles.RecordGame(ctx, tx, finished)
+}
+
+// insertGameEvent appends the event to the game's log and publishes it
+// to the live game streams once the transaction commits.
+func insertGameEvent(ctx context.Context, tx *sql.Tx, event domain.GameEvent) error {
+	const stmt = `
+		INSERT INTO
+			game_event (game_id, version, type, payload, created_at)
+		VALUES
+			(:game_id, :version, :type, :payload, :created_at);`
+	if _, err := tql.Exec(ctx, tx, stmt, event); err != nil {
+		return err
+	}
+
+	message, err := event.Message()
+	if err != nil {
+		return err
+	}
+
+	return core.Publish(ctx, tx, domain.GameEventsTopic(event.GameID), json.RawMessage(message))
+}
@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	tournamentsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GameActionResponse is the state of the game after a player's action.
+type GameActionResponse struct {
+	Ply         int
+	FEN         string
+	Status      domain.GameStatus
+	Result      chess.Result
+	Termination chess.Termination
+}
+
+func newGameActionResponse(game domain.Game) GameActionResponse {
+	return GameActionResponse{
+		Ply:         game.Ply,
+		FEN:         game.FEN,
+		Status:      game.Status,
+		Result:      game.Result,
+		Termination: game.Termination,
+	}
+}
+
+func validateGameAction(sessionID string, playerID uuid.UUID) error {
+	if sessionID == "" {
+		return fmt.Errorf("invalid SessionID - '%s'", sessionID)
+	}
+
+	if playerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", playerID)
+	}
+
+	return nil
+}
+
+// runGameAction locks the current game of the session and runs the player's action
+// on it in a transaction. A flag which fell before the action ends the game instead,
+// the action is then refused with ErrFlagged.
+func runGameAction(
+	ctx context.Context,
+	db *sql.DB,
+	sessionID string,
+	now time.Time,
+	action func(ctx context.Context, tx *sql.Tx, game *domain.Game) error,
+) (domain.Game, error) {
+	var (
+		game    domain.Game
+		flagged bool
+	)
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const query = `
+			SELECT
+				g.*
+			FROM
+				game g
+			INNER JOIN
+				game_session s ON s.game_id = g.id
+			WHERE
+				s.id = $1
+			FOR UPDATE OF g;`
+		var err error
+		game, err = tql.QueryFirst[domain.Game](ctx, tx, query, sessionID)
+		if err != nil {
+			return err
+		}
+
+		flagged, err = game.CheckFlag(now)
+		if err != nil {
+			return err
+		}
+
+		if flagged {
+			if err := recordGameOver(ctx, tx, &game, now); err != nil {
+				return err
+			}
+			return updateGame(ctx, tx, game)
+		}
+
+		return action(ctx, tx, &game)
+	}
+
+	if err := core.Tx(ctx, db, txFn); err != nil {
+		return domain.Game{}, gameActionError(err, game)
+	}
+
+	if flagged {
+		return domain.Game{}, gameActionError(domain.ErrFlagged, game)
+	}
+
+	return game, nil
+}
+
+// gameActionError maps the errors of the in-game actions to their status codes.
+func gameActionError(err error, game domain.Game) error {
+	var commandErr core.CommandError
+	switch {
+	case errors.As(err, &commandErr):
+		return commandErr
+	case errors.Is(err, sql.ErrNoRows):
+		return core.NewCommandError(404, err)
+	case errors.Is(err, domain.ErrNotAPlayer), errors.Is(err, domain.ErrCannotAnswerOwnOffer):
+		return core.NewCommandError(403, err)
+	case errors.Is(err, domain.ErrGameOver), errors.Is(err, domain.ErrFlagged):
+		return core.NewCommandError(409, err, core.WithReason(string(game.Result)))
+	case errors.Is(err, domain.ErrOfferPending),
+		errors.Is(err, domain.ErrOfferNotPending),
+		errors.Is(err, domain.ErrNothingToTakeBack),
+		errors.Is(err, domain.ErrDrawNotClaimable),
+		errors.Is(err, domain.ErrVictoryNotClaimable),
+		errors.Is(err, domain.ErrGameNotOver),
+		errors.Is(err, domain.ErrAlreadyRematched),
+		errors.Is(err, domain.ErrRematchUnavailable),
+		errors.Is(err, domain.ErrBerserkUnavailable),
+		errors.Is(err, domain.ErrBerserkTooLate),
+		errors.Is(err, tournamentsdomain.ErrBerserkNotAllowed),
+		errors.Is(err, tournamentsdomain.ErrAlreadyBerserk):
+		return core.NewCommandError(409, err)
+	case errors.Is(err, domain.ErrOfferTooSoon), errors.Is(err, domain.ErrOfferLimitReached):
+		return core.NewCommandError(429, err)
+	default:
+		return core.NewCommandError(500, err)
+	}
+}
+
+func getGameMoves(ctx context.Context, tx *sql.Tx, gameID uuid.UUID) ([]domain.GameMove, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			game_move
+		WHERE
+			game_id = $1
+		ORDER BY
+			ply;`
+	return tql.Query[domain.GameMove](ctx, tx, query, gameID)
+}
+
+// notifyGameEnded tells the opponent of the player who ended the game how it ended.
+func notifyGameEnded(ctx context.Context, tx *sql.Tx, game domain.Game, playerID uuid.UUID, now time.Time) error {
+	notification, err := notificationsdomain.NewUserNotification(
+		game.OpponentID(playerID),
+		notificationsdomain.GameEndedNotification,
+		notificationsdomain.GameEndedPayload{
+			SessionID:   game.SessionID,
+			GameID:      game.ID,
+			Result:      string(game.Result),
+			Termination: string(game.Termination),
+		},
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	return notifications.Notify(ctx, tx, notification)
+}
@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// makeGameOffer makes the player's draw offer or takeback request and lets the opponent know.
+func makeGameOffer(
+	ctx context.Context,
+	tx *sql.Tx,
+	game *domain.Game,
+	offerType domain.OfferType,
+	playerID uuid.UUID,
+	now time.Time,
+) error {
+	const query = `
+		SELECT
+			*
+		FROM
+			game_offer
+		WHERE
+			game_id = $1
+		FOR UPDATE;`
+	previous, err := tql.Query[domain.GameOffer](ctx, tx, query, game.ID)
+	if err != nil {
+		return err
+	}
+
+	offer, err := domain.NewGameOffer(*game, offerType, playerID, previous, now)
+	if err != nil {
+		return err
+	}
+
+	const stmt = `
+		INSERT INTO
+			game_offer (id, game_id, type, offered_by, ply, status, created_at)
+		VALUES
+			(:id, :game_id, :type, :offered_by, :ply, :status, :created_at);`
+	if _, err := tql.Exec(ctx, tx, stmt, offer); err != nil {
+		return err
+	}
+
+	if err := recordGameEvent(ctx, tx, game, domain.OfferMadeEvent, offer.Payload(), now); err != nil {
+		return err
+	}
+
+	if err := updateGame(ctx, tx, *game); err != nil {
+		return err
+	}
+
+	return notifyGameOffer(ctx, tx, *game, game.OpponentID(playerID), offer, notificationsdomain.GameOfferReceivedNotification, now)
+}
+
+// getPendingGameOffer returns the pending offer of the type, sql.ErrNoRows when there is none.
+func getPendingGameOffer(
+	ctx context.Context,
+	tx *sql.Tx,
+	gameID uuid.UUID,
+	offerType domain.OfferType,
+) (domain.GameOffer, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			game_offer
+		WHERE
+			game_id = $1 AND type = $2 AND status = $3
+		FOR UPDATE;`
+	return tql.QueryFirst[domain.GameOffer](ctx, tx, query, gameID, offerType, domain.OfferPending)
+}
+
+func updateGameOffer(ctx context.Context, tx *sql.Tx, offer domain.GameOffer) error {
+	const stmt = `
+		UPDATE
+			game_offer
+		SET
+			status = :status,
+			responded_at = :responded_at
+		WHERE
+			id = :id;`
+	_, err := tql.Exec(ctx, tx, stmt, offer)
+	return err
+}
+
+// answerGameOffer stores the answer to the offer and records it in the game's log.
+// The game still needs to be updated by the caller.
+func answerGameOffer(ctx context.Context, tx *sql.Tx, game *domain.Game, offer domain.GameOffer, now time.Time) error {
+	if err := updateGameOffer(ctx, tx, offer); err != nil {
+		return err
+	}
+
+	eventType := domain.OfferDeclinedEvent
+	if offer.Status == domain.OfferAccepted {
+		eventType = domain.OfferAcceptedEvent
+	}
+
+	return recordGameEvent(ctx, tx, game, eventType, offer.Payload(), now)
+}
+
+// declineGameOffer declines the opponent's pending offer of the type and lets them know.
+func declineGameOffer(
+	ctx context.Context,
+	tx *sql.Tx,
+	game *domain.Game,
+	offerType domain.OfferType,
+	playerID uuid.UUID,
+	now time.Time,
+) error {
+	offer, err := getPendingGameOffer(ctx, tx, game.ID, offerType)
+	if err != nil {
+		return err
+	}
+
+	if err := offer.Decline(*game, playerID, now); err != nil {
+		return err
+	}
+
+	if err := answerGameOffer(ctx, tx, game, offer, now); err != nil {
+		return err
+	}
+
+	if err := updateGame(ctx, tx, *game); err != nil {
+		return err
+	}
+
+	return notifyGameOffer(ctx, tx, *game, offer.OfferedBy, offer, notificationsdomain.GameOfferAnsweredNotification, now)
+}
+
+// expireGameOffers expires the pending offers once a move is made or the game ends.
+// The game still needs to be updated by the caller.
+func expireGameOffers(ctx context.Context, tx *sql.Tx, game *domain.Game, now time.Time) error {
+	const query = `
+		SELECT
+			*
+		FROM
+			game_offer
+		WHERE
+			game_id = $1 AND status = $2
+		FOR UPDATE;`
+	offers, err := tql.Query[domain.GameOffer](ctx, tx, query, game.ID, domain.OfferPending)
+	if err != nil {
+		return err
+	}
+
+	for _, offer := range offers {
+		if err := offer.Expire(now); err != nil {
+			return err
+		}
+
+		if err := updateGameOffer(ctx, tx, offer); err != nil {
+			return err
+		}
+
+		if err := recordGameEvent(ctx, tx, game, domain.OfferExpiredEvent, offer.Payload(), now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func notifyGameOffer(
+	ctx context.Context,
+	tx *sql.Tx,
+	game domain.Game,
+	userID uuid.UUID,
+	offer domain.GameOffer,
+	notificationType notificationsdomain.NotificationType,
+	now time.Time,
+) error {
+	notification, err := notificationsdomain.NewUserNotification(
+		userID,
+		notificationType,
+		notificationsdomain.GameOfferPayload{
+			OfferID:   offer.ID,
+			SessionID: game.SessionID,
+			GameID:    game.ID,
+			Type:      string(offer.Type),
+			Status:    string(offer.Status),
+		},
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	return notifications.Notify(ctx, tx, notification)
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// maxImportBytes caps the request body of an import, leaving room for the JSON
+// escaping of the largest PGN accepted.
+const maxImportBytes = 2 * domain.MaxImportedPGNBytes
+
+// ImportPGNCommand imports the games of a PGN file for the owner to analyze.
+// Either every game is imported or, when any of them breaks the rules, none is.
+type ImportPGNCommand struct {
+	OwnerID uuid.UUID
+	PGN     string
+}
+
+func (c ImportPGNCommand) Validate() error {
+	if c.OwnerID == uuid.Nil {
+		return fmt.Errorf("invalid OwnerID - '%s'", c.OwnerID)
+	}
+
+	if c.PGN == "" {
+		return fmt.Errorf("invalid PGN - empty")
+	}
+
+	if len(c.PGN) > domain.MaxImportedPGNBytes {
+		return fmt.Errorf("invalid PGN - larger than %d bytes", domain.MaxImportedPGNBytes)
+	}
+
+	return nil
+}
+
+type ImportPGNResponse struct {
+	GameIDs []uuid.UUID
+}
+
+func HandleImportPGN(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+
+	command, err := core.RequestBody[ImportPGNCommand](r)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			core.WriteResponse(w, r, 413, err)
+			return
+		}
+
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	command.OwnerID = core.Session(ctx).UserID
+
+	response, err := mediator.Send[ImportPGNCommand, ImportPGNResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteResponse(w, r, http.StatusCreated, response)
+}
+
+type ImportPGNCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewImportPGNCommandHandler(db *sql.DB, clock core.Clock) *ImportPGNCommandHandler {
+	return &ImportPGNCommandHandler{db: db, clock: clock}
+}
+
+func (h *ImportPGNCommandHandler) Handle(ctx context.Context, request ImportPGNCommand) (ImportPGNResponse, error) {
+	games, err := domain.ImportPGN(request.OwnerID, request.PGN, h.clock.Now())
+	if err != nil {
+		return ImportPGNResponse{}, core.NewCommandError(400, err, core.WithReason(err.Error()))
+	}
+
+	const stmt = `
+		INSERT INTO
+			imported_game (id, owner_id, event, white, black, result, variant, initial_fen, moves, pgn, created_at)
+		VALUES
+			(:id, :owner_id, :event, :white, :black, :result, :variant, :initial_fen, :moves, :pgn, :created_at);`
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		for _, game := range games {
+			if _, err := tql.Exec(ctx, tx, stmt, game); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return ImportPGNResponse{}, core.NewCommandError(500, err)
+	}
+
+	return ImportPGNResponse{GameIDs: core.Map(games, func(g domain.ImportedGame) uuid.UUID { return g.ID })}, nil
+}
@@ -0,0 +1,201 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+func getInvitationForUpdate(ctx context.Context, tx *sql.Tx, invitationID uuid.UUID) (domain.SessionInvitation, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			session_invitation
+		WHERE
+			id = $1
+		FOR UPDATE;`
+	return tql.QueryFirst[domain.SessionInvitation](ctx, tx, query, invitationID)
+}
+
+// insertInvitation stores the invitation and notifies the invitee. It reports false
+// without storing anything when the invitee already has a pending invitation to the session.
+func insertInvitation(ctx context.Context, tx *sql.Tx, invitation domain.SessionInvitation) (bool, error) {
+	const stmt = `
+		INSERT INTO
+			session_invitation (id, session_id, inviter_id, invitee_id, status, created_at, expires_at)
+		VALUES
+			(:id, :session_id, :inviter_id, :invitee_id, :status, :created_at, :expires_at)
+		ON CONFLICT DO NOTHING;`
+	result, err := tql.Exec(ctx, tx, stmt, invitation)
+	if err != nil {
+		return false, err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil || inserted == 0 {
+		return false, err
+	}
+
+	notification, err := notificationsdomain.NewUserNotification(
+		invitation.InviteeID,
+		notificationsdomain.InvitationReceivedNotification,
+		notificationsdomain.InvitationReceivedPayload{
+			InvitationID: invitation.ID,
+			SessionID:    invitation.SessionID,
+			InviterID:    invitation.InviterID,
+		},
+		invitation.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return true, notifications.Notify(ctx, tx, notification)
+}
+
+func updateInvitation(ctx context.Context, tx *sql.Tx, invitation domain.SessionInvitation) error {
+	const stmt = `
+		UPDATE
+			session_invitation
+		SET
+			status = :status,
+			responded_at = :responded_at
+		WHERE
+			id = :id;`
+	_, err := tql.Exec(ctx, tx, stmt, invitation)
+	return err
+}
+
+// withdrawSessionInvitations cancels the pending invitations and email invitations
+// of a session which was joined or closed, and lets the invitees know.
+func withdrawSessionInvitations(ctx context.Context, tx *sql.Tx, sessionID string, now time.Time) error {
+	const query = `
+		SELECT
+			*
+		FROM
+			session_invitation
+		WHERE
+			session_id = $1 AND status = $2
+		FOR UPDATE;`
+	invitations, err := tql.Query[domain.SessionInvitation](ctx, tx, query, sessionID, domain.InvitationPending)
+	if err != nil {
+		return err
+	}
+
+	withdrawn := make([]notificationsdomain.Notification, 0, len(invitations))
+	for _, invitation := range invitations {
+		if err := invitation.Withdraw(now); err != nil {
+			return err
+		}
+
+		if err := updateInvitation(ctx, tx, invitation); err != nil {
+			return err
+		}
+
+		notification, err := newInvitationClosedNotification(
+			invitation.InviteeID,
+			notificationsdomain.InvitationCancelledNotification,
+			invitation,
+			now,
+		)
+		if err != nil {
+			return err
+		}
+
+		withdrawn = append(withdrawn, notification)
+	}
+
+	const emailQuery = `
+		SELECT
+			*
+		FROM
+			email_invitation
+		WHERE
+			session_id = $1 AND status = $2
+		FOR UPDATE;`
+	emailInvitations, err := tql.Query[domain.EmailInvitation](ctx, tx, emailQuery, sessionID, domain.InvitationPending)
+	if err != nil {
+		return err
+	}
+
+	for _, emailInvitation := range emailInvitations {
+		if err := emailInvitation.Withdraw(now); err != nil {
+			return err
+		}
+
+		if err := updateEmailInvitation(ctx, tx, emailInvitation); err != nil {
+			return err
+		}
+	}
+
+	return notifications.Notify(ctx, tx, withdrawn...)
+}
+
+func updateEmailInvitation(ctx context.Context, tx *sql.Tx, invitation domain.EmailInvitation) error {
+	const stmt = `
+		UPDATE
+			email_invitation
+		SET
+			status = :status,
+			invitation_id = :invitation_id,
+			sent_at = :sent_at,
+			responded_at = :responded_at
+		WHERE
+			id = :id;`
+	_, err := tql.Exec(ctx, tx, stmt, invitation)
+	return err
+}
+
+func newInvitationClosedNotification(
+	userID uuid.UUID,
+	notificationType notificationsdomain.NotificationType,
+	invitation domain.SessionInvitation,
+	now time.Time,
+) (notificationsdomain.Notification, error) {
+	return notificationsdomain.NewUserNotification(
+		userID,
+		notificationType,
+		notificationsdomain.InvitationClosedPayload{
+			InvitationID: invitation.ID,
+			SessionID:    invitation.SessionID,
+		},
+		now,
+	)
+}
+
+// invitationCommandError maps the errors of the invitation and invite link commands to their status codes.
+func invitationCommandError(err error) error {
+	var commandErr core.CommandError
+	switch {
+	case errors.As(err, &commandErr):
+		return commandErr
+	case errors.Is(err, sql.ErrNoRows):
+		return core.NewCommandError(404, err)
+	case errors.Is(err, domain.ErrCannotInviteSelf):
+		return core.NewCommandError(400, err)
+	case errors.Is(err, domain.ErrNotSessionOwner),
+		errors.Is(err, domain.ErrNotInvitee),
+		errors.Is(err, domain.ErrNotInviter):
+		return core.NewCommandError(403, err)
+	case errors.Is(err, domain.ErrSessionNotOpen),
+		errors.Is(err, domain.ErrInvitationNotPending),
+		errors.Is(err, domain.ErrInviteLinkUsedUp):
+		return core.NewCommandError(409, err)
+	case errors.Is(err, domain.ErrInvitationExpired), errors.Is(err, domain.ErrInviteLinkExpired):
+		return core.NewCommandError(410, err)
+	case errors.Is(err, domain.ErrTooManyEmailInvitations):
+		return core.NewCommandError(429, err)
+	default:
+		return core.NewCommandError(500, err)
+	}
+}
@@ -3,10 +3,14 @@ package commands
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
+	"path"
 
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
 
 	"github.com/eskrenkovic/mediator-go"
 	"github.com/eskrenkovic/tql"
@@ -32,6 +36,7 @@ func (c JoinSessionCommand) Validate() error {
 
 type JoinSessionResponse struct {
 	SessionURL string
+	GameID     uuid.UUID
 }
 
 func HandleJoinSession(w http.ResponseWriter, r *http.Request) {
@@ -42,37 +47,97 @@ func HandleJoinSession(w http.ResponseWriter, r *http.Request) {
 		PlayerID:  core.Session(ctx).UserID, // you join someone else's session as the logged-in user
 	}
 
-	_, err := mediator.Send[JoinSessionCommand, core.Unit](ctx, command)
+	response, err := mediator.Send[JoinSessionCommand, JoinSessionResponse](ctx, command)
 	if err != nil {
 		core.WriteCommandError(w, r, err)
 		return
 	}
 
-	core.WriteOK(w, r, nil)
+	core.WriteOK(w, r, response)
 }
 
 type JoinSessionCommandHandler struct {
-	db *sql.DB
+	db    *sql.DB
+	clock core.Clock
 }
 
-func NewJoinSessionCommandHandler(db *sql.DB) *JoinSessionCommandHandler {
-	return &JoinSessionCommandHandler{db}
+func NewJoinSessionCommandHandler(db *sql.DB, clock core.Clock) *JoinSessionCommandHandler {
+	return &JoinSessionCommandHandler{db, clock}
 }
 
 func (h *JoinSessionCommandHandler) Handle(
 	ctx context.Context,
 	request JoinSessionCommand,
 ) (JoinSessionResponse, error) {
-	const stmt = `
-		UPDATE
-			game_session
-		SET
-			active = true
-		WHERE
-			session_id = $1 AND owner_id = $2;`
-	if _, err := tql.Exec(ctx, h.db, stmt, request.SessionID, request.PlayerID); err != nil {
-		return JoinSessionResponse{}, core.NewCommandError(400, err)
+	var game domain.Game
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const query = `
+			SELECT
+				*
+			FROM
+				game_session
+			WHERE
+				id = $1
+			FOR UPDATE;`
+		session, err := tql.QueryFirst[domain.Session](ctx, tx, query, request.SessionID)
+		if err != nil {
+			return err
+		}
+
+		if session.OwnerID == request.PlayerID || session.Player1ID == request.PlayerID {
+			return core.NewCommandError(400, fmt.Errorf("cannot join own session"))
+		}
+
+		if session.Visibility == domain.Private {
+			return core.NewCommandError(403, fmt.Errorf("session '%s' is private, it can only be joined by invitation", session.ID))
+		}
+
+		if session.Closed {
+			return core.NewCommandError(409, fmt.Errorf("session '%s' is closed", session.ID))
+		}
+
+		if session.Player2ID != uuid.Nil {
+			return core.NewCommandError(409, fmt.Errorf("session '%s' is full", session.ID))
+		}
+
+		if err := social.CheckNotBlocked(ctx, tx, session.Player1ID, request.PlayerID); err != nil {
+			return err
+		}
+
+		session.Player2ID = request.PlayerID
+
+		now := h.clock.Now()
+
+		game, err = startSessionGame(ctx, tx, session, now)
+		if err != nil {
+			return err
+		}
+
+		if err := withdrawSessionInvitations(ctx, tx, session.ID, now); err != nil {
+			return err
+		}
+
+		if err := notifyGameStarted(ctx, tx, session, game, now); err != nil {
+			return err
+		}
+
+		return notifyLobbySessionClosed(ctx, tx, session.ID, now)
 	}
 
-	return JoinSessionResponse{SessionURL: "TODO"}, nil
+	err := core.Tx(ctx, h.db, txFn)
+	var commandErr core.CommandError
+	switch {
+	case err != nil && errors.As(err, &commandErr):
+		return JoinSessionResponse{}, commandErr
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return JoinSessionResponse{}, core.NewCommandError(404, err)
+	case err != nil:
+		return JoinSessionResponse{}, core.NewCommandError(500, err)
+	}
+
+	return JoinSessionResponse{
+		SessionURL: path.Join("/game-sessions", request.SessionID),
+		GameID:     game.ID,
+	}, nil
 }
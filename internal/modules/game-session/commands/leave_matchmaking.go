@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+type LeaveMatchmakingCommand struct {
+	PlayerID uuid.UUID
+}
+
+func (c LeaveMatchmakingCommand) Validate() error {
+	if c.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", c.PlayerID)
+	}
+
+	return nil
+}
+
+func HandleLeaveMatchmaking(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := LeaveMatchmakingCommand{PlayerID: core.Session(ctx).UserID}
+
+	_, err := mediator.Send[LeaveMatchmakingCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type LeaveMatchmakingCommandHandler struct {
+	db *sql.DB
+}
+
+func NewLeaveMatchmakingCommandHandler(db *sql.DB) *LeaveMatchmakingCommandHandler {
+	return &LeaveMatchmakingCommandHandler{db}
+}
+
+func (h *LeaveMatchmakingCommandHandler) Handle(
+	ctx context.Context,
+	request LeaveMatchmakingCommand,
+) (core.Unit, error) {
+	// A ticket being matched is locked by the matcher, the delete waits for it
+	// and finds nothing to delete if the player was matched in the meantime.
+	const stmt = `
+		DELETE FROM
+			matchmaking_ticket
+		WHERE
+			player_id = $1 AND status = $2;`
+	result, err := tql.Exec(ctx, h.db, stmt, request.PlayerID, domain.TicketWaiting)
+	if err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	if deleted == 0 {
+		return core.Unit{}, core.NewCommandError(404, fmt.Errorf("player '%s' is not waiting in the queue", request.PlayerID))
+	}
+
+	return core.Unit{}, nil
+}
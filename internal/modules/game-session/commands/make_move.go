@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+type MakeMoveCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+	Move      string
+}
+
+func (c MakeMoveCommand) Validate() error {
+	if c.SessionID == "" {
+		return fmt.Errorf("invalid SessionID - '%s'", c.SessionID)
+	}
+
+	if c.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", c.PlayerID)
+	}
+
+	if _, err := chess.ParseUCI(c.Move); err != nil {
+		return fmt.Errorf("invalid Move - '%s'", c.Move)
+	}
+
+	return nil
+}
+
+type MakeMoveResponse struct {
+	Ply              int
+	SAN              string
+	FEN              string
+	WhiteRemainingMs int64
+	BlackRemainingMs int64
+	Status           domain.GameStatus
+	Result           chess.Result
+}
+
+func HandleMakeMove(w http.ResponseWriter, r *http.Request) {
+	command, err := core.RequestBody[MakeMoveCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+	command.SessionID = r.PathValue("id")
+	command.PlayerID = core.Session(r.Context()).UserID
+
+	response, err := mediator.Send[MakeMoveCommand, MakeMoveResponse](r.Context(), command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type MakeMoveCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewMakeMoveCommandHandler(db *sql.DB, clock core.Clock) *MakeMoveCommandHandler {
+	return &MakeMoveCommandHandler{db, clock}
+}
+
+func (h *MakeMoveCommandHandler) Handle(ctx context.Context, request MakeMoveCommand) (MakeMoveResponse, error) {
+	move, err := chess.ParseUCI(request.Move)
+	if err != nil {
+		return MakeMoveResponse{}, core.NewCommandError(400, err)
+	}
+
+	var (
+		game     domain.Game
+		gameMove domain.GameMove
+		moveErr  error
+	)
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const query = `
+			SELECT
+				g.*
+			FROM
+				game g
+			INNER JOIN
+				game_session s ON s.game_id = g.id
+			WHERE
+				s.id = $1
+			FOR UPDATE OF g;`
+		game, err = tql.QueryFirst[domain.Game](ctx, tx, query, request.SessionID)
+		if err != nil {
+			return err
+		}
+
+		// Server time is the only time that counts for the clocks.
+		now := h.clock.Now()
+
+		gameMove, moveErr = game.Move(request.PlayerID, move, now)
+		switch {
+		case moveErr != nil && errors.Is(moveErr, domain.ErrFlagged):
+			// The flag ends the game, which still needs to be stored.
+			if err := recordGameOver(ctx, tx, &game, now); err != nil {
+				return err
+			}
+			return updateGame(ctx, tx, game)
+		case moveErr != nil:
+			return nil
+		}
+
+		if err := insertGameMove(ctx, tx, gameMove); err != nil {
+			return err
+		}
+
+		if err := postponeForVacation(ctx, tx, &game, now); err != nil {
+			return err
+		}
+
+		payload := domain.MoveMadePayload{
+			Ply:   gameMove.Ply,
+			UCI:   gameMove.UCI,
+			SAN:   gameMove.SAN,
+			FEN:   game.FEN,
+			Clock: game.Clock(),
+		}
+		moveEvent, err := game.RecordEvent(domain.MoveMadeEvent, payload, now)
+		if err != nil {
+			return err
+		}
+
+		if err := insertGameEvent(ctx, tx, moveEvent); err != nil {
+			return err
+		}
+
+		// Offers are only valid in the position they were made in.
+		if err := expireGameOffers(ctx, tx, &game, now); err != nil {
+			return err
+		}
+
+		if err := recordGameOver(ctx, tx, &game, now); err != nil {
+			return err
+		}
+
+		return updateGame(ctx, tx, game)
+	}
+
+	err = core.Tx(ctx, h.db, txFn)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return MakeMoveResponse{}, core.NewCommandError(404, err)
+	case err != nil:
+		return MakeMoveResponse{}, core.NewCommandError(500, err)
+	}
+
+	switch {
+	case moveErr != nil && errors.Is(moveErr, domain.ErrNotAPlayer):
+		return MakeMoveResponse{}, core.NewCommandError(403, moveErr)
+	case moveErr != nil && (errors.Is(moveErr, domain.ErrGameOver) || errors.Is(moveErr, domain.ErrFlagged)):
+		return MakeMoveResponse{}, core.NewCommandError(409, moveErr, core.WithReason(string(game.Result)))
+	case moveErr != nil:
+		return MakeMoveResponse{}, core.NewCommandError(400, moveErr)
+	}
+
+	return MakeMoveResponse{
+		Ply:              gameMove.Ply,
+		SAN:              gameMove.SAN,
+		FEN:              game.FEN,
+		WhiteRemainingMs: game.WhiteRemainingMs,
+		BlackRemainingMs: game.BlackRemainingMs,
+		Status:           game.Status,
+		Result:           game.Result,
+	}, nil
+}
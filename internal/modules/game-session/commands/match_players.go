@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"math/rand/v2"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// matchmakingLockID is the advisory lock held by the instance running the matcher.
+const matchmakingLockID int64 = 0x6d61746368
+
+// MatchPlayersCommand pairs the players waiting in the matchmaking queue and
+// starts their games. It is run periodically by a background job.
+type MatchPlayersCommand struct{}
+
+type MatchPlayersCommandHandler struct {
+	db         *sql.DB
+	clock      core.Clock
+	whiteFirst func() bool
+}
+
+func NewMatchPlayersCommandHandler(db *sql.DB, clock core.Clock) *MatchPlayersCommandHandler {
+	return &MatchPlayersCommandHandler{
+		db:         db,
+		clock:      clock,
+		whiteFirst: func() bool { return rand.IntN(2) == 0 },
+	}
+}
+
+func (h *MatchPlayersCommandHandler) Handle(ctx context.Context, _ MatchPlayersCommand) (core.Unit, error) {
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		// The whole queue is needed to find the best pairs, so only one instance
+		// matches at a time, the others skip the run.
+		locked, err := tql.QueryFirst[bool](ctx, tx, `SELECT pg_try_advisory_xact_lock($1);`, matchmakingLockID)
+		if err != nil {
+			return err
+		}
+
+		if !locked {
+			return nil
+		}
+
+		// Locking the tickets makes the players leaving the queue wait for the matcher.
+		const query = `
+			SELECT
+				*
+			FROM
+				matchmaking_ticket
+			WHERE
+				status = $1
+			ORDER BY
+				created_at
+			FOR UPDATE;`
+		tickets, err := tql.Query[domain.MatchmakingTicket](ctx, tx, query, domain.TicketWaiting)
+		if err != nil {
+			return err
+		}
+
+		playerIDs := make([]uuid.UUID, 0, len(tickets))
+		for _, ticket := range tickets {
+			playerIDs = append(playerIDs, ticket.PlayerID)
+		}
+
+		blocks, err := social.BlocksAmong(ctx, tx, playerIDs)
+		if err != nil {
+			return err
+		}
+
+		now := h.clock.Now()
+
+		for _, match := range domain.FindMatches(tickets, now, blocks.Between, h.whiteFirst) {
+			if err := startMatch(ctx, tx, match, now); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
+
+func startMatch(ctx context.Context, tx *sql.Tx, match domain.Match, now time.Time) error {
+	session := match.Session(now)
+
+	const sessionStmt = `
+		INSERT INTO
+			game_session (
+				id,
+				owner_id,
+				player_1_id,
+				player_2_id,
+				name,
+				rated,
+				visibility,
+				variant,
+				time_control_base_seconds,
+				time_control_increment_seconds,
+				time_control_delay,
+				time_control_days_per_move,
+				time_control_category,
+				created_at
+			)
+		VALUES
+			(
+				:id,
+				:owner_id,
+				:player_1_id,
+				:player_2_id,
+				:name,
+				:rated,
+				:visibility,
+				:variant,
+				:time_control_base_seconds,
+				:time_control_increment_seconds,
+				:time_control_delay,
+				:time_control_days_per_move,
+				:time_control_category,
+				:created_at
+			);`
+	if _, err := tql.Exec(ctx, tx, sessionStmt, session); err != nil {
+		return err
+	}
+
+	game, err := startSessionGame(ctx, tx, session, now)
+	if err != nil {
+		return err
+	}
+
+	for _, ticket := range []domain.MatchmakingTicket{match.White, match.Black} {
+		if err := ticket.Match(session.ID, now); err != nil {
+			return err
+		}
+
+		const ticketStmt = `
+			UPDATE
+				matchmaking_ticket
+			SET
+				status = :status,
+				session_id = :session_id,
+				matched_at = :matched_at
+			WHERE
+				id = :id;`
+		if _, err := tql.Exec(ctx, tx, ticketStmt, ticket); err != nil {
+			return err
+		}
+	}
+
+	return notifyGameStarted(ctx, tx, session, game, now)
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// OfferDrawCommand offers the opponent a draw. The offer expires on the next move.
+type OfferDrawCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c OfferDrawCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+func HandleOfferDraw(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := OfferDrawCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[OfferDrawCommand, GameActionResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type OfferDrawCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewOfferDrawCommandHandler(db *sql.DB, clock core.Clock) *OfferDrawCommandHandler {
+	return &OfferDrawCommandHandler{db: db, clock: clock}
+}
+
+func (h *OfferDrawCommandHandler) Handle(ctx context.Context, request OfferDrawCommand) (GameActionResponse, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		return makeGameOffer(ctx, tx, game, domain.DrawOffer, request.PlayerID, now)
+	}
+
+	game, err := runGameAction(ctx, h.db, request.SessionID, now, action)
+	if err != nil {
+		return GameActionResponse{}, err
+	}
+
+	return newGameActionResponse(game), nil
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// OfferRematchCommand offers the opponent to play again once the session's game is over.
+// The offer expires after domain.RematchOfferTTL.
+type OfferRematchCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c OfferRematchCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+func HandleOfferRematch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := OfferRematchCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[OfferRematchCommand, domain.RematchOffer](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteResponse(w, r, http.StatusCreated, response)
+}
+
+type OfferRematchCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewOfferRematchCommandHandler(db *sql.DB, clock core.Clock) *OfferRematchCommandHandler {
+	return &OfferRematchCommandHandler{db: db, clock: clock}
+}
+
+func (h *OfferRematchCommandHandler) Handle(ctx context.Context, request OfferRematchCommand) (domain.RematchOffer, error) {
+	now := h.clock.Now()
+
+	var offer domain.RematchOffer
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		const sessionQuery = `
+			SELECT
+				*
+			FROM
+				game_session
+			WHERE
+				id = $1;`
+		session, err := tql.QueryFirst[domain.Session](ctx, tx, sessionQuery, request.SessionID)
+		if err != nil {
+			return err
+		}
+
+		// The game is locked, so the offers cannot change meanwhile.
+		const query = `
+			SELECT
+				*
+			FROM
+				rematch_offer
+			WHERE
+				game_id = $1;`
+		previous, err := tql.Query[domain.RematchOffer](ctx, tx, query, game.ID)
+		if err != nil {
+			return err
+		}
+
+		offer, err = domain.NewRematchOffer(session, *game, request.PlayerID, previous, now)
+		if err != nil {
+			return err
+		}
+
+		if err := social.CheckNotBlocked(ctx, tx, offer.OfferedBy, offer.OfferedTo); err != nil {
+			return err
+		}
+
+		const stmt = `
+			INSERT INTO
+				rematch_offer (id, game_id, session_id, offered_by, offered_to, status, expires_at, created_at)
+			VALUES
+				(:id, :game_id, :session_id, :offered_by, :offered_to, :status, :expires_at, :created_at);`
+		if _, err := tql.Exec(ctx, tx, stmt, offer); err != nil {
+			return err
+		}
+
+		return notifyRematchOffer(ctx, tx, offer.OfferedTo, offer, notificationsdomain.RematchOfferedNotification, now)
+	}
+
+	if _, err := runGameAction(ctx, h.db, request.SessionID, now, action); err != nil {
+		return domain.RematchOffer{}, err
+	}
+
+	return offer, nil
+}
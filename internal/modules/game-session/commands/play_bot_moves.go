@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/engine"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// botMoveClaimMargin is added to the engine's move time when claiming a move, for
+// the time it waits in the pool's queue. A claim which outlives it was lost, with
+// the instance which made it, and the move is searched again.
+const botMoveClaimMargin = 10 * time.Second
+
+// PlayBotMovesCommand starts the computer's searches in the games where it is to
+// move. It is run periodically by a background job. The searches run on the worker
+// pool and play their moves through MakeMoveCommand, like the other players do.
+// The computer does not answer draw offers or takeback requests.
+type PlayBotMovesCommand struct{}
+
+type PlayBotMovesCommandHandler struct {
+	db    *sql.DB
+	pool  *core.WorkerPool
+	clock core.Clock
+}
+
+func NewPlayBotMovesCommandHandler(db *sql.DB, pool *core.WorkerPool, clock core.Clock) *PlayBotMovesCommandHandler {
+	return &PlayBotMovesCommandHandler{db, pool, clock}
+}
+
+func (h *PlayBotMovesCommandHandler) Handle(ctx context.Context, _ PlayBotMovesCommand) (core.Unit, error) {
+	// Only claim as many moves as the pool can take, the rest are left for
+	// the next run or another instance.
+	available := h.pool.Available()
+	if available == 0 {
+		return core.Unit{}, nil
+	}
+
+	now := h.clock.Now().UTC()
+
+	var (
+		botMoves []domain.BotMove
+		sessions = make(map[uuid.UUID]string)
+		// Games which fail to be prepared should not stop the others from being played.
+		errs []error
+	)
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		// The side to move is the second field of the FEN.
+		const query = `
+			SELECT
+				g.*
+			FROM
+				game g
+			WHERE
+				g.status = $1
+				AND CASE split_part(g.fen, ' ', 2) WHEN 'w' THEN g.white_id ELSE g.black_id END = $2
+				AND NOT EXISTS (
+					SELECT
+						1
+					FROM
+						bot_move b
+					WHERE
+						b.game_id = g.id AND b.ply = g.ply AND b.claimed_until > $3
+				)
+			ORDER BY
+				g.turn_started_at
+			LIMIT $4
+			FOR UPDATE OF g SKIP LOCKED;`
+		games, err := tql.Query[domain.Game](ctx, tx, query, domain.GameStarted, domain.BotUserID, now, available)
+		if err != nil {
+			return err
+		}
+
+		if len(games) == 0 {
+			return nil
+		}
+
+		sessionIDs := make([]string, 0, len(games))
+		for _, game := range games {
+			sessionIDs = append(sessionIDs, game.SessionID)
+		}
+
+		const sessionsQuery = `
+			SELECT
+				*
+			FROM
+				game_session
+			WHERE
+				id = ANY($1);`
+		gameSessions, err := tql.Query[domain.Session](ctx, tx, sessionsQuery, pq.Array(sessionIDs))
+		if err != nil {
+			return err
+		}
+
+		levels := make(map[string]engine.Level, len(gameSessions))
+		for _, session := range gameSessions {
+			levels[session.ID] = engine.Level(session.BotLevel)
+		}
+
+		for _, game := range games {
+			moves, err := getGameMoves(ctx, tx, game.ID)
+			if err != nil {
+				return err
+			}
+
+			botMove, err := game.BotMove(levels[game.SessionID], moves, now)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("game '%s': %w", game.ID, err))
+				continue
+			}
+
+			if err := claimBotMove(ctx, tx, botMove, now.Add(botMove.Limits.MoveTime+botMoveClaimMargin)); err != nil {
+				return err
+			}
+
+			botMoves = append(botMoves, botMove)
+			sessions[game.ID] = game.SessionID
+		}
+
+		return nil
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	for _, botMove := range botMoves {
+		if err := h.pool.Submit(playBotMove(botMove, sessions[botMove.GameID])); err != nil {
+			// The claim expires and the move is searched on a later run.
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
+
+func claimBotMove(ctx context.Context, tx *sql.Tx, botMove domain.BotMove, claimedUntil time.Time) error {
+	const stmt = `
+		INSERT INTO
+			bot_move (game_id, ply, claimed_until)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (game_id) DO UPDATE SET
+			ply = EXCLUDED.ply,
+			claimed_until = EXCLUDED.claimed_until;`
+	_, err := tql.Exec(ctx, tx, stmt, botMove.GameID, botMove.Ply, claimedUntil)
+	return err
+}
+
+// playBotMove searches for the computer's move and plays it.
+func playBotMove(botMove domain.BotMove, sessionID string) core.Task {
+	return func(ctx context.Context) error {
+		result, err := engine.Search(ctx, botMove.Position, botMove.Limits, botMove.History...)
+		if err != nil {
+			return fmt.Errorf("game '%s': %w", botMove.GameID, err)
+		}
+
+		_, err = mediator.Send[MakeMoveCommand, MakeMoveResponse](ctx, MakeMoveCommand{
+			SessionID: sessionID,
+			PlayerID:  domain.BotUserID,
+			Move:      result.Move.UCI(),
+		})
+
+		// The game may have ended while the computer was thinking.
+		var commandErr core.CommandError
+		if errors.As(err, &commandErr) && commandErr.StatusCode == 409 {
+			return nil
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// ProcessAbandonedGamesCommand adjudicates the games and sessions the players left.
+// Games left before both players moved are aborted, in the others the opponent of
+// the inactive player can claim the game, and sessions nobody joined are closed.
+// It is run periodically by a background job.
+type ProcessAbandonedGamesCommand struct{}
+
+type ProcessAbandonedGamesCommandHandler struct {
+	db     *sql.DB
+	clock  core.Clock
+	policy domain.AbandonmentPolicy
+}
+
+func NewProcessAbandonedGamesCommandHandler(
+	db *sql.DB,
+	clock core.Clock,
+	policy domain.AbandonmentPolicy,
+) *ProcessAbandonedGamesCommandHandler {
+	return &ProcessAbandonedGamesCommandHandler{db: db, clock: clock, policy: policy}
+}
+
+func (h *ProcessAbandonedGamesCommandHandler) Handle(
+	ctx context.Context,
+	_ ProcessAbandonedGamesCommand,
+) (core.Unit, error) {
+	now := h.clock.Now()
+
+	// Stale sessions should still be closed when the games fail to be processed.
+	var errs []error
+
+	if err := core.Tx(ctx, h.db, func(ctx context.Context, tx *sql.Tx) error {
+		return h.processInactiveGames(ctx, tx, now)
+	}); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := core.Tx(ctx, h.db, func(ctx context.Context, tx *sql.Tx) error {
+		return h.closeStaleSessions(ctx, tx, now)
+	}); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
+
+func (h *ProcessAbandonedGamesCommandHandler) processInactiveGames(ctx context.Context, tx *sql.Tx, now time.Time) error {
+	// Skip the games locked by a move in progress or by another instance running
+	// the same job, a move makes them active again anyway.
+	const query = `
+		SELECT
+			*
+		FROM
+			game
+		WHERE
+			status = $1
+			AND claimable_at IS NULL
+			AND (
+				(move_due_at IS NULL AND turn_started_at <= $2)
+				OR move_due_at <= $3
+			)
+		ORDER BY
+			turn_started_at
+		LIMIT
+			100
+		FOR UPDATE SKIP LOCKED;`
+	games, err := tql.Query[domain.Game](ctx, tx, query, domain.GameStarted, h.policy.InactiveSince(now), now)
+	if err != nil {
+		return err
+	}
+
+	var claimable []notificationsdomain.Notification
+	for _, game := range games {
+		if game.Ply < 2 {
+			if err := abortGame(ctx, tx, &game, now); err != nil {
+				return err
+			}
+			continue
+		}
+
+		playerID, err := game.MarkAbandoned(h.policy, now)
+		if err != nil {
+			return err
+		}
+
+		if playerID == uuid.Nil {
+			continue
+		}
+
+		payload := domain.PlayerAbandonedPayload{PlayerID: playerID, ClaimableAt: *game.ClaimableAt}
+		if err := recordGameEvent(ctx, tx, &game, domain.PlayerAbandonedEvent, payload, now); err != nil {
+			return err
+		}
+
+		if err := updateGame(ctx, tx, game); err != nil {
+			return err
+		}
+
+		notification, err := notificationsdomain.NewUserNotification(
+			game.OpponentID(playerID),
+			notificationsdomain.VictoryClaimableNotification,
+			notificationsdomain.VictoryClaimablePayload{
+				SessionID:   game.SessionID,
+				GameID:      game.ID,
+				ClaimableAt: payload.ClaimableAt,
+			},
+			now,
+		)
+		if err != nil {
+			return err
+		}
+
+		claimable = append(claimable, notification)
+	}
+
+	return notifications.Notify(ctx, tx, claimable...)
+}
+
+// abortGame ends the game without a result and tells both players.
+func abortGame(ctx context.Context, tx *sql.Tx, game *domain.Game, now time.Time) error {
+	if err := game.Abort(now); err != nil {
+		return err
+	}
+
+	if err := recordGameOver(ctx, tx, game, now); err != nil {
+		return err
+	}
+
+	if err := updateGame(ctx, tx, *game); err != nil {
+		return err
+	}
+
+	if err := notifyGameEnded(ctx, tx, *game, game.WhiteID, now); err != nil {
+		return err
+	}
+
+	return notifyGameEnded(ctx, tx, *game, game.BlackID, now)
+}
+
+func (h *ProcessAbandonedGamesCommandHandler) closeStaleSessions(ctx context.Context, tx *sql.Tx, now time.Time) error {
+	// Skip the sessions being joined, they are no longer open on the next run.
+	const query = `
+		SELECT
+			*
+		FROM
+			game_session
+		WHERE
+			closed = false AND player_2_id IS NULL AND created_at <= $1
+		ORDER BY
+			created_at
+		LIMIT
+			100
+		FOR UPDATE SKIP LOCKED;`
+	sessions, err := tql.Query[domain.Session](ctx, tx, query, h.policy.StaleSince(now))
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		const stmt = `
+			UPDATE
+				game_session
+			SET
+				closed = true
+			WHERE
+				id = $1;`
+		if _, err := tql.Exec(ctx, tx, stmt, session.ID); err != nil {
+			return err
+		}
+
+		if err := withdrawSessionInvitations(ctx, tx, session.ID, now); err != nil {
+			return err
+		}
+
+		if err := notifyLobbySessionClosed(ctx, tx, session.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
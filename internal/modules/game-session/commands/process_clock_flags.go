@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/tql"
+)
+
+// ProcessClockFlagsCommand ends the games in which the side to move ran out of
+// time without making a move. It is run periodically by a background job.
+type ProcessClockFlagsCommand struct{}
+
+type ProcessClockFlagsCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewProcessClockFlagsCommandHandler(db *sql.DB, clock core.Clock) *ProcessClockFlagsCommandHandler {
+	return &ProcessClockFlagsCommandHandler{db, clock}
+}
+
+func (h *ProcessClockFlagsCommandHandler) Handle(
+	ctx context.Context,
+	_ ProcessClockFlagsCommand,
+) (core.Unit, error) {
+	now := h.clock.Now()
+
+	// Games which fail to be checked should not stop the others from being flagged.
+	var errs []error
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		// Skip the games locked by a move in progress or by another instance
+		// running the same job, they will be picked up on the next run.
+		const query = `
+			SELECT
+				*
+			FROM
+				game
+			WHERE
+				status = $1 AND flag_at <= $2
+			FOR UPDATE SKIP LOCKED;`
+		games, err := tql.Query[domain.Game](ctx, tx, query, domain.GameStarted, now)
+		if err != nil {
+			return err
+		}
+
+		for _, game := range games {
+			flagged, err := game.CheckFlag(now)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			if !flagged {
+				continue
+			}
+
+			if err := recordGameOver(ctx, tx, &game, now); err != nil {
+				return err
+			}
+
+			if err := updateGame(ctx, tx, game); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
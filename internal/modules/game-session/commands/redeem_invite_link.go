@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// RedeemInviteLinkCommand joins the session of the invite link with the given code.
+type RedeemInviteLinkCommand struct {
+	Code   string
+	UserID uuid.UUID
+}
+
+func (c RedeemInviteLinkCommand) Validate() error {
+	if len(c.Code) != domain.JoinCodeLength {
+		return fmt.Errorf("invalid Code - '%s'", c.Code)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+type RedeemInviteLinkResponse struct {
+	SessionURL string
+	GameID     uuid.UUID
+}
+
+func HandleRedeemInviteLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := RedeemInviteLinkCommand{
+		// Codes are typed in by players, so they are not case sensitive.
+		Code:   strings.ToUpper(strings.TrimSpace(r.PathValue("code"))),
+		UserID: core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[RedeemInviteLinkCommand, RedeemInviteLinkResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type RedeemInviteLinkCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewRedeemInviteLinkCommandHandler(db *sql.DB, clock core.Clock) *RedeemInviteLinkCommandHandler {
+	return &RedeemInviteLinkCommandHandler{db: db, clock: clock}
+}
+
+func (h *RedeemInviteLinkCommandHandler) Handle(
+	ctx context.Context,
+	request RedeemInviteLinkCommand,
+) (RedeemInviteLinkResponse, error) {
+	var (
+		session domain.Session
+		game    domain.Game
+	)
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const sessionQuery = `
+			SELECT
+				s.*
+			FROM
+				game_session s
+			INNER JOIN
+				invite_link l ON l.session_id = s.id
+			WHERE
+				l.code = $1
+			FOR UPDATE OF s;`
+		var err error
+		session, err = tql.QueryFirst[domain.Session](ctx, tx, sessionQuery, request.Code)
+		if err != nil {
+			return err
+		}
+
+		const linkQuery = `
+			SELECT
+				*
+			FROM
+				invite_link
+			WHERE
+				code = $1
+			FOR UPDATE;`
+		link, err := tql.QueryFirst[domain.InviteLink](ctx, tx, linkQuery, request.Code)
+		if err != nil {
+			return err
+		}
+
+		if err := social.CheckNotBlocked(ctx, tx, session.Player1ID, request.UserID); err != nil {
+			return err
+		}
+
+		now := h.clock.Now()
+
+		if err := link.Redeem(request.UserID, &session, now); err != nil {
+			return err
+		}
+
+		const redemptionStmt = `
+			INSERT INTO
+				invite_link_redemption (link_id, user_id, redeemed_at)
+			VALUES
+				($1, $2, $3)
+			ON CONFLICT DO NOTHING;`
+		result, err := tql.Exec(ctx, tx, redemptionStmt, link.ID, request.UserID, now)
+		if err != nil {
+			return err
+		}
+
+		redeemed, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if redeemed == 0 {
+			return core.NewCommandError(409, fmt.Errorf("invite link was already redeemed"))
+		}
+
+		const linkStmt = `
+			UPDATE
+				invite_link
+			SET
+				uses = :uses
+			WHERE
+				id = :id;`
+		if _, err := tql.Exec(ctx, tx, linkStmt, link); err != nil {
+			return err
+		}
+
+		game, err = startSessionGame(ctx, tx, session, now)
+		if err != nil {
+			return err
+		}
+
+		if err := withdrawSessionInvitations(ctx, tx, session.ID, now); err != nil {
+			return err
+		}
+
+		if err := notifyGameStarted(ctx, tx, session, game, now); err != nil {
+			return err
+		}
+
+		return notifyLobbySessionClosed(ctx, tx, session.ID, now)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return RedeemInviteLinkResponse{}, invitationCommandError(err)
+	}
+
+	return RedeemInviteLinkResponse{
+		SessionURL: path.Join("/game-sessions", session.ID),
+		GameID:     game.ID,
+	}, nil
+}
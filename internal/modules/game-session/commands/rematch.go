@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// getPendingRematchOffer returns the pending rematch offer of the game, sql.ErrNoRows when there is none.
+func getPendingRematchOffer(ctx context.Context, tx *sql.Tx, gameID uuid.UUID) (domain.RematchOffer, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			rematch_offer
+		WHERE
+			game_id = $1 AND status = $2
+		FOR UPDATE;`
+	return tql.QueryFirst[domain.RematchOffer](ctx, tx, query, gameID, domain.OfferPending)
+}
+
+func updateRematchOffer(ctx context.Context, tx *sql.Tx, offer domain.RematchOffer) error {
+	const stmt = `
+		UPDATE
+			rematch_offer
+		SET
+			status = :status,
+			rematch_session_id = :rematch_session_id,
+			responded_at = :responded_at
+		WHERE
+			id = :id;`
+	_, err := tql.Exec(ctx, tx, stmt, offer)
+	return err
+}
+
+func newRematchOfferNotification(
+	userID uuid.UUID,
+	notificationType notificationsdomain.NotificationType,
+	offer domain.RematchOffer,
+	now time.Time,
+) (notificationsdomain.Notification, error) {
+	payload := notificationsdomain.RematchOfferPayload{
+		OfferID:   offer.ID,
+		SessionID: offer.SessionID,
+		GameID:    offer.GameID,
+		Status:    string(offer.Status),
+		ExpiresAt: offer.ExpiresAt,
+	}
+	if offer.RematchSessionID != nil {
+		payload.RematchSessionID = *offer.RematchSessionID
+	}
+
+	return notificationsdomain.NewUserNotification(userID, notificationType, payload, now)
+}
+
+func notifyRematchOffer(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID uuid.UUID,
+	offer domain.RematchOffer,
+	notificationType notificationsdomain.NotificationType,
+	now time.Time,
+) error {
+	notification, err := newRematchOfferNotification(userID, notificationType, offer, now)
+	if err != nil {
+		return err
+	}
+
+	return notifications.Notify(ctx, tx, notification)
+}
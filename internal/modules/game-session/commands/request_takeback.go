@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// RequestTakebackCommand asks the opponent to take back the player's last move.
+// The request expires on the next move.
+type RequestTakebackCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c RequestTakebackCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+func HandleRequestTakeback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := RequestTakebackCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[RequestTakebackCommand, GameActionResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type RequestTakebackCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewRequestTakebackCommandHandler(db *sql.DB, clock core.Clock) *RequestTakebackCommandHandler {
+	return &RequestTakebackCommandHandler{db: db, clock: clock}
+}
+
+func (h *RequestTakebackCommandHandler) Handle(ctx context.Context, request RequestTakebackCommand) (GameActionResponse, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		return makeGameOffer(ctx, tx, game, domain.TakebackOffer, request.PlayerID, now)
+	}
+
+	game, err := runGameAction(ctx, h.db, request.SessionID, now, action)
+	if err != nil {
+		return GameActionResponse{}, err
+	}
+
+	return newGameActionResponse(game), nil
+}
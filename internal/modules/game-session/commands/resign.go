@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// ResignCommand ends the game with a win for the player's opponent.
+type ResignCommand struct {
+	SessionID string
+	PlayerID  uuid.UUID
+}
+
+func (c ResignCommand) Validate() error {
+	return validateGameAction(c.SessionID, c.PlayerID)
+}
+
+func HandleResign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := ResignCommand{
+		SessionID: r.PathValue("id"),
+		PlayerID:  core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[ResignCommand, GameActionResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type ResignCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewResignCommandHandler(db *sql.DB, clock core.Clock) *ResignCommandHandler {
+	return &ResignCommandHandler{db: db, clock: clock}
+}
+
+func (h *ResignCommandHandler) Handle(ctx context.Context, request ResignCommand) (GameActionResponse, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, game *domain.Game) error {
+		if err := game.Resign(request.PlayerID, now); err != nil {
+			return err
+		}
+
+		if err := recordGameOver(ctx, tx, game, now); err != nil {
+			return err
+		}
+
+		if err := updateGame(ctx, tx, *game); err != nil {
+			return err
+		}
+
+		return notifyGameEnded(ctx, tx, *game, request.PlayerID, now)
+	}
+
+	game, err := runGameAction(ctx, h.db, request.SessionID, now, action)
+	if err != nil {
+		return GameActionResponse{}, err
+	}
+
+	return newGameActionResponse(game), nil
+}
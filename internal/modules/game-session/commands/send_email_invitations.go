@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// SendEmailInvitationsCommand emails the players invited by an email address without
+// an account. Emails with a confirmed account are left to the conversion into an
+// invitation instead. It is run periodically by a background job.
+type SendEmailInvitationsCommand struct{}
+
+type SendEmailInvitationsCommandHandler struct {
+	db          *sql.DB
+	emailClient *core.EmailClient
+	sender      string
+	clock       core.Clock
+}
+
+func NewSendEmailInvitationsCommandHandler(
+	db *sql.DB,
+	emailClient *core.EmailClient,
+	sender string,
+	clock core.Clock,
+) *SendEmailInvitationsCommandHandler {
+	return &SendEmailInvitationsCommandHandler{db: db, emailClient: emailClient, sender: sender, clock: clock}
+}
+
+type inviterUsername struct {
+	ID       uuid.UUID `db:"id"`
+	Username string    `db:"username"`
+}
+
+func (h *SendEmailInvitationsCommandHandler) Handle(
+	ctx context.Context,
+	_ SendEmailInvitationsCommand,
+) (core.Unit, error) {
+	var (
+		invitations []domain.EmailInvitation
+		usernames   = make(map[uuid.UUID]string)
+	)
+
+	// The invitations are marked as sent before sending them, so an invitation
+	// is never emailed twice, not even by two instances running the job.
+	// An invitation which fails to send is not retried.
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const query = `
+			SELECT
+				e.*
+			FROM
+				email_invitation e
+			WHERE
+				e.sent_at IS NULL
+				AND e.status = $1
+				AND NOT EXISTS (SELECT 1 FROM auth.user u WHERE lower(u.email) = e.email AND u.email_confirmed = true)
+			ORDER BY
+				e.created_at
+			LIMIT
+				50
+			FOR UPDATE SKIP LOCKED;`
+		var err error
+		invitations, err = tql.Query[domain.EmailInvitation](ctx, tx, query, domain.InvitationPending)
+		if err != nil {
+			return err
+		}
+
+		now := h.clock.Now()
+		for i := range invitations {
+			invitations[i].SentAt = &now
+
+			if err := updateEmailInvitation(ctx, tx, invitations[i]); err != nil {
+				return err
+			}
+		}
+
+		inviterIDs := core.Map(invitations, func(i domain.EmailInvitation) uuid.UUID {
+			return i.InviterID
+		})
+
+		const usernamesQuery = `
+			SELECT
+				id,
+				username
+			FROM
+				auth.user
+			WHERE
+				id = ANY($1);`
+		inviters, err := tql.Query[inviterUsername](ctx, tx, usernamesQuery, pq.Array(inviterIDs))
+		if err != nil {
+			return err
+		}
+
+		for _, inviter := range inviters {
+			usernames[inviter.ID] = inviter.Username
+		}
+
+		return nil
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	var errs []error
+	for _, invitation := range invitations {
+		if err := h.emailClient.Send(invitation.Message(usernames[invitation.InviterID], h.sender)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// SendMoveRemindersCommand emails the players of correspondence games whose move
+// is due, unless they turned the reminders off. It is run periodically by a
+// background job, each player is reminded at most once a day.
+type SendMoveRemindersCommand struct{}
+
+type SendMoveRemindersCommandHandler struct {
+	db          *sql.DB
+	emailClient *core.EmailClient
+	sender      string
+	clock       core.Clock
+}
+
+func NewSendMoveRemindersCommandHandler(
+	db *sql.DB,
+	emailClient *core.EmailClient,
+	sender string,
+	clock core.Clock,
+) *SendMoveRemindersCommandHandler {
+	return &SendMoveRemindersCommandHandler{db: db, emailClient: emailClient, sender: sender, clock: clock}
+}
+
+type reminderRecipient struct {
+	ID    uuid.UUID `db:"id"`
+	Email string    `db:"email"`
+}
+
+func (h *SendMoveRemindersCommandHandler) Handle(
+	ctx context.Context,
+	_ SendMoveRemindersCommand,
+) (core.Unit, error) {
+	now := h.clock.Now()
+
+	var reminders []core.MailMessage
+
+	// The players are marked as reminded before the emails are sent, so no player
+	// is reminded twice a day, not even by two instances running the job.
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const gamesQuery = `
+			SELECT
+				*
+			FROM
+				game
+			WHERE
+				status = $1 AND move_due_at IS NOT NULL AND claimable_at IS NULL
+			ORDER BY
+				move_due_at;`
+		games, err := tql.Query[domain.Game](ctx, tx, gamesQuery, domain.GameStarted)
+		if err != nil {
+			return err
+		}
+
+		due, err := domain.DueMovesByPlayer(games)
+		if err != nil {
+			return err
+		}
+
+		playerIDs := make([]uuid.UUID, 0, len(due))
+		for playerID := range due {
+			playerIDs = append(playerIDs, playerID)
+		}
+
+		const recipientsQuery = `
+			SELECT
+				u.id,
+				u.email
+			FROM
+				auth.user u
+				LEFT JOIN auth.user_preferences p ON p.user_id = u.id
+			WHERE
+				u.id = ANY($1) AND COALESCE(p.correspondence_reminders, true);`
+		recipients, err := tql.Query[reminderRecipient](ctx, tx, recipientsQuery, pq.Array(playerIDs))
+		if err != nil {
+			return err
+		}
+
+		for _, recipient := range recipients {
+			const stmt = `
+				INSERT INTO
+					move_reminder (user_id, sent_at)
+				VALUES
+					($1, $2)
+				ON CONFLICT (user_id) DO UPDATE SET
+					sent_at = EXCLUDED.sent_at
+				WHERE
+					move_reminder.sent_at <= $3;`
+			result, err := tql.Exec(ctx, tx, stmt, recipient.ID, now, now.Add(-domain.MoveReminderInterval))
+			if err != nil {
+				return err
+			}
+
+			reminded, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			if reminded == 0 {
+				continue
+			}
+
+			reminders = append(reminders, domain.MoveReminderMessage(recipient.Email, due[recipient.ID], h.sender))
+		}
+
+		return nil
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	var errs []error
+	for _, reminder := range reminders {
+		if err := h.emailClient.Send(reminder); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
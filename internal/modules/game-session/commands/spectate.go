@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// SpectateCommand joins the user to the spectators of the session's game, or
+// refreshes them as watching. Spectators follow the game on the session's stream.
+type SpectateCommand struct {
+	SessionID string
+	UserID    uuid.UUID
+}
+
+func (c SpectateCommand) Validate() error {
+	if c.SessionID == "" {
+		return fmt.Errorf("invalid SessionID - '%s'", c.SessionID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+type SpectateResponse struct {
+	GameID         uuid.UUID
+	SpectatorDelay domain.SpectatorDelay
+	// Spectators is the number of users watching the game, the user included.
+	Spectators int
+}
+
+func HandleSpectate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := SpectateCommand{
+		SessionID: r.PathValue("id"),
+		UserID:    core.Session(ctx).UserID,
+	}
+
+	response, err := mediator.Send[SpectateCommand, SpectateResponse](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type SpectateCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewSpectateCommandHandler(db *sql.DB, clock core.Clock) *SpectateCommandHandler {
+	return &SpectateCommandHandler{db: db, clock: clock}
+}
+
+func (h *SpectateCommandHandler) Handle(ctx context.Context, request SpectateCommand) (SpectateResponse, error) {
+	now := h.clock.Now()
+
+	const query = `
+		SELECT
+			*
+		FROM
+			game_session
+		WHERE
+			id = $1;`
+	session, err := tql.QueryFirst[domain.Session](ctx, h.db, query, request.SessionID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return SpectateResponse{}, core.NewCommandError(404, err)
+	case err != nil:
+		return SpectateResponse{}, core.NewCommandError(500, err)
+	}
+
+	if err := session.CanSpectate(request.UserID); err != nil {
+		if errors.Is(err, domain.ErrSpectatingPrivateSession) {
+			return SpectateResponse{}, core.NewCommandError(403, err)
+		}
+		return SpectateResponse{}, core.NewCommandError(409, err)
+	}
+
+	const stmt = `
+		INSERT INTO
+			game_spectator (session_id, user_id, last_seen_at)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (session_id, user_id) DO UPDATE SET
+			last_seen_at = EXCLUDED.last_seen_at;`
+	if _, err := tql.Exec(ctx, h.db, stmt, session.ID, request.UserID, now); err != nil {
+		return SpectateResponse{}, core.NewCommandError(500, err)
+	}
+
+	const countQuery = `
+		SELECT
+			count(*)
+		FROM
+			game_spectator
+		WHERE
+			session_id = $1 AND last_seen_at > $2;`
+	spectators, err := tql.QueryFirst[int](ctx, h.db, countQuery, session.ID, now.Add(-domain.SpectatorPresenceTTL))
+	if err != nil {
+		return SpectateResponse{}, core.NewCommandError(500, err)
+	}
+
+	return SpectateResponse{
+		GameID:         session.GameID,
+		SpectatorDelay: session.SpectatorDelay(),
+		Spectators:     spectators,
+	}, nil
+}
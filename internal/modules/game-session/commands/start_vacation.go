@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// vacationLockSpace is the first key of the advisory locks serializing the vacations
+// of a user, so two vacations cannot both fit the allowance.
+const vacationLockSpace int32 = 0x76616361
+
+// StartVacationCommand pauses the user's correspondence games for Days, taken from
+// the user's yearly allowance. The moves due meanwhile are postponed by the vacation.
+type StartVacationCommand struct {
+	UserID uuid.UUID
+	Days   int
+}
+
+func (c StartVacationCommand) Validate() error {
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	if c.Days < 1 || c.Days > domain.VacationDaysPerYear {
+		return fmt.Errorf("invalid Days - '%d'", c.Days)
+	}
+
+	return nil
+}
+
+func HandleStartVacation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command, err := core.RequestBody[StartVacationCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	command.UserID = core.Session(ctx).UserID
+
+	response, err := mediator.Send[StartVacationCommand, domain.Vacation](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteResponse(w, r, http.StatusCreated, response)
+}
+
+type StartVacationCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewStartVacationCommandHandler(db *sql.DB, clock core.Clock) *StartVacationCommandHandler {
+	return &StartVacationCommandHandler{db: db, clock: clock}
+}
+
+func (h *StartVacationCommandHandler) Handle(ctx context.Context, request StartVacationCommand) (domain.Vacation, error) {
+	now := h.clock.Now()
+
+	var vacation domain.Vacation
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const lockStmt = `SELECT pg_advisory_xact_lock($1, hashtext($2));`
+		if _, err := tql.Exec(ctx, tx, lockStmt, vacationLockSpace, request.UserID.String()); err != nil {
+			return err
+		}
+
+		const allowanceQuery = `
+			SELECT
+				COALESCE(sum(days) FILTER (WHERE starts_at >= $2), 0) AS used_days,
+				COALESCE(bool_or(ends_at > $3), false) AS on_vacation
+			FROM
+				user_vacation
+			WHERE
+				user_id = $1;`
+		allowance, err := tql.QueryFirst[vacationAllowance](
+			ctx,
+			tx,
+			allowanceQuery,
+			request.UserID,
+			domain.VacationYearStart(now),
+			now,
+		)
+		if err != nil {
+			return err
+		}
+
+		if allowance.OnVacation {
+			return core.NewCommandError(409, domain.ErrOnVacation)
+		}
+
+		vacation, err = domain.NewVacation(request.UserID, request.Days, allowance.UsedDays, now)
+		if err != nil {
+			return core.NewCommandError(409, err)
+		}
+
+		const stmt = `
+			INSERT INTO
+				user_vacation (id, user_id, days, starts_at, ends_at)
+			VALUES
+				(:id, :user_id, :days, :starts_at, :ends_at);`
+		if _, err := tql.Exec(ctx, tx, stmt, vacation); err != nil {
+			return err
+		}
+
+		return postponeDueMoves(ctx, tx, vacation, now)
+	}
+
+	err := core.Tx(ctx, h.db, txFn)
+	var commandErr core.CommandError
+	switch {
+	case err != nil && errors.As(err, &commandErr):
+		return domain.Vacation{}, commandErr
+	case err != nil:
+		return domain.Vacation{}, core.NewCommandError(500, err)
+	}
+
+	return vacation, nil
+}
+
+type vacationAllowance struct {
+	UsedDays   int  `db:"used_days"`
+	OnVacation bool `db:"on_vacation"`
+}
+
+// postponeDueMoves postpones the moves the user on vacation has to make.
+func postponeDueMoves(ctx context.Context, tx *sql.Tx, vacation domain.Vacation, now time.Time) error {
+	const query = `
+		SELECT
+			*
+		FROM
+			game
+		WHERE
+			status = $1
+			AND move_due_at IS NOT NULL
+			AND claimable_at IS NULL
+			AND (white_id = $2 OR black_id = $2)
+		FOR UPDATE;`
+	games, err := tql.Query[domain.Game](ctx, tx, query, domain.GameStarted, vacation.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, game := range games {
+		playerID, err := game.PlayerToMove()
+		if err != nil {
+			return err
+		}
+
+		if playerID != vacation.UserID || !game.PostponeMove(vacation.Remaining(now)) {
+			continue
+		}
+
+		payload := domain.MovePostponedPayload{PlayerID: playerID, MoveDueAt: *game.MoveDueAt}
+		if err := recordGameEvent(ctx, tx, &game, domain.MovePostponedEvent, payload, now); err != nil {
+			return err
+		}
+
+		if err := updateGame(ctx, tx, game); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+type StopSpectatingCommand struct {
+	SessionID string
+	UserID    uuid.UUID
+}
+
+func (c StopSpectatingCommand) Validate() error {
+	if c.SessionID == "" {
+		return fmt.Errorf("invalid SessionID - '%s'", c.SessionID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleStopSpectating(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command := StopSpectatingCommand{
+		SessionID: r.PathValue("id"),
+		UserID:    core.Session(ctx).UserID,
+	}
+
+	_, err := mediator.Send[StopSpectatingCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type StopSpectatingCommandHandler struct {
+	db *sql.DB
+}
+
+func NewStopSpectatingCommandHandler(db *sql.DB) *StopSpectatingCommandHandler {
+	return &StopSpectatingCommandHandler{db}
+}
+
+func (h *StopSpectatingCommandHandler) Handle(
+	ctx context.Context,
+	request StopSpectatingCommand,
+) (core.Unit, error) {
+	// Leaving twice is not an error, the stream leaves when it disconnects
+	// whether or not the client already did.
+	const stmt = `
+		DELETE FROM
+			game_spectator
+		WHERE
+			session_id = $1 AND user_id = $2;`
+	if _, err := tql.Exec(ctx, h.db, stmt, request.SessionID, request.UserID); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrVictoryNotClaimable = errors.New("the opponent has not abandoned the game")
+	ErrGameUnderway        = errors.New("both players have already moved")
+)
+
+// PlayerAbandonedEvent is recorded once the side to move has been inactive for long
+// enough, telling the opponent when they can claim the game.
+const PlayerAbandonedEvent GameEventType = "player_abandoned"
+
+type PlayerAbandonedPayload struct {
+	PlayerID    uuid.UUID
+	ClaimableAt time.Time
+}
+
+// AbandonmentPolicy decides when games and sessions are considered left behind.
+type AbandonmentPolicy struct {
+	// InactiveAfter is how long the side to move can go without moving.
+	InactiveAfter time.Duration
+	// ClaimGrace is how much longer the opponent of an inactive player waits before
+	// they can claim the game, giving the player a last chance to come back.
+	ClaimGrace time.Duration
+	// StaleSessionAfter is how long a session waits for a second player.
+	StaleSessionAfter time.Duration
+}
+
+// InactiveSince is the moment the game's side to move was last seen moving.
+func (p AbandonmentPolicy) InactiveSince(now time.Time) time.Time {
+	return now.Add(-p.InactiveAfter)
+}
+
+// StaleSince is the moment sessions created before are stale if nobody joined them.
+func (p AbandonmentPolicy) StaleSince(now time.Time) time.Time {
+	return now.Add(-p.StaleSessionAfter)
+}
+
+// IsInactive reports whether the side to move has not moved in time. Timed games
+// whose clock runs out first end on time instead, while correspondence games time
+// out once the move is due and are adjudicated as abandoned.
+func (g Game) IsInactive(policy AbandonmentPolicy, now time.Time) bool {
+	return !g.IsOver() && !now.Before(g.inactiveAt(policy))
+}
+
+func (g Game) inactiveAt(policy AbandonmentPolicy) time.Time {
+	if g.MoveDueAt != nil {
+		return *g.MoveDueAt
+	}
+	return g.TurnStartedAt.Add(policy.InactiveAfter)
+}
+
+// Abort ends the game without a result when it was left before both players moved.
+func (g *Game) Abort(now time.Time) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	if g.Ply >= 2 {
+		return ErrGameUnderway
+	}
+
+	g.end(chess.NoResult, chess.Aborted, now)
+	return nil
+}
+
+// MarkAbandoned lets the opponent of the inactive side to move claim the game once
+// the grace period is over. It returns the inactive player, uuid.Nil when the game
+// is not abandoned or was already marked.
+func (g *Game) MarkAbandoned(policy AbandonmentPolicy, now time.Time) (uuid.UUID, error) {
+	if g.ClaimableAt != nil || !g.IsInactive(policy, now) {
+		return uuid.Nil, nil
+	}
+
+	playerID, err := g.PlayerToMove()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	claimableAt := g.inactiveAt(policy).Add(policy.ClaimGrace)
+	g.ClaimableAt = &claimableAt
+
+	return playerID, nil
+}
+
+// ClaimVictory ends the game for the player whose opponent abandoned it. As with
+// running out of time, the game is drawn when the player has no way to deliver mate.
+func (g *Game) ClaimVictory(playerID uuid.UUID, now time.Time) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	color, err := g.PlayerColor(playerID)
+	if err != nil {
+		return err
+	}
+
+	position, err := g.Position()
+	if err != nil {
+		return err
+	}
+
+	if position.Turn == color || g.ClaimableAt == nil || now.Before(*g.ClaimableAt) {
+		return ErrVictoryNotClaimable
+	}
+
+	result := chess.Win(color)
+	if !position.HasMatingMaterial(color) {
+		result = chess.Draw
+	}
+
+	g.end(result, chess.Abandonment, now)
+	return nil
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var testAbandonmentPolicy = AbandonmentPolicy{
+	InactiveAfter:     5 * time.Minute,
+	ClaimGrace:        2 * time.Minute,
+	StaleSessionAfter: 24 * time.Hour,
+}
+
+func Test_Game_Abort_Only_Before_Both_Players_Moved(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+	underway := startTestGame(t, TimeControl{}, now)
+	play(t, &underway, "e2e4", now)
+	play(t, &underway, "e7e5", now)
+
+	// Act
+	err := game.Abort(now)
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, game.IsOver())
+	require.Equal(t, chess.NoResult, game.Result)
+	require.Equal(t, chess.Aborted, game.Termination)
+	require.ErrorIs(t, game.Abort(now), ErrGameOver)
+	require.ErrorIs(t, underway.Abort(now), ErrGameUnderway)
+}
+
+func Test_Game_MarkAbandoned_After_Inactivity(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+	play(t, &game, "e2e4", now)
+	play(t, &game, "e7e5", now)
+
+	// Act
+	early, err := game.MarkAbandoned(testAbandonmentPolicy, now.Add(time.Minute))
+	require.NoError(t, err)
+	playerID, err := game.MarkAbandoned(testAbandonmentPolicy, now.Add(6*time.Minute))
+	require.NoError(t, err)
+	again, err := game.MarkAbandoned(testAbandonmentPolicy, now.Add(7*time.Minute))
+	require.NoError(t, err)
+
+	// Assert
+	require.Equal(t, uuid.Nil, early)
+	require.Equal(t, game.WhiteID, playerID)
+	require.Equal(t, now.Add(7*time.Minute), *game.ClaimableAt)
+	require.Equal(t, uuid.Nil, again)
+}
+
+func Test_Game_ClaimVictory(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+	play(t, &game, "e2e4", now)
+	play(t, &game, "e7e5", now)
+
+	_, err := game.MarkAbandoned(testAbandonmentPolicy, now.Add(6*time.Minute))
+	require.NoError(t, err)
+
+	// Act & Assert
+	require.ErrorIs(t, game.ClaimVictory(game.BlackID, now.Add(6*time.Minute)), ErrVictoryNotClaimable)
+	require.ErrorIs(t, game.ClaimVictory(game.WhiteID, now.Add(8*time.Minute)), ErrVictoryNotClaimable)
+	require.ErrorIs(t, game.ClaimVictory(uuid.New(), now.Add(8*time.Minute)), ErrNotAPlayer)
+
+	require.NoError(t, game.ClaimVictory(game.BlackID, now.Add(8*time.Minute)))
+	require.Equal(t, chess.BlackWins, game.Result)
+	require.Equal(t, chess.Abandonment, game.Termination)
+	require.Nil(t, game.ClaimableAt)
+}
+
+func Test_Game_Move_Clears_Abandonment(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+	play(t, &game, "e2e4", now)
+	play(t, &game, "e7e5", now)
+
+	_, err := game.MarkAbandoned(testAbandonmentPolicy, now.Add(6*time.Minute))
+	require.NoError(t, err)
+
+	// Act
+	play(t, &game, "g1f3", now.Add(7*time.Minute))
+
+	// Assert
+	require.Nil(t, game.ClaimableAt)
+	require.ErrorIs(t, game.ClaimVictory(game.BlackID, now.Add(8*time.Minute)), ErrVictoryNotClaimable)
+}
+
+func Test_Game_ClaimVictory_Is_Draw_Without_Mating_Material(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+	game.FEN = "4k3/8/8/8/8/8/4P3/4K3 b - - 0 1"
+	game.Ply = 2
+
+	_, err := game.MarkAbandoned(testAbandonmentPolicy, now.Add(6*time.Minute))
+	require.NoError(t, err)
+
+	// Act
+	err = game.ClaimVictory(game.WhiteID, now.Add(8*time.Minute))
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, chess.WhiteWins, game.Result)
+
+	// The lone king cannot win.
+	game = startTestGame(t, TimeControl{}, now)
+	game.FEN = "4k3/8/8/8/8/8/4P3/4K3 w - - 0 1"
+	game.Ply = 2
+
+	_, err = game.MarkAbandoned(testAbandonmentPolicy, now.Add(6*time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, game.ClaimVictory(game.BlackID, now.Add(8*time.Minute)))
+	require.Equal(t, chess.Draw, game.Result)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBerserkUnavailable = errors.New("only timed games can be played berserk")
+	ErrBerserkTooLate     = errors.New("berserk is only possible before the player's first move")
+)
+
+// BerserkEvent is recorded when a player halves their own clock for a bigger reward.
+const BerserkEvent GameEventType = "berserk"
+
+type BerserkPayload struct {
+	PlayerID uuid.UUID
+	Clock    Clock
+}
+
+// Berserk halves the player's remaining time. It is only possible in timed games and
+// before the player's first move, whether the game rewards it is up to where it is played.
+func (g *Game) Berserk(playerID uuid.UUID, now time.Time) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	color, err := g.PlayerColor(playerID)
+	if err != nil {
+		return err
+	}
+
+	timeControl := g.TimeControl()
+	if !timeControl.IsTimed() || timeControl.IsCorrespondence() {
+		return ErrBerserkUnavailable
+	}
+
+	// White's first move is ply 0 and black's ply 1.
+	if g.Ply > int(color) {
+		return ErrBerserkTooLate
+	}
+
+	g.setRemaining(color, g.Remaining(color)/2)
+	g.updateFlagAt()
+	return nil
+}
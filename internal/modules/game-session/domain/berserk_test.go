@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Game_Berserk_Halves_The_Players_Clock(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{BaseSeconds: 180, IncrementSeconds: 2}, now)
+
+	// Act
+	err := game.Berserk(game.BlackID, now)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, int64(180_000), game.WhiteRemainingMs)
+	require.Equal(t, int64(90_000), game.BlackRemainingMs)
+}
+
+func Test_Game_Berserk_Only_Before_The_Players_First_Move(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{BaseSeconds: 180}, now)
+	play(t, &game, "e2e4", now)
+
+	// Act & Assert
+	require.ErrorIs(t, game.Berserk(game.WhiteID, now), ErrBerserkTooLate)
+	require.NoError(t, game.Berserk(game.BlackID, now))
+
+	play(t, &game, "e7e5", now)
+	require.ErrorIs(t, game.Berserk(game.BlackID, now), ErrBerserkTooLate)
+	require.ErrorIs(t, game.Berserk(uuid.New(), now), ErrNotAPlayer)
+}
+
+func Test_Game_Berserk_Unavailable_Without_A_Clock(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	untimed := startTestGame(t, TimeControl{}, now)
+	correspondence := startTestGame(t, TimeControl{DaysPerMove: 3}, now)
+
+	// Act & Assert
+	require.ErrorIs(t, untimed.Berserk(untimed.WhiteID, now), ErrBerserkUnavailable)
+	require.ErrorIs(t, correspondence.Berserk(correspondence.WhiteID, now), ErrBerserkUnavailable)
+
+	require.NoError(t, untimed.Abort(now))
+	require.ErrorIs(t, untimed.Berserk(untimed.WhiteID, now), ErrGameOver)
+}
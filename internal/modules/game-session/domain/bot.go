@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/engine"
+
+	"github.com/google/uuid"
+)
+
+// BotUserID is the user the computer opponent plays as, created by the migrations.
+var BotUserID = uuid.MustParse("00000000-0000-4000-8000-00000000b071")
+
+var ErrNotBotsTurn = errors.New("it is not the computer's turn")
+
+// BotMove is a search for the computer's move in a game. The game's position and the
+// positions before it are kept for the engine to avoid or aim for repetitions.
+type BotMove struct {
+	GameID   uuid.UUID
+	Ply      int
+	Position chess.Position
+	History  []chess.Position
+	Limits   engine.Limits
+}
+
+// BotMove prepares the search for the computer's move at the level. The moves are
+// the game's moves in order. The engine's time is cut so it does not lose on time.
+func (g Game) BotMove(level engine.Level, moves []GameMove, now time.Time) (BotMove, error) {
+	if g.IsOver() {
+		return BotMove{}, ErrGameOver
+	}
+
+	if err := level.Validate(); err != nil {
+		return BotMove{}, err
+	}
+
+	positions, err := g.replay(moves)
+	if err != nil {
+		return BotMove{}, err
+	}
+
+	position := positions[len(positions)-1]
+	if position.Turn == chess.White && g.WhiteID != BotUserID || position.Turn == chess.Black && g.BlackID != BotUserID {
+		return BotMove{}, ErrNotBotsTurn
+	}
+
+	limits := level.Limits()
+	if g.TimeControl().IsTimed() {
+		remaining := g.Remaining(position.Turn)
+		if g.clockRunning() {
+			remaining -= now.Sub(g.TurnStartedAt)
+		}
+
+		limits = limits.ForClock(max(remaining, time.Millisecond), g.TimeControl().Increment())
+	}
+
+	return BotMove{
+		GameID:   g.ID,
+		Ply:      g.Ply,
+		Position: position,
+		History:  positions[:len(positions)-1],
+		Limits:   limits,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/engine"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func startBotGame(t *testing.T, tc TimeControl, now time.Time) Game {
+	session := Session{
+		ID:                          uuid.NewString(),
+		Player1ID:                   uuid.New(),
+		Player2ID:                   BotUserID,
+		BotLevel:                    int(engine.MinLevel),
+		TimeControlBaseSeconds:      tc.BaseSeconds,
+		TimeControlIncrementSeconds: tc.IncrementSeconds,
+		TimeControlDelay:            tc.Delay,
+	}
+
+	game, err := StartGame(session, now)
+	require.NoError(t, err)
+
+	return game
+}
+
+func Test_Game_BotMove_Searches_Current_Position(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	game := startBotGame(t, TimeControl{}, now)
+	moves := playedMoves(t, &game, []string{"e2e4"}, now)
+
+	// Act
+	botMove, err := game.BotMove(engine.MaxLevel, moves, now)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, 1, botMove.Ply)
+	require.Equal(t, game.FEN, botMove.Position.FEN())
+	require.Len(t, botMove.History, 1)
+	require.Equal(t, engine.MaxLevel.Limits(), botMove.Limits)
+}
+
+func Test_Game_BotMove_Requires_Bots_Turn(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	game := startBotGame(t, TimeControl{}, now)
+
+	// Act
+	_, err := game.BotMove(engine.MinLevel, nil, now)
+
+	// Assert
+	require.ErrorIs(t, err, ErrNotBotsTurn)
+}
+
+func Test_Game_BotMove_Saves_Time_On_The_Clock(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	game := startBotGame(t, TimeControl{BaseSeconds: 60, Delay: FischerDelay}, now)
+	moves := playedMoves(t, &game, []string{"e2e4", "e7e5", "g1f3"}, now)
+
+	// Act
+	// The bot already used 58 of its 60 seconds thinking about this move.
+	botMove, err := game.BotMove(engine.MaxLevel, moves, now.Add(58*time.Second))
+
+	// Assert
+	require.NoError(t, err)
+	require.Less(t, botMove.Limits.MoveTime, 100*time.Millisecond)
+}
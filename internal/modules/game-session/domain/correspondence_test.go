@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TimeControl_Validate_DaysPerMove(t *testing.T) {
+	require.NoError(t, TimeControl{DaysPerMove: 1}.Validate())
+	require.NoError(t, TimeControl{DaysPerMove: MaxDaysPerMove}.Validate())
+	require.Error(t, TimeControl{DaysPerMove: MaxDaysPerMove + 1}.Validate())
+	require.Error(t, TimeControl{DaysPerMove: -1}.Validate())
+	require.Error(t, TimeControl{DaysPerMove: 3, BaseSeconds: 60}.Validate())
+
+	require.Equal(t, Correspondence, TimeControl{DaysPerMove: 3}.Category())
+	require.False(t, TimeControl{DaysPerMove: 3}.IsTimed())
+}
+
+func Test_Correspondence_Game_Moves_Are_Due_After_DaysPerMove(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{DaysPerMove: 3}, now)
+	require.Equal(t, now.Add(72*time.Hour), *game.MoveDueAt)
+	require.Nil(t, game.FlagAt)
+
+	// Act
+	play(t, &game, "e2e4", now.Add(48*time.Hour))
+
+	// Assert
+	require.Equal(t, now.Add(120*time.Hour), *game.MoveDueAt)
+	require.Equal(t, game.MoveDueAt, game.Clock().MoveDueAt)
+	require.False(t, game.IsInactive(testAbandonmentPolicy, now.Add(119*time.Hour)))
+	require.True(t, game.IsInactive(testAbandonmentPolicy, now.Add(120*time.Hour)))
+
+	require.NoError(t, game.Resign(game.WhiteID, now.Add(50*time.Hour)))
+	require.Nil(t, game.MoveDueAt)
+}
+
+func Test_Correspondence_Game_Times_Out_As_Abandoned(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{DaysPerMove: 1}, now)
+	play(t, &game, "e2e4", now)
+	play(t, &game, "e7e5", now)
+
+	// Act
+	playerID, err := game.MarkAbandoned(testAbandonmentPolicy, now.Add(25*time.Hour))
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, game.WhiteID, playerID)
+	require.Equal(t, now.Add(24*time.Hour+testAbandonmentPolicy.ClaimGrace), *game.ClaimableAt)
+}
+
+func Test_NewVacation_Takes_From_Allowance(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	vacation, err := NewVacation(userID, 7, 20, now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(7*24*time.Hour), vacation.EndsAt)
+	require.Equal(t, 24*time.Hour, vacation.Remaining(now.Add(6*24*time.Hour)))
+	require.Equal(t, time.Duration(0), vacation.Remaining(now.Add(8*24*time.Hour)))
+
+	_, err = NewVacation(userID, 11, 20, now)
+	require.ErrorIs(t, err, ErrVacationAllowanceExceeded)
+
+	_, err = NewVacation(userID, 0, 0, now)
+	require.Error(t, err)
+
+	require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), VacationYearStart(now))
+}
+
+func Test_Game_PostponeMove(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{DaysPerMove: 2}, now)
+	live := startTestGame(t, TimeControl{BaseSeconds: 60}, now)
+
+	// Act & Assert
+	require.True(t, game.PostponeMove(24*time.Hour))
+	require.Equal(t, now.Add(72*time.Hour), *game.MoveDueAt)
+	require.False(t, live.PostponeMove(24*time.Hour))
+
+	_, err := game.MarkAbandoned(testAbandonmentPolicy, now.Add(73*time.Hour))
+	require.NoError(t, err)
+	require.False(t, game.PostponeMove(24*time.Hour))
+}
+
+func Test_DueMovesByPlayer_Groups_By_Player_To_Move(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	first := startTestGame(t, TimeControl{DaysPerMove: 1}, now)
+	second := startTestGame(t, TimeControl{DaysPerMove: 1}, now)
+	play(t, &second, "e2e4", now)
+	live := startTestGame(t, TimeControl{}, now)
+
+	// Act
+	due, err := DueMovesByPlayer([]Game{first, second, live})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	require.Len(t, due[first.WhiteID], 1)
+	require.Len(t, due[second.BlackID], 1)
+
+	message := MoveReminderMessage("player@test.com", []Game{first, second}, "sender@test.com")
+	require.Equal(t, []string{"player@test.com"}, message.To)
+	require.Contains(t, message.BodyString, first.SessionID)
+	require.Contains(t, message.Subject, "2 games")
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/google/uuid"
+)
+
+// EmailInvitationTTL leaves the invited player time to register.
+const EmailInvitationTTL = 7 * 24 * time.Hour
+
+// MaxEmailInvitations is how many email invitations a user can send in any
+// EmailInvitationWindow, so inviting by email cannot be used to send spam.
+const (
+	MaxEmailInvitations   = 10
+	EmailInvitationWindow = time.Hour
+)
+
+var ErrTooManyEmailInvitations = errors.New("too many email invitations, try again later")
+
+// EmailInvitation invites someone without an account by email. It turns into a
+// SessionInvitation once an account with the email is registered and confirmed.
+type EmailInvitation struct {
+	ID        uuid.UUID `db:"id"`
+	SessionID string    `db:"session_id"`
+	InviterID uuid.UUID `db:"inviter_id"`
+	Email     string    `db:"email"`
+
+	Status       InvitationStatus `db:"status"`
+	InvitationID uuid.NullUUID    `db:"invitation_id"`
+
+	CreatedAt   time.Time  `db:"created_at"`
+	ExpiresAt   time.Time  `db:"expires_at"`
+	SentAt      *time.Time `db:"sent_at"`
+	RespondedAt *time.Time `db:"responded_at"`
+}
+
+// NormalizeEmail returns the address part of the email in lower case,
+// the form emails are compared in.
+func NormalizeEmail(email string) (string, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return "", fmt.Errorf("invalid email - '%s'", email)
+	}
+
+	return strings.ToLower(address.Address), nil
+}
+
+func NewEmailInvitation(session Session, inviterID uuid.UUID, email string, now time.Time) (EmailInvitation, error) {
+	if session.OwnerID != inviterID {
+		return EmailInvitation{}, ErrNotSessionOwner
+	}
+
+	if !session.IsOpen() {
+		return EmailInvitation{}, ErrSessionNotOpen
+	}
+
+	normalized, err := NormalizeEmail(email)
+	if err != nil {
+		return EmailInvitation{}, err
+	}
+
+	return EmailInvitation{
+		ID:        uuid.New(),
+		SessionID: session.ID,
+		InviterID: inviterID,
+		Email:     normalized,
+		Status:    InvitationPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(EmailInvitationTTL),
+	}, nil
+}
+
+// CheckEmailInvitationLimit fails when the inviter already sent MaxEmailInvitations
+// in the window, sentInWindow being how many they sent since EmailInvitationWindowStart.
+func CheckEmailInvitationLimit(sentInWindow int) error {
+	if sentInWindow >= MaxEmailInvitations {
+		return ErrTooManyEmailInvitations
+	}
+
+	return nil
+}
+
+// EmailInvitationWindowStart is the start of the window ending at now.
+func EmailInvitationWindowStart(now time.Time) time.Time {
+	return now.Add(-EmailInvitationWindow)
+}
+
+// Convert creates the invitation for the player who registered with the email,
+// who answers it like any other invitation.
+func (e *EmailInvitation) Convert(session Session, inviteeID uuid.UUID, now time.Time) (SessionInvitation, error) {
+	if e.Status != InvitationPending {
+		return SessionInvitation{}, ErrInvitationNotPending
+	}
+
+	if !now.Before(e.ExpiresAt) {
+		return SessionInvitation{}, ErrInvitationExpired
+	}
+
+	invitation, err := NewSessionInvitation(session, e.InviterID, inviteeID, now)
+	if err != nil {
+		return SessionInvitation{}, err
+	}
+
+	e.Status = InvitationConverted
+	e.InvitationID = uuid.NullUUID{UUID: invitation.ID, Valid: true}
+	e.RespondedAt = &now
+
+	return invitation, nil
+}
+
+// Withdraw cancels the email invitation because its session was joined or closed.
+func (e *EmailInvitation) Withdraw(now time.Time) error {
+	if e.Status != InvitationPending {
+		return ErrInvitationNotPending
+	}
+
+	e.Status = InvitationCancelled
+	e.RespondedAt = &now
+
+	return nil
+}
+
+// Expire marks the email invitation as expired once it is past its expiry.
+func (e *EmailInvitation) Expire(now time.Time) error {
+	if e.Status != InvitationPending || now.Before(e.ExpiresAt) {
+		return ErrInvitationNotPending
+	}
+
+	e.Status = InvitationExpired
+	e.RespondedAt = &now
+
+	return nil
+}
+
+// Message is the email telling the invited player about the invitation.
+func (e EmailInvitation) Message(inviterUsername string, sender string) core.MailMessage {
+	// The username ends up in a header, where line breaks would start new headers.
+	subjectUsername := strings.NewReplacer("\r", "", "\n", "").Replace(inviterUsername)
+
+	return core.MailMessage{
+		Subject: fmt.Sprintf("%s invited you to play chess", subjectUsername),
+		From:    sender,
+		To:      []string{e.Email},
+		IsHTML:  true,
+		BodyString: fmt.Sprintf(
+			"<p>%s invited you to a game of chess.</p><p>Register with this email address before %s to receive the invitation.</p>",
+			html.EscapeString(inviterUsername),
+			e.ExpiresAt.Format(time.RFC1123),
+		),
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NormalizeEmail(t *testing.T) {
+	email, err := NormalizeEmail("  Magnus <Magnus.Carlsen@Example.com> ")
+	require.NoError(t, err)
+	require.Equal(t, "magnus.carlsen@example.com", email)
+
+	_, err = NormalizeEmail("not an email")
+	require.Error(t, err)
+}
+
+func Test_EmailInvitation_Convert_Creates_Invitation(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := newTestSession()
+
+	emailInvitation, err := NewEmailInvitation(session, session.OwnerID, "player@example.com", now)
+	require.NoError(t, err)
+
+	inviteeID := uuid.New()
+
+	// Act
+	invitation, err := emailInvitation.Convert(session, inviteeID, now.Add(time.Hour))
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, InvitationConverted, emailInvitation.Status)
+	require.Equal(t, invitation.ID, emailInvitation.InvitationID.UUID)
+	require.Equal(t, session.OwnerID, invitation.InviterID)
+	require.Equal(t, inviteeID, invitation.InviteeID)
+	require.Equal(t, InvitationPending, invitation.Status)
+
+	_, err = emailInvitation.Convert(session, inviteeID, now.Add(time.Hour))
+	require.ErrorIs(t, err, ErrInvitationNotPending)
+}
+
+func Test_EmailInvitation_Convert_Fails_After_Expiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := newTestSession()
+
+	emailInvitation, err := NewEmailInvitation(session, session.OwnerID, "player@example.com", now)
+	require.NoError(t, err)
+
+	_, err = emailInvitation.Convert(session, uuid.New(), now.Add(EmailInvitationTTL))
+	require.ErrorIs(t, err, ErrInvitationExpired)
+
+	require.NoError(t, emailInvitation.Expire(now.Add(EmailInvitationTTL)))
+	require.Equal(t, InvitationExpired, emailInvitation.Status)
+}
+
+func Test_EmailInvitation_Message_Escapes_Username(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := newTestSession()
+
+	emailInvitation, err := NewEmailInvitation(session, session.OwnerID, "player@example.com", now)
+	require.NoError(t, err)
+
+	message := emailInvitation.Message("<b>evil</b>\r\nBcc: x@example.com", "chess@example.com")
+
+	require.Equal(t, []string{"player@example.com"}, message.To)
+	require.NotContains(t, message.Subject, "\n")
+	require.NotContains(t, message.BodyString, "<b>evil</b>")
+}
+
+func Test_CheckEmailInvitationLimit(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, CheckEmailInvitationLimit(MaxEmailInvitations-1))
+	require.ErrorIs(t, CheckEmailInvitationLimit(MaxEmailInvitations), ErrTooManyEmailInvitations)
+	require.Equal(t, now.Add(-EmailInvitationWindow), EmailInvitationWindowStart(now))
+}
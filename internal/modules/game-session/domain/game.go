@@ -0,0 +1,482 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrGameOver    = errors.New("game is over")
+	ErrGameNotOver = errors.New("game is not over yet")
+	ErrNotAPlayer  = errors.New("user is not a player in the game")
+	ErrNotYourTurn = errors.New("it is not the player's turn")
+	ErrFlagged     = errors.New("player ran out of time")
+
+	ErrDrawNotClaimable  = errors.New("neither threefold repetition nor the fifty-move rule applies")
+	ErrNothingToTakeBack = errors.New("there are no moves to take back")
+)
+
+type GameStatus string
+
+const (
+	GameStarted GameStatus = "started"
+	GameEnded   GameStatus = "ended"
+)
+
+// Game is a single game played in a session. The clocks are tracked
+// by the server - the remaining time is only charged on moves and
+// FlagAt is the moment the side to move runs out of time.
+type Game struct {
+	ID        uuid.UUID `db:"id"`
+	SessionID string    `db:"session_id"`
+	WhiteID   uuid.UUID `db:"white_id"`
+	BlackID   uuid.UUID `db:"black_id"`
+
+	Variant     Variant           `db:"variant"`
+	InitialFEN  string            `db:"initial_fen"`
+	FEN         string            `db:"fen"`
+	Ply         int               `db:"ply"`
+	Status      GameStatus        `db:"status"`
+	Result      chess.Result      `db:"result"`
+	Termination chess.Termination `db:"termination"`
+	Version     int               `db:"version"`
+	Rated       bool              `db:"rated"`
+
+	TimeControlBaseSeconds      int       `db:"time_control_base_seconds"`
+	TimeControlIncrementSeconds int       `db:"time_control_increment_seconds"`
+	TimeControlDelay            DelayMode `db:"time_control_delay"`
+	TimeControlDaysPerMove      int       `db:"time_control_days_per_move"`
+
+	WhiteRemainingMs int64      `db:"white_remaining_ms"`
+	BlackRemainingMs int64      `db:"black_remaining_ms"`
+	TurnStartedAt    time.Time  `db:"turn_started_at"`
+	FlagAt           *time.Time `db:"flag_at"`
+	// MoveDueAt is when the side to move of a correspondence game has to have moved.
+	MoveDueAt *time.Time `db:"move_due_at"`
+	// ClaimableAt is set once the side to move abandoned the game, the opponent
+	// can claim the game from then on. The next move clears it.
+	ClaimableAt *time.Time `db:"claimable_at"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	EndedAt   *time.Time `db:"ended_at"`
+}
+
+type GameMove struct {
+	GameID      uuid.UUID `db:"game_id"`
+	Ply         int       `db:"ply"`
+	UCI         string    `db:"uci"`
+	SAN         string    `db:"san"`
+	RemainingMs *int64    `db:"remaining_ms"`
+	PlayedAt    time.Time `db:"played_at"`
+}
+
+// StartGame starts the game between the seated players. Player1 plays white.
+func StartGame(session Session, now time.Time) (Game, error) {
+	if session.Player1ID == uuid.Nil || session.Player2ID == uuid.Nil {
+		return Game{}, fmt.Errorf("session '%s' needs two players to start a game", session.ID)
+	}
+
+	tc := session.TimeControl()
+
+	variant := session.Variant
+	if variant == "" {
+		variant = Standard
+	}
+
+	initialFEN := session.InitialFEN
+	if initialFEN == "" {
+		initialFEN = chess.StartingFEN
+	}
+
+	return Game{
+		ID:                          uuid.New(),
+		SessionID:                   session.ID,
+		WhiteID:                     session.Player1ID,
+		BlackID:                     session.Player2ID,
+		Variant:                     variant,
+		InitialFEN:                  initialFEN,
+		FEN:                         initialFEN,
+		Status:                      GameStarted,
+		Result:                      chess.NoResult,
+		Termination:                 chess.NoTermination,
+		Rated:                       session.Rated,
+		TimeControlBaseSeconds:      tc.BaseSeconds,
+		TimeControlIncrementSeconds: tc.IncrementSeconds,
+		TimeControlDelay:            tc.Delay,
+		TimeControlDaysPerMove:      tc.DaysPerMove,
+		MoveDueAt:                   moveDueAt(tc, now),
+		WhiteRemainingMs:            tc.Base().Milliseconds(),
+		BlackRemainingMs:            tc.Base().Milliseconds(),
+		TurnStartedAt:               now,
+		CreatedAt:                   now,
+	}, nil
+}
+
+func (g Game) TimeControl() TimeControl {
+	return TimeControl{
+		BaseSeconds:      g.TimeControlBaseSeconds,
+		IncrementSeconds: g.TimeControlIncrementSeconds,
+		Delay:            g.TimeControlDelay,
+		DaysPerMove:      g.TimeControlDaysPerMove,
+	}
+}
+
+func (g Game) Position() (chess.Position, error) {
+	return chess.ParseVariantFEN(g.variant(), g.FEN)
+}
+
+// variant defaults to standard for games started before there were variants.
+func (g Game) variant() Variant {
+	if g.Variant == "" {
+		return Standard
+	}
+	return g.Variant
+}
+
+// RatingCategory is the rating the game counts towards.
+func (g Game) RatingCategory() string {
+	return RatingCategory(g.variant(), g.TimeControl())
+}
+
+func (g Game) PlayerColor(playerID uuid.UUID) (chess.Color, error) {
+	switch playerID {
+	case g.WhiteID:
+		return chess.White, nil
+	case g.BlackID:
+		return chess.Black, nil
+	default:
+		return chess.White, ErrNotAPlayer
+	}
+}
+
+// OpponentID is the ID of the other player, uuid.Nil for users not playing the game.
+func (g Game) OpponentID(playerID uuid.UUID) uuid.UUID {
+	switch playerID {
+	case g.WhiteID:
+		return g.BlackID
+	case g.BlackID:
+		return g.WhiteID
+	default:
+		return uuid.Nil
+	}
+}
+
+// PlayerToMove is the ID of the player whose turn it is.
+func (g Game) PlayerToMove() (uuid.UUID, error) {
+	position, err := g.Position()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if position.Turn == chess.White {
+		return g.WhiteID, nil
+	}
+	return g.BlackID, nil
+}
+
+func (g Game) IsOver() bool {
+	return g.Status == GameEnded
+}
+
+func (g Game) Remaining(c chess.Color) time.Duration {
+	if c == chess.White {
+		return time.Duration(g.WhiteRemainingMs) * time.Millisecond
+	}
+	return time.Duration(g.BlackRemainingMs) * time.Millisecond
+}
+
+func (g *Game) setRemaining(c chess.Color, remaining time.Duration) {
+	if c == chess.White {
+		g.WhiteRemainingMs = remaining.Milliseconds()
+		return
+	}
+	g.BlackRemainingMs = remaining.Milliseconds()
+}
+
+// clockRunning reports whether the side to move is on the clock.
+// Neither player's first move is timed.
+func (g Game) clockRunning() bool {
+	return g.Status == GameStarted && g.TimeControl().IsTimed() && g.Ply >= 2
+}
+
+// Move plays the move for the player at the server time now. If the player ran out
+// of time before the move arrived, the game ends and ErrFlagged is returned.
+func (g *Game) Move(playerID uuid.UUID, move chess.Move, now time.Time) (GameMove, error) {
+	if g.IsOver() {
+		return GameMove{}, ErrGameOver
+	}
+
+	color, err := g.PlayerColor(playerID)
+	if err != nil {
+		return GameMove{}, err
+	}
+
+	position, err := g.Position()
+	if err != nil {
+		return GameMove{}, err
+	}
+
+	if position.Turn != color {
+		return GameMove{}, ErrNotYourTurn
+	}
+
+	if !position.IsLegal(move) {
+		return GameMove{}, fmt.Errorf("%w - '%s'", chess.ErrIllegalMove, move)
+	}
+
+	gameMove := GameMove{
+		GameID:   g.ID,
+		Ply:      g.Ply + 1,
+		UCI:      move.UCI(),
+		SAN:      position.SAN(move),
+		PlayedAt: now,
+	}
+
+	if g.clockRunning() {
+		remaining, flagged := g.TimeControl().Charge(g.Remaining(color), now.Sub(g.TurnStartedAt))
+		if flagged {
+			g.flag(position, now)
+			return GameMove{}, ErrFlagged
+		}
+		g.setRemaining(color, remaining)
+	}
+
+	if g.TimeControl().IsTimed() {
+		remainingMs := g.Remaining(color).Milliseconds()
+		gameMove.RemainingMs = &remainingMs
+	}
+
+	next, err := position.Play(move)
+	if err != nil {
+		return GameMove{}, err
+	}
+
+	g.FEN = next.FEN()
+	g.Ply++
+	g.TurnStartedAt = now
+	g.MoveDueAt = moveDueAt(g.TimeControl(), now)
+	g.ClaimableAt = nil
+
+	if result, termination := next.Outcome(); result != chess.NoResult {
+		g.end(result, termination, now)
+	}
+
+	g.updateFlagAt()
+
+	return gameMove, nil
+}
+
+// Resign ends the game with a win for the player's opponent.
+func (g *Game) Resign(playerID uuid.UUID, now time.Time) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	color, err := g.PlayerColor(playerID)
+	if err != nil {
+		return err
+	}
+
+	g.end(chess.Win(color.Other()), chess.Resignation, now)
+	return nil
+}
+
+// AgreeDraw ends the game in a draw once a draw offer was accepted.
+func (g *Game) AgreeDraw(now time.Time) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	g.end(chess.Draw, chess.Agreement, now)
+	return nil
+}
+
+// ClaimDraw ends the game in a draw when the current position occurred for the
+// third time or fifty moves were made without a pawn move or a capture. Either
+// player can claim. The moves are the game's moves in order.
+func (g *Game) ClaimDraw(playerID uuid.UUID, moves []GameMove, now time.Time) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	if _, err := g.PlayerColor(playerID); err != nil {
+		return err
+	}
+
+	positions, err := g.replay(moves)
+	if err != nil {
+		return err
+	}
+
+	switch current := positions[len(positions)-1]; {
+	case chess.IsThreefoldRepetition(positions):
+		g.end(chess.Draw, chess.ThreefoldRepetition, now)
+	case current.IsFiftyMoveDraw():
+		g.end(chess.Draw, chess.FiftyMoveRule, now)
+	default:
+		return ErrDrawNotClaimable
+	}
+
+	return nil
+}
+
+// TakebackPlies is the number of moves taken back for the player: their last move,
+// and the opponent's reply when the opponent already answered it.
+func (g Game) TakebackPlies(playerID uuid.UUID) (int, error) {
+	color, err := g.PlayerColor(playerID)
+	if err != nil {
+		return 0, err
+	}
+
+	position, err := g.Position()
+	if err != nil {
+		return 0, err
+	}
+
+	plies := 1
+	if position.Turn == color {
+		plies = 2
+	}
+
+	if plies > g.Ply {
+		return 0, ErrNothingToTakeBack
+	}
+
+	return plies, nil
+}
+
+// TakeBack returns the game to the position before the requester's last move.
+// The time the side to move spent is charged without an increment, then the
+// clocks restart from now. It returns the plies that remain.
+func (g *Game) TakeBack(requesterID uuid.UUID, moves []GameMove, now time.Time) (int, error) {
+	if g.IsOver() {
+		return 0, ErrGameOver
+	}
+
+	plies, err := g.TakebackPlies(requesterID)
+	if err != nil {
+		return 0, err
+	}
+
+	position, err := g.Position()
+	if err != nil {
+		return 0, err
+	}
+
+	if g.clockRunning() {
+		elapsed := now.Sub(g.TurnStartedAt)
+		g.setRemaining(position.Turn, max(g.Remaining(position.Turn)-elapsed, 0))
+	}
+
+	remaining := g.Ply - plies
+	positions, err := g.replay(moves[:remaining])
+	if err != nil {
+		return 0, err
+	}
+
+	g.FEN = positions[len(positions)-1].FEN()
+	g.Ply = remaining
+	g.TurnStartedAt = now
+	g.MoveDueAt = moveDueAt(g.TimeControl(), now)
+	g.ClaimableAt = nil
+	g.updateFlagAt()
+
+	return remaining, nil
+}
+
+// replay plays the moves from the initial position, checking that they lead
+// to the game's position when all of them are played.
+func (g Game) replay(moves []GameMove) ([]chess.Position, error) {
+	if len(moves) > g.Ply {
+		return nil, fmt.Errorf("game '%s' has %d plies, got %d moves", g.ID, g.Ply, len(moves))
+	}
+
+	ucis := make([]string, 0, len(moves))
+	for i, move := range moves {
+		if move.Ply != i+1 {
+			return nil, fmt.Errorf("game '%s' is missing ply %d", g.ID, i+1)
+		}
+		ucis = append(ucis, move.UCI)
+	}
+
+	positions, err := chess.Replay(g.variant(), g.InitialFEN, ucis)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(moves) == g.Ply && positions[len(positions)-1].FEN() != g.FEN {
+		return nil, fmt.Errorf("moves of game '%s' do not lead to its position", g.ID)
+	}
+
+	return positions, nil
+}
+
+// CheckFlag ends the game if the side to move ran out of time at now.
+func (g *Game) CheckFlag(now time.Time) (bool, error) {
+	if g.FlagAt == nil || now.Before(*g.FlagAt) {
+		return false, nil
+	}
+
+	position, err := g.Position()
+	if err != nil {
+		return false, err
+	}
+
+	g.flag(position, now)
+	return true, nil
+}
+
+// flag ends the game on time for the side to move. Running out of time is a draw
+// when the opponent has no way to deliver mate.
+func (g *Game) flag(position chess.Position, now time.Time) {
+	g.setRemaining(position.Turn, 0)
+
+	result := chess.Win(position.Turn.Other())
+	if !position.HasMatingMaterial(position.Turn.Other()) {
+		result = chess.Draw
+	}
+
+	g.end(result, chess.Timeout, now)
+}
+
+func (g *Game) end(result chess.Result, termination chess.Termination, now time.Time) {
+	g.Status = GameEnded
+	g.Result = result
+	g.Termination = termination
+	g.EndedAt = &now
+	g.FlagAt = nil
+	g.MoveDueAt = nil
+	g.ClaimableAt = nil
+}
+
+// moveDueAt is when a move started at now is due, nil for games which are not
+// played by correspondence.
+func moveDueAt(tc TimeControl, now time.Time) *time.Time {
+	if !tc.IsCorrespondence() {
+		return nil
+	}
+
+	dueAt := now.Add(tc.MoveTime())
+	return &dueAt
+}
+
+func (g *Game) updateFlagAt() {
+	if !g.clockRunning() {
+		g.FlagAt = nil
+		return
+	}
+
+	position, err := g.Position()
+	if err != nil {
+		g.FlagAt = nil
+		return
+	}
+
+	flagAt := g.TurnStartedAt.Add(g.Remaining(position.Turn))
+	g.FlagAt = &flagAt
+}
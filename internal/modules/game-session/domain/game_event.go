@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+)
+
+type GameEventType string
+
+const (
+	GameStartedEvent GameEventType = "game_started"
+	MoveMadeEvent    GameEventType = "move_made"
+	GameOverEvent    GameEventType = "game_over"
+
+	OfferMadeEvent     GameEventType = "offer_made"
+	OfferAcceptedEvent GameEventType = "offer_accepted"
+	OfferDeclinedEvent GameEventType = "offer_declined"
+	OfferExpiredEvent  GameEventType = "offer_expired"
+	TakebackEvent      GameEventType = "takeback"
+)
+
+// GameEvent is an entry in the event log of a game. Version increases by one with
+// each event, so clients can resume a stream from the last version they have seen.
+type GameEvent struct {
+	GameID    uuid.UUID     `db:"game_id"`
+	Version   int           `db:"version"`
+	Type      GameEventType `db:"type"`
+	Payload   string        `db:"payload"`
+	CreatedAt time.Time     `db:"created_at"`
+}
+
+func GameEventsTopic(gameID uuid.UUID) string {
+	return fmt.Sprintf("game:%s", gameID)
+}
+
+type Clock struct {
+	WhiteRemainingMs int64
+	BlackRemainingMs int64
+	// Running is true when the clock of the side to move is ticking since TurnStartedAt.
+	Running       bool
+	TurnStartedAt time.Time
+	// MoveDueAt is when the move of a correspondence game is due.
+	MoveDueAt *time.Time
+}
+
+type GameStartedPayload struct {
+	GameID  uuid.UUID
+	WhiteID uuid.UUID
+	BlackID uuid.UUID
+	FEN     string
+	Clock   Clock
+}
+
+type MoveMadePayload struct {
+	Ply   int
+	UCI   string
+	SAN   string
+	FEN   string
+	Clock Clock
+}
+
+type GameOverPayload struct {
+	Result      chess.Result
+	Termination chess.Termination
+	Clock       Clock
+}
+
+type OfferPayload struct {
+	OfferID   uuid.UUID
+	Type      OfferType
+	OfferedBy uuid.UUID
+}
+
+// TakebackPayload is the position the game returned to.
+type TakebackPayload struct {
+	Ply   int
+	FEN   string
+	Clock Clock
+}
+
+func (o GameOffer) Payload() OfferPayload {
+	return OfferPayload{OfferID: o.ID, Type: o.Type, OfferedBy: o.OfferedBy}
+}
+
+func (g Game) Clock() Clock {
+	return Clock{
+		WhiteRemainingMs: g.WhiteRemainingMs,
+		BlackRemainingMs: g.BlackRemainingMs,
+		Running:          g.clockRunning(),
+		TurnStartedAt:    g.TurnStartedAt,
+		MoveDueAt:        g.MoveDueAt,
+	}
+}
+
+// RecordEvent appends the event to the game's log by bumping the game version.
+func (g *Game) RecordEvent(eventType GameEventType, payload any, now time.Time) (GameEvent, error) {
+	serialized, err := json.Marshal(payload)
+	if err != nil {
+		return GameEvent{}, err
+	}
+
+	g.Version++
+
+	return GameEvent{
+		GameID:    g.ID,
+		Version:   g.Version,
+		Type:      eventType,
+		Payload:   string(serialized),
+		CreatedAt: now,
+	}, nil
+}
+
+// Message is the representation of the event sent to the clients.
+func (e GameEvent) Message() ([]byte, error) {
+	return json.Marshal(struct {
+		Type    GameEventType   `json:"type"`
+		Version int             `json:"version"`
+		Payload json.RawMessage `json:"payload"`
+	}{e.Type, e.Version, json.RawMessage(e.Payload)})
+}
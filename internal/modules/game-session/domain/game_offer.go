@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxOffersPerGame caps the offers of each type a player can make in a game.
+const MaxOffersPerGame = 3
+
+var (
+	ErrOfferPending         = errors.New("an offer is already pending")
+	ErrOfferTooSoon         = errors.New("an offer was already made in this position")
+	ErrOfferLimitReached    = errors.New("no more offers can be made in this game")
+	ErrOfferNotPending      = errors.New("offer is not pending")
+	ErrCannotAnswerOwnOffer = errors.New("players cannot answer their own offers")
+)
+
+type OfferType string
+
+const (
+	DrawOffer     OfferType = "draw"
+	TakebackOffer OfferType = "takeback"
+)
+
+type OfferStatus string
+
+const (
+	OfferPending  OfferStatus = "pending"
+	OfferAccepted OfferStatus = "accepted"
+	OfferDeclined OfferStatus = "declined"
+	// OfferExpired offers were not answered before the next move or the end of the game.
+	OfferExpired OfferStatus = "expired"
+)
+
+// GameOffer is a draw offer or a takeback request one player makes to the other
+// during a game. Offers are only valid in the position they were made in.
+type GameOffer struct {
+	ID          uuid.UUID   `db:"id"`
+	GameID      uuid.UUID   `db:"game_id"`
+	Type        OfferType   `db:"type"`
+	OfferedBy   uuid.UUID   `db:"offered_by"`
+	Ply         int         `db:"ply"`
+	Status      OfferStatus `db:"status"`
+	CreatedAt   time.Time   `db:"created_at"`
+	RespondedAt *time.Time  `db:"responded_at"`
+}
+
+// NewGameOffer makes the player's offer in the game's current position. The offers
+// of the type made so far in the game keep players from spamming their opponent:
+// there is a single pending offer at a time, a player makes at most one offer per
+// position and at most MaxOffersPerGame in the game.
+func NewGameOffer(
+	game Game,
+	offerType OfferType,
+	playerID uuid.UUID,
+	previous []GameOffer,
+	now time.Time,
+) (GameOffer, error) {
+	if game.IsOver() {
+		return GameOffer{}, ErrGameOver
+	}
+
+	if _, err := game.PlayerColor(playerID); err != nil {
+		return GameOffer{}, err
+	}
+
+	if offerType == TakebackOffer {
+		if _, err := game.TakebackPlies(playerID); err != nil {
+			return GameOffer{}, err
+		}
+	}
+
+	made := 0
+	for _, offer := range previous {
+		if offer.Type != offerType {
+			continue
+		}
+
+		if offer.Status == OfferPending {
+			return GameOffer{}, ErrOfferPending
+		}
+
+		if offer.OfferedBy != playerID {
+			continue
+		}
+
+		if offer.Ply == game.Ply {
+			return GameOffer{}, ErrOfferTooSoon
+		}
+		made++
+	}
+
+	if made >= MaxOffersPerGame {
+		return GameOffer{}, ErrOfferLimitReached
+	}
+
+	return GameOffer{
+		ID:        uuid.New(),
+		GameID:    game.ID,
+		Type:      offerType,
+		OfferedBy: playerID,
+		Ply:       game.Ply,
+		Status:    OfferPending,
+		CreatedAt: now,
+	}, nil
+}
+
+// Accept accepts the offer for the opponent of the player who made it.
+func (o *GameOffer) Accept(game Game, playerID uuid.UUID, now time.Time) error {
+	return o.answer(game, playerID, OfferAccepted, now)
+}
+
+// Decline declines the offer for the opponent of the player who made it.
+func (o *GameOffer) Decline(game Game, playerID uuid.UUID, now time.Time) error {
+	return o.answer(game, playerID, OfferDeclined, now)
+}
+
+// Expire expires the pending offer once a move is made or the game ends.
+func (o *GameOffer) Expire(now time.Time) error {
+	if o.Status != OfferPending {
+		return ErrOfferNotPending
+	}
+
+	o.Status = OfferExpired
+	o.RespondedAt = &now
+	return nil
+}
+
+func (o *GameOffer) answer(game Game, playerID uuid.UUID, status OfferStatus, now time.Time) error {
+	if o.Status != OfferPending {
+		return ErrOfferNotPending
+	}
+
+	if game.IsOver() {
+		return ErrGameOver
+	}
+
+	if _, err := game.PlayerColor(playerID); err != nil {
+		return err
+	}
+
+	if playerID == o.OfferedBy {
+		return ErrCannotAnswerOwnOffer
+	}
+
+	// Offers are expired on every move, this only guards against a missed expiry.
+	if o.Ply != game.Ply {
+		return ErrOfferNotPending
+	}
+
+	o.Status = status
+	o.RespondedAt = &now
+	return nil
+}
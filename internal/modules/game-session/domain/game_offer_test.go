@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewGameOffer_Allows_One_Offer_Per_Position(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+
+	offer, err := NewGameOffer(game, DrawOffer, game.WhiteID, nil, now)
+	require.NoError(t, err)
+
+	// Act & Assert
+	_, err = NewGameOffer(game, DrawOffer, game.BlackID, []GameOffer{offer}, now)
+	require.ErrorIs(t, err, ErrOfferPending)
+
+	require.NoError(t, offer.Decline(game, game.BlackID, now))
+
+	_, err = NewGameOffer(game, DrawOffer, game.WhiteID, []GameOffer{offer}, now)
+	require.ErrorIs(t, err, ErrOfferTooSoon)
+
+	playedMoves(t, &game, []string{"e2e4"}, now)
+
+	_, err = NewGameOffer(game, DrawOffer, game.WhiteID, []GameOffer{offer}, now)
+	require.NoError(t, err)
+}
+
+func Test_NewGameOffer_Limits_Offers_Per_Game(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+
+	var previous []GameOffer
+	for _, uci := range []string{"e2e4", "e7e5", "g1f3", "b8c6", "f1c4", "g8f6"} {
+		offer, err := NewGameOffer(game, DrawOffer, game.WhiteID, previous, now)
+		if len(previous) == MaxOffersPerGame {
+			// Act & Assert
+			require.ErrorIs(t, err, ErrOfferLimitReached)
+			return
+		}
+		require.NoError(t, err)
+		require.NoError(t, offer.Expire(now))
+
+		previous = append(previous, offer)
+		playedMoves(t, &game, []string{uci}, now)
+	}
+
+	t.Fatal("offer limit was never reached")
+}
+
+func Test_GameOffer_Answer(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+	playedMoves(t, &game, []string{"e2e4"}, now)
+
+	offer, err := NewGameOffer(game, TakebackOffer, game.WhiteID, nil, now)
+	require.NoError(t, err)
+
+	// Act & Assert
+	require.ErrorIs(t, offer.Accept(game, game.WhiteID, now), ErrCannotAnswerOwnOffer)
+	require.NoError(t, offer.Accept(game, game.BlackID, now))
+	require.Equal(t, OfferAccepted, offer.Status)
+	require.ErrorIs(t, offer.Decline(game, game.BlackID, now), ErrOfferNotPending)
+}
+
+func Test_NewGameOffer_Rejects_Takeback_Without_Own_Move(t *testing.T) {
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+	playedMoves(t, &game, []string{"e2e4"}, now)
+
+	_, err := NewGameOffer(game, TakebackOffer, game.BlackID, nil, now)
+	require.ErrorIs(t, err, ErrNothingToTakeBack)
+}
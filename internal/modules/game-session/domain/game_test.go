@@ -0,0 +1,309 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func startTestGame(t *testing.T, tc TimeControl, now time.Time) Game {
+	session := Session{
+		ID:                          uuid.NewString(),
+		Player1ID:                   uuid.New(),
+		Player2ID:                   uuid.New(),
+		TimeControlBaseSeconds:      tc.BaseSeconds,
+		TimeControlIncrementSeconds: tc.IncrementSeconds,
+		TimeControlDelay:            tc.Delay,
+		TimeControlDaysPerMove:      tc.DaysPerMove,
+	}
+
+	game, err := StartGame(session, now)
+	require.NoError(t, err)
+
+	return game
+}
+
+func play(t *testing.T, g *Game, uci string, now time.Time) {
+	move, err := chess.ParseUCI(uci)
+	require.NoError(t, err)
+
+	position, err := g.Position()
+	require.NoError(t, err)
+
+	playerID := g.WhiteID
+	if position.Turn == chess.Black {
+		playerID = g.BlackID
+	}
+
+	_, err = g.Move(playerID, move, now)
+	require.NoError(t, err)
+}
+
+func Test_TimeControl_Charge(t *testing.T) {
+	tests := []struct {
+		name      string
+		delay     DelayMode
+		elapsed   time.Duration
+		remaining time.Duration
+	}{
+		{"fischer adds full increment", FischerDelay, 1 * time.Second, 61 * time.Second},
+		{"bronstein refunds time spent", BronsteinDelay, 1 * time.Second, 60 * time.Second},
+		{"bronstein refunds at most the increment", BronsteinDelay, 5 * time.Second, 57 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tc := TimeControl{BaseSeconds: 60, IncrementSeconds: 2, Delay: tt.delay}
+
+			// Act
+			remaining, flagged := tc.Charge(60*time.Second, tt.elapsed)
+
+			// Assert
+			require.False(t, flagged)
+			require.Equal(t, tt.remaining, remaining)
+		})
+	}
+}
+
+func Test_Game_Move_Does_Not_Charge_First_Moves(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{BaseSeconds: 60, Delay: FischerDelay}, now)
+
+	// Act
+	play(t, &game, "e2e4", now.Add(10*time.Second))
+	play(t, &game, "e7e5", now.Add(20*time.Second))
+
+	// Assert
+	require.Equal(t, time.Minute, game.Remaining(chess.White))
+	require.Equal(t, time.Minute, game.Remaining(chess.Black))
+	require.NotNil(t, game.FlagAt)
+	require.Equal(t, now.Add(20*time.Second+time.Minute), *game.FlagAt)
+}
+
+func Test_Game_Move_Ends_Game_When_Player_Flagged(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{BaseSeconds: 60, Delay: FischerDelay}, now)
+
+	play(t, &game, "e2e4", now)
+	play(t, &game, "e7e5", now)
+
+	move, err := chess.ParseUCI("g1f3")
+	require.NoError(t, err)
+
+	// Act
+	_, err = game.Move(game.WhiteID, move, now.Add(2*time.Minute))
+
+	// Assert
+	require.ErrorIs(t, err, ErrFlagged)
+	require.Equal(t, GameEnded, game.Status)
+	require.Equal(t, chess.BlackWins, game.Result)
+	require.Equal(t, chess.Timeout, game.Termination)
+}
+
+func Test_Game_CheckFlag_Draws_When_Opponent_Cannot_Mate(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{BaseSeconds: 60, Delay: FischerDelay}, now)
+	game.FEN = "8/8/4k3/8/8/8/3NK3/8 b - - 0 40"
+	game.Ply = 80
+	game.updateFlagAt()
+
+	// Act
+	flagged, err := game.CheckFlag(now.Add(2 * time.Minute))
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, flagged)
+	require.Equal(t, chess.Draw, game.Result)
+	require.Equal(t, chess.Timeout, game.Termination)
+}
+
+func playedMoves(t *testing.T, g *Game, ucis []string, now time.Time) []GameMove {
+	moves := make([]GameMove, 0, len(ucis))
+	for _, uci := range ucis {
+		move, err := chess.ParseUCI(uci)
+		require.NoError(t, err)
+
+		position, err := g.Position()
+		require.NoError(t, err)
+
+		playerID := g.WhiteID
+		if position.Turn == chess.Black {
+			playerID = g.BlackID
+		}
+
+		gameMove, err := g.Move(playerID, move, now)
+		require.NoError(t, err)
+
+		moves = append(moves, gameMove)
+	}
+
+	return moves
+}
+
+func Test_Game_Resign_Wins_For_Opponent(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+
+	// Act
+	err := game.Resign(game.BlackID, now)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, chess.WhiteWins, game.Result)
+	require.Equal(t, chess.Resignation, game.Termination)
+	require.ErrorIs(t, game.Resign(game.WhiteID, now), ErrGameOver)
+}
+
+func Test_Game_ClaimDraw_On_Threefold_Repetition(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+
+	moves := playedMoves(t, &game, []string{"g1f3", "g8f6", "f3g1", "f6g8", "g1f3", "g8f6", "f3g1"}, now)
+	require.ErrorIs(t, game.ClaimDraw(game.WhiteID, moves, now), ErrDrawNotClaimable)
+
+	moves = append(moves, playedMoves(t, &game, []string{"f6g8"}, now)...)
+
+	// Act
+	err := game.ClaimDraw(game.WhiteID, moves, now)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, chess.Draw, game.Result)
+	require.Equal(t, chess.ThreefoldRepetition, game.Termination)
+}
+
+func Test_Game_ClaimDraw_On_Fifty_Move_Rule(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+	game.InitialFEN = "4k3/8/8/8/8/8/8/4K2R w K - 99 80"
+	game.FEN = game.InitialFEN
+
+	moves := playedMoves(t, &game, []string{"h1h2"}, now)
+
+	// Act
+	err := game.ClaimDraw(game.BlackID, moves, now)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, chess.FiftyMoveRule, game.Termination)
+}
+
+func Test_Game_TakeBack(t *testing.T) {
+	tests := []struct {
+		name      string
+		requester func(Game) uuid.UUID
+		ply       int
+		fen       string
+	}{
+		{
+			name:      "requester's last move",
+			requester: func(g Game) uuid.UUID { return g.WhiteID },
+			ply:       2,
+			fen:       "rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 0 2",
+		},
+		{
+			name:      "requester's last move and the reply",
+			requester: func(g Game) uuid.UUID { return g.BlackID },
+			ply:       1,
+			fen:       "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			now := time.Now().UTC()
+			game := startTestGame(t, TimeControl{}, now)
+			moves := playedMoves(t, &game, []string{"e2e4", "e7e5", "g1f3"}, now)
+
+			// Act
+			ply, err := game.TakeBack(tt.requester(game), moves, now)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, tt.ply, ply)
+			require.Equal(t, tt.ply, game.Ply)
+			require.Equal(t, tt.fen, game.FEN)
+		})
+	}
+}
+
+func Test_Game_TakeBack_Charges_Side_To_Move(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{BaseSeconds: 60, Delay: FischerDelay}, now)
+	moves := playedMoves(t, &game, []string{"e2e4", "e7e5", "g1f3"}, now)
+
+	// Act
+	_, err := game.TakeBack(game.WhiteID, moves, now.Add(10*time.Second))
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, 50*time.Second, game.Remaining(chess.Black))
+	require.Equal(t, time.Minute, game.Remaining(chess.White))
+	// White is back to move after Nf3 was taken back.
+	require.Equal(t, now.Add(10*time.Second+time.Minute), *game.FlagAt)
+}
+
+func Test_Game_TakebackPlies_Requires_Moves(t *testing.T) {
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+
+	_, err := game.TakebackPlies(game.WhiteID)
+	require.ErrorIs(t, err, ErrNothingToTakeBack)
+
+	playedMoves(t, &game, []string{"e2e4"}, now)
+
+	_, err = game.TakebackPlies(game.BlackID)
+	require.ErrorIs(t, err, ErrNothingToTakeBack)
+
+	plies, err := game.TakebackPlies(game.WhiteID)
+	require.NoError(t, err)
+	require.Equal(t, 1, plies)
+}
+
+func Test_StartGame_From_Custom_Position(t *testing.T) {
+	// Arrange
+	session := Session{
+		ID:         uuid.NewString(),
+		Player1ID:  uuid.New(),
+		Player2ID:  uuid.New(),
+		InitialFEN: "4k3/8/8/8/8/8/4P3/4K3 b - - 0 40",
+	}
+
+	// Act
+	game, err := StartGame(session, time.Now().UTC())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, session.InitialFEN, game.InitialFEN)
+	require.Equal(t, session.InitialFEN, game.FEN)
+
+	play(t, &game, "e8d7", time.Now().UTC())
+	require.Equal(t, 1, game.Ply)
+}
+
+func Test_ValidateInitialFEN(t *testing.T) {
+	require.NoError(t, ValidateInitialFEN(Standard, chess.StartingFEN))
+
+	var validationErr core.ValidationError
+	require.ErrorAs(t, ValidateInitialFEN(Standard, "4k3/8/8/8/8/8/8/8 x - - 0 1"), &validationErr)
+	require.Len(t, validationErr.ValidationErrors, 1)
+
+	require.ErrorAs(t, ValidateInitialFEN(Standard, "kk6/8/8/8/8/8/8/KP6 w - - 0 1"), &validationErr)
+	require.Len(t, validationErr.ValidationErrors, 2)
+
+	require.Error(t, ValidateInitialFEN(Standard, "7k/5Q2/6K1/8/8/8/8/8 b - - 0 1"))
+}
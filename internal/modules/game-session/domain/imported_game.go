@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxImportedPGNBytes caps the size of a single import.
+	MaxImportedPGNBytes = 1 << 20
+	// MaxImportedGames caps the games of a single import.
+	MaxImportedGames = 100
+)
+
+// ImportedGame is a game played elsewhere, uploaded by its owner in PGN for analysis.
+// Its PGN is stored as exported after it was checked against the rules, keeping the
+// comments and variations of the upload.
+type ImportedGame struct {
+	ID         uuid.UUID    `db:"id"`
+	OwnerID    uuid.UUID    `db:"owner_id"`
+	Event      string       `db:"event"`
+	White      string       `db:"white"`
+	Black      string       `db:"black"`
+	Result     chess.Result `db:"result"`
+	Variant    Variant      `db:"variant"`
+	InitialFEN string       `db:"initial_fen"`
+	// Moves are the UCI moves of the main line, separated by spaces.
+	Moves     string    `db:"moves"`
+	PGN       string    `db:"pgn"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// NewImportedGame checks the game against the rules and imports it for the owner.
+func NewImportedGame(ownerID uuid.UUID, game chess.PGNGame, now time.Time) (ImportedGame, error) {
+	if err := game.Resolve(); err != nil {
+		return ImportedGame{}, err
+	}
+
+	// Resolve already rejected unknown variants.
+	variant, _ := game.Variant()
+
+	if len(game.Moves) == 0 {
+		return ImportedGame{}, fmt.Errorf("game has no moves")
+	}
+
+	return ImportedGame{
+		ID:         uuid.New(),
+		OwnerID:    ownerID,
+		Event:      game.Tag("Event"),
+		White:      game.Tag("White"),
+		Black:      game.Tag("Black"),
+		Result:     game.Result,
+		Variant:    variant,
+		InitialFEN: game.InitialFEN(),
+		Moves:      strings.Join(game.MainLine(), " "),
+		PGN:        game.String(),
+		CreatedAt:  now,
+	}, nil
+}
+
+// ImportPGN imports every game of the PGN, failing on the first game that
+// does not follow the rules.
+func ImportPGN(ownerID uuid.UUID, pgn string, now time.Time) ([]ImportedGame, error) {
+	if len(pgn) > MaxImportedPGNBytes {
+		return nil, fmt.Errorf("pgn is larger than %d bytes", MaxImportedPGNBytes)
+	}
+
+	games, err := chess.ParsePGN(pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(games) == 0:
+		return nil, fmt.Errorf("pgn has no games")
+	case len(games) > MaxImportedGames:
+		return nil, fmt.Errorf("pgn has more than %d games", MaxImportedGames)
+	}
+
+	imported := make([]ImportedGame, 0, len(games))
+	for i, game := range games {
+		importedGame, err := NewImportedGame(ownerID, game, now)
+		if err != nil {
+			return nil, fmt.Errorf("game %d: %w", i+1, err)
+		}
+
+		imported = append(imported, importedGame)
+	}
+
+	return imported, nil
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ImportPGN_Imports_Every_Game(t *testing.T) {
+	// Arrange
+	ownerID := uuid.New()
+	now := time.Now().UTC()
+	pgn := `[Event "Club night"]
+[White "Morphy"]
+[Black "Duke"]
+
+1. e4 e5 2. Nf3 d6 3. d4 Bg4 {Philidor} (3... exd4) 4. dxe5 Bxf3 5. Qxf3 dxe5 *
+
+[Event "Blitz"]
+
+1. f3 e5 2. g4 Qh4# 0-1`
+
+	// Act
+	games, err := ImportPGN(ownerID, pgn, now)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, games, 2)
+
+	require.Equal(t, ownerID, games[0].OwnerID)
+	require.Equal(t, "Club night", games[0].Event)
+	require.Equal(t, "Morphy", games[0].White)
+	require.Equal(t, chess.NoResult, games[0].Result)
+	require.Equal(t, chess.StartingFEN, games[0].InitialFEN)
+	require.Equal(t, "e2e4 e7e5 g1f3 d7d6 d2d4 c8g4 d4e5 g4f3 d1f3 d6e5", games[0].Moves)
+	require.Contains(t, games[0].PGN, "3. d4 Bg4 {Philidor} (3... exd4) 4. dxe5")
+
+	require.Equal(t, chess.BlackWins, games[1].Result)
+}
+
+func Test_ImportPGN_Rejects_Invalid_Games(t *testing.T) {
+	tests := []struct {
+		name string
+		pgn  string
+	}{
+		{"no games", "  "},
+		{"syntax error", "1. e4 (e5"},
+		{"illegal move", "1. e4 e5 2. Qh5 Ke6 3. Qxe5+ Kd5 4. Ke3 *\n\n1. e5 *"},
+		{"no moves", "[Event \"Empty\"]\n\n*"},
+		{"too many games", strings.Repeat("1. e4 *\n", MaxImportedGames+1)},
+		{"too large", strings.Repeat(" ", MaxImportedPGNBytes) + "1. e4 *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportPGN(uuid.New(), tt.pgn, time.Now().UTC())
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvitationTTL is how long an invitation can be answered before it expires.
+const InvitationTTL = 24 * time.Hour
+
+var (
+	ErrNotSessionOwner      = errors.New("only the session owner can invite players")
+	ErrSessionNotOpen       = errors.New("session is not open for players")
+	ErrCannotInviteSelf     = errors.New("cannot invite yourself")
+	ErrInvitationNotPending = errors.New("invitation is no longer pending")
+	ErrInvitationExpired    = errors.New("invitation has expired")
+	ErrNotInvitee           = errors.New("user is not the invitee")
+	ErrNotInviter           = errors.New("user is not the inviter")
+)
+
+type InvitationStatus string
+
+const (
+	InvitationPending   InvitationStatus = "pending"
+	InvitationAccepted  InvitationStatus = "accepted"
+	InvitationDeclined  InvitationStatus = "declined"
+	InvitationCancelled InvitationStatus = "cancelled"
+	InvitationExpired   InvitationStatus = "expired"
+
+	// InvitationConverted is the final status of an email invitation
+	// which became an invitation of the registered player.
+	InvitationConverted InvitationStatus = "converted"
+)
+
+// SessionInvitation invites a player to take the second seat of a session.
+// Only pending invitations can change their status, every other status is final.
+type SessionInvitation struct {
+	ID        uuid.UUID `db:"id"`
+	SessionID string    `db:"session_id"`
+
+	InviterID uuid.UUID `db:"inviter_id"`
+	InviteeID uuid.UUID `db:"invitee_id"`
+
+	Status      InvitationStatus `db:"status"`
+	CreatedAt   time.Time        `db:"created_at"`
+	ExpiresAt   time.Time        `db:"expires_at"`
+	RespondedAt *time.Time       `db:"responded_at"`
+}
+
+func NewSessionInvitation(
+	session Session,
+	inviterID uuid.UUID,
+	inviteeID uuid.UUID,
+	now time.Time,
+) (SessionInvitation, error) {
+	if session.OwnerID != inviterID {
+		return SessionInvitation{}, ErrNotSessionOwner
+	}
+
+	if inviterID == inviteeID {
+		return SessionInvitation{}, ErrCannotInviteSelf
+	}
+
+	if !session.IsOpen() {
+		return SessionInvitation{}, ErrSessionNotOpen
+	}
+
+	return SessionInvitation{
+		ID:        uuid.New(),
+		SessionID: session.ID,
+		InviterID: inviterID,
+		InviteeID: inviteeID,
+		Status:    InvitationPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(InvitationTTL),
+	}, nil
+}
+
+// IsExpired reports whether the pending invitation can no longer be answered.
+func (i SessionInvitation) IsExpired(now time.Time) bool {
+	return i.Status == InvitationPending && !now.Before(i.ExpiresAt)
+}
+
+// Accept seats the invitee in the session, which has to be still open.
+func (i *SessionInvitation) Accept(userID uuid.UUID, session *Session, now time.Time) error {
+	if userID != i.InviteeID {
+		return ErrNotInvitee
+	}
+
+	if err := i.checkPending(now); err != nil {
+		return err
+	}
+
+	if session.ID != i.SessionID || !session.IsOpen() {
+		return ErrSessionNotOpen
+	}
+
+	session.Player2ID = i.InviteeID
+
+	return i.respond(InvitationAccepted, now)
+}
+
+func (i *SessionInvitation) Decline(userID uuid.UUID, now time.Time) error {
+	if userID != i.InviteeID {
+		return ErrNotInvitee
+	}
+
+	return i.respond(InvitationDeclined, now)
+}
+
+func (i *SessionInvitation) Cancel(userID uuid.UUID, now time.Time) error {
+	if userID != i.InviterID {
+		return ErrNotInviter
+	}
+
+	return i.respond(InvitationCancelled, now)
+}
+
+// Withdraw cancels the invitation because its session was joined or closed.
+func (i *SessionInvitation) Withdraw(now time.Time) error {
+	if i.Status != InvitationPending {
+		return ErrInvitationNotPending
+	}
+
+	i.Status = InvitationCancelled
+	i.RespondedAt = &now
+
+	return nil
+}
+
+// Expire marks the invitation as expired once it is past its expiry.
+func (i *SessionInvitation) Expire(now time.Time) error {
+	if !i.IsExpired(now) {
+		return ErrInvitationNotPending
+	}
+
+	i.Status = InvitationExpired
+	i.RespondedAt = &now
+
+	return nil
+}
+
+func (i SessionInvitation) checkPending(now time.Time) error {
+	if i.Status != InvitationPending {
+		return ErrInvitationNotPending
+	}
+
+	if i.IsExpired(now) {
+		return ErrInvitationExpired
+	}
+
+	return nil
+}
+
+func (i *SessionInvitation) respond(status InvitationStatus, now time.Time) error {
+	if err := i.checkPending(now); err != nil {
+		return err
+	}
+
+	i.Status = status
+	i.RespondedAt = &now
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSession() Session {
+	ownerID := uuid.New()
+
+	return Session{ID: uuid.NewString(), OwnerID: ownerID, Player1ID: ownerID, Name: "test"}
+}
+
+func Test_NewSessionInvitation_Requires_Owner_And_Open_Session(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := newTestSession()
+
+	_, err := NewSessionInvitation(session, uuid.New(), uuid.New(), now)
+	require.ErrorIs(t, err, ErrNotSessionOwner)
+
+	_, err = NewSessionInvitation(session, session.OwnerID, session.OwnerID, now)
+	require.ErrorIs(t, err, ErrCannotInviteSelf)
+
+	full := session
+	full.Player2ID = uuid.New()
+	_, err = NewSessionInvitation(full, session.OwnerID, uuid.New(), now)
+	require.ErrorIs(t, err, ErrSessionNotOpen)
+
+	closed := session
+	closed.Closed = true
+	_, err = NewSessionInvitation(closed, session.OwnerID, uuid.New(), now)
+	require.ErrorIs(t, err, ErrSessionNotOpen)
+
+	invitation, err := NewSessionInvitation(session, session.OwnerID, uuid.New(), now)
+	require.NoError(t, err)
+	require.Equal(t, InvitationPending, invitation.Status)
+	require.Equal(t, now.Add(InvitationTTL), invitation.ExpiresAt)
+}
+
+func Test_SessionInvitation_Accept_Seats_Invitee(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := newTestSession()
+	invitation, err := NewSessionInvitation(session, session.OwnerID, uuid.New(), now)
+	require.NoError(t, err)
+
+	// Act
+	require.ErrorIs(t, invitation.Accept(session.OwnerID, &session, now), ErrNotInvitee)
+	err = invitation.Accept(invitation.InviteeID, &session, now.Add(time.Minute))
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, InvitationAccepted, invitation.Status)
+	require.Equal(t, invitation.InviteeID, session.Player2ID)
+	require.Equal(t, now.Add(time.Minute), *invitation.RespondedAt)
+
+	require.ErrorIs(t, invitation.Decline(invitation.InviteeID, now), ErrInvitationNotPending)
+}
+
+func Test_SessionInvitation_Accept_Fails_When_Session_Was_Joined(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := newTestSession()
+	invitation, err := NewSessionInvitation(session, session.OwnerID, uuid.New(), now)
+	require.NoError(t, err)
+
+	session.Player2ID = uuid.New()
+
+	require.ErrorIs(t, invitation.Accept(invitation.InviteeID, &session, now), ErrSessionNotOpen)
+	require.Equal(t, InvitationPending, invitation.Status)
+}
+
+func Test_SessionInvitation_Decline_And_Cancel_Check_User(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := newTestSession()
+
+	declined, err := NewSessionInvitation(session, session.OwnerID, uuid.New(), now)
+	require.NoError(t, err)
+	require.ErrorIs(t, declined.Decline(session.OwnerID, now), ErrNotInvitee)
+	require.NoError(t, declined.Decline(declined.InviteeID, now))
+	require.Equal(t, InvitationDeclined, declined.Status)
+
+	cancelled, err := NewSessionInvitation(session, session.OwnerID, uuid.New(), now)
+	require.NoError(t, err)
+	require.ErrorIs(t, cancelled.Cancel(cancelled.InviteeID, now), ErrNotInviter)
+	require.NoError(t, cancelled.Cancel(session.OwnerID, now))
+	require.Equal(t, InvitationCancelled, cancelled.Status)
+}
+
+func Test_SessionInvitation_Expires_After_TTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := newTestSession()
+	invitation, err := NewSessionInvitation(session, session.OwnerID, uuid.New(), now)
+	require.NoError(t, err)
+
+	expiry := now.Add(InvitationTTL)
+
+	require.ErrorIs(t, invitation.Expire(expiry.Add(-time.Second)), ErrInvitationNotPending)
+	require.ErrorIs(t, invitation.Accept(invitation.InviteeID, &session, expiry), ErrInvitationExpired)
+
+	require.NoError(t, invitation.Expire(expiry))
+	require.Equal(t, InvitationExpired, invitation.Status)
+	require.False(t, invitation.IsExpired(expiry))
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultInviteLinkTTL = 24 * time.Hour
+	MaxInviteLinkTTL     = 7 * 24 * time.Hour
+	MaxInviteLinkUses    = 100
+
+	// JoinCodeLength is the length of the codes players type in to join a session.
+	JoinCodeLength = 8
+	// joinCodeAlphabet leaves out the characters which are easily mistaken for one another.
+	joinCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+var (
+	ErrInviteLinkExpired = errors.New("invite link has expired")
+	ErrInviteLinkUsedUp  = errors.New("invite link has no uses left")
+)
+
+// InviteLink lets anyone with its code join a session, each player once,
+// until it expires or runs out of uses.
+type InviteLink struct {
+	ID        uuid.UUID `db:"id"`
+	SessionID string    `db:"session_id"`
+	CreatorID uuid.UUID `db:"creator_id"`
+	Code      string    `db:"code"`
+
+	MaxUses int `db:"max_uses"`
+	Uses    int `db:"uses"`
+
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func NewInviteLink(
+	session Session,
+	creatorID uuid.UUID,
+	maxUses int,
+	ttl time.Duration,
+	now time.Time,
+) (InviteLink, error) {
+	if session.OwnerID != creatorID {
+		return InviteLink{}, ErrNotSessionOwner
+	}
+
+	if !session.IsOpen() {
+		return InviteLink{}, ErrSessionNotOpen
+	}
+
+	if maxUses < 1 || maxUses > MaxInviteLinkUses {
+		return InviteLink{}, fmt.Errorf("invalid MaxUses - '%d'", maxUses)
+	}
+
+	if ttl <= 0 || ttl > MaxInviteLinkTTL {
+		return InviteLink{}, fmt.Errorf("invalid TTL - '%s'", ttl)
+	}
+
+	code, err := NewJoinCode()
+	if err != nil {
+		return InviteLink{}, err
+	}
+
+	return InviteLink{
+		ID:        uuid.New(),
+		SessionID: session.ID,
+		CreatorID: creatorID,
+		Code:      code,
+		MaxUses:   maxUses,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+// NewJoinCode generates a random join code of JoinCodeLength characters.
+func NewJoinCode() (string, error) {
+	b := make([]byte, JoinCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	// The alphabet has 32 characters, so taking the remainder keeps the characters uniform.
+	for i := range b {
+		b[i] = joinCodeAlphabet[int(b[i])%len(joinCodeAlphabet)]
+	}
+
+	return string(b), nil
+}
+
+// Redeem seats the player in the session of the link and uses the link up once.
+func (l *InviteLink) Redeem(userID uuid.UUID, session *Session, now time.Time) error {
+	if !now.Before(l.ExpiresAt) {
+		return ErrInviteLinkExpired
+	}
+
+	if l.Uses >= l.MaxUses {
+		return ErrInviteLinkUsedUp
+	}
+
+	if session.ID != l.SessionID || !session.IsOpen() {
+		return ErrSessionNotOpen
+	}
+
+	if userID == session.OwnerID {
+		return ErrCannotInviteSelf
+	}
+
+	session.Player2ID = userID
+	l.Uses++
+
+	return nil
+}
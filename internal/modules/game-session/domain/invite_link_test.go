@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewJoinCode_Uses_Alphabet(t *testing.T) {
+	for range 100 {
+		code, err := NewJoinCode()
+		require.NoError(t, err)
+		require.Len(t, code, JoinCodeLength)
+
+		for _, c := range code {
+			require.True(t, strings.ContainsRune(joinCodeAlphabet, c), "unexpected character '%c'", c)
+		}
+	}
+}
+
+func Test_NewInviteLink_Validates_Limits(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := newTestSession()
+
+	_, err := NewInviteLink(session, uuid.New(), 1, time.Hour, now)
+	require.ErrorIs(t, err, ErrNotSessionOwner)
+
+	_, err = NewInviteLink(session, session.OwnerID, 0, time.Hour, now)
+	require.Error(t, err)
+
+	_, err = NewInviteLink(session, session.OwnerID, 1, MaxInviteLinkTTL+time.Second, now)
+	require.Error(t, err)
+
+	link, err := NewInviteLink(session, session.OwnerID, 2, time.Hour, now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(time.Hour), link.ExpiresAt)
+}
+
+func Test_InviteLink_Redeem_Seats_Player_Until_Used_Up_Or_Expired(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := newTestSession()
+
+	link, err := NewInviteLink(session, session.OwnerID, 1, time.Hour, now)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, link.Redeem(session.OwnerID, &session, now), ErrCannotInviteSelf)
+	require.ErrorIs(t, link.Redeem(uuid.New(), &session, now.Add(time.Hour)), ErrInviteLinkExpired)
+
+	playerID := uuid.New()
+	require.NoError(t, link.Redeem(playerID, &session, now))
+	require.Equal(t, playerID, session.Player2ID)
+	require.Equal(t, 1, link.Uses)
+
+	reopened := newTestSession()
+	link.SessionID = reopened.ID
+	require.ErrorIs(t, link.Redeem(uuid.New(), &reopened, now), ErrInviteLinkUsedUp)
+}
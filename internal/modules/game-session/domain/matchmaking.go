@@ -0,0 +1,229 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxRatingRange = 1000
+
+	// The rating range of a waiting ticket grows by RatingRangeWidening for every
+	// RatingRangeWideningInterval spent in the queue, up to MaxRatingRange.
+	RatingRangeWidening         = 50
+	RatingRangeWideningInterval = 10 * time.Second
+)
+
+var ErrTicketNotWaiting = errors.New("ticket is not waiting for a match")
+
+type TicketStatus string
+
+const (
+	TicketWaiting TicketStatus = "waiting"
+	TicketMatched TicketStatus = "matched"
+)
+
+// MatchmakingTicket is a player's place in the matchmaking queue.
+// A player has at most one ticket.
+type MatchmakingTicket struct {
+	ID          uuid.UUID `db:"id"`
+	PlayerID    uuid.UUID `db:"player_id"`
+	Rating      int       `db:"rating"`
+	RatingRange int       `db:"rating_range"`
+	Rated       bool      `db:"rated"`
+
+	TimeControlBaseSeconds      int       `db:"time_control_base_seconds"`
+	TimeControlIncrementSeconds int       `db:"time_control_increment_seconds"`
+	TimeControlDelay            DelayMode `db:"time_control_delay"`
+	TimeControlDaysPerMove      int       `db:"time_control_days_per_move"`
+
+	Status    TicketStatus `db:"status"`
+	SessionID *string      `db:"session_id"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	MatchedAt *time.Time `db:"matched_at"`
+}
+
+func NewMatchmakingTicket(
+	playerID uuid.UUID,
+	rating int,
+	ratingRange int,
+	timeControl TimeControl,
+	rated bool,
+	now time.Time,
+) (MatchmakingTicket, error) {
+	if ratingRange < 0 || ratingRange > MaxRatingRange {
+		return MatchmakingTicket{}, fmt.Errorf("invalid RatingRange - '%d'", ratingRange)
+	}
+
+	if err := timeControl.Validate(); err != nil {
+		return MatchmakingTicket{}, err
+	}
+
+	if timeControl.Delay == "" {
+		timeControl.Delay = FischerDelay
+	}
+
+	return MatchmakingTicket{
+		ID:                          uuid.New(),
+		PlayerID:                    playerID,
+		Rating:                      rating,
+		RatingRange:                 ratingRange,
+		Rated:                       rated,
+		TimeControlBaseSeconds:      timeControl.BaseSeconds,
+		TimeControlIncrementSeconds: timeControl.IncrementSeconds,
+		TimeControlDelay:            timeControl.Delay,
+		TimeControlDaysPerMove:      timeControl.DaysPerMove,
+		Status:                      TicketWaiting,
+		CreatedAt:                   now,
+	}, nil
+}
+
+func (t MatchmakingTicket) TimeControl() TimeControl {
+	return TimeControl{
+		BaseSeconds:      t.TimeControlBaseSeconds,
+		IncrementSeconds: t.TimeControlIncrementSeconds,
+		Delay:            t.TimeControlDelay,
+		DaysPerMove:      t.TimeControlDaysPerMove,
+	}
+}
+
+// RatingRangeAt is the rating range the ticket accepts at the given time.
+func (t MatchmakingTicket) RatingRangeAt(now time.Time) int {
+	waited := now.Sub(t.CreatedAt)
+	if waited < 0 {
+		waited = 0
+	}
+
+	ratingRange := t.RatingRange + int(waited/RatingRangeWideningInterval)*RatingRangeWidening
+	return min(ratingRange, MaxRatingRange)
+}
+
+// Accepts reports whether the ticket's player is willing to play the other ticket's player.
+func (t MatchmakingTicket) Accepts(other MatchmakingTicket, now time.Time) bool {
+	if t.PlayerID == other.PlayerID || t.TimeControl() != other.TimeControl() || t.Rated != other.Rated {
+		return false
+	}
+
+	return ratingDiff(t, other) <= t.RatingRangeAt(now)
+}
+
+func (t *MatchmakingTicket) Match(sessionID string, now time.Time) error {
+	if t.Status != TicketWaiting {
+		return ErrTicketNotWaiting
+	}
+
+	t.Status = TicketMatched
+	t.SessionID = &sessionID
+	t.MatchedAt = &now
+
+	return nil
+}
+
+type Match struct {
+	White MatchmakingTicket
+	Black MatchmakingTicket
+}
+
+// Session is the session the matched players are seated in.
+func (m Match) Session(now time.Time) Session {
+	timeControl := m.White.TimeControl()
+
+	name := fmt.Sprintf("Matchmaking %d+%d", timeControl.BaseSeconds, timeControl.IncrementSeconds)
+	if timeControl.IsCorrespondence() {
+		name = fmt.Sprintf("Matchmaking %d days per move", timeControl.DaysPerMove)
+	}
+
+	return Session{
+		ID:                          uuid.NewString(),
+		OwnerID:                     m.White.PlayerID,
+		Player1ID:                   m.White.PlayerID,
+		Player2ID:                   m.Black.PlayerID,
+		Name:                        name,
+		Rated:                       m.White.Rated,
+		Visibility:                  Public,
+		Variant:                     Standard,
+		TimeControlBaseSeconds:      timeControl.BaseSeconds,
+		TimeControlIncrementSeconds: timeControl.IncrementSeconds,
+		TimeControlDelay:            timeControl.Delay,
+		TimeControlDaysPerMove:      timeControl.DaysPerMove,
+		TimeControlCategory:         timeControl.Category(),
+		CreatedAt:                   now,
+	}
+}
+
+// FindMatches pairs the waiting tickets which accept each other. The tickets waiting
+// the longest are matched first, each with the closest rated compatible ticket.
+//
+// blocked reports whether either player blocked the other, such players are never
+// paired. whiteFirst decides the colors, when it returns true the player of the
+// longer waiting ticket plays white.
+func FindMatches(
+	tickets []MatchmakingTicket,
+	now time.Time,
+	blocked func(playerID, otherID uuid.UUID) bool,
+	whiteFirst func() bool,
+) []Match {
+	waiting := make([]MatchmakingTicket, 0, len(tickets))
+	for _, ticket := range tickets {
+		if ticket.Status == TicketWaiting {
+			waiting = append(waiting, ticket)
+		}
+	}
+
+	sort.SliceStable(waiting, func(i, j int) bool {
+		return waiting[i].CreatedAt.Before(waiting[j].CreatedAt)
+	})
+
+	matched := make([]bool, len(waiting))
+	var matches []Match
+
+	for i, ticket := range waiting {
+		if matched[i] {
+			continue
+		}
+
+		best := -1
+		for j := i + 1; j < len(waiting); j++ {
+			candidate := waiting[j]
+			if matched[j] || !ticket.Accepts(candidate, now) || !candidate.Accepts(ticket, now) {
+				continue
+			}
+
+			if blocked(ticket.PlayerID, candidate.PlayerID) {
+				continue
+			}
+
+			if best == -1 || ratingDiff(ticket, candidate) < ratingDiff(ticket, waiting[best]) {
+				best = j
+			}
+		}
+
+		if best == -1 {
+			continue
+		}
+
+		matched[i], matched[best] = true, true
+
+		if whiteFirst() {
+			matches = append(matches, Match{White: ticket, Black: waiting[best]})
+		} else {
+			matches = append(matches, Match{White: waiting[best], Black: ticket})
+		}
+	}
+
+	return matches
+}
+
+func ratingDiff(a, b MatchmakingTicket) int {
+	diff := a.Rating - b.Rating
+	if diff < 0 {
+		return -diff
+	}
+
+	return diff
+}
@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var blitz = TimeControl{BaseSeconds: 300, IncrementSeconds: 3, Delay: FischerDelay}
+
+func newTestTicket(t *testing.T, rating int, ratingRange int, tc TimeControl, createdAt time.Time) MatchmakingTicket {
+	ticket, err := NewMatchmakingTicket(uuid.New(), rating, ratingRange, tc, true, createdAt)
+	require.NoError(t, err)
+
+	return ticket
+}
+
+func always(v bool) func() bool {
+	return func() bool { return v }
+}
+
+func noBlocks(uuid.UUID, uuid.UUID) bool {
+	return false
+}
+
+func Test_MatchmakingTicket_RatingRangeAt_Widens_Over_Time(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ticket := newTestTicket(t, 1500, 100, blitz, now)
+
+	require.Equal(t, 100, ticket.RatingRangeAt(now))
+	require.Equal(t, 100, ticket.RatingRangeAt(now.Add(RatingRangeWideningInterval-time.Millisecond)))
+	require.Equal(t, 100+RatingRangeWidening, ticket.RatingRangeAt(now.Add(RatingRangeWideningInterval)))
+	require.Equal(t, MaxRatingRange, ticket.RatingRangeAt(now.Add(24*time.Hour)))
+}
+
+func Test_FindMatches_Pairs_Compatible_Tickets(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	first := newTestTicket(t, 1500, 100, blitz, now)
+	second := newTestTicket(t, 1550, 100, blitz, now.Add(time.Second))
+
+	// Act
+	matches := FindMatches([]MatchmakingTicket{second, first}, now.Add(time.Second), noBlocks, always(true))
+
+	// Assert
+	require.Len(t, matches, 1)
+	require.Equal(t, first.PlayerID, matches[0].White.PlayerID)
+	require.Equal(t, second.PlayerID, matches[0].Black.PlayerID)
+}
+
+func Test_FindMatches_Assigns_Colors_With_Coin(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	first := newTestTicket(t, 1500, 100, blitz, now)
+	second := newTestTicket(t, 1500, 100, blitz, now.Add(time.Second))
+
+	// Act
+	matches := FindMatches([]MatchmakingTicket{first, second}, now.Add(time.Second), noBlocks, always(false))
+
+	// Assert
+	require.Len(t, matches, 1)
+	require.Equal(t, second.PlayerID, matches[0].White.PlayerID)
+
+	session := matches[0].Session(now)
+	require.Equal(t, second.PlayerID, session.Player1ID)
+	require.Equal(t, first.PlayerID, session.Player2ID)
+	require.Equal(t, blitz, session.TimeControl())
+}
+
+func Test_FindMatches_Does_Not_Pair_Different_Time_Controls(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rapid := TimeControl{BaseSeconds: 600, IncrementSeconds: 0, Delay: FischerDelay}
+
+	tickets := []MatchmakingTicket{
+		newTestTicket(t, 1500, 100, blitz, now),
+		newTestTicket(t, 1500, 100, rapid, now),
+	}
+
+	// Act
+	matches := FindMatches(tickets, now, noBlocks, always(true))
+
+	// Assert
+	require.Empty(t, matches)
+}
+
+func Test_FindMatches_Pairs_After_Range_Widens(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tickets := []MatchmakingTicket{
+		newTestTicket(t, 1500, 100, blitz, now),
+		newTestTicket(t, 1700, 100, blitz, now),
+	}
+
+	// Act
+	before := FindMatches(tickets, now.Add(RatingRangeWideningInterval), noBlocks, always(true))
+	after := FindMatches(tickets, now.Add(2*RatingRangeWideningInterval), noBlocks, always(true))
+
+	// Assert
+	require.Empty(t, before)
+	require.Len(t, after, 1)
+}
+
+func Test_FindMatches_Requires_Both_Ranges_To_Accept(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tickets := []MatchmakingTicket{
+		newTestTicket(t, 1500, 500, blitz, now),
+		newTestTicket(t, 1800, 0, blitz, now),
+	}
+
+	// Act
+	matches := FindMatches(tickets, now, noBlocks, always(true))
+
+	// Assert
+	require.Empty(t, matches)
+}
+
+func Test_FindMatches_Prefers_Closest_Rating_And_Longest_Waiting(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	oldest := newTestTicket(t, 1500, 300, blitz, now)
+	far := newTestTicket(t, 1750, 300, blitz, now.Add(time.Second))
+	closest := newTestTicket(t, 1520, 300, blitz, now.Add(2*time.Second))
+	last := newTestTicket(t, 1760, 300, blitz, now.Add(3*time.Second))
+
+	// Act
+	matches := FindMatches([]MatchmakingTicket{last, closest, far, oldest}, now.Add(3*time.Second), noBlocks, always(true))
+
+	// Assert
+	require.Len(t, matches, 2)
+	require.Equal(t, oldest.PlayerID, matches[0].White.PlayerID)
+	require.Equal(t, closest.PlayerID, matches[0].Black.PlayerID)
+	require.Equal(t, far.PlayerID, matches[1].White.PlayerID)
+	require.Equal(t, last.PlayerID, matches[1].Black.PlayerID)
+}
+
+func Test_FindMatches_Skips_Matched_Tickets(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	matched := newTestTicket(t, 1500, 100, blitz, now)
+	require.NoError(t, matched.Match(uuid.NewString(), now))
+
+	tickets := []MatchmakingTicket{matched, newTestTicket(t, 1500, 100, blitz, now)}
+
+	// Act
+	matches := FindMatches(tickets, now, noBlocks, always(true))
+
+	// Assert
+	require.Empty(t, matches)
+	require.ErrorIs(t, matched.Match(uuid.NewString(), now), ErrTicketNotWaiting)
+}
+
+func Test_FindMatches_Does_Not_Pair_Rated_With_Casual(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	casual, err := NewMatchmakingTicket(uuid.New(), 1500, 100, blitz, false, now)
+	require.NoError(t, err)
+
+	tickets := []MatchmakingTicket{newTestTicket(t, 1500, 100, blitz, now), casual}
+
+	// Act
+	matches := FindMatches(tickets, now, noBlocks, always(true))
+
+	// Assert
+	require.Empty(t, matches)
+}
+
+func Test_FindMatches_Does_Not_Pair_Blocked_Players(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	blocker := newTestTicket(t, 1500, 100, blitz, now)
+	blocked := newTestTicket(t, 1500, 100, blitz, now.Add(time.Second))
+	other := newTestTicket(t, 1600, 100, blitz, now.Add(2*time.Second))
+
+	isBlocked := func(playerID, otherID uuid.UUID) bool {
+		return (playerID == blocker.PlayerID && otherID == blocked.PlayerID) ||
+			(playerID == blocked.PlayerID && otherID == blocker.PlayerID)
+	}
+
+	// Act
+	matches := FindMatches([]MatchmakingTicket{blocker, blocked, other}, now.Add(3*time.Second), isBlocked, always(true))
+
+	// Assert
+	require.Len(t, matches, 1)
+	require.Equal(t, blocker.PlayerID, matches[0].White.PlayerID)
+	require.Equal(t, other.PlayerID, matches[0].Black.PlayerID)
+}
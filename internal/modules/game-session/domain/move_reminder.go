@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/google/uuid"
+)
+
+// MoveReminderInterval is how often a player is reminded of their due moves at most.
+const MoveReminderInterval = 24 * time.Hour
+
+// DueMovesByPlayer groups the correspondence games by the player who has to move.
+func DueMovesByPlayer(games []Game) (map[uuid.UUID][]Game, error) {
+	due := make(map[uuid.UUID][]Game)
+	for _, game := range games {
+		if game.IsOver() || game.MoveDueAt == nil {
+			continue
+		}
+
+		playerID, err := game.PlayerToMove()
+		if err != nil {
+			return nil, err
+		}
+
+		due[playerID] = append(due[playerID], game)
+	}
+
+	return due, nil
+}
+
+// MoveReminderMessage is the email reminding the player of the games waiting for their move.
+func MoveReminderMessage(email string, games []Game, sender string) core.MailMessage {
+	var body strings.Builder
+	body.WriteString("<p>Your opponents are waiting for your move.</p><ul>")
+	for _, game := range games {
+		fmt.Fprintf(
+			&body,
+			"<li>Game in session %s, your move is due by %s.</li>",
+			html.EscapeString(game.SessionID),
+			game.MoveDueAt.Format(time.RFC1123),
+		)
+	}
+	body.WriteString("</ul><p>You can turn these reminders off in your preferences.</p>")
+
+	subject := "Your move is due"
+	if len(games) > 1 {
+		subject = fmt.Sprintf("Your move is due in %d games", len(games))
+	}
+
+	return core.MailMessage{
+		Subject:    subject,
+		From:       sender,
+		To:         []string{email},
+		IsHTML:     true,
+		BodyString: body.String(),
+	}
+}
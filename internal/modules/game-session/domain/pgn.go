@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+)
+
+// PGNSite is the Site tag of the exported games.
+const PGNSite = "vertical-slice-go"
+
+// PGN exports the game with its moves, played in the session between the players
+// with the given usernames. Every move is commented with the mover's remaining clock
+// time when the game is timed.
+func (g Game) PGN(session Session, moves []GameMove, white, black string) chess.PGNGame {
+	startedAt := g.CreatedAt.UTC()
+
+	pgn := chess.PGNGame{
+		Tags: []chess.PGNTag{
+			{Name: "Event", Value: session.Name},
+			{Name: "Site", Value: PGNSite},
+			{Name: "Date", Value: startedAt.Format("2006.01.02")},
+			{Name: "Round", Value: "-"},
+			{Name: "White", Value: white},
+			{Name: "Black", Value: black},
+			{Name: "Result", Value: string(g.Result)},
+			{Name: "TimeControl", Value: pgnTimeControl(g.TimeControl())},
+			{Name: "Termination", Value: pgnTermination(g.Termination)},
+			{Name: "UTCDate", Value: startedAt.Format("2006.01.02")},
+			{Name: "UTCTime", Value: startedAt.Format("15:04:05")},
+		},
+		Result: g.Result,
+	}
+
+	if variant := g.variant(); variant != Standard {
+		pgn.SetTag("Variant", variant.PGNName())
+	}
+
+	if g.InitialFEN != chess.StartingFEN {
+		pgn.SetTag("SetUp", "1")
+		pgn.SetTag("FEN", g.InitialFEN)
+	}
+
+	for _, move := range moves {
+		pgnMove := chess.PGNMove{SAN: move.SAN, UCI: move.UCI}
+		if move.RemainingMs != nil {
+			pgnMove.Comments = []string{pgnClock(time.Duration(*move.RemainingMs) * time.Millisecond)}
+		}
+
+		pgn.Moves = append(pgn.Moves, pgnMove)
+	}
+
+	return pgn
+}
+
+// pgnTimeControl formats the time control as in the PGN standard, "-" being untimed
+// and correspondence games giving a single move the days per move.
+func pgnTimeControl(tc TimeControl) string {
+	if tc.IsCorrespondence() {
+		return fmt.Sprintf("1/%d", int(tc.MoveTime().Seconds()))
+	}
+
+	if !tc.IsTimed() {
+		return "-"
+	}
+
+	if tc.IncrementSeconds == 0 {
+		return fmt.Sprintf("%d", tc.BaseSeconds)
+	}
+
+	return fmt.Sprintf("%d+%d", tc.BaseSeconds, tc.IncrementSeconds)
+}
+
+// pgnTermination maps the termination to the values the PGN standard defines,
+// which only tell games decided over the board apart from lost on time or left.
+func pgnTermination(termination chess.Termination) string {
+	switch termination {
+	case chess.NoTermination:
+		return "unterminated"
+	case chess.Timeout:
+		return "time forfeit"
+	case chess.Abandonment, chess.Aborted:
+		return "abandoned"
+	default:
+		return "normal"
+	}
+}
+
+func pgnClock(remaining time.Duration) string {
+	seconds := int64(max(remaining, 0) / time.Second)
+	return fmt.Sprintf("[%%clk %d:%02d:%02d]", seconds/3600, seconds/60%60, seconds%60)
+}
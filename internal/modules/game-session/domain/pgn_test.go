@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Game_PGN_Exports_Tags_Moves_And_Clocks(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 3, 9, 18, 30, 5, 0, time.UTC)
+	game := startTestGame(t, TimeControl{BaseSeconds: 180, IncrementSeconds: 2, Delay: FischerDelay}, now)
+	moves := playedMoves(t, &game, []string{"f2f3", "e7e5", "g2g4", "d8h4"}, now)
+
+	// Act
+	pgn := game.PGN(Session{Name: "Friday blitz"}, moves, "alice", "bob")
+
+	// Assert
+	expected := `[Event "Friday blitz"]
+[Site "vertical-slice-go"]
+[Date "2024.03.09"]
+[Round "-"]
+[White "alice"]
+[Black "bob"]
+[Result "0-1"]
+[TimeControl "180+2"]
+[Termination "normal"]
+[UTCDate "2024.03.09"]
+[UTCTime "18:30:05"]
+
+1. f3 {[%clk 0:03:00]} 1... e5 {[%clk 0:03:00]} 2. g4 {[%clk 0:03:02]}
+2... Qh4# {[%clk 0:03:02]} 0-1
+
+`
+	require.Equal(t, expected, pgn.String())
+
+	require.NoError(t, pgn.Resolve())
+	require.Equal(t, []string{"f2f3", "e7e5", "g2g4", "d8h4"}, pgn.MainLine())
+}
+
+func Test_Game_PGN_Sets_Up_Custom_Start_Position(t *testing.T) {
+	// Arrange
+	game := startTestGame(t, TimeControl{}, time.Now().UTC())
+	game.InitialFEN = "4k3/8/8/8/8/8/8/4K2R w K - 0 1"
+	game.FEN = game.InitialFEN
+
+	// Act
+	pgn := game.PGN(Session{}, nil, "alice", "bob")
+
+	// Assert
+	require.Equal(t, "1", pgn.Tag("SetUp"))
+	require.Equal(t, game.InitialFEN, pgn.Tag("FEN"))
+	require.Equal(t, "-", pgn.Tag("TimeControl"))
+	require.Equal(t, "unterminated", pgn.Tag("Termination"))
+	require.Equal(t, chess.NoResult, pgn.Result)
+}
@@ -0,0 +1,225 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+)
+
+// RematchOfferTTL is how long the opponent has to answer a rematch offer.
+const RematchOfferTTL = 5 * time.Minute
+
+var (
+	ErrRematchUnavailable = errors.New("games against the computer cannot be rematched")
+	ErrAlreadyRematched   = errors.New("the game was already rematched")
+	ErrNotInSeries        = errors.New("session is not part of a series")
+)
+
+// RematchOffer is one player's offer to play the opponent again once their game is
+// over, in a new session with the same settings and colors swapped.
+type RematchOffer struct {
+	ID        uuid.UUID   `db:"id"`
+	GameID    uuid.UUID   `db:"game_id"`
+	SessionID string      `db:"session_id"`
+	OfferedBy uuid.UUID   `db:"offered_by"`
+	OfferedTo uuid.UUID   `db:"offered_to"`
+	Status    OfferStatus `db:"status"`
+	ExpiresAt time.Time   `db:"expires_at"`
+	CreatedAt time.Time   `db:"created_at"`
+	// RematchSessionID is the session of the rematch once the offer is accepted.
+	RematchSessionID *string    `db:"rematch_session_id"`
+	RespondedAt      *time.Time `db:"responded_at"`
+}
+
+// NewRematchOffer makes the player's rematch offer for the finished game of the session.
+// As with the offers during a game there is a single pending offer at a time and
+// each player makes at most MaxOffersPerGame, and a game is rematched only once.
+func NewRematchOffer(
+	session Session,
+	game Game,
+	playerID uuid.UUID,
+	previous []RematchOffer,
+	now time.Time,
+) (RematchOffer, error) {
+	if !game.IsOver() {
+		return RematchOffer{}, ErrGameNotOver
+	}
+
+	if session.BotLevel > 0 {
+		return RematchOffer{}, ErrRematchUnavailable
+	}
+
+	if _, err := game.PlayerColor(playerID); err != nil {
+		return RematchOffer{}, err
+	}
+
+	made := 0
+	for _, offer := range previous {
+		switch {
+		case offer.Status == OfferPending:
+			return RematchOffer{}, ErrOfferPending
+		case offer.Status == OfferAccepted:
+			return RematchOffer{}, ErrAlreadyRematched
+		case offer.OfferedBy == playerID:
+			made++
+		}
+	}
+
+	if made >= MaxOffersPerGame {
+		return RematchOffer{}, ErrOfferLimitReached
+	}
+
+	return RematchOffer{
+		ID:        uuid.New(),
+		GameID:    game.ID,
+		SessionID: session.ID,
+		OfferedBy: playerID,
+		OfferedTo: game.OpponentID(playerID),
+		Status:    OfferPending,
+		ExpiresAt: now.Add(RematchOfferTTL),
+		CreatedAt: now,
+	}, nil
+}
+
+// Accept accepts the offer for the player it was made to, the rematch is played in
+// rematchSessionID.
+func (o *RematchOffer) Accept(playerID uuid.UUID, rematchSessionID string, now time.Time) error {
+	if err := o.answer(playerID, OfferAccepted, now); err != nil {
+		return err
+	}
+
+	o.RematchSessionID = &rematchSessionID
+	return nil
+}
+
+// Decline declines the offer for the player it was made to.
+func (o *RematchOffer) Decline(playerID uuid.UUID, now time.Time) error {
+	return o.answer(playerID, OfferDeclined, now)
+}
+
+// Expire expires the pending offer which was not answered in time.
+func (o *RematchOffer) Expire(now time.Time) error {
+	if o.Status != OfferPending {
+		return ErrOfferNotPending
+	}
+
+	o.Status = OfferExpired
+	o.RespondedAt = &now
+	return nil
+}
+
+// IsExpired reports whether the offer can no longer be answered.
+func (o RematchOffer) IsExpired(now time.Time) bool {
+	return !now.Before(o.ExpiresAt)
+}
+
+func (o *RematchOffer) answer(playerID uuid.UUID, status OfferStatus, now time.Time) error {
+	if o.Status != OfferPending || o.IsExpired(now) {
+		return ErrOfferNotPending
+	}
+
+	if playerID == o.OfferedBy {
+		return ErrCannotAnswerOwnOffer
+	}
+
+	if playerID != o.OfferedTo {
+		return ErrNotAPlayer
+	}
+
+	o.Status = status
+	o.RespondedAt = &now
+	return nil
+}
+
+// Rematch sets up the session of the rematch of the game, with the same settings
+// and the colors swapped. Both players are seated, so the game can start right away.
+func (s Session) Rematch(game Game, seriesID uuid.UUID, now time.Time) Session {
+	return Session{
+		ID:                          uuid.NewString(),
+		OwnerID:                     s.OwnerID,
+		Player1ID:                   game.BlackID,
+		Player2ID:                   game.WhiteID,
+		Name:                        s.Name,
+		Rated:                       s.Rated,
+		Visibility:                  s.Visibility,
+		Variant:                     s.Variant,
+		InitialFEN:                  s.InitialFEN,
+		SpectatorDelayMoves:         s.SpectatorDelayMoves,
+		SpectatorDelaySeconds:       s.SpectatorDelaySeconds,
+		TimeControlBaseSeconds:      s.TimeControlBaseSeconds,
+		TimeControlIncrementSeconds: s.TimeControlIncrementSeconds,
+		TimeControlDelay:            s.TimeControlDelay,
+		TimeControlDaysPerMove:      s.TimeControlDaysPerMove,
+		TimeControlCategory:         s.TimeControlCategory,
+		SeriesID:                    &seriesID,
+		CreatedAt:                   now,
+	}
+}
+
+// Series links the sessions of a game and its rematches.
+type Series struct {
+	ID        uuid.UUID `db:"id"`
+	Player1ID uuid.UUID `db:"player_1_id"`
+	Player2ID uuid.UUID `db:"player_2_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// NewSeries starts the series of the game's players when the game is first rematched.
+func NewSeries(game Game, now time.Time) Series {
+	return Series{
+		ID:        uuid.New(),
+		Player1ID: game.WhiteID,
+		Player2ID: game.BlackID,
+		CreatedAt: now,
+	}
+}
+
+// SeriesScore is the running score of the series, a point for a win and half
+// a point each for a draw.
+type SeriesScore struct {
+	SeriesID      uuid.UUID
+	Player1ID     uuid.UUID
+	Player2ID     uuid.UUID
+	Player1Points float64
+	Player2Points float64
+	// Games counts the finished games, aborted games and the game in progress do not count.
+	Games int
+}
+
+// Score adds up the results of the series' games.
+func (s Series) Score(games []Game) SeriesScore {
+	score := SeriesScore{
+		SeriesID:  s.ID,
+		Player1ID: s.Player1ID,
+		Player2ID: s.Player2ID,
+	}
+
+	for _, game := range games {
+		if !game.IsOver() || game.Result == chess.NoResult {
+			continue
+		}
+		score.Games++
+
+		if game.Result == chess.Draw {
+			score.Player1Points += 0.5
+			score.Player2Points += 0.5
+			continue
+		}
+
+		winnerID := game.WhiteID
+		if game.Result == chess.BlackWins {
+			winnerID = game.BlackID
+		}
+
+		if winnerID == s.Player1ID {
+			score.Player1Points++
+		} else {
+			score.Player2Points++
+		}
+	}
+
+	return score
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func finishedTestGame(t *testing.T, now time.Time) Game {
+	game := startTestGame(t, TimeControl{}, now)
+	require.NoError(t, game.Resign(game.BlackID, now))
+
+	return game
+}
+
+func Test_NewRematchOffer_Requires_Finished_Game(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := startTestGame(t, TimeControl{}, now)
+	session := Session{ID: game.SessionID}
+
+	// Act
+	_, err := NewRematchOffer(session, game, game.WhiteID, nil, now)
+
+	// Assert
+	require.ErrorIs(t, err, ErrGameNotOver)
+}
+
+func Test_NewRematchOffer_Refuses_Bots_And_Other_Users(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := finishedTestGame(t, now)
+
+	// Act & Assert
+	_, err := NewRematchOffer(Session{ID: game.SessionID, BotLevel: 3}, game, game.WhiteID, nil, now)
+	require.ErrorIs(t, err, ErrRematchUnavailable)
+
+	_, err = NewRematchOffer(Session{ID: game.SessionID}, game, uuid.New(), nil, now)
+	require.ErrorIs(t, err, ErrNotAPlayer)
+}
+
+func Test_NewRematchOffer_Allows_One_Pending_Offer_And_One_Rematch(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := finishedTestGame(t, now)
+	session := Session{ID: game.SessionID}
+
+	offer, err := NewRematchOffer(session, game, game.WhiteID, nil, now)
+	require.NoError(t, err)
+	require.Equal(t, game.BlackID, offer.OfferedTo)
+	require.Equal(t, now.Add(RematchOfferTTL), offer.ExpiresAt)
+
+	// Act & Assert
+	_, err = NewRematchOffer(session, game, game.BlackID, []RematchOffer{offer}, now)
+	require.ErrorIs(t, err, ErrOfferPending)
+
+	require.NoError(t, offer.Decline(game.BlackID, now))
+
+	_, err = NewRematchOffer(session, game, game.BlackID, []RematchOffer{offer}, now)
+	require.NoError(t, err)
+
+	offer.Status = OfferAccepted
+	_, err = NewRematchOffer(session, game, game.BlackID, []RematchOffer{offer}, now)
+	require.ErrorIs(t, err, ErrAlreadyRematched)
+}
+
+func Test_NewRematchOffer_Limits_Offers_Per_Game(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := finishedTestGame(t, now)
+	session := Session{ID: game.SessionID}
+
+	var previous []RematchOffer
+	for range MaxOffersPerGame {
+		offer, err := NewRematchOffer(session, game, game.WhiteID, previous, now)
+		require.NoError(t, err)
+		require.NoError(t, offer.Expire(now))
+
+		previous = append(previous, offer)
+	}
+
+	// Act
+	_, err := NewRematchOffer(session, game, game.WhiteID, previous, now)
+
+	// Assert
+	require.ErrorIs(t, err, ErrOfferLimitReached)
+}
+
+func Test_RematchOffer_Answer(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := finishedTestGame(t, now)
+
+	offer, err := NewRematchOffer(Session{ID: game.SessionID}, game, game.WhiteID, nil, now)
+	require.NoError(t, err)
+
+	// Act & Assert
+	require.ErrorIs(t, offer.Accept(game.WhiteID, uuid.NewString(), now), ErrCannotAnswerOwnOffer)
+	require.ErrorIs(t, offer.Accept(uuid.New(), uuid.NewString(), now), ErrNotAPlayer)
+	require.ErrorIs(t, offer.Accept(game.BlackID, uuid.NewString(), offer.ExpiresAt), ErrOfferNotPending)
+
+	rematchSessionID := uuid.NewString()
+	require.NoError(t, offer.Accept(game.BlackID, rematchSessionID, now))
+	require.Equal(t, OfferAccepted, offer.Status)
+	require.Equal(t, rematchSessionID, *offer.RematchSessionID)
+
+	require.ErrorIs(t, offer.Decline(game.BlackID, now), ErrOfferNotPending)
+	require.ErrorIs(t, offer.Expire(now), ErrOfferNotPending)
+}
+
+func Test_Session_Rematch_Swaps_Colors_And_Keeps_Settings(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	game := finishedTestGame(t, now)
+	session := Session{
+		ID:                          game.SessionID,
+		OwnerID:                     game.WhiteID,
+		Player1ID:                   game.WhiteID,
+		Player2ID:                   game.BlackID,
+		Name:                        "blitz",
+		Rated:                       true,
+		Visibility:                  Private,
+		Variant:                     Chess960,
+		InitialFEN:                  "bbqnnrkr/pppppppp/8/8/8/8/PPPPPPPP/BBQNNRKR w HFhf - 0 1",
+		SpectatorDelayMoves:         2,
+		TimeControlBaseSeconds:      180,
+		TimeControlIncrementSeconds: 2,
+		TimeControlDelay:            FischerDelay,
+		TimeControlCategory:         Blitz,
+	}
+	seriesID := uuid.New()
+
+	// Act
+	rematch := session.Rematch(game, seriesID, now)
+
+	// Assert
+	require.NotEqual(t, session.ID, rematch.ID)
+	require.Equal(t, game.BlackID, rematch.Player1ID)
+	require.Equal(t, game.WhiteID, rematch.Player2ID)
+	require.Equal(t, seriesID, *rematch.SeriesID)
+	require.Equal(t, now, rematch.CreatedAt)
+
+	rematch.ID, rematch.Player1ID, rematch.Player2ID = session.ID, session.Player1ID, session.Player2ID
+	rematch.SeriesID, rematch.CreatedAt = nil, session.CreatedAt
+	require.Equal(t, session, rematch)
+
+	rematchGame, err := StartGame(session.Rematch(game, seriesID, now), now)
+	require.NoError(t, err)
+	require.Equal(t, game.BlackID, rematchGame.WhiteID)
+	require.Equal(t, game.WhiteID, rematchGame.BlackID)
+}
+
+func Test_Series_Score(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	first := finishedTestGame(t, now)
+	series := NewSeries(first, now)
+
+	second := startTestGame(t, TimeControl{}, now)
+	second.WhiteID, second.BlackID = first.BlackID, first.WhiteID
+	require.NoError(t, second.AgreeDraw(now))
+
+	third := startTestGame(t, TimeControl{}, now)
+	third.WhiteID, third.BlackID = first.WhiteID, first.BlackID
+	require.NoError(t, third.Resign(third.WhiteID, now))
+
+	aborted := startTestGame(t, TimeControl{}, now)
+	require.NoError(t, aborted.Abort(now))
+
+	ongoing := startTestGame(t, TimeControl{}, now)
+
+	// Act
+	score := series.Score([]Game{first, second, third, aborted, ongoing})
+
+	// Assert
+	require.Equal(t, chess.WhiteWins, first.Result)
+	require.Equal(t, SeriesScore{
+		SeriesID:      series.ID,
+		Player1ID:     first.WhiteID,
+		Player2ID:     first.BlackID,
+		Player1Points: 1.5,
+		Player2Points: 1.5,
+		Games:         3,
+	}, score)
+}
@@ -1,11 +1,57 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
 	"github.com/google/uuid"
 )
 
+// Visibility decides who can find and join a session.
+type Visibility string
+
+const (
+	// Public sessions are listed in the lobby and anyone can join them.
+	Public Visibility = "public"
+	// Private sessions can only be joined through invitations and invite links.
+	Private Visibility = "private"
+)
+
+func (v Visibility) Validate() error {
+	switch v {
+	case Public, Private:
+		return nil
+	default:
+		return fmt.Errorf("invalid Visibility - '%s'", v)
+	}
+}
+
+// ValidateInitialFEN checks a game of the variant can start from the FEN's position,
+// which has to be possible in a game and not over already. Every problem is listed.
+func ValidateInitialFEN(variant Variant, fen string) error {
+	problems := chess.ValidateVariantFEN(variant, fen)
+
+	if len(problems) == 0 {
+		position, err := chess.ParseVariantFEN(variant, fen)
+		if err != nil {
+			return err
+		}
+
+		if result, termination := position.Outcome(); result != chess.NoResult {
+			problems = append(problems, fmt.Errorf("invalid InitialFEN - the game is already over by %s", termination))
+		}
+	}
+
+	if len(problems) > 0 {
+		return core.ValidationError{ValidationErrors: problems}
+	}
+
+	return nil
+}
+
 type Session struct {
 	ID        string    `db:"id"`
 	OwnerID   uuid.UUID `db:"owner_id"`
@@ -14,18 +60,46 @@ type Session struct {
 	GameID    uuid.UUID `db:"game_id"`
 	Active    bool      `db:"active"`
 	Name      string    `db:"name"`
-}
-
-type SessionInvitation struct {
-	ID        uuid.UUID `db:"id"`
-	SessionID string    `db:"session_id"`
+	// Closed sessions can no longer be joined.
+	Closed bool `db:"closed"`
+	// Rated games count towards the players' ratings, casual games do not.
+	Rated      bool       `db:"rated"`
+	Visibility Visibility `db:"visibility"`
+	Variant    Variant    `db:"variant"`
+	// InitialFEN is the position the session's games start from.
+	InitialFEN string `db:"initial_fen"`
+	// BotLevel is the strength of the computer opponent in Player2ID, 0 when both players are people.
+	BotLevel int `db:"bot_level"`
+	// SpectatorDelayMoves and SpectatorDelaySeconds hold back the game from its spectators.
+	SpectatorDelayMoves   int `db:"spectator_delay_moves"`
+	SpectatorDelaySeconds int `db:"spectator_delay_seconds"`
 
-	InviterID uuid.UUID `db:"inviter_id"`
-	InviteeID uuid.UUID `db:"invitee_id"`
+	TimeControlBaseSeconds      int       `db:"time_control_base_seconds"`
+	TimeControlIncrementSeconds int       `db:"time_control_increment_seconds"`
+	TimeControlDelay            DelayMode `db:"time_control_delay"`
+	TimeControlDaysPerMove      int       `db:"time_control_days_per_move"`
+	// TimeControlCategory is stored for the lobby to filter by.
+	TimeControlCategory TimeControlCategory `db:"time_control_category"`
+	// SeriesID links the session to the other games between its players once rematched.
+	SeriesID *uuid.UUID `db:"series_id"`
 
 	CreatedAt time.Time `db:"created_at"`
 }
 
+// IsOpen reports whether the session is waiting for a second player.
+func (s Session) IsOpen() bool {
+	return !s.Closed && s.Player2ID == uuid.Nil
+}
+
+func (s Session) TimeControl() TimeControl {
+	return TimeControl{
+		BaseSeconds:      s.TimeControlBaseSeconds,
+		IncrementSeconds: s.TimeControlIncrementSeconds,
+		Delay:            s.TimeControlDelay,
+		DaysPerMove:      s.TimeControlDaysPerMove,
+	}
+}
+
 // Create session +
 // See your open sessions +
 // See sessions you are invited to
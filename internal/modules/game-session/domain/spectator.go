@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSpectatingPrivateSession = errors.New("games of private sessions can only be watched by their players")
+	ErrSpectatingOwnGame        = errors.New("players cannot spectate their own game")
+	ErrNoGameInProgress         = errors.New("session has no game in progress")
+	ErrSpectatorCannotMove      = errors.New("spectators cannot make moves")
+)
+
+const (
+	MaxSpectatorDelayMoves   = 10
+	MaxSpectatorDelaySeconds = 10 * 60
+
+	// SpectatorPresenceTTL is how long a spectator counts as watching after it was
+	// last seen. Streams refresh their spectators well within it.
+	SpectatorPresenceTTL = 2 * time.Minute
+)
+
+// Role is what a user can do in a session's game.
+type Role string
+
+const (
+	PlayerRole    Role = "player"
+	SpectatorRole Role = "spectator"
+)
+
+// Role is the user's role in the session. Only the players of private sessions
+// can follow their games.
+func (s Session) Role(userID uuid.UUID) (Role, error) {
+	if userID != uuid.Nil && (userID == s.Player1ID || userID == s.Player2ID) {
+		return PlayerRole, nil
+	}
+
+	if s.Visibility == Private {
+		return "", ErrSpectatingPrivateSession
+	}
+
+	return SpectatorRole, nil
+}
+
+// CanSpectate checks the user can join the spectators of the session's game.
+func (s Session) CanSpectate(userID uuid.UUID) error {
+	role, err := s.Role(userID)
+	if err != nil {
+		return err
+	}
+
+	if role == PlayerRole {
+		return ErrSpectatingOwnGame
+	}
+
+	if !s.Active {
+		return ErrNoGameInProgress
+	}
+
+	return nil
+}
+
+// SpectatorDelay holds back the events of a game from its spectators, so they
+// cannot relay the moves to one of the players. Events are shown to spectators
+// once both the moves and the seconds passed since them. Everything is shown
+// once the game is over.
+type SpectatorDelay struct {
+	Moves   int
+	Seconds int
+}
+
+func (d SpectatorDelay) Validate() error {
+	if d.Moves < 0 || d.Moves > MaxSpectatorDelayMoves {
+		return fmt.Errorf("invalid SpectatorDelay.Moves - '%d', expected 0 to %d", d.Moves, MaxSpectatorDelayMoves)
+	}
+
+	if d.Seconds < 0 || d.Seconds > MaxSpectatorDelaySeconds {
+		return fmt.Errorf("invalid SpectatorDelay.Seconds - '%d', expected 0 to %d", d.Seconds, MaxSpectatorDelaySeconds)
+	}
+
+	return nil
+}
+
+func (d SpectatorDelay) IsZero() bool {
+	return d.Moves == 0 && d.Seconds == 0
+}
+
+func (s Session) SpectatorDelay() SpectatorDelay {
+	return SpectatorDelay{Moves: s.SpectatorDelayMoves, Seconds: s.SpectatorDelaySeconds}
+}
+
+type pendingEvent struct {
+	event GameEvent
+	// moves is the number of moves made up to and including the event.
+	moves int
+}
+
+// SpectatorFeed holds back a game's events from a spectator for the delay and
+// releases them in order.
+type SpectatorFeed struct {
+	delay   SpectatorDelay
+	pending []pendingEvent
+	moves   int
+	over    bool
+}
+
+func NewSpectatorFeed(delay SpectatorDelay) *SpectatorFeed {
+	return &SpectatorFeed{delay: delay}
+}
+
+// Push adds the next event of the game.
+func (f *SpectatorFeed) Push(event GameEvent) {
+	switch event.Type {
+	case MoveMadeEvent:
+		f.moves++
+	case GameOverEvent:
+		f.over = true
+	}
+
+	f.pending = append(f.pending, pendingEvent{event: event, moves: f.moves})
+}
+
+// Release returns the events which can be shown at now, oldest first.
+func (f *SpectatorFeed) Release(now time.Time) []GameEvent {
+	var released []GameEvent
+
+	for len(f.pending) > 0 && f.ready(f.pending[0], now) {
+		released = append(released, f.pending[0].event)
+		f.pending = f.pending[1:]
+	}
+
+	return released
+}
+
+// NextRelease is when the oldest held back event can be shown, if only time holds
+// it back. It is false when nothing is held back or it waits for moves.
+func (f *SpectatorFeed) NextRelease() (time.Time, bool) {
+	if len(f.pending) == 0 {
+		return time.Time{}, false
+	}
+
+	next := f.pending[0]
+	if f.moves-next.moves < f.delay.Moves {
+		return time.Time{}, false
+	}
+
+	return next.event.CreatedAt.Add(time.Duration(f.delay.Seconds) * time.Second), true
+}
+
+func (f *SpectatorFeed) ready(p pendingEvent, now time.Time) bool {
+	// The start of the game and its end give nothing away.
+	if f.over || p.event.Type == GameStartedEvent {
+		return true
+	}
+
+	if f.moves-p.moves < f.delay.Moves {
+		return false
+	}
+
+	return !now.Before(p.event.CreatedAt.Add(time.Duration(f.delay.Seconds) * time.Second))
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func gameEvent(version int, eventType GameEventType, createdAt time.Time) GameEvent {
+	return GameEvent{Version: version, Type: eventType, Payload: "{}", CreatedAt: createdAt}
+}
+
+func versions(events []GameEvent) []int {
+	result := make([]int, 0, len(events))
+	for _, event := range events {
+		result = append(result, event.Version)
+	}
+	return result
+}
+
+func Test_Session_Role(t *testing.T) {
+	session := Session{Player1ID: uuid.New(), Player2ID: uuid.New(), Visibility: Public}
+
+	role, err := session.Role(session.Player2ID)
+	require.NoError(t, err)
+	require.Equal(t, PlayerRole, role)
+
+	role, err = session.Role(uuid.New())
+	require.NoError(t, err)
+	require.Equal(t, SpectatorRole, role)
+
+	session.Visibility = Private
+	_, err = session.Role(uuid.New())
+	require.ErrorIs(t, err, ErrSpectatingPrivateSession)
+}
+
+func Test_Session_CanSpectate(t *testing.T) {
+	session := Session{Player1ID: uuid.New(), Player2ID: uuid.New(), Visibility: Public, Active: true}
+
+	require.NoError(t, session.CanSpectate(uuid.New()))
+	require.ErrorIs(t, session.CanSpectate(session.Player1ID), ErrSpectatingOwnGame)
+
+	session.Active = false
+	require.ErrorIs(t, session.CanSpectate(uuid.New()), ErrNoGameInProgress)
+}
+
+func Test_SpectatorDelay_Validate(t *testing.T) {
+	require.NoError(t, SpectatorDelay{}.Validate())
+	require.NoError(t, SpectatorDelay{Moves: MaxSpectatorDelayMoves, Seconds: MaxSpectatorDelaySeconds}.Validate())
+	require.Error(t, SpectatorDelay{Moves: -1}.Validate())
+	require.Error(t, SpectatorDelay{Moves: MaxSpectatorDelayMoves + 1}.Validate())
+	require.Error(t, SpectatorDelay{Seconds: MaxSpectatorDelaySeconds + 1}.Validate())
+}
+
+func Test_SpectatorFeed_Without_Delay_Releases_Everything(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	feed := NewSpectatorFeed(SpectatorDelay{})
+
+	// Act
+	feed.Push(gameEvent(1, GameStartedEvent, now))
+	feed.Push(gameEvent(2, MoveMadeEvent, now))
+
+	// Assert
+	require.Equal(t, []int{1, 2}, versions(feed.Release(now)))
+	_, ok := feed.NextRelease()
+	require.False(t, ok)
+}
+
+func Test_SpectatorFeed_Holds_Back_Moves(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	feed := NewSpectatorFeed(SpectatorDelay{Moves: 2})
+
+	feed.Push(gameEvent(1, GameStartedEvent, now))
+	feed.Push(gameEvent(2, MoveMadeEvent, now))
+	feed.Push(gameEvent(3, MoveMadeEvent, now))
+
+	// Act & Assert
+	// The start of the game is not held back.
+	require.Equal(t, []int{1}, versions(feed.Release(now)))
+	_, ok := feed.NextRelease()
+	require.False(t, ok)
+
+	feed.Push(gameEvent(4, MoveMadeEvent, now))
+	require.Equal(t, []int{2}, versions(feed.Release(now)))
+
+	// An offer is held back as long as the move before it.
+	feed.Push(gameEvent(5, OfferMadeEvent, now))
+	feed.Push(gameEvent(6, MoveMadeEvent, now))
+	require.Equal(t, []int{3}, versions(feed.Release(now)))
+
+	feed.Push(gameEvent(7, MoveMadeEvent, now))
+	require.Equal(t, []int{4, 5}, versions(feed.Release(now)))
+}
+
+func Test_SpectatorFeed_Holds_Back_Seconds(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	feed := NewSpectatorFeed(SpectatorDelay{Seconds: 30})
+
+	feed.Push(gameEvent(1, MoveMadeEvent, now))
+	feed.Push(gameEvent(2, MoveMadeEvent, now.Add(10*time.Second)))
+
+	// Act & Assert
+	require.Empty(t, feed.Release(now.Add(29*time.Second)))
+
+	next, ok := feed.NextRelease()
+	require.True(t, ok)
+	require.Equal(t, now.Add(30*time.Second), next)
+
+	require.Equal(t, []int{1}, versions(feed.Release(next)))
+
+	next, ok = feed.NextRelease()
+	require.True(t, ok)
+	require.Equal(t, now.Add(40*time.Second), next)
+}
+
+func Test_SpectatorFeed_Holds_Back_Moves_And_Seconds(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	feed := NewSpectatorFeed(SpectatorDelay{Moves: 1, Seconds: 30})
+
+	feed.Push(gameEvent(1, MoveMadeEvent, now))
+
+	// Act & Assert
+	require.Empty(t, feed.Release(now.Add(time.Minute)))
+
+	feed.Push(gameEvent(2, MoveMadeEvent, now.Add(5*time.Second)))
+	require.Empty(t, feed.Release(now.Add(10*time.Second)))
+	require.Equal(t, []int{1}, versions(feed.Release(now.Add(30*time.Second))))
+}
+
+func Test_SpectatorFeed_Releases_Everything_When_Game_Is_Over(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	feed := NewSpectatorFeed(SpectatorDelay{Moves: 3, Seconds: 60})
+
+	feed.Push(gameEvent(1, MoveMadeEvent, now))
+	feed.Push(gameEvent(2, MoveMadeEvent, now))
+
+	// Act
+	feed.Push(gameEvent(3, GameOverEvent, now))
+
+	// Assert
+	require.Equal(t, []int{1, 2, 3}, versions(feed.Release(now)))
+}
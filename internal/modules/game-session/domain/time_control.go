@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const maxTimeControlBaseSeconds = 3 * 60 * 60
+
+// MaxDaysPerMove is the longest a correspondence game gives for a move.
+const MaxDaysPerMove = 14
+
+type TimeControlCategory string
+
+const (
+	Untimed   TimeControlCategory = "untimed"
+	Bullet    TimeControlCategory = "bullet"
+	Blitz     TimeControlCategory = "blitz"
+	Rapid     TimeControlCategory = "rapid"
+	Classical TimeControlCategory = "classical"
+	// Correspondence games are played over days, one move at a time.
+	Correspondence TimeControlCategory = "correspondence"
+)
+
+// DelayMode decides how the increment is credited to the clock after each move.
+type DelayMode string
+
+const (
+	// FischerDelay adds the full increment after every move.
+	FischerDelay DelayMode = "fischer"
+	// BronsteinDelay gives back the time spent on the move, up to the increment.
+	BronsteinDelay DelayMode = "bronstein"
+)
+
+// TimeControl of zero value means the game is untimed.
+type TimeControl struct {
+	BaseSeconds      int
+	IncrementSeconds int
+	Delay            DelayMode
+	// DaysPerMove makes the game a correspondence game without a clock,
+	// every move is due that many days after the opponent's.
+	DaysPerMove int
+}
+
+func (tc TimeControl) Validate() error {
+	if tc.DaysPerMove < 0 || tc.DaysPerMove > MaxDaysPerMove {
+		return fmt.Errorf("invalid DaysPerMove - '%d'", tc.DaysPerMove)
+	}
+
+	if tc.IsCorrespondence() && (tc.BaseSeconds != 0 || tc.IncrementSeconds != 0) {
+		return fmt.Errorf("invalid DaysPerMove - correspondence games have no clock")
+	}
+
+	if tc.BaseSeconds < 0 || tc.BaseSeconds > maxTimeControlBaseSeconds {
+		return fmt.Errorf("invalid BaseSeconds - '%d'", tc.BaseSeconds)
+	}
+
+	if tc.IncrementSeconds < 0 || tc.IncrementSeconds > tc.BaseSeconds {
+		return fmt.Errorf("invalid IncrementSeconds - '%d'", tc.IncrementSeconds)
+	}
+
+	switch tc.Delay {
+	case "", FischerDelay, BronsteinDelay:
+	default:
+		return fmt.Errorf("invalid Delay - '%s'", tc.Delay)
+	}
+
+	return nil
+}
+
+// IsTimed reports whether the game is played on a clock.
+func (tc TimeControl) IsTimed() bool {
+	return tc.BaseSeconds > 0
+}
+
+func (tc TimeControl) IsCorrespondence() bool {
+	return tc.DaysPerMove > 0
+}
+
+// MoveTime is the time a correspondence game gives for each move.
+func (tc TimeControl) MoveTime() time.Duration {
+	return time.Duration(tc.DaysPerMove) * 24 * time.Hour
+}
+
+func (tc TimeControl) Base() time.Duration {
+	return time.Duration(tc.BaseSeconds) * time.Second
+}
+
+func (tc TimeControl) Increment() time.Duration {
+	return time.Duration(tc.IncrementSeconds) * time.Second
+}
+
+// Category buckets the time control by the estimated duration of a 40 move game.
+func (tc TimeControl) Category() TimeControlCategory {
+	if tc.IsCorrespondence() {
+		return Correspondence
+	}
+
+	if !tc.IsTimed() {
+		return Untimed
+	}
+
+	estimated := tc.BaseSeconds + 40*tc.IncrementSeconds
+	switch {
+	case estimated < 180:
+		return Bullet
+	case estimated < 480:
+		return Blitz
+	case estimated < 1500:
+		return Rapid
+	default:
+		return Classical
+	}
+}
+
+// Charge deducts the time spent on a move from the remaining time and credits the
+// increment. It reports whether the clock ran out before the move was made.
+func (tc TimeControl) Charge(remaining, elapsed time.Duration) (time.Duration, bool) {
+	remaining -= elapsed
+	if remaining <= 0 {
+		return 0, true
+	}
+
+	switch tc.Delay {
+	case BronsteinDelay:
+		remaining += min(elapsed, tc.Increment())
+	default:
+		remaining += tc.Increment()
+	}
+
+	return remaining, false
+}
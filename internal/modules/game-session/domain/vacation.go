@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOnVacation                = errors.New("user is already on vacation")
+	ErrVacationAllowanceExceeded = errors.New("not enough vacation days left this year")
+)
+
+// VacationDaysPerYear is how many days a user can take off their correspondence
+// games each calendar year.
+const VacationDaysPerYear = 30
+
+// MovePostponedEvent is recorded when the move of a player on vacation is due later.
+const MovePostponedEvent GameEventType = "move_postponed"
+
+type MovePostponedPayload struct {
+	PlayerID  uuid.UUID
+	MoveDueAt time.Time
+}
+
+// Vacation pauses the user's correspondence games, their moves are not due
+// until the vacation is over. The user can still move meanwhile.
+type Vacation struct {
+	ID       uuid.UUID `db:"id"`
+	UserID   uuid.UUID `db:"user_id"`
+	Days     int       `db:"days"`
+	StartsAt time.Time `db:"starts_at"`
+	EndsAt   time.Time `db:"ends_at"`
+}
+
+// NewVacation starts a vacation of days now. usedDays are the vacation days
+// the user already took this year.
+func NewVacation(userID uuid.UUID, days int, usedDays int, now time.Time) (Vacation, error) {
+	if days < 1 || days > VacationDaysPerYear {
+		return Vacation{}, fmt.Errorf("invalid Days - '%d'", days)
+	}
+
+	if usedDays+days > VacationDaysPerYear {
+		return Vacation{}, ErrVacationAllowanceExceeded
+	}
+
+	return Vacation{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Days:     days,
+		StartsAt: now,
+		EndsAt:   now.Add(time.Duration(days) * 24 * time.Hour),
+	}, nil
+}
+
+// Remaining is how much of the vacation is left at now.
+func (v Vacation) Remaining(now time.Time) time.Duration {
+	if now.Before(v.StartsAt) {
+		return v.EndsAt.Sub(v.StartsAt)
+	}
+	return max(v.EndsAt.Sub(now), 0)
+}
+
+// VacationYearStart is the start of the calendar year the allowance of now is counted in.
+func VacationYearStart(now time.Time) time.Time {
+	return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+}
+
+// PostponeMove makes the move of a correspondence game due later. Moves which are
+// already overdue cannot be postponed, it reports whether the move was.
+func (g *Game) PostponeMove(by time.Duration) bool {
+	if g.IsOver() || g.MoveDueAt == nil || g.ClaimableAt != nil || by <= 0 {
+		return false
+	}
+
+	dueAt := g.MoveDueAt.Add(by)
+	g.MoveDueAt = &dueAt
+	return true
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+// Variant is the set of rules a game is played by.
+type Variant = chess.Variant
+
+const (
+	Standard      = chess.Standard
+	Chess960      = chess.Chess960
+	KingOfTheHill = chess.KingOfTheHill
+	ThreeCheck    = chess.ThreeCheck
+)
+
+// RatingCategory is the rating the game counts towards. Standard games are rated
+// by their time control, every other variant has a single rating of its own.
+func RatingCategory(variant Variant, tc TimeControl) string {
+	if variant == "" || variant == Standard {
+		return string(tc.Category())
+	}
+	return string(variant)
+}
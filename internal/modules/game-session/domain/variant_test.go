@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func startVariantGame(t *testing.T, variant Variant, initialFEN string) Game {
+	session := Session{
+		ID:         uuid.NewString(),
+		Player1ID:  uuid.New(),
+		Player2ID:  uuid.New(),
+		Variant:    variant,
+		InitialFEN: initialFEN,
+	}
+
+	game, err := StartGame(session, time.Now().UTC())
+	require.NoError(t, err)
+
+	return game
+}
+
+func Test_RatingCategory(t *testing.T) {
+	blitz := TimeControl{BaseSeconds: 180, IncrementSeconds: 2}
+
+	require.Equal(t, string(blitz.Category()), RatingCategory(Standard, blitz))
+	require.Equal(t, string(blitz.Category()), RatingCategory("", blitz))
+	require.Equal(t, "chess960", RatingCategory(Chess960, blitz))
+	require.Equal(t, "three_check", RatingCategory(ThreeCheck, blitz))
+}
+
+func Test_Game_Chess960_Castles(t *testing.T) {
+	// Arrange
+	fen, err := chess.Chess960FEN(0)
+	require.NoError(t, err)
+
+	game := startVariantGame(t, Chess960, fen)
+	now := time.Now().UTC()
+
+	// Act
+	for _, uci := range []string{"f2f4", "f7f5", "f1f3", "f8f6", "g1h1"} {
+		play(t, &game, uci, now)
+	}
+
+	// Assert
+	require.Equal(t, Chess960, game.Variant)
+	require.Equal(t, "bbqnn1kr/ppppp1pp/5r2/5p2/5P2/5R2/PPPPP1PP/BBQNNRK1 b k - 3 3", game.FEN)
+}
+
+func Test_Game_ThreeCheck_Ends_On_Third_Check(t *testing.T) {
+	// Arrange
+	game := startVariantGame(t, ThreeCheck, "4k3/8/8/8/8/8/8/R3K2R w - - 0 1 +2+0")
+
+	// Act
+	play(t, &game, "h1h8", time.Now().UTC())
+
+	// Assert
+	require.True(t, game.IsOver())
+	require.Equal(t, chess.WhiteWins, game.Result)
+	require.Equal(t, chess.ThreeChecks, game.Termination)
+}
+
+func Test_Game_KingOfTheHill_Ends_On_Center(t *testing.T) {
+	// Arrange
+	game := startVariantGame(t, KingOfTheHill, "4k3/8/8/8/8/4K3/8/8 w - - 0 1")
+
+	// Act
+	play(t, &game, "e3d4", time.Now().UTC())
+
+	// Assert
+	require.True(t, game.IsOver())
+	require.Equal(t, chess.WhiteWins, game.Result)
+	require.Equal(t, chess.KingInTheCenter, game.Termination)
+}
+
+func Test_Game_PGN_Tags_Variant(t *testing.T) {
+	// Arrange
+	fen, err := chess.Chess960FEN(0)
+	require.NoError(t, err)
+
+	game := startVariantGame(t, Chess960, fen)
+
+	// Act
+	pgn := game.PGN(Session{}, nil, "alice", "bob")
+
+	// Assert
+	require.Equal(t, "Chess960", pgn.Tag("Variant"))
+	require.Equal(t, fen, pgn.Tag("FEN"))
+	require.NoError(t, pgn.Resolve())
+}
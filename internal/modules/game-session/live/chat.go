@@ -0,0 +1,103 @@
+package live
+
+import (
+	"context"
+
+	chatcommands "github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/commands"
+	chatdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	chatqueries "github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/queries"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// chatFeed delivers the messages of the chat channel the user reads to the stream,
+// leaving out those of the users the user muted.
+type chatFeed struct {
+	pubSub  *core.PubSub
+	channel chatdomain.Channel
+
+	messages *core.Subscription
+	mutes    *core.Subscription
+	muted    map[uuid.UUID]struct{}
+}
+
+func subscribeChat(
+	ctx context.Context,
+	pubSub *core.PubSub,
+	sessionID string,
+	role domain.Role,
+) (*chatFeed, error) {
+	channel := chatdomain.LiveChannel(role)
+	userID := core.Session(ctx).UserID
+
+	f := &chatFeed{
+		pubSub:   pubSub,
+		channel:  channel,
+		messages: pubSub.Subscribe(chatdomain.ChannelTopic(sessionID, channel), subscriptionBuffer),
+		mutes:    pubSub.Subscribe(chatdomain.MutesTopic(userID), subscriptionBuffer),
+	}
+
+	// Subscribed first, so a mute made in the meantime is reloaded.
+	if err := f.reloadMutes(ctx); err != nil {
+		f.unsubscribe()
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *chatFeed) unsubscribe() {
+	f.pubSub.Unsubscribe(f.messages)
+	f.pubSub.Unsubscribe(f.mutes)
+}
+
+func (f *chatFeed) reloadMutes(ctx context.Context) error {
+	muted, err := mediator.Send[chatqueries.GetMutedUsersQuery, []uuid.UUID](
+		ctx,
+		chatqueries.GetMutedUsersQuery{UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		return err
+	}
+
+	f.muted = make(map[uuid.UUID]struct{}, len(muted))
+	for _, id := range muted {
+		f.muted[id] = struct{}{}
+	}
+
+	return nil
+}
+
+// shows reports whether the published chat event is shown to the user.
+func (f *chatFeed) shows(message []byte) bool {
+	event, err := chatdomain.ParseChannelEvent(message)
+	if err != nil {
+		return false
+	}
+
+	_, muted := f.muted[event.Payload.SenderID]
+	return !muted
+}
+
+func (s *stream) handleChat(ctx context.Context, message clientMessage) replyMessage {
+	channel := message.Channel
+	if channel == "" {
+		channel = s.chat.channel
+	}
+
+	command := chatcommands.SendMessageCommand{
+		SessionID: s.sessionID,
+		Channel:   channel,
+		SenderID:  core.Session(ctx).UserID,
+		Body:      message.Text,
+	}
+
+	if _, err := mediator.Send[chatcommands.SendMessageCommand, chatdomain.Message](ctx, command); err != nil {
+		return replyMessage{Type: "chat_rejected", Error: err.Error()}
+	}
+
+	return replyMessage{Type: "chat_accepted"}
+}
@@ -0,0 +1,404 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	chatdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/queries"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = pongWait * 9 / 10
+	maxMessageSize = 4 * 1024
+
+	// Events buffered for a client before it is considered too slow and disconnected.
+	subscriptionBuffer = 64
+	repliesBuffer      = 16
+)
+
+type clientMessage struct {
+	Type string `json:"type"`
+	Move string `json:"move"`
+	// Channel and Text are the chat message, sent to the channel the user reads
+	// when no Channel is given.
+	Channel chatdomain.Channel `json:"channel"`
+	Text    string             `json:"text"`
+}
+
+type replyMessage struct {
+	Type  string `json:"type"`
+	Move  string `json:"move,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// HandleGameStream streams the events of the session's game over a WebSocket
+// to the players and spectators, and accepts moves from the players. Spectators
+// receive the events once the session's spectator delay has passed and are
+// counted as watching while they are connected. The messages of the chat
+// channel the user reads are streamed along, and chat messages are accepted.
+//
+// Clients which reconnect pass the last version they have seen in the
+// 'version' query param to receive only the events they have missed.
+func HandleGameStream(pubSub *core.PubSub) http.HandlerFunc {
+	upgrader := websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		sessionID := r.PathValue("id")
+
+		afterVersion := 0
+		if versionParam := r.URL.Query().Get("version"); versionParam != "" {
+			version, err := strconv.Atoi(versionParam)
+			if err != nil {
+				core.WriteBadRequest(w, r, fmt.Errorf("invalid format for query param 'version'"))
+				return
+			}
+			afterVersion = version
+		}
+
+		backlog, err := mediator.Send[queries.GetGameEventsQuery, queries.GetGameEventsResponse](
+			ctx,
+			queries.GetGameEventsQuery{SessionID: sessionID, UserID: core.Session(ctx).UserID, AfterVersion: afterVersion},
+		)
+		if err != nil {
+			core.WriteCommandError(w, r, err)
+			return
+		}
+
+		// Subscribe before upgrading so no event published in the meantime is lost,
+		// the events already sent from the backlog are skipped by version.
+		subscription := pubSub.Subscribe(domain.GameEventsTopic(backlog.GameID), subscriptionBuffer)
+		defer pubSub.Unsubscribe(subscription)
+
+		chat, err := subscribeChat(ctx, pubSub, sessionID, backlog.Role)
+		if err != nil {
+			core.WriteCommandError(w, r, err)
+			return
+		}
+		defer chat.unsubscribe()
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// The upgrader already responded with an error.
+			return
+		}
+		defer conn.Close()
+
+		s := stream{
+			conn:         conn,
+			sessionID:    sessionID,
+			role:         backlog.Role,
+			chat:         chat,
+			lastVersion:  afterVersion,
+			shownVersion: afterVersion,
+			replies:      make(chan replyMessage, repliesBuffer),
+			readerClosed: make(chan struct{}),
+		}
+
+		if s.role == domain.SpectatorRole {
+			s.feed = domain.NewSpectatorFeed(backlog.SpectatorDelay)
+
+			s.spectate(ctx)
+			// The request's context may already be cancelled when the connection is gone.
+			defer s.stopSpectating(context.WithoutCancel(ctx))
+		}
+
+		go s.readMessages(ctx)
+
+		if err := s.writeEvents(backlog.Events...); err != nil {
+			return
+		}
+
+		s.writeMessages(ctx, pubSub, subscription)
+	}
+}
+
+type stream struct {
+	conn      *websocket.Conn
+	sessionID string
+	role      domain.Role
+	// lastVersion is the last event received, shownVersion the last one written to
+	// the client. They only differ while events are held back from spectators.
+	lastVersion  int
+	shownVersion int
+
+	chat *chatFeed
+
+	// feed holds back the events from spectators, it is only set for them.
+	feed      *domain.SpectatorFeed
+	releaseAt <-chan time.Time
+
+	replies      chan replyMessage
+	readerClosed chan struct{}
+}
+
+// readMessages handles the messages sent by the client until the connection is closed.
+func (s *stream) readMessages(ctx context.Context) {
+	defer close(s.readerClosed)
+
+	s.conn.SetReadLimit(maxMessageSize)
+	_ = s.conn.SetReadDeadline(time.Now().Add(pongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		var message clientMessage
+		if err := s.conn.ReadJSON(&message); err != nil {
+			return
+		}
+
+		switch message.Type {
+		case "move":
+			s.reply(s.handleMove(ctx, message))
+		case "chat":
+			s.reply(s.handleChat(ctx, message))
+		default:
+			s.reply(replyMessage{Type: "error", Error: fmt.Sprintf("unknown message type '%s'", message.Type)})
+		}
+	}
+}
+
+func (s *stream) handleMove(ctx context.Context, message clientMessage) replyMessage {
+	if s.role != domain.PlayerRole {
+		return replyMessage{Type: "move_rejected", Move: message.Move, Error: domain.ErrSpectatorCannotMove.Error()}
+	}
+
+	// Moves go through the same command as the HTTP endpoint, only
+	// the player from the authenticated session can make them.
+	command := commands.MakeMoveCommand{
+		SessionID: s.sessionID,
+		PlayerID:  core.Session(ctx).UserID,
+		Move:      message.Move,
+	}
+
+	if _, err := mediator.Send[commands.MakeMoveCommand, commands.MakeMoveResponse](ctx, command); err != nil {
+		return replyMessage{Type: "move_rejected", Move: message.Move, Error: err.Error()}
+	}
+
+	return replyMessage{Type: "move_accepted", Move: message.Move}
+}
+
+// reply drops the reply instead of blocking the reader if the client is not reading.
+func (s *stream) reply(message replyMessage) {
+	select {
+	case s.replies <- message:
+	default:
+	}
+}
+
+// writeMessages is the only writer to the connection.
+func (s *stream) writeMessages(ctx context.Context, pubSub *core.PubSub, subscription *core.Subscription) {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.readerClosed:
+			return
+
+		case <-ticker.C:
+			_ = s.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := s.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+
+			if s.role == domain.SpectatorRole {
+				s.spectate(ctx)
+			}
+
+		case <-s.releaseAt:
+			if err := s.release(); err != nil {
+				return
+			}
+
+		case message, ok := <-s.chat.messages.Messages():
+			if !ok {
+				s.close(pubSub.Dropped(s.chat.messages))
+				return
+			}
+
+			if !s.chat.shows(message) {
+				continue
+			}
+
+			if err := s.write(message); err != nil {
+				return
+			}
+
+		case _, ok := <-s.chat.mutes.Messages():
+			if !ok {
+				s.close(pubSub.Dropped(s.chat.mutes))
+				return
+			}
+
+			if err := s.chat.reloadMutes(ctx); err != nil {
+				return
+			}
+
+		case reply := <-s.replies:
+			_ = s.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := s.conn.WriteJSON(reply); err != nil {
+				return
+			}
+
+		case message, ok := <-subscription.Messages():
+			if !ok {
+				s.close(pubSub.Dropped(subscription))
+				return
+			}
+
+			if err := s.writeLiveEvent(ctx, message); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *stream) writeLiveEvent(ctx context.Context, message []byte) error {
+	var event struct {
+		Type    domain.GameEventType `json:"type"`
+		Version int                  `json:"version"`
+		Payload json.RawMessage      `json:"payload"`
+	}
+	if err := json.Unmarshal(message, &event); err != nil {
+		return err
+	}
+
+	switch {
+	case event.Version <= s.lastVersion:
+		return nil
+
+	case event.Version > s.lastVersion+1:
+		// Events were missed, catch up from the event log.
+		missed, err := mediator.Send[queries.GetGameEventsQuery, queries.GetGameEventsResponse](
+			ctx,
+			queries.GetGameEventsQuery{
+				SessionID:    s.sessionID,
+				UserID:       core.Session(ctx).UserID,
+				AfterVersion: s.lastVersion,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		return s.writeEvents(missed.Events...)
+	}
+
+	if s.feed != nil {
+		// Live messages do not carry the time of the event, it was published just now.
+		return s.writeEvents(domain.GameEvent{
+			Version:   event.Version,
+			Type:      event.Type,
+			Payload:   string(event.Payload),
+			CreatedAt: time.Now(),
+		})
+	}
+
+	if err := s.write(message); err != nil {
+		return err
+	}
+	s.lastVersion = event.Version
+	s.shownVersion = event.Version
+
+	return nil
+}
+
+func (s *stream) writeEvents(events ...domain.GameEvent) error {
+	for _, event := range events {
+		if event.Version <= s.lastVersion {
+			continue
+		}
+
+		if s.feed != nil {
+			s.feed.Push(event)
+			s.lastVersion = event.Version
+			continue
+		}
+
+		if err := s.writeEvent(event); err != nil {
+			return err
+		}
+		s.lastVersion = event.Version
+	}
+
+	if s.feed != nil {
+		return s.release()
+	}
+
+	return nil
+}
+
+// release writes the events the spectator delay no longer holds back, and waits
+// for the next one.
+func (s *stream) release() error {
+	for _, event := range s.feed.Release(time.Now()) {
+		if err := s.writeEvent(event); err != nil {
+			return err
+		}
+	}
+
+	s.releaseAt = nil
+	if next, ok := s.feed.NextRelease(); ok {
+		s.releaseAt = time.After(time.Until(next))
+	}
+
+	return nil
+}
+
+func (s *stream) writeEvent(event domain.GameEvent) error {
+	message, err := event.Message()
+	if err != nil {
+		return err
+	}
+
+	if err := s.write(message); err != nil {
+		return err
+	}
+	s.shownVersion = event.Version
+
+	return nil
+}
+
+// spectate counts the spectator as watching. A game which has ended has no spectators
+// to count, so failures are not a reason to disconnect.
+func (s *stream) spectate(ctx context.Context) {
+	command := commands.SpectateCommand{SessionID: s.sessionID, UserID: core.Session(ctx).UserID}
+	_, _ = mediator.Send[commands.SpectateCommand, commands.SpectateResponse](ctx, command)
+}
+
+func (s *stream) stopSpectating(ctx context.Context) {
+	command := commands.StopSpectatingCommand{SessionID: s.sessionID, UserID: core.Session(ctx).UserID}
+	_, _ = mediator.Send[commands.StopSpectatingCommand, core.Unit](ctx, command)
+}
+
+func (s *stream) write(message []byte) error {
+	_ = s.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return s.conn.WriteMessage(websocket.TextMessage, message)
+}
+
+// close tells a client which might have missed events, because it fell behind or
+// because the server lost its connection to the database, to reconnect from its
+// last version.
+func (s *stream) close(dropped bool) {
+	code, reason := websocket.CloseNormalClosure, ""
+	if dropped {
+		code, reason = websocket.CloseTryAgainLater, fmt.Sprintf("events missed, resume from version %d", s.shownVersion)
+	}
+
+	message := websocket.FormatCloseMessage(code, reason)
+	_ = s.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeWait))
+}
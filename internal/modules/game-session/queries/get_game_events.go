@@ -0,0 +1,101 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetGameEventsQuery returns the events of the session's game recorded after
+// the given version, and what the user can do in the game. Games of private
+// sessions are only shown to their players.
+type GetGameEventsQuery struct {
+	SessionID    string
+	UserID       uuid.UUID
+	AfterVersion int
+}
+
+func (q GetGameEventsQuery) Validate() error {
+	if q.SessionID == "" {
+		return fmt.Errorf("invalid SessionID - '%s'", q.SessionID)
+	}
+
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	if q.AfterVersion < 0 {
+		return fmt.Errorf("invalid AfterVersion - '%d'", q.AfterVersion)
+	}
+
+	return nil
+}
+
+type GetGameEventsResponse struct {
+	GameID uuid.UUID
+	Role   domain.Role
+	// SpectatorDelay is how long the events are held back from spectators.
+	SpectatorDelay domain.SpectatorDelay
+	Events         []domain.GameEvent
+}
+
+type GetGameEventsQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetGameEventsQueryHandler(db *sql.DB) *GetGameEventsQueryHandler {
+	return &GetGameEventsQueryHandler{db}
+}
+
+func (h *GetGameEventsQueryHandler) Handle(
+	ctx context.Context,
+	request GetGameEventsQuery,
+) (GetGameEventsResponse, error) {
+	const sessionQuery = `
+		SELECT
+			*
+		FROM
+			game_session
+		WHERE
+			id = $1 AND game_id IS NOT NULL;`
+	session, err := tql.QueryFirst[domain.Session](ctx, h.db, sessionQuery, request.SessionID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return GetGameEventsResponse{}, core.NewCommandError(404, err)
+	case err != nil:
+		return GetGameEventsResponse{}, core.NewCommandError(500, err)
+	}
+
+	role, err := session.Role(request.UserID)
+	if err != nil {
+		return GetGameEventsResponse{}, core.NewCommandError(403, err)
+	}
+
+	const eventsQuery = `
+		SELECT
+			*
+		FROM
+			game_event
+		WHERE
+			game_id = $1 AND version > $2
+		ORDER BY
+			version;`
+	events, err := tql.Query[domain.GameEvent](ctx, h.db, eventsQuery, session.GameID, request.AfterVersion)
+	if err != nil {
+		return GetGameEventsResponse{}, core.NewCommandError(500, err)
+	}
+
+	return GetGameEventsResponse{
+		GameID:         session.GameID,
+		Role:           role,
+		SpectatorDelay: session.SpectatorDelay(),
+		Events:         events,
+	}, nil
+}
@@ -0,0 +1,190 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const pgnContentType = "application/x-chess-pgn"
+
+// GetGamePGNQuery exports a finished game in PGN. Games of private sessions
+// are only exported for their players.
+type GetGamePGNQuery struct {
+	GameID uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q GetGamePGNQuery) Validate() error {
+	if q.GameID == uuid.Nil {
+		return fmt.Errorf("invalid GameID - '%s'", q.GameID)
+	}
+
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return nil
+}
+
+func HandleGetGamePGN(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetGamePGNQuery, chess.PGNGame](
+		ctx,
+		GetGamePGNQuery{GameID: gameID, UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", pgnContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pgn"`, gameID))
+	w.WriteHeader(http.StatusOK)
+
+	// The status is already written, a failed write only means the client is gone.
+	_ = chess.WritePGN(w, response)
+}
+
+type GetGamePGNQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetGamePGNQueryHandler(db *sql.DB) *GetGamePGNQueryHandler {
+	return &GetGamePGNQueryHandler{db}
+}
+
+func (h *GetGamePGNQueryHandler) Handle(ctx context.Context, request GetGamePGNQuery) (chess.PGNGame, error) {
+	const query = `
+		SELECT
+			g.*
+		FROM
+			game g
+			JOIN game_session s ON s.id = g.session_id
+		WHERE
+			g.id = $1 AND (s.visibility = $2 OR g.white_id = $3 OR g.black_id = $3);`
+	game, err := tql.QueryFirst[domain.Game](ctx, h.db, query, request.GameID, domain.Public, request.UserID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return chess.PGNGame{}, core.NewCommandError(404, err)
+	case err != nil:
+		return chess.PGNGame{}, core.NewCommandError(500, err)
+	}
+
+	if !game.IsOver() {
+		return chess.PGNGame{}, core.NewCommandError(409, domain.ErrGameNotOver)
+	}
+
+	pgns, err := gamesPGN(ctx, h.db, []domain.Game{game})
+	if err != nil {
+		return chess.PGNGame{}, core.NewCommandError(500, err)
+	}
+
+	return pgns[0], nil
+}
+
+type playerUsername struct {
+	ID       uuid.UUID `db:"id"`
+	Username string    `db:"username"`
+}
+
+// gamesPGN exports the games with the sessions, players and moves
+// of all of them loaded at once.
+func gamesPGN(ctx context.Context, db *sql.DB, games []domain.Game) ([]chess.PGNGame, error) {
+	if len(games) == 0 {
+		return []chess.PGNGame{}, nil
+	}
+
+	gameIDs := make([]uuid.UUID, 0, len(games))
+	sessionIDs := make([]string, 0, len(games))
+	playerIDs := make([]uuid.UUID, 0, 2*len(games))
+	for _, game := range games {
+		gameIDs = append(gameIDs, game.ID)
+		sessionIDs = append(sessionIDs, game.SessionID)
+		playerIDs = append(playerIDs, game.WhiteID, game.BlackID)
+	}
+
+	const sessionsQuery = `
+		SELECT
+			*
+		FROM
+			game_session
+		WHERE
+			id = ANY($1);`
+	sessions, err := tql.Query[domain.Session](ctx, db, sessionsQuery, pq.Array(sessionIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	const usernamesQuery = `
+		SELECT
+			id,
+			username
+		FROM
+			auth.user
+		WHERE
+			id = ANY($1);`
+	players, err := tql.Query[playerUsername](ctx, db, usernamesQuery, pq.Array(playerIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	const movesQuery = `
+		SELECT
+			*
+		FROM
+			game_move
+		WHERE
+			game_id = ANY($1)
+		ORDER BY
+			game_id, ply;`
+	moves, err := tql.Query[domain.GameMove](ctx, db, movesQuery, pq.Array(gameIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	sessionsByID := make(map[string]domain.Session, len(sessions))
+	for _, session := range sessions {
+		sessionsByID[session.ID] = session
+	}
+
+	usernames := make(map[uuid.UUID]string, len(players))
+	for _, player := range players {
+		usernames[player.ID] = player.Username
+	}
+
+	movesByGame := make(map[uuid.UUID][]domain.GameMove, len(games))
+	for _, move := range moves {
+		movesByGame[move.GameID] = append(movesByGame[move.GameID], move)
+	}
+
+	pgns := make([]chess.PGNGame, 0, len(games))
+	for _, game := range games {
+		pgns = append(pgns, game.PGN(
+			sessionsByID[game.SessionID],
+			movesByGame[game.ID],
+			usernames[game.WhiteID],
+			usernames[game.BlackID],
+		))
+	}
+
+	return pgns, nil
+}
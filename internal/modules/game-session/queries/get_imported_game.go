@@ -0,0 +1,85 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetImportedGameQuery returns an imported game, which is only visible to its owner.
+type GetImportedGameQuery struct {
+	GameID  uuid.UUID
+	OwnerID uuid.UUID
+}
+
+func (q GetImportedGameQuery) Validate() error {
+	if q.GameID == uuid.Nil {
+		return fmt.Errorf("invalid GameID - '%s'", q.GameID)
+	}
+
+	if q.OwnerID == uuid.Nil {
+		return fmt.Errorf("invalid OwnerID - '%s'", q.OwnerID)
+	}
+
+	return nil
+}
+
+func HandleGetImportedGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetImportedGameQuery, domain.ImportedGame](
+		ctx,
+		GetImportedGameQuery{GameID: gameID, OwnerID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetImportedGameQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetImportedGameQueryHandler(db *sql.DB) *GetImportedGameQueryHandler {
+	return &GetImportedGameQueryHandler{db}
+}
+
+func (h *GetImportedGameQueryHandler) Handle(
+	ctx context.Context,
+	request GetImportedGameQuery,
+) (domain.ImportedGame, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			imported_game
+		WHERE
+			id = $1 AND owner_id = $2;`
+	game, err := tql.QueryFirst[domain.ImportedGame](ctx, h.db, query, request.GameID, request.OwnerID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return domain.ImportedGame{}, core.NewCommandError(404, err)
+	case err != nil:
+		return domain.ImportedGame{}, core.NewCommandError(500, err)
+	}
+
+	return game, nil
+}
@@ -0,0 +1,85 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetInvitationQuery returns an invitation, which is only visible to its inviter and invitee.
+type GetInvitationQuery struct {
+	InvitationID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (q GetInvitationQuery) Validate() error {
+	if q.InvitationID == uuid.Nil {
+		return fmt.Errorf("invalid InvitationID - '%s'", q.InvitationID)
+	}
+
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return nil
+}
+
+func HandleGetInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	invitationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetInvitationQuery, domain.SessionInvitation](
+		ctx,
+		GetInvitationQuery{InvitationID: invitationID, UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetInvitationQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetInvitationQueryHandler(db *sql.DB) *GetInvitationQueryHandler {
+	return &GetInvitationQueryHandler{db}
+}
+
+func (h *GetInvitationQueryHandler) Handle(
+	ctx context.Context,
+	request GetInvitationQuery,
+) (domain.SessionInvitation, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			session_invitation
+		WHERE
+			id = $1 AND (inviter_id = $2 OR invitee_id = $2);`
+	invitation, err := tql.QueryFirst[domain.SessionInvitation](ctx, h.db, query, request.InvitationID, request.UserID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return domain.SessionInvitation{}, core.NewCommandError(404, err)
+	case err != nil:
+		return domain.SessionInvitation{}, core.NewCommandError(500, err)
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,405 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/presence"
+	presencedomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/presence/domain"
+	ratingsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// LobbySort is the order the lobby lists the open sessions in.
+type LobbySort string
+
+const (
+	// SortNewest lists the most recently created sessions first.
+	SortNewest LobbySort = "newest"
+	// SortRating lists the sessions by their owner's rating, lowest first.
+	SortRating LobbySort = "rating"
+	// SortTimeControl lists the sessions by their time control, fastest first.
+	SortTimeControl LobbySort = "time_control"
+)
+
+func (s LobbySort) Validate() error {
+	switch s {
+	case SortNewest, SortRating, SortTimeControl:
+		return nil
+	default:
+		return fmt.Errorf("invalid Sort - '%s'", s)
+	}
+}
+
+// LobbyStatus picks the sessions the lobby lists.
+type LobbyStatus string
+
+const (
+	// StatusOpen lists the sessions waiting for a second player.
+	StatusOpen LobbyStatus = "open"
+	// StatusPlaying lists the sessions with a game in progress, for spectators.
+	StatusPlaying LobbyStatus = "playing"
+)
+
+func (s LobbyStatus) Validate() error {
+	switch s {
+	case StatusOpen, StatusPlaying:
+		return nil
+	default:
+		return fmt.Errorf("invalid Status - '%s'", s)
+	}
+}
+
+// GetLobbyQuery lists the public sessions waiting for a second player, or
+// with a game in progress. Filters left empty match every session.
+type GetLobbyQuery struct {
+	Page     core.PageRequest
+	Sort     LobbySort
+	Status   LobbyStatus
+	Category domain.TimeControlCategory
+	Rated    *bool
+	Variant  domain.Variant
+	// MinRating and MaxRating bound the owner's rating in the session's category.
+	MinRating *int
+	MaxRating *int
+}
+
+func (q GetLobbyQuery) Validate() error {
+	if err := q.Page.Validate(); err != nil {
+		return err
+	}
+
+	if err := q.Sort.Validate(); err != nil {
+		return err
+	}
+
+	if err := q.Status.Validate(); err != nil {
+		return err
+	}
+
+	switch q.Category {
+	case "", domain.Untimed, domain.Bullet, domain.Blitz, domain.Rapid, domain.Classical:
+	default:
+		return fmt.Errorf("invalid Category - '%s'", q.Category)
+	}
+
+	if q.Variant != "" {
+		if err := q.Variant.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if q.MinRating != nil && q.MaxRating != nil && *q.MinRating > *q.MaxRating {
+		return fmt.Errorf("MinRating cannot be greater than MaxRating")
+	}
+
+	return nil
+}
+
+// LobbySession is a session as listed in the lobby. OwnerRating is the owner's
+// rating in the session's time control category. GameID is only set for sessions
+// with a game in progress, SpectatorCount is the number of users watching it.
+// OwnerStatus tells whether the owner is still around to play.
+type LobbySession struct {
+	SessionID                   string                     `db:"session_id"`
+	GameID                      uuid.UUID                  `db:"game_id"`
+	Name                        string                     `db:"name"`
+	OwnerID                     uuid.UUID                  `db:"owner_id"`
+	OwnerRating                 int                        `db:"owner_rating"`
+	Rated                       bool                       `db:"rated"`
+	Variant                     domain.Variant             `db:"variant"`
+	TimeControlBaseSeconds      int                        `db:"time_control_base_seconds"`
+	TimeControlIncrementSeconds int                        `db:"time_control_increment_seconds"`
+	TimeControlDelay            domain.DelayMode           `db:"time_control_delay"`
+	TimeControlDaysPerMove      int                        `db:"time_control_days_per_move"`
+	TimeControlCategory         domain.TimeControlCategory `db:"time_control_category"`
+	SpectatorCount              int                        `db:"spectator_count"`
+	CreatedAt                   time.Time                  `db:"created_at"`
+	OwnerStatus                 presencedomain.Status
+}
+
+// lobbyCursor holds the sort keys of the last session on a page. It remembers the
+// sort and status it was made for, so it is not used to continue another list.
+type lobbyCursor struct {
+	Sort        LobbySort
+	Status      LobbyStatus
+	SessionID   string
+	CreatedAt   time.Time `json:",omitempty"`
+	OwnerRating int       `json:",omitempty"`
+	Base        int       `json:",omitempty"`
+	Increment   int       `json:",omitempty"`
+}
+
+func newLobbyCursor(sort LobbySort, status LobbyStatus) func(LobbySession) lobbyCursor {
+	return func(s LobbySession) lobbyCursor {
+		cursor := lobbyCursor{Sort: sort, Status: status, SessionID: s.SessionID}
+
+		switch sort {
+		case SortRating:
+			cursor.OwnerRating = s.OwnerRating
+		case SortTimeControl:
+			cursor.Base = s.TimeControlBaseSeconds
+			cursor.Increment = s.TimeControlIncrementSeconds
+		default:
+			cursor.CreatedAt = s.CreatedAt
+		}
+
+		return cursor
+	}
+}
+
+func HandleGetLobby(w http.ResponseWriter, r *http.Request) {
+	query, err := lobbyQueryParams(r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	response, err := mediator.Send[GetLobbyQuery, core.Page[LobbySession]](r.Context(), query)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+func lobbyQueryParams(r *http.Request) (GetLobbyQuery, error) {
+	page, err := core.ParsePageRequest(r)
+	if err != nil {
+		return GetLobbyQuery{}, err
+	}
+
+	params := r.URL.Query()
+
+	query := GetLobbyQuery{
+		Page:     page,
+		Sort:     LobbySort(params.Get("sort")),
+		Status:   LobbyStatus(params.Get("status")),
+		Category: domain.TimeControlCategory(params.Get("category")),
+		Variant:  domain.Variant(params.Get("variant")),
+	}
+
+	if query.Sort == "" {
+		query.Sort = SortNewest
+	}
+
+	if query.Status == "" {
+		query.Status = StatusOpen
+	}
+
+	if rated := params.Get("rated"); rated != "" {
+		parsed, err := strconv.ParseBool(rated)
+		if err != nil {
+			return GetLobbyQuery{}, fmt.Errorf("invalid format for query param 'rated'")
+		}
+		query.Rated = &parsed
+	}
+
+	if query.MinRating, err = optionalIntParam(r, "minRating"); err != nil {
+		return GetLobbyQuery{}, err
+	}
+
+	if query.MaxRating, err = optionalIntParam(r, "maxRating"); err != nil {
+		return GetLobbyQuery{}, err
+	}
+
+	return query, nil
+}
+
+func optionalIntParam(r *http.Request, name string) (*int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format for query param '%s'", name)
+	}
+
+	return &parsed, nil
+}
+
+type GetLobbyQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetLobbyQueryHandler(db *sql.DB) *GetLobbyQueryHandler {
+	return &GetLobbyQueryHandler{db}
+}
+
+func (h *GetLobbyQueryHandler) Handle(ctx context.Context, request GetLobbyQuery) (core.Page[LobbySession], error) {
+	var cursor *lobbyCursor
+	if request.Page.Cursor != "" {
+		decoded, err := core.DecodeCursor[lobbyCursor](request.Page.Cursor)
+		if err != nil {
+			return core.Page[LobbySession]{}, core.NewCommandError(400, err)
+		}
+
+		if decoded.Sort != request.Sort {
+			return core.Page[LobbySession]{}, core.NewCommandError(400, fmt.Errorf("cursor does not match Sort '%s'", request.Sort))
+		}
+
+		if decoded.Status != request.Status {
+			return core.Page[LobbySession]{}, core.NewCommandError(400, fmt.Errorf("cursor does not match Status '%s'", request.Status))
+		}
+		cursor = &decoded
+	}
+
+	now := time.Now().UTC()
+
+	query, args := lobbyQuery(request, cursor, now.Add(-domain.SpectatorPresenceTTL))
+
+	sessions, err := tql.Query[LobbySession](ctx, h.db, query, args...)
+	if err != nil {
+		return core.Page[LobbySession]{}, core.NewCommandError(500, err)
+	}
+
+	ownerIDs := make([]uuid.UUID, 0, len(sessions))
+	for _, session := range sessions {
+		ownerIDs = append(ownerIDs, session.OwnerID)
+	}
+
+	statuses, err := presence.Statuses(ctx, h.db, ownerIDs, now)
+	if err != nil {
+		return core.Page[LobbySession]{}, core.NewCommandError(500, err)
+	}
+
+	for i := range sessions {
+		sessions[i].OwnerStatus = statuses.Of(sessions[i].OwnerID)
+	}
+
+	page, err := core.NewPage(sessions, request.Page.Limit, newLobbyCursor(request.Sort, request.Status))
+	if err != nil {
+		return core.Page[LobbySession]{}, core.NewCommandError(500, err)
+	}
+
+	return page, nil
+}
+
+// lobbyQuery builds the lobby query out of the filters. Sessions are filtered on
+// their owner's rating, so the lobby is selected in a subquery and filtered outside it.
+// Spectators last seen before spectatorsSeenAfter are no longer counted as watching.
+func lobbyQuery(request GetLobbyQuery, cursor *lobbyCursor, spectatorsSeenAfter time.Time) (string, []any) {
+	args := []any{ratingsdomain.DefaultRating, spectatorsSeenAfter}
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{"true"}
+
+	if request.Category != "" {
+		conditions = append(conditions, "time_control_category = "+arg(request.Category))
+	}
+
+	if request.Rated != nil {
+		conditions = append(conditions, "rated = "+arg(*request.Rated))
+	}
+
+	if request.Variant != "" {
+		conditions = append(conditions, "variant = "+arg(request.Variant))
+	}
+
+	if request.MinRating != nil {
+		conditions = append(conditions, "owner_rating >= "+arg(*request.MinRating))
+	}
+
+	if request.MaxRating != nil {
+		conditions = append(conditions, "owner_rating <= "+arg(*request.MaxRating))
+	}
+
+	status := "s.closed = false AND s.player_2_id IS NULL"
+	if request.Status == StatusPlaying {
+		status = "s.active = true"
+	}
+
+	var order string
+	switch request.Sort {
+	case SortRating:
+		order = "owner_rating, session_id"
+		if cursor != nil {
+			conditions = append(conditions, fmt.Sprintf(
+				"(owner_rating, session_id) > (%s, %s)",
+				arg(cursor.OwnerRating), arg(cursor.SessionID),
+			))
+		}
+	case SortTimeControl:
+		order = "time_control_base_seconds, time_control_increment_seconds, session_id"
+		if cursor != nil {
+			conditions = append(conditions, fmt.Sprintf(
+				"(time_control_base_seconds, time_control_increment_seconds, session_id) > (%s, %s, %s)",
+				arg(cursor.Base), arg(cursor.Increment), arg(cursor.SessionID),
+			))
+		}
+	default:
+		order = "created_at DESC, session_id DESC"
+		if cursor != nil {
+			conditions = append(conditions, fmt.Sprintf(
+				"(created_at, session_id) < (%s, %s)",
+				arg(cursor.CreatedAt), arg(cursor.SessionID),
+			))
+		}
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			*
+		FROM
+			(
+				SELECT
+					s.id AS session_id,
+					s.game_id,
+					s.name,
+					s.owner_id,
+					CAST(round(COALESCE(r.rating, $1)) AS integer) AS owner_rating,
+					s.rated,
+					s.variant,
+					s.time_control_base_seconds,
+					s.time_control_increment_seconds,
+					s.time_control_delay,
+					s.time_control_days_per_move,
+					s.time_control_category,
+					(
+						SELECT
+							count(*)
+						FROM
+							game_spectator sp
+						WHERE
+							sp.session_id = s.id AND sp.last_seen_at > $2
+					) AS spectator_count,
+					s.created_at
+				FROM
+					game_session s
+					LEFT JOIN player_rating r ON r.player_id = s.owner_id AND r.category = CASE
+						WHEN s.variant = 'standard' THEN s.time_control_category
+						ELSE s.variant
+					END
+				WHERE
+					s.visibility = 'public' AND %s
+			) lobby
+		WHERE
+			%s
+		ORDER BY
+			%s
+		LIMIT
+			%s;`,
+		status,
+		strings.Join(conditions, " AND "),
+		order,
+		arg(request.Page.Limit+1),
+	)
+
+	return query, args
+}
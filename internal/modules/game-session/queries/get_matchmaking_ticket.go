@@ -0,0 +1,75 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetMatchmakingTicketQuery returns the player's ticket, which
+// holds the session once the player is matched.
+type GetMatchmakingTicketQuery struct {
+	PlayerID uuid.UUID
+}
+
+func (q GetMatchmakingTicketQuery) Validate() error {
+	if q.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", q.PlayerID)
+	}
+
+	return nil
+}
+
+func HandleGetMatchmakingTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	response, err := mediator.Send[GetMatchmakingTicketQuery, domain.MatchmakingTicket](
+		ctx,
+		GetMatchmakingTicketQuery{PlayerID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetMatchmakingTicketQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetMatchmakingTicketQueryHandler(db *sql.DB) *GetMatchmakingTicketQueryHandler {
+	return &GetMatchmakingTicketQueryHandler{db}
+}
+
+func (h *GetMatchmakingTicketQueryHandler) Handle(
+	ctx context.Context,
+	request GetMatchmakingTicketQuery,
+) (domain.MatchmakingTicket, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			matchmaking_ticket
+		WHERE
+			player_id = $1;`
+	ticket, err := tql.QueryFirst[domain.MatchmakingTicket](ctx, h.db, query, request.PlayerID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return domain.MatchmakingTicket{}, core.NewCommandError(404, err)
+	case err != nil:
+		return domain.MatchmakingTicket{}, core.NewCommandError(500, err)
+	}
+
+	return ticket, nil
+}
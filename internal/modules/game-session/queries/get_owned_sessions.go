@@ -26,17 +26,22 @@ func (q GetOwnedSessionsQuery) Validate() error {
 	return nil
 }
 
+// HandleGetOwnedSessions lists the logged-in user's sessions. The 'ownerId' query
+// param is optional, only the user's own ID is allowed.
 func HandleGetOwnedSessions(w http.ResponseWriter, r *http.Request) {
-	ownerIDParam, found := r.URL.Query()["ownerId"]
-	if !found {
-		core.WriteBadRequest(w, r, fmt.Errorf("missing required query param 'ownerId'"))
-		return
-	}
+	ownerID := core.Session(r.Context()).UserID
 
-	ownerID, err := uuid.Parse(ownerIDParam[0])
-	if err != nil {
-		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for query param 'ownerId'"))
-		return
+	if ownerIDParam := r.URL.Query().Get("ownerId"); ownerIDParam != "" {
+		requested, err := uuid.Parse(ownerIDParam)
+		if err != nil {
+			core.WriteBadRequest(w, r, fmt.Errorf("invalid format for query param 'ownerId'"))
+			return
+		}
+
+		if requested != ownerID {
+			core.WriteCommandError(w, r, core.NewCommandError(http.StatusForbidden, fmt.Errorf("cannot list sessions owned by another user")))
+			return
+		}
 	}
 
 	response, err := mediator.Send[GetOwnedSessionsQuery, []domain.Session](
@@ -0,0 +1,149 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetPlayerGamesPGNQuery returns a page of the player's finished games in PGN,
+// in the order they ended.
+type GetPlayerGamesPGNQuery struct {
+	PlayerID uuid.UUID
+	Page     core.PageRequest
+}
+
+func (q GetPlayerGamesPGNQuery) Validate() error {
+	if q.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", q.PlayerID)
+	}
+
+	return q.Page.Validate()
+}
+
+// HandleExportPlayerGamesPGN streams all the finished games of the user as a single
+// PGN file. The games are queried a page at a time, and every page is flushed to the
+// client before the next is queried, so exports of any size are never held in memory.
+func HandleExportPlayerGamesPGN(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		core.WriteInternalServerError(w, r, fmt.Errorf("streaming is not supported"))
+		return
+	}
+
+	query := GetPlayerGamesPGNQuery{
+		PlayerID: core.Session(ctx).UserID,
+		Page:     core.PageRequest{Limit: core.MaxPageLimit},
+	}
+
+	written := false
+	for {
+		page, err := mediator.Send[GetPlayerGamesPGNQuery, core.Page[chess.PGNGame]](ctx, query)
+		if err != nil {
+			// Once the export started the status is sent, the client gets a truncated file.
+			if !written {
+				core.WriteCommandError(w, r, err)
+			}
+			return
+		}
+
+		if !written {
+			w.Header().Set("Content-Type", pgnContentType)
+			w.Header().Set("Content-Disposition", `attachment; filename="games.pgn"`)
+			w.WriteHeader(http.StatusOK)
+			written = true
+		}
+
+		if err := chess.WritePGN(w, page.Items...); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		if page.NextCursor == "" {
+			return
+		}
+
+		query.Page.Cursor = page.NextCursor
+	}
+}
+
+type GetPlayerGamesPGNQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetPlayerGamesPGNQueryHandler(db *sql.DB) *GetPlayerGamesPGNQueryHandler {
+	return &GetPlayerGamesPGNQueryHandler{db}
+}
+
+// playerGamesCursor is the last game on a page.
+type playerGamesCursor struct {
+	EndedAt time.Time
+	GameID  uuid.UUID
+}
+
+func (h *GetPlayerGamesPGNQueryHandler) Handle(
+	ctx context.Context,
+	request GetPlayerGamesPGNQuery,
+) (core.Page[chess.PGNGame], error) {
+	var cursor playerGamesCursor
+	if request.Page.Cursor != "" {
+		decoded, err := core.DecodeCursor[playerGamesCursor](request.Page.Cursor)
+		if err != nil {
+			return core.Page[chess.PGNGame]{}, core.NewCommandError(400, err)
+		}
+		cursor = decoded
+	}
+
+	const query = `
+		SELECT
+			*
+		FROM
+			game
+		WHERE
+			(white_id = $1 OR black_id = $1)
+			AND status = $2
+			AND (ended_at, id) > ($3, $4)
+		ORDER BY
+			ended_at, id
+		LIMIT
+			$5;`
+	games, err := tql.Query[domain.Game](
+		ctx,
+		h.db,
+		query,
+		request.PlayerID,
+		domain.GameEnded,
+		cursor.EndedAt,
+		cursor.GameID,
+		request.Page.Limit+1,
+	)
+	if err != nil {
+		return core.Page[chess.PGNGame]{}, core.NewCommandError(500, err)
+	}
+
+	page, err := core.NewPage(games, request.Page.Limit, func(g domain.Game) playerGamesCursor {
+		return playerGamesCursor{EndedAt: *g.EndedAt, GameID: g.ID}
+	})
+	if err != nil {
+		return core.Page[chess.PGNGame]{}, core.NewCommandError(500, err)
+	}
+
+	pgns, err := gamesPGN(ctx, h.db, page.Items)
+	if err != nil {
+		return core.Page[chess.PGNGame]{}, core.NewCommandError(500, err)
+	}
+
+	return core.Page[chess.PGNGame]{Items: pgns, NextCursor: page.NextCursor}, nil
+}
@@ -0,0 +1,114 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// invitationsLimit caps the number of invitations listed, newest first.
+const invitationsLimit = 100
+
+// GetReceivedInvitationsQuery returns the invitations sent to the user with the
+// given status. Pending invitations past their expiry are left out.
+type GetReceivedInvitationsQuery struct {
+	InviteeID uuid.UUID
+	Status    domain.InvitationStatus
+}
+
+func (q GetReceivedInvitationsQuery) Validate() error {
+	if q.InviteeID == uuid.Nil {
+		return fmt.Errorf("invalid InviteeID - '%s'", q.InviteeID)
+	}
+
+	return validateInvitationStatus(q.Status)
+}
+
+func HandleGetReceivedInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := GetReceivedInvitationsQuery{
+		InviteeID: core.Session(ctx).UserID,
+		Status:    invitationStatusParam(r),
+	}
+
+	response, err := mediator.Send[GetReceivedInvitationsQuery, []domain.SessionInvitation](ctx, query)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetReceivedInvitationsQueryHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewGetReceivedInvitationsQueryHandler(db *sql.DB, clock core.Clock) *GetReceivedInvitationsQueryHandler {
+	return &GetReceivedInvitationsQueryHandler{db: db, clock: clock}
+}
+
+func (h *GetReceivedInvitationsQueryHandler) Handle(
+	ctx context.Context,
+	request GetReceivedInvitationsQuery,
+) ([]domain.SessionInvitation, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			session_invitation
+		WHERE
+			invitee_id = $1 AND status = $2 AND (status <> $3 OR expires_at > $4)
+		ORDER BY
+			created_at DESC
+		LIMIT
+			$5;`
+	invitations, err := tql.Query[domain.SessionInvitation](
+		ctx,
+		h.db,
+		query,
+		request.InviteeID,
+		request.Status,
+		domain.InvitationPending,
+		h.clock.Now(),
+		invitationsLimit,
+	)
+	if err != nil {
+		return nil, core.NewCommandError(500, err)
+	}
+
+	return invitations, nil
+}
+
+// invitationStatusParam is the 'status' query param, pending when missing.
+func invitationStatusParam(r *http.Request) domain.InvitationStatus {
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		return domain.InvitationPending
+	}
+
+	return domain.InvitationStatus(status)
+}
+
+func validateInvitationStatus(status domain.InvitationStatus) error {
+	switch status {
+	case domain.InvitationPending,
+		domain.InvitationAccepted,
+		domain.InvitationDeclined,
+		domain.InvitationCancelled,
+		domain.InvitationExpired:
+		return nil
+	default:
+		return fmt.Errorf("invalid Status - '%s'", status)
+	}
+}
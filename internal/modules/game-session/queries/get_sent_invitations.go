@@ -0,0 +1,88 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetSentInvitationsQuery returns the invitations sent by the user with the
+// given status. Pending invitations past their expiry are left out.
+type GetSentInvitationsQuery struct {
+	InviterID uuid.UUID
+	Status    domain.InvitationStatus
+}
+
+func (q GetSentInvitationsQuery) Validate() error {
+	if q.InviterID == uuid.Nil {
+		return fmt.Errorf("invalid InviterID - '%s'", q.InviterID)
+	}
+
+	return validateInvitationStatus(q.Status)
+}
+
+func HandleGetSentInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := GetSentInvitationsQuery{
+		InviterID: core.Session(ctx).UserID,
+		Status:    invitationStatusParam(r),
+	}
+
+	response, err := mediator.Send[GetSentInvitationsQuery, []domain.SessionInvitation](ctx, query)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetSentInvitationsQueryHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewGetSentInvitationsQueryHandler(db *sql.DB, clock core.Clock) *GetSentInvitationsQueryHandler {
+	return &GetSentInvitationsQueryHandler{db: db, clock: clock}
+}
+
+func (h *GetSentInvitationsQueryHandler) Handle(
+	ctx context.Context,
+	request GetSentInvitationsQuery,
+) ([]domain.SessionInvitation, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			session_invitation
+		WHERE
+			inviter_id = $1 AND status = $2 AND (status <> $3 OR expires_at > $4)
+		ORDER BY
+			created_at DESC
+		LIMIT
+			$5;`
+	invitations, err := tql.Query[domain.SessionInvitation](
+		ctx,
+		h.db,
+		query,
+		request.InviterID,
+		request.Status,
+		domain.InvitationPending,
+		h.clock.Now(),
+		invitationsLimit,
+	)
+	if err != nil {
+		return nil, core.NewCommandError(500, err)
+	}
+
+	return invitations, nil
+}
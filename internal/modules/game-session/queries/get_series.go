@@ -0,0 +1,93 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetSeriesQuery returns the running score of the series the session is part of.
+// Series of private sessions are only shown to their players.
+type GetSeriesQuery struct {
+	SessionID string
+	UserID    uuid.UUID
+}
+
+func (q GetSeriesQuery) Validate() error {
+	if q.SessionID == "" {
+		return fmt.Errorf("invalid SessionID - '%s'", q.SessionID)
+	}
+
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return nil
+}
+
+func HandleGetSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	response, err := mediator.Send[GetSeriesQuery, domain.SeriesScore](
+		ctx,
+		GetSeriesQuery{SessionID: r.PathValue("id"), UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetSeriesQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetSeriesQueryHandler(db *sql.DB) *GetSeriesQueryHandler {
+	return &GetSeriesQueryHandler{db}
+}
+
+func (h *GetSeriesQueryHandler) Handle(ctx context.Context, request GetSeriesQuery) (domain.SeriesScore, error) {
+	const query = `
+		SELECT
+			gs.*
+		FROM
+			game_series gs
+			JOIN game_session s ON s.series_id = gs.id
+		WHERE
+			s.id = $1 AND (s.visibility = $2 OR gs.player_1_id = $3 OR gs.player_2_id = $3);`
+	series, err := tql.QueryFirst[domain.Series](ctx, h.db, query, request.SessionID, domain.Public, request.UserID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return domain.SeriesScore{}, core.NewCommandError(404, domain.ErrNotInSeries)
+	case err != nil:
+		return domain.SeriesScore{}, core.NewCommandError(500, err)
+	}
+
+	const gamesQuery = `
+		SELECT
+			g.*
+		FROM
+			game g
+			JOIN game_session s ON s.id = g.session_id
+		WHERE
+			s.series_id = $1
+		ORDER BY
+			g.created_at;`
+	games, err := tql.Query[domain.Game](ctx, h.db, gamesQuery, series.ID)
+	if err != nil {
+		return domain.SeriesScore{}, core.NewCommandError(500, err)
+	}
+
+	return series.Score(games), nil
+}
@@ -0,0 +1,99 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetVacationsQuery returns the user's vacation allowance for this year and the
+// vacations taken from it, latest first.
+type GetVacationsQuery struct {
+	UserID uuid.UUID
+}
+
+func (q GetVacationsQuery) Validate() error {
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return nil
+}
+
+type GetVacationsResponse struct {
+	DaysPerYear int
+	UsedDays    int
+	// OnVacation is true while one of the vacations is running.
+	OnVacation bool
+	Vacations  []domain.Vacation
+}
+
+func HandleGetVacations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	response, err := mediator.Send[GetVacationsQuery, GetVacationsResponse](
+		ctx,
+		GetVacationsQuery{UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetVacationsQueryHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewGetVacationsQueryHandler(db *sql.DB, clock core.Clock) *GetVacationsQueryHandler {
+	return &GetVacationsQueryHandler{db: db, clock: clock}
+}
+
+func (h *GetVacationsQueryHandler) Handle(ctx context.Context, request GetVacationsQuery) (GetVacationsResponse, error) {
+	now := h.clock.Now()
+
+	// A vacation started last year can still be running.
+	const query = `
+		SELECT
+			*
+		FROM
+			user_vacation
+		WHERE
+			user_id = $1 AND (starts_at >= $2 OR ends_at > $3)
+		ORDER BY
+			starts_at DESC;`
+	vacations, err := tql.Query[domain.Vacation](ctx, h.db, query, request.UserID, domain.VacationYearStart(now), now)
+	if err != nil {
+		return GetVacationsResponse{}, core.NewCommandError(500, err)
+	}
+
+	response := GetVacationsResponse{
+		DaysPerYear: domain.VacationDaysPerYear,
+		Vacations:   make([]domain.Vacation, 0, len(vacations)),
+	}
+
+	for _, vacation := range vacations {
+		if !vacation.StartsAt.Before(domain.VacationYearStart(now)) {
+			response.UsedDays += vacation.Days
+		}
+
+		if vacation.Remaining(now) > 0 {
+			response.OnVacation = true
+		}
+
+		response.Vacations = append(response.Vacations, vacation)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	InvitationReceivedNotification     NotificationType = "invitation_received"
+	InvitationDeclinedNotification     NotificationType = "invitation_declined"
+	InvitationCancelledNotification    NotificationType = "invitation_cancelled"
+	InvitationExpiredNotification      NotificationType = "invitation_expired"
+	GameStartedNotification            NotificationType = "game_started"
+	GameEndedNotification              NotificationType = "game_ended"
+	GameOfferReceivedNotification      NotificationType = "game_offer_received"
+	GameOfferAnsweredNotification      NotificationType = "game_offer_answered"
+	VictoryClaimableNotification       NotificationType = "victory_claimable"
+	RematchOfferedNotification         NotificationType = "rematch_offered"
+	RematchAnsweredNotification        NotificationType = "rematch_answered"
+	TournamentRoundStartedNotification NotificationType = "tournament_round_started"
+	TournamentFinishedNotification     NotificationType = "tournament_finished"
+	ArenaFinishedNotification          NotificationType = "arena_finished"
+	FriendRequestReceivedNotification  NotificationType = "friend_request_received"
+	FriendRequestAcceptedNotification  NotificationType = "friend_request_accepted"
+
+	LobbySessionOpenedNotification NotificationType = "lobby_session_opened"
+	LobbySessionClosedNotification NotificationType = "lobby_session_closed"
+)
+
+// Notification is an entry in the notification log. Notifications without
+// a UserID are lobby notifications and are delivered to everyone.
+//
+// IDs are increasing, so clients can resume a stream from the last ID they have seen.
+type Notification struct {
+	ID        int64            `db:"id"`
+	UserID    uuid.NullUUID    `db:"user_id"`
+	Type      NotificationType `db:"type"`
+	Payload   string           `db:"payload"`
+	CreatedAt time.Time        `db:"created_at"`
+}
+
+func NewUserNotification(
+	userID uuid.UUID,
+	notificationType NotificationType,
+	payload any,
+	now time.Time,
+) (Notification, error) {
+	return newNotification(uuid.NullUUID{UUID: userID, Valid: true}, notificationType, payload, now)
+}
+
+func NewLobbyNotification(notificationType NotificationType, payload any, now time.Time) (Notification, error) {
+	return newNotification(uuid.NullUUID{}, notificationType, payload, now)
+}
+
+func newNotification(
+	userID uuid.NullUUID,
+	notificationType NotificationType,
+	payload any,
+	now time.Time,
+) (Notification, error) {
+	serialized, err := json.Marshal(payload)
+	if err != nil {
+		return Notification{}, err
+	}
+
+	return Notification{
+		UserID:    userID,
+		Type:      notificationType,
+		Payload:   string(serialized),
+		CreatedAt: now,
+	}, nil
+}
+
+// Topic is the pub/sub topic the streams of the notification's recipients subscribe to.
+func (n Notification) Topic() string {
+	if !n.UserID.Valid {
+		return LobbyTopic
+	}
+
+	return UserTopic(n.UserID.UUID)
+}
+
+const LobbyTopic = "notifications:lobby"
+
+func UserTopic(userID uuid.UUID) string {
+	return fmt.Sprintf("notifications:%s", userID)
+}
+
+type InvitationReceivedPayload struct {
+	InvitationID uuid.UUID
+	SessionID    string
+	InviterID    uuid.UUID
+}
+
+// InvitationClosedPayload is sent when an invitation is declined, cancelled or expires.
+type InvitationClosedPayload struct {
+	InvitationID uuid.UUID
+	SessionID    string
+}
+
+type GameStartedPayload struct {
+	SessionID string
+	GameID    uuid.UUID
+	WhiteID   uuid.UUID
+	BlackID   uuid.UUID
+}
+
+type GameEndedPayload struct {
+	SessionID   string
+	GameID      uuid.UUID
+	Result      string
+	Termination string
+}
+
+// VictoryClaimablePayload is sent to the opponent of a player who abandoned the game.
+type VictoryClaimablePayload struct {
+	SessionID   string
+	GameID      uuid.UUID
+	ClaimableAt time.Time
+}
+
+// GameOfferPayload is sent for draw offers and takeback requests, Status tells
+// whether the offer was accepted or declined once answered.
+type GameOfferPayload struct {
+	OfferID   uuid.UUID
+	SessionID string
+	GameID    uuid.UUID
+	Type      string
+	Status    string
+}
+
+// RematchOfferPayload is sent for rematch offers, Status tells whether the offer was
+// accepted, declined or expired once answered. RematchSessionID is the session of
+// the rematch when accepted.
+type RematchOfferPayload struct {
+	OfferID          uuid.UUID
+	SessionID        string
+	GameID           uuid.UUID
+	Status           string
+	ExpiresAt        time.Time
+	RematchSessionID string
+}
+
+// TournamentPayload is sent to the players of a tournament when a round starts and
+// when the tournament finishes. Round is the round which started or the last round.
+type TournamentPayload struct {
+	TournamentID uuid.UUID
+	Name         string
+	Round        int
+}
+
+// ArenaPayload is sent to the players of an arena once its time is up.
+type ArenaPayload struct {
+	ArenaID uuid.UUID
+	Name    string
+}
+
+// FriendRequestPayload is sent to the recipient of a friend request and to its
+// sender once it is accepted. UserID is the other user.
+type FriendRequestPayload struct {
+	RequestID uuid.UUID
+	UserID    uuid.UUID
+}
+
+type LobbySessionOpenedPayload struct {
+	SessionID                   string
+	Name                        string
+	OwnerID                     uuid.UUID
+	Rated                       bool
+	Variant                     string
+	TimeControlBaseSeconds      int
+	TimeControlIncrementSeconds int
+}
+
+type LobbySessionClosedPayload struct {
+	SessionID string
+}
@@ -0,0 +1,182 @@
+package live
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/queries"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+const (
+	heartbeatPeriod = 15 * time.Second
+	retryMs         = 3000
+
+	// Published notifications are only wake-ups, the notifications themselves are
+	// read from the notification log in order, so a small buffer is enough.
+	subscriptionBuffer = 16
+	batchSize          = 100
+)
+
+// HandleNotificationStream streams the user's invitations, game starts and
+// the lobby changes as server-sent events.
+//
+// The ID of each event is the notification ID. Clients which reconnect send the
+// last ID they have seen in the Last-Event-ID header, or the 'lastEventId' query
+// param, to receive only the notifications they have missed.
+func HandleNotificationStream(pubSub *core.PubSub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := core.Session(ctx).UserID
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			core.WriteInternalServerError(w, r, fmt.Errorf("streaming is not supported"))
+			return
+		}
+
+		lastEventID := r.Header.Get("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = r.URL.Query().Get("lastEventId")
+		}
+
+		// Subscribe before reading the log so no notification stored in the meantime is lost.
+		s := stream{w: w, flusher: flusher, pubSub: pubSub, userID: userID}
+		s.subscribe()
+		defer s.unsubscribe()
+
+		if lastEventID != "" {
+			id, err := strconv.ParseInt(lastEventID, 10, 64)
+			if err != nil || id < 0 {
+				core.WriteBadRequest(w, r, fmt.Errorf("invalid format for Last-Event-ID"))
+				return
+			}
+			s.lastID = id
+		} else {
+			latestID, err := mediator.Send[queries.GetLatestNotificationIDQuery, int64](
+				ctx,
+				queries.GetLatestNotificationIDQuery{},
+			)
+			if err != nil {
+				core.WriteCommandError(w, r, err)
+				return
+			}
+			s.lastID = latestID
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		if err := s.write(fmt.Sprintf("retry: %d\n\n", retryMs)); err != nil {
+			return
+		}
+
+		if err := s.catchUp(ctx); err != nil {
+			return
+		}
+
+		s.run(ctx)
+	}
+}
+
+type stream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	pubSub  *core.PubSub
+
+	userID uuid.UUID
+	lastID int64
+
+	user  *core.Subscription
+	lobby *core.Subscription
+}
+
+func (s *stream) subscribe() {
+	s.user = s.pubSub.Subscribe(domain.UserTopic(s.userID), subscriptionBuffer)
+	s.lobby = s.pubSub.Subscribe(domain.LobbyTopic, subscriptionBuffer)
+}
+
+func (s *stream) unsubscribe() {
+	s.pubSub.Unsubscribe(s.user)
+	s.pubSub.Unsubscribe(s.lobby)
+}
+
+func (s *stream) run(ctx context.Context) {
+	ticker := time.NewTicker(heartbeatPeriod)
+	defer ticker.Stop()
+
+	for {
+		var ok bool
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			// Also how a closed connection is detected.
+			if err := s.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+			continue
+
+		case _, ok = <-s.user.Messages():
+		case _, ok = <-s.lobby.Messages():
+		}
+
+		if !ok {
+			// A subscription was dropped for falling behind or for a reconnect of the
+			// listener, the log has everything missed so it is enough to subscribe
+			// again and catch up.
+			s.unsubscribe()
+			s.subscribe()
+		}
+
+		if err := s.catchUp(ctx); err != nil {
+			return
+		}
+	}
+}
+
+// catchUp writes all notifications stored after the last one sent.
+func (s *stream) catchUp(ctx context.Context) error {
+	for {
+		notifications, err := mediator.Send[queries.GetNotificationsQuery, []domain.Notification](
+			ctx,
+			queries.GetNotificationsQuery{UserID: s.userID, AfterID: s.lastID, Limit: batchSize},
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, notification := range notifications {
+			event := fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", notification.ID, notification.Type, notification.Payload)
+			if err := s.write(event); err != nil {
+				return err
+			}
+			s.lastID = notification.ID
+		}
+
+		if len(notifications) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (s *stream) write(event string) error {
+	if _, err := fmt.Fprint(s.w, event); err != nil {
+		return err
+	}
+
+	s.flusher.Flush()
+	return nil
+}
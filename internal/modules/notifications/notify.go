@@ -0,0 +1,61 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/tql"
+)
+
+// notificationLockID is the advisory lock serializing the notification writers.
+const notificationLockID int64 = 0x6e6f7469667931
+
+// Notify stores the notifications as part of the transaction and publishes them
+// to the streams on every API instance once the transaction commits.
+//
+// The notifications are stored right before the transaction commits, since storing
+// them takes a lock shared by all notification writers until the transaction ends.
+func Notify(ctx context.Context, tx *sql.Tx, notifications ...domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return core.BeforeCommit(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return store(ctx, tx, notifications)
+	})
+}
+
+func store(ctx context.Context, tx *sql.Tx, notifications []domain.Notification) error {
+	const stmt = `
+		INSERT INTO
+			notification (user_id, type, payload, created_at)
+		VALUES
+			(:user_id, :type, :payload, :created_at)
+		RETURNING
+			id;`
+
+	// IDs come from a sequence and are handed out before commit, so without the lock
+	// a notification could become visible after one with a greater ID was already
+	// streamed and a client resuming from that ID would never receive it. The lock is
+	// held until the transaction ends, so notifications are committed in ID order.
+	if _, err := tql.Exec(ctx, tx, `SELECT pg_advisory_xact_lock($1);`, notificationLockID); err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		id, err := tql.QueryFirst[int64](ctx, tx, stmt, notification)
+		if err != nil {
+			return err
+		}
+		notification.ID = id
+
+		if err := core.Publish(ctx, tx, notification.Topic(), notification); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+const maxNotificationsLimit = 500
+
+// GetNotificationsQuery returns the user's and the lobby notifications
+// stored after the given ID, oldest first.
+type GetNotificationsQuery struct {
+	UserID  uuid.UUID
+	AfterID int64
+	Limit   int
+}
+
+func (q GetNotificationsQuery) Validate() error {
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	if q.AfterID < 0 {
+		return fmt.Errorf("invalid AfterID - '%d'", q.AfterID)
+	}
+
+	if q.Limit < 1 || q.Limit > maxNotificationsLimit {
+		return fmt.Errorf("invalid Limit - '%d'", q.Limit)
+	}
+
+	return nil
+}
+
+type GetNotificationsQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetNotificationsQueryHandler(db *sql.DB) *GetNotificationsQueryHandler {
+	return &GetNotificationsQueryHandler{db}
+}
+
+func (h *GetNotificationsQueryHandler) Handle(
+	ctx context.Context,
+	request GetNotificationsQuery,
+) ([]domain.Notification, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			notification
+		WHERE
+			(user_id = $1 OR user_id IS NULL) AND id > $2
+		ORDER BY
+			id
+		LIMIT $3;`
+	notifications, err := tql.Query[domain.Notification](ctx, h.db, query, request.UserID, request.AfterID, request.Limit)
+	if err != nil {
+		return nil, core.NewCommandError(500, err)
+	}
+
+	return notifications, nil
+}
+
+// GetLatestNotificationIDQuery returns the ID of the newest notification,
+// streams opened without a Last-Event-ID start after it.
+type GetLatestNotificationIDQuery struct{}
+
+type GetLatestNotificationIDQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetLatestNotificationIDQueryHandler(db *sql.DB) *GetLatestNotificationIDQueryHandler {
+	return &GetLatestNotificationIDQueryHandler{db}
+}
+
+func (h *GetLatestNotificationIDQueryHandler) Handle(
+	ctx context.Context,
+	_ GetLatestNotificationIDQuery,
+) (int64, error) {
+	const query = `
+		SELECT
+			COALESCE(MAX(id), 0)
+		FROM
+			notification;`
+	id, err := tql.QueryFirst[int64](ctx, h.db, query)
+	if err != nil {
+		return 0, core.NewCommandError(500, err)
+	}
+
+	return id, nil
+}
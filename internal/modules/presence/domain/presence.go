@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RefreshInterval is how often each instance reports the users it serves.
+	RefreshInterval = 5 * time.Second
+	// TTL is how long a report keeps the user online. It spans a few refreshes, so a
+	// late refresh does not flicker the user offline, while the users of a crashed
+	// instance go offline once its last reports expire.
+	TTL = 30 * time.Second
+)
+
+// Status is whether the user is around and whether they are busy with a game.
+type Status string
+
+const (
+	Offline Status = "offline"
+	Online  Status = "online"
+	// Playing users are online and in a game played over the board, correspondence
+	// games do not keep anyone busy.
+	Playing Status = "playing"
+)
+
+func NewStatus(online bool, playing bool) Status {
+	switch {
+	case !online:
+		return Offline
+	case playing:
+		return Playing
+	default:
+		return Online
+	}
+}
+
+// Presence is a user's status.
+type Presence struct {
+	UserID uuid.UUID
+	Status Status
+}
+
+// Statuses are the statuses of a set of users, the users missing from it are offline.
+type Statuses map[uuid.UUID]Status
+
+func (s Statuses) Of(userID uuid.UUID) Status {
+	status, found := s[userID]
+	if !found {
+		return Offline
+	}
+
+	return status
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewStatus(t *testing.T) {
+	require.Equal(t, Offline, NewStatus(false, false))
+	require.Equal(t, Offline, NewStatus(false, true))
+	require.Equal(t, Online, NewStatus(true, false))
+	require.Equal(t, Playing, NewStatus(true, true))
+}
+
+func Test_Statuses_Of_Missing_User_Is_Offline(t *testing.T) {
+	// Arrange
+	onlineID := uuid.New()
+	statuses := Statuses{onlineID: Online}
+
+	// Act & Assert
+	require.Equal(t, Online, statuses.Of(onlineID))
+	require.Equal(t, Offline, statuses.Of(uuid.New()))
+	require.Equal(t, Offline, Statuses(nil).Of(onlineID))
+}
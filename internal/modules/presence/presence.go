@@ -0,0 +1,192 @@
+package presence
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/presence/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Tracker follows the users this instance serves and reports them every
+// domain.RefreshInterval. A user is served while they have a request in flight,
+// which keeps users with a live stream or a game socket open online, and until the
+// refresh after their last request.
+type Tracker struct {
+	db         *sql.DB
+	clock      core.Clock
+	instanceID uuid.UUID
+
+	mu sync.Mutex
+	// connections counts the requests in flight of each user.
+	connections map[uuid.UUID]int
+	// seen are the users who made a request since the last refresh.
+	seen map[uuid.UUID]struct{}
+}
+
+func NewTracker(db *sql.DB, clock core.Clock) *Tracker {
+	return &Tracker{
+		db:          db,
+		clock:       clock,
+		instanceID:  uuid.New(),
+		connections: make(map[uuid.UUID]int),
+		seen:        make(map[uuid.UUID]struct{}),
+	}
+}
+
+// Middleware tracks the logged-in user for as long as their request runs. It runs
+// after the AuthenticationMiddleware, which puts the user in the context.
+func (t *Tracker) Middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := core.Session(r.Context()).UserID
+		if userID == uuid.Nil {
+			next(w, r)
+			return
+		}
+
+		t.connect(userID)
+		defer t.disconnect(userID)
+
+		next(w, r)
+	}
+}
+
+func (t *Tracker) connect(userID uuid.UUID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.connections[userID]++
+	t.seen[userID] = struct{}{}
+}
+
+func (t *Tracker) disconnect(userID uuid.UUID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.connections[userID]--
+	if t.connections[userID] <= 0 {
+		delete(t.connections, userID)
+	}
+}
+
+// served returns the users to report and forgets the ones only seen since the last refresh.
+func (t *Tracker) served() []uuid.UUID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	userIDs := make([]uuid.UUID, 0, len(t.seen)+len(t.connections))
+	for userID := range t.seen {
+		userIDs = append(userIDs, userID)
+	}
+
+	for userID := range t.connections {
+		if _, seen := t.seen[userID]; !seen {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	clear(t.seen)
+	return userIDs
+}
+
+// Refresh reports the users this instance serves and deletes the expired reports of
+// every instance, including those which are gone.
+func (t *Tracker) Refresh(ctx context.Context) error {
+	userIDs := t.served()
+	now := t.clock.Now()
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		if len(userIDs) > 0 {
+			const stmt = `
+				INSERT INTO
+					user_presence (user_id, instance_id, expires_at)
+				SELECT
+					u.id, $2, $3
+				FROM
+					auth.user u
+				WHERE
+					u.id = ANY($1)
+				ON CONFLICT (user_id, instance_id) DO UPDATE SET
+					expires_at = EXCLUDED.expires_at;`
+			if _, err := tql.Exec(ctx, tx, stmt, pq.Array(userIDs), t.instanceID, now.Add(domain.TTL)); err != nil {
+				return err
+			}
+		}
+
+		const cleanupStmt = `
+			DELETE FROM
+				user_presence
+			WHERE
+				expires_at <= $1;`
+		_, err := tql.Exec(ctx, tx, cleanupStmt, now)
+		return err
+	}
+
+	if err := core.Tx(ctx, t.db, txFn); err != nil {
+		// The users seen meanwhile are reported by the next refresh.
+		t.mu.Lock()
+		for _, userID := range userIDs {
+			t.seen[userID] = struct{}{}
+		}
+		t.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// Statuses loads the statuses of the users. A user is online while a report of them
+// has not expired, whichever instance made it.
+func Statuses(ctx context.Context, q tql.Querier, userIDs []uuid.UUID, now time.Time) (domain.Statuses, error) {
+	const onlineQuery = `
+		SELECT DISTINCT
+			user_id
+		FROM
+			user_presence
+		WHERE
+			user_id = ANY($1) AND expires_at > $2;`
+	online, err := tql.Query[uuid.UUID](ctx, q, onlineQuery, pq.Array(userIDs), now)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(domain.Statuses, len(online))
+	if len(online) == 0 {
+		return statuses, nil
+	}
+
+	const playingQuery = `
+		SELECT
+			white_id
+		FROM
+			game
+		WHERE
+			white_id = ANY($1) AND status = $2 AND time_control_days_per_move = 0
+		UNION
+		SELECT
+			black_id
+		FROM
+			game
+		WHERE
+			black_id = ANY($1) AND status = $2 AND time_control_days_per_move = 0;`
+	playing, err := tql.Query[uuid.UUID](ctx, q, playingQuery, pq.Array(online), gamesessiondomain.GameStarted)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, userID := range online {
+		statuses[userID] = domain.NewStatus(true, slices.Contains(playing, userID))
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,54 @@
+package presence
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Tracker_Serves_Users_While_Connected_And_Until_Refreshed(t *testing.T) {
+	// Arrange
+	tracker := NewTracker(nil, core.SystemClock{})
+	streamingID, requestingID := uuid.New(), uuid.New()
+
+	release := make(chan struct{})
+	streaming := make(chan struct{})
+	stream := tracker.Middleware(func(http.ResponseWriter, *http.Request) {
+		close(streaming)
+		<-release
+	})
+
+	request := func(userID uuid.UUID) *http.Request {
+		ctx := context.WithValue(context.Background(), core.SessionContextKey, core.ContextSession{UserID: userID})
+		return httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	}
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stream(httptest.NewRecorder(), request(streamingID))
+	}()
+	<-streaming
+
+	tracker.Middleware(func(http.ResponseWriter, *http.Request) {})(httptest.NewRecorder(), request(requestingID))
+	tracker.Middleware(func(http.ResponseWriter, *http.Request) {})(httptest.NewRecorder(), request(uuid.Nil))
+
+	first := tracker.served()
+	second := tracker.served()
+
+	close(release)
+	<-done
+	third := tracker.served()
+
+	// Assert
+	require.ElementsMatch(t, []uuid.UUID{streamingID, requestingID}, first)
+	require.Equal(t, []uuid.UUID{streamingID}, second, "only the open stream keeps its user served")
+	require.Empty(t, third)
+}
@@ -0,0 +1,76 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/presence"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/presence/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetPresenceQuery returns whether the player is offline, online or playing.
+type GetPresenceQuery struct {
+	PlayerID uuid.UUID
+}
+
+func (q GetPresenceQuery) Validate() error {
+	if q.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", q.PlayerID)
+	}
+
+	return nil
+}
+
+func HandleGetPresence(w http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetPresenceQuery, domain.Presence](
+		r.Context(),
+		GetPresenceQuery{PlayerID: playerID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetPresenceQueryHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewGetPresenceQueryHandler(db *sql.DB, clock core.Clock) *GetPresenceQueryHandler {
+	return &GetPresenceQueryHandler{db: db, clock: clock}
+}
+
+func (h *GetPresenceQueryHandler) Handle(ctx context.Context, request GetPresenceQuery) (domain.Presence, error) {
+	const userQuery = `SELECT id FROM auth.user WHERE id = $1;`
+	_, err := tql.QueryFirst[uuid.UUID](ctx, h.db, userQuery, request.PlayerID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return domain.Presence{}, core.NewCommandError(404, fmt.Errorf("player '%s' not found", request.PlayerID))
+	case err != nil:
+		return domain.Presence{}, core.NewCommandError(500, err)
+	}
+
+	statuses, err := presence.Statuses(ctx, h.db, []uuid.UUID{request.PlayerID}, h.clock.Now())
+	if err != nil {
+		return domain.Presence{}, core.NewCommandError(500, err)
+	}
+
+	return domain.Presence{UserID: request.PlayerID, Status: statuses.Of(request.PlayerID)}, nil
+}
@@ -0,0 +1,59 @@
+package profiles
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+// AvatarStore keeps the users' avatars on the local disk, one PNG per user.
+type AvatarStore struct {
+	dir string
+}
+
+func NewAvatarStore(dir string) *AvatarStore {
+	return &AvatarStore{dir: dir}
+}
+
+// Path is the file the user's avatar is stored in.
+func (s *AvatarStore) Path(userID uuid.UUID) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s.png", userID))
+}
+
+// Save replaces the user's avatar. The avatar is written next to the old one first,
+// so the old avatar is served until the new one is complete.
+func (s *AvatarStore) Save(userID uuid.UUID, avatar []byte) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.dir, fmt.Sprintf("%s-*.tmp", userID))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(avatar); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), s.Path(userID))
+}
+
+// Remove deletes the user's avatar, if they have one.
+func (s *AvatarStore) Remove(userID uuid.UUID) error {
+	if err := os.Remove(s.Path(userID)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
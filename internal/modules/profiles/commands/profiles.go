@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// getProfile locks the user's profile, a new one if the user has none yet.
+func getProfile(ctx context.Context, tx *sql.Tx, userID uuid.UUID, now time.Time) (domain.Profile, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			player_profile
+		WHERE
+			user_id = $1
+		FOR UPDATE;`
+	profile, err := tql.QueryFirst[domain.Profile](ctx, tx, query, userID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return domain.NewProfile(userID, now), nil
+	case err != nil:
+		return domain.Profile{}, err
+	}
+
+	return profile, nil
+}
+
+func saveProfile(ctx context.Context, tx *sql.Tx, profile domain.Profile) error {
+	const stmt = `
+		INSERT INTO
+			player_profile (user_id, display_name, bio, country, avatar_updated_at, updated_at)
+		VALUES
+			(:user_id, :display_name, :bio, :country, :avatar_updated_at, :updated_at)
+		ON CONFLICT (user_id) DO UPDATE SET
+			display_name = EXCLUDED.display_name,
+			bio = EXCLUDED.bio,
+			country = EXCLUDED.country,
+			avatar_updated_at = EXCLUDED.avatar_updated_at,
+			updated_at = EXCLUDED.updated_at;`
+	_, err := tql.Exec(ctx, tx, stmt, profile)
+	return err
+}
+
+func profileCommandError(err error) error {
+	var commandErr core.CommandError
+	switch {
+	case errors.As(err, &commandErr):
+		return commandErr
+	case errors.Is(err, domain.ErrInvalidDisplayName),
+		errors.Is(err, domain.ErrInvalidBio),
+		errors.Is(err, domain.ErrInvalidCountry),
+		errors.Is(err, domain.ErrInvalidAvatarImage):
+		return core.NewCommandError(400, err)
+	case errors.Is(err, domain.ErrAvatarTooLarge):
+		return core.NewCommandError(413, err)
+	default:
+		return core.NewCommandError(500, err)
+	}
+}
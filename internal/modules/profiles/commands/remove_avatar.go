@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// RemoveAvatarCommand removes the user's avatar.
+type RemoveAvatarCommand struct {
+	UserID uuid.UUID
+}
+
+func (c RemoveAvatarCommand) Validate() error {
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleRemoveAvatar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, err := mediator.Send[RemoveAvatarCommand, core.Unit](ctx, RemoveAvatarCommand{UserID: core.Session(ctx).UserID})
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type RemoveAvatarCommandHandler struct {
+	db      *sql.DB
+	clock   core.Clock
+	avatars *profiles.AvatarStore
+}
+
+func NewRemoveAvatarCommandHandler(db *sql.DB, clock core.Clock, avatars *profiles.AvatarStore) *RemoveAvatarCommandHandler {
+	return &RemoveAvatarCommandHandler{db: db, clock: clock, avatars: avatars}
+}
+
+func (h *RemoveAvatarCommandHandler) Handle(ctx context.Context, request RemoveAvatarCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		profile, err := getProfile(ctx, tx, request.UserID, now)
+		if err != nil {
+			return err
+		}
+
+		if profile.AvatarUpdatedAt == nil {
+			return nil
+		}
+
+		profile.AvatarUpdatedAt = nil
+		profile.UpdatedAt = now
+		if err := saveProfile(ctx, tx, profile); err != nil {
+			return err
+		}
+
+		return h.avatars.Remove(request.UserID)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, profileCommandError(err)
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// UpdateProfileCommand replaces the details of the user's profile. Empty
+// details are cleared.
+type UpdateProfileCommand struct {
+	UserID      uuid.UUID
+	DisplayName string
+	Bio         string
+	Country     string
+}
+
+func (c UpdateProfileCommand) Validate() error {
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleUpdateProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command, err := core.RequestBody[UpdateProfileCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	command.UserID = core.Session(ctx).UserID
+
+	_, err = mediator.Send[UpdateProfileCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type UpdateProfileCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewUpdateProfileCommandHandler(db *sql.DB, clock core.Clock) *UpdateProfileCommandHandler {
+	return &UpdateProfileCommandHandler{db: db, clock: clock}
+}
+
+func (h *UpdateProfileCommandHandler) Handle(ctx context.Context, request UpdateProfileCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		profile, err := getProfile(ctx, tx, request.UserID, now)
+		if err != nil {
+			return err
+		}
+
+		if err := profile.Update(request.DisplayName, request.Bio, request.Country, now); err != nil {
+			return err
+		}
+
+		return saveProfile(ctx, tx, profile)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, profileCommandError(err)
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// UploadAvatarCommand replaces the user's avatar with the uploaded image, which
+// is sent as the request body.
+type UploadAvatarCommand struct {
+	UserID uuid.UUID
+	Image  []byte
+}
+
+func (c UploadAvatarCommand) Validate() error {
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	if len(c.Image) == 0 {
+		return fmt.Errorf("invalid Image - the request body is empty")
+	}
+
+	return nil
+}
+
+// LogValue keeps the image out of the request logs.
+func (c UploadAvatarCommand) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("UserID", c.UserID.String()), slog.Int("ImageBytes", len(c.Image)))
+}
+
+func HandleUploadAvatar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// One byte more than allowed is read, so too large uploads are told apart.
+	image, err := io.ReadAll(io.LimitReader(r.Body, domain.MaxAvatarBytes+1))
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	command := UploadAvatarCommand{UserID: core.Session(ctx).UserID, Image: image}
+
+	_, err = mediator.Send[UploadAvatarCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type UploadAvatarCommandHandler struct {
+	db      *sql.DB
+	clock   core.Clock
+	avatars *profiles.AvatarStore
+}
+
+func NewUploadAvatarCommandHandler(db *sql.DB, clock core.Clock, avatars *profiles.AvatarStore) *UploadAvatarCommandHandler {
+	return &UploadAvatarCommandHandler{db: db, clock: clock, avatars: avatars}
+}
+
+func (h *UploadAvatarCommandHandler) Handle(ctx context.Context, request UploadAvatarCommand) (core.Unit, error) {
+	avatar, err := domain.NewAvatar(request.Image)
+	if err != nil {
+		return core.Unit{}, profileCommandError(err)
+	}
+
+	now := h.clock.Now()
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		profile, err := getProfile(ctx, tx, request.UserID, now)
+		if err != nil {
+			return err
+		}
+
+		profile.AvatarUpdatedAt = &now
+		profile.UpdatedAt = now
+		if err := saveProfile(ctx, tx, profile); err != nil {
+			return err
+		}
+
+		// Written while the profile is locked, so concurrent uploads are stored in order.
+		return h.avatars.Save(request.UserID, avatar)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, profileCommandError(err)
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"slices"
+
+	// The formats avatars can be uploaded in.
+	_ "image/gif"
+	_ "image/jpeg"
+)
+
+const (
+	// MaxAvatarBytes is the largest avatar upload accepted.
+	MaxAvatarBytes = 2 << 20
+	// MaxAvatarDimension bounds the uploaded image, so small files cannot
+	// decode into huge images.
+	MaxAvatarDimension = 4096
+	// AvatarSize is the width and height avatars are stored in.
+	AvatarSize = 256
+)
+
+var avatarFormats = []string{"png", "jpeg", "gif"}
+
+var (
+	ErrAvatarTooLarge     = fmt.Errorf("avatar must be at most %d bytes and %dx%d pixels", MaxAvatarBytes, MaxAvatarDimension, MaxAvatarDimension)
+	ErrInvalidAvatarImage = errors.New("avatar must be a PNG, JPEG or GIF image")
+)
+
+// NewAvatar validates the uploaded image and turns it into the stored avatar, a square
+// AvatarSize PNG. Images which are not square are cropped around their center.
+func NewAvatar(upload []byte) ([]byte, error) {
+	if len(upload) > MaxAvatarBytes {
+		return nil, ErrAvatarTooLarge
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(upload))
+	if err != nil || !slices.Contains(avatarFormats, format) {
+		return nil, ErrInvalidAvatarImage
+	}
+
+	if config.Width > MaxAvatarDimension || config.Height > MaxAvatarDimension {
+		return nil, ErrAvatarTooLarge
+	}
+
+	if config.Width == 0 || config.Height == 0 {
+		return nil, ErrInvalidAvatarImage
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(upload))
+	if err != nil {
+		return nil, ErrInvalidAvatarImage
+	}
+
+	var avatar bytes.Buffer
+	if err := png.Encode(&avatar, resize(cropSquare(img.Bounds()), img, AvatarSize)); err != nil {
+		return nil, err
+	}
+
+	return avatar.Bytes(), nil
+}
+
+// cropSquare is the largest square around the center of the bounds.
+func cropSquare(bounds image.Rectangle) image.Rectangle {
+	side := min(bounds.Dx(), bounds.Dy())
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+	return image.Rect(x, y, x+side, y+side)
+}
+
+// resize scales the square area of the image to size by averaging the source pixels
+// each target pixel covers. Images smaller than size are scaled up.
+func resize(area image.Rectangle, img image.Image, size int) *image.NRGBA {
+	resized := image.NewNRGBA(image.Rect(0, 0, size, size))
+	side := area.Dx()
+
+	for y := range size {
+		y0 := area.Min.Y + y*side/size
+		y1 := max(area.Min.Y+(y+1)*side/size, y0+1)
+
+		for x := range size {
+			x0 := area.Min.X + x*side/size
+			x1 := max(area.Min.X+(x+1)*side/size, x0+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					// Premultiplied, so transparent pixels do not darken the average.
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+
+			average := color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)}
+			resized.Set(x, y, average)
+		}
+	}
+
+	return resized
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// Opening is a named sequence of moves from the standard starting position.
+type Opening struct {
+	ECO   string
+	Name  string
+	Moves []string
+}
+
+// openings are the openings games are classified by. Longer lines are more
+// specific, a game is in the longest line it starts with.
+var openings = []Opening{
+	{"A00", "Polish Opening", []string{"b4"}},
+	{"A01", "Nimzo-Larsen Attack", []string{"b3"}},
+	{"A04", "Zukertort Opening", []string{"Nf3"}},
+	{"A09", "Réti Opening", []string{"Nf3", "d5", "c4"}},
+	{"A10", "English Opening", []string{"c4"}},
+	{"A20", "English Opening: King's English Variation", []string{"c4", "e5"}},
+	{"A30", "English Opening: Symmetrical Variation", []string{"c4", "c5"}},
+	{"A40", "Queen's Pawn Game", []string{"d4"}},
+	{"A45", "Indian Defense", []string{"d4", "Nf6"}},
+	{"A56", "Benoni Defense", []string{"d4", "Nf6", "c4", "c5"}},
+	{"A57", "Benko Gambit", []string{"d4", "Nf6", "c4", "c5", "d5", "b5"}},
+	{"A80", "Dutch Defense", []string{"d4", "f5"}},
+	{"B00", "King's Pawn Game", []string{"e4"}},
+	{"B01", "Scandinavian Defense", []string{"e4", "d5"}},
+	{"B02", "Alekhine Defense", []string{"e4", "Nf6"}},
+	{"B06", "Modern Defense", []string{"e4", "g6"}},
+	{"B07", "Pirc Defense", []string{"e4", "d6", "d4", "Nf6"}},
+	{"B10", "Caro-Kann Defense", []string{"e4", "c6"}},
+	{"B20", "Sicilian Defense", []string{"e4", "c5"}},
+	{"B22", "Sicilian Defense: Alapin Variation", []string{"e4", "c5", "c3"}},
+	{"B70", "Sicilian Defense: Dragon Variation", []string{"e4", "c5", "Nf3", "d6", "d4", "cxd4", "Nxd4", "Nf6", "Nc3", "g6"}},
+	{"B90", "Sicilian Defense: Najdorf Variation", []string{"e4", "c5", "Nf3", "d6", "d4", "cxd4", "Nxd4", "Nf6", "Nc3", "a6"}},
+	{"C00", "French Defense", []string{"e4", "e6"}},
+	{"C20", "King's Pawn Game", []string{"e4", "e5"}},
+	{"C23", "Bishop's Opening", []string{"e4", "e5", "Bc4"}},
+	{"C25", "Vienna Game", []string{"e4", "e5", "Nc3"}},
+	{"C30", "King's Gambit", []string{"e4", "e5", "f4"}},
+	{"C40", "King's Knight Opening", []string{"e4", "e5", "Nf3"}},
+	{"C41", "Philidor Defense", []string{"e4", "e5", "Nf3", "d6"}},
+	{"C42", "Petrov's Defense", []string{"e4", "e5", "Nf3", "Nf6"}},
+	{"C45", "Scotch Game", []string{"e4", "e5", "Nf3", "Nc6", "d4"}},
+	{"C46", "Three Knights Opening", []string{"e4", "e5", "Nf3", "Nc6", "Nc3"}},
+	{"C47", "Four Knights Game", []string{"e4", "e5", "Nf3", "Nc6", "Nc3", "Nf6"}},
+	{"C50", "Italian Game", []string{"e4", "e5", "Nf3", "Nc6", "Bc4"}},
+	{"C50", "Italian Game: Giuoco Piano", []string{"e4", "e5", "Nf3", "Nc6", "Bc4", "Bc5"}},
+	{"C51", "Italian Game: Evans Gambit", []string{"e4", "e5", "Nf3", "Nc6", "Bc4", "Bc5", "b4"}},
+	{"C55", "Italian Game: Two Knights Defense", []string{"e4", "e5", "Nf3", "Nc6", "Bc4", "Nf6"}},
+	{"C60", "Ruy Lopez", []string{"e4", "e5", "Nf3", "Nc6", "Bb5"}},
+	{"C65", "Ruy Lopez: Berlin Defense", []string{"e4", "e5", "Nf3", "Nc6", "Bb5", "Nf6"}},
+	{"C70", "Ruy Lopez: Morphy Defense", []string{"e4", "e5", "Nf3", "Nc6", "Bb5", "a6"}},
+	{"D00", "Queen's Pawn Game", []string{"d4", "d5"}},
+	{"D00", "London System", []string{"d4", "d5", "Bf4"}},
+	{"D06", "Queen's Gambit", []string{"d4", "d5", "c4"}},
+	{"D10", "Slav Defense", []string{"d4", "d5", "c4", "c6"}},
+	{"D20", "Queen's Gambit Accepted", []string{"d4", "d5", "c4", "dxc4"}},
+	{"D30", "Queen's Gambit Declined", []string{"d4", "d5", "c4", "e6"}},
+	{"D80", "Grünfeld Defense", []string{"d4", "Nf6", "c4", "g6", "Nc3", "d5"}},
+	{"E00", "Catalan Opening", []string{"d4", "Nf6", "c4", "e6", "g3"}},
+	{"E12", "Queen's Indian Defense", []string{"d4", "Nf6", "c4", "e6", "Nf3", "b6"}},
+	{"E20", "Nimzo-Indian Defense", []string{"d4", "Nf6", "c4", "e6", "Nc3", "Bb4"}},
+	{"E60", "King's Indian Defense", []string{"d4", "Nf6", "c4", "g6"}},
+}
+
+// OpeningPlies is how many of a game's first moves decide its opening.
+var OpeningPlies = len(slices.MaxFunc(openings, func(a, b Opening) int {
+	return len(a.Moves) - len(b.Moves)
+}).Moves)
+
+// FindOpening returns the most specific opening the moves, in SAN, start with.
+func FindOpening(moves []string) (Opening, bool) {
+	var found Opening
+	for _, opening := range openings {
+		if len(opening.Moves) > len(moves) || len(opening.Moves) <= len(found.Moves) {
+			continue
+		}
+
+		if slices.EqualFunc(opening.Moves, moves[:len(opening.Moves)], equalSAN) {
+			found = opening
+		}
+	}
+
+	return found, found.Name != ""
+}
+
+// equalSAN compares moves regardless of check and mate suffixes.
+func equalSAN(a string, b string) bool {
+	return strings.TrimRight(a, "+#") == strings.TrimRight(b, "+#")
+}
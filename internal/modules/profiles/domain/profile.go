@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	presencedomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/presence/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxDisplayNameLength = 32
+	MaxBioLength         = 500
+)
+
+var (
+	ErrInvalidDisplayName = errors.New("display name is too long or contains control characters")
+	ErrInvalidBio         = errors.New("bio is too long")
+	ErrInvalidCountry     = errors.New("country must be an ISO 3166-1 alpha-2 code")
+)
+
+// Profile is what a user tells others about themselves. Users without a
+// profile are shown by their username alone.
+type Profile struct {
+	UserID      uuid.UUID `db:"user_id"`
+	DisplayName string    `db:"display_name"`
+	Bio         string    `db:"bio"`
+	// Country is an ISO 3166-1 alpha-2 code, empty when not given.
+	Country string `db:"country"`
+	// AvatarUpdatedAt is set while the user has an avatar, clients can use it to bust caches.
+	AvatarUpdatedAt *time.Time `db:"avatar_updated_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+func NewProfile(userID uuid.UUID, now time.Time) Profile {
+	return Profile{UserID: userID, UpdatedAt: now}
+}
+
+// Update replaces the profile's details. The display name and bio are trimmed and
+// the country is upper-cased.
+func (p *Profile) Update(displayName string, bio string, country string, now time.Time) error {
+	displayName = strings.TrimSpace(displayName)
+	if utf8.RuneCountInString(displayName) > MaxDisplayNameLength || strings.IndexFunc(displayName, unicode.IsControl) >= 0 {
+		return ErrInvalidDisplayName
+	}
+
+	bio = strings.TrimSpace(bio)
+	if utf8.RuneCountInString(bio) > MaxBioLength {
+		return ErrInvalidBio
+	}
+
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country != "" && (len(country) != 2 || strings.IndexFunc(country, isNotLetter) >= 0) {
+		return ErrInvalidCountry
+	}
+
+	p.DisplayName = displayName
+	p.Bio = bio
+	p.Country = country
+	p.UpdatedAt = now
+
+	return nil
+}
+
+func isNotLetter(r rune) bool {
+	return r < 'A' || r > 'Z'
+}
+
+// PlayerProfile is the public profile of a player.
+type PlayerProfile struct {
+	UserID          uuid.UUID  `db:"user_id"`
+	Username        string     `db:"username"`
+	DisplayName     string     `db:"display_name"`
+	Bio             string     `db:"bio"`
+	Country         string     `db:"country"`
+	AvatarUpdatedAt *time.Time `db:"avatar_updated_at"`
+	Status          presencedomain.Status
+	Stats           Stats
+}
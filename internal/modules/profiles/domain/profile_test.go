@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Profile_Update_Normalizes_Details(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	profile := NewProfile(uuid.New(), now.Add(-time.Hour))
+
+	// Act
+	err := profile.Update("  Magnus  ", " Plays the Berlin. ", "no", now)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "Magnus", profile.DisplayName)
+	require.Equal(t, "Plays the Berlin.", profile.Bio)
+	require.Equal(t, "NO", profile.Country)
+	require.Equal(t, now, profile.UpdatedAt)
+}
+
+func Test_Profile_Update_Rejects_Invalid_Details(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	profile := NewProfile(uuid.New(), now)
+
+	// Act & Assert
+	require.ErrorIs(t, profile.Update(strings.Repeat("a", MaxDisplayNameLength+1), "", "", now), ErrInvalidDisplayName)
+	require.ErrorIs(t, profile.Update("new\nline", "", "", now), ErrInvalidDisplayName)
+	require.ErrorIs(t, profile.Update("", strings.Repeat("a", MaxBioLength+1), "", now), ErrInvalidBio)
+	require.ErrorIs(t, profile.Update("", "", "NOR", now), ErrInvalidCountry)
+	require.ErrorIs(t, profile.Update("", "", "N1", now), ErrInvalidCountry)
+	require.NoError(t, profile.Update("", "", "", now))
+}
+
+func encodedImage(t *testing.T, width int, height int, encode func(*bytes.Buffer, image.Image) error) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			// The left half is red and the right half blue.
+			c := color.NRGBA{R: 255, A: 255}
+			if x >= width/2 {
+				c = color.NRGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, encode(&buf, img))
+	return buf.Bytes()
+}
+
+func encodePNG(buf *bytes.Buffer, img image.Image) error {
+	return png.Encode(buf, img)
+}
+
+func encodeJPEG(buf *bytes.Buffer, img image.Image) error {
+	return jpeg.Encode(buf, img, nil)
+}
+
+func Test_NewAvatar_Crops_And_Resizes_To_A_Square_PNG(t *testing.T) {
+	// Arrange
+	upload := encodedImage(t, 1024, 512, encodeJPEG)
+
+	// Act
+	avatar, err := NewAvatar(upload)
+
+	// Assert
+	require.NoError(t, err)
+
+	img, format, err := image.Decode(bytes.NewReader(avatar))
+	require.NoError(t, err)
+	require.Equal(t, "png", format)
+	require.Equal(t, image.Rect(0, 0, AvatarSize, AvatarSize), img.Bounds())
+
+	// The center crop keeps both halves.
+	r, _, b, _ := img.At(AvatarSize/4, AvatarSize/2).RGBA()
+	require.Greater(t, r, b)
+	r, _, b, _ = img.At(3*AvatarSize/4, AvatarSize/2).RGBA()
+	require.Greater(t, b, r)
+}
+
+func Test_NewAvatar_Scales_Up_Small_Images(t *testing.T) {
+	// Act
+	avatar, err := NewAvatar(encodedImage(t, 16, 16, encodePNG))
+
+	// Assert
+	require.NoError(t, err)
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(avatar))
+	require.NoError(t, err)
+	require.Equal(t, AvatarSize, config.Width)
+	require.Equal(t, AvatarSize, config.Height)
+}
+
+func Test_NewAvatar_Rejects_Invalid_Uploads(t *testing.T) {
+	// Act
+	_, notAnImageErr := NewAvatar([]byte("<svg></svg>"))
+	_, tooLargeErr := NewAvatar(make([]byte, MaxAvatarBytes+1))
+	_, tooWideErr := NewAvatar(encodedImage(t, MaxAvatarDimension+1, 1, encodePNG))
+
+	// Assert
+	require.ErrorIs(t, notAnImageErr, ErrInvalidAvatarImage)
+	require.ErrorIs(t, tooLargeErr, ErrAvatarTooLarge)
+	require.ErrorIs(t, tooWideErr, ErrAvatarTooLarge)
+}
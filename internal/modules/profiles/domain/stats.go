@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+)
+
+// MaxFavoriteOpenings is how many of the player's most played openings the stats list.
+const MaxFavoriteOpenings = 5
+
+type Outcome string
+
+const (
+	Win  Outcome = "win"
+	Draw Outcome = "draw"
+	Loss Outcome = "loss"
+)
+
+// FinishedGame is a game which ended with a result, as the stats count it.
+type FinishedGame struct {
+	WhiteID     uuid.UUID
+	BlackID     uuid.UUID
+	TimeControl string
+	Result      chess.Result
+	// Moves are the game's first moves in SAN, nil for games which did not
+	// start from the standard starting position.
+	Moves []string
+}
+
+// Outcome is the game's outcome for the player with the color. Games without a
+// result have no outcome.
+func (g FinishedGame) Outcome(color chess.Color) (Outcome, bool) {
+	switch g.Result {
+	case chess.Draw:
+		return Draw, true
+	case chess.Win(color):
+		return Win, true
+	case chess.Win(color.Other()):
+		return Loss, true
+	default:
+		return "", false
+	}
+}
+
+func (g FinishedGame) PlayerID(color chess.Color) uuid.UUID {
+	if color == chess.White {
+		return g.WhiteID
+	}
+	return g.BlackID
+}
+
+// Results counts the games a player won, drew and lost.
+type Results struct {
+	Games  int
+	Wins   int
+	Draws  int
+	Losses int
+}
+
+func (r *Results) add(other Results) {
+	r.Games += other.Games
+	r.Wins += other.Wins
+	r.Draws += other.Draws
+	r.Losses += other.Losses
+}
+
+// Record holds the player's results with one color in one time control. Records
+// are counted up as games end, so the stats never need to go through the games.
+type Record struct {
+	PlayerID    uuid.UUID `db:"player_id"`
+	TimeControl string    `db:"time_control"`
+	Color       string    `db:"color"`
+	Wins        int       `db:"wins"`
+	Draws       int       `db:"draws"`
+	Losses      int       `db:"losses"`
+}
+
+func (r Record) Results() Results {
+	return Results{Games: r.Wins + r.Draws + r.Losses, Wins: r.Wins, Draws: r.Draws, Losses: r.Losses}
+}
+
+// NewRecord is the record of the single game for the player with the color,
+// to be added to the player's record.
+func NewRecord(game FinishedGame, color chess.Color) (Record, bool) {
+	outcome, ok := game.Outcome(color)
+	if !ok {
+		return Record{}, false
+	}
+
+	record := Record{PlayerID: game.PlayerID(color), TimeControl: game.TimeControl, Color: color.String()}
+	switch outcome {
+	case Win:
+		record.Wins = 1
+	case Draw:
+		record.Draws = 1
+	case Loss:
+		record.Losses = 1
+	}
+
+	return record, true
+}
+
+// Streak tracks the player's consecutive wins. Draws and losses end a streak.
+type Streak struct {
+	PlayerID uuid.UUID `db:"player_id"`
+	Current  int       `db:"current_streak"`
+	Longest  int       `db:"longest_streak"`
+}
+
+func (s *Streak) Record(outcome Outcome) {
+	if outcome != Win {
+		s.Current = 0
+		return
+	}
+
+	s.Current++
+	s.Longest = max(s.Longest, s.Current)
+}
+
+// OpeningRecord holds the player's results in an opening with one color.
+type OpeningRecord struct {
+	PlayerID uuid.UUID `db:"player_id"`
+	Color    string    `db:"color"`
+	ECO      string    `db:"eco"`
+	Name     string    `db:"name"`
+	Wins     int       `db:"wins"`
+	Draws    int       `db:"draws"`
+	Losses   int       `db:"losses"`
+}
+
+func (r OpeningRecord) Results() Results {
+	return Record{Wins: r.Wins, Draws: r.Draws, Losses: r.Losses}.Results()
+}
+
+// NewOpeningRecord is the record of the single game in its opening, for the
+// player with the color. Games in no known opening have no opening record.
+func NewOpeningRecord(game FinishedGame, color chess.Color) (OpeningRecord, bool) {
+	record, ok := NewRecord(game, color)
+	if !ok {
+		return OpeningRecord{}, false
+	}
+
+	opening, ok := FindOpening(game.Moves)
+	if !ok {
+		return OpeningRecord{}, false
+	}
+
+	return OpeningRecord{
+		PlayerID: record.PlayerID,
+		Color:    record.Color,
+		ECO:      opening.ECO,
+		Name:     opening.Name,
+		Wins:     record.Wins,
+		Draws:    record.Draws,
+		Losses:   record.Losses,
+	}, true
+}
+
+type TimeControlResults struct {
+	TimeControl string
+	Results
+}
+
+type OpeningResults struct {
+	ECO   string
+	Name  string
+	Color string
+	Results
+}
+
+// Stats sum up the games a player finished.
+type Stats struct {
+	Total            Results
+	White            Results
+	Black            Results
+	ByTimeControl    []TimeControlResults
+	CurrentStreak    int
+	LongestStreak    int
+	FavoriteOpenings []OpeningResults
+}
+
+// NewStats totals the player's records. The favorite openings are the most played ones.
+func NewStats(records []Record, streak Streak, openings []OpeningRecord) Stats {
+	stats := Stats{
+		ByTimeControl:    []TimeControlResults{},
+		CurrentStreak:    streak.Current,
+		LongestStreak:    streak.Longest,
+		FavoriteOpenings: []OpeningResults{},
+	}
+
+	for _, record := range records {
+		results := record.Results()
+		stats.Total.add(results)
+
+		if record.Color == chess.White.String() {
+			stats.White.add(results)
+		} else {
+			stats.Black.add(results)
+		}
+
+		i := slices.IndexFunc(stats.ByTimeControl, func(tc TimeControlResults) bool {
+			return tc.TimeControl == record.TimeControl
+		})
+		if i < 0 {
+			stats.ByTimeControl = append(stats.ByTimeControl, TimeControlResults{TimeControl: record.TimeControl})
+			i = len(stats.ByTimeControl) - 1
+		}
+		stats.ByTimeControl[i].add(results)
+	}
+
+	slices.SortFunc(stats.ByTimeControl, func(a, b TimeControlResults) int {
+		return cmp.Compare(a.TimeControl, b.TimeControl)
+	})
+
+	for _, opening := range openings {
+		stats.FavoriteOpenings = append(stats.FavoriteOpenings, OpeningResults{
+			ECO:     opening.ECO,
+			Name:    opening.Name,
+			Color:   opening.Color,
+			Results: opening.Results(),
+		})
+	}
+
+	slices.SortStableFunc(stats.FavoriteOpenings, func(a, b OpeningResults) int {
+		return cmp.Compare(b.Games, a.Games)
+	})
+	stats.FavoriteOpenings = stats.FavoriteOpenings[:min(len(stats.FavoriteOpenings), MaxFavoriteOpenings)]
+
+	return stats
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewRecord_Counts_The_Game_For_Each_Player(t *testing.T) {
+	// Arrange
+	game := FinishedGame{WhiteID: uuid.New(), BlackID: uuid.New(), TimeControl: "blitz", Result: chess.BlackWins}
+	aborted := FinishedGame{WhiteID: game.WhiteID, BlackID: game.BlackID, Result: chess.NoResult}
+
+	// Act
+	white, whiteOK := NewRecord(game, chess.White)
+	black, blackOK := NewRecord(game, chess.Black)
+	_, abortedOK := NewRecord(aborted, chess.White)
+
+	// Assert
+	require.True(t, whiteOK)
+	require.True(t, blackOK)
+	require.False(t, abortedOK, "games without a result are not counted")
+	require.Equal(t, Record{PlayerID: game.WhiteID, TimeControl: "blitz", Color: "white", Losses: 1}, white)
+	require.Equal(t, Record{PlayerID: game.BlackID, TimeControl: "blitz", Color: "black", Wins: 1}, black)
+}
+
+func Test_Streak_Counts_Consecutive_Wins(t *testing.T) {
+	// Arrange
+	streak := Streak{}
+
+	// Act
+	for _, outcome := range []Outcome{Win, Win, Win, Draw, Win, Loss, Win, Win} {
+		streak.Record(outcome)
+	}
+
+	// Assert
+	require.Equal(t, 2, streak.Current)
+	require.Equal(t, 3, streak.Longest)
+}
+
+func Test_FindOpening_Finds_The_Most_Specific_Opening(t *testing.T) {
+	// Act
+	najdorf, najdorfOK := FindOpening([]string{"e4", "c5", "Nf3", "d6", "d4", "cxd4", "Nxd4", "Nf6", "Nc3", "a6", "Be3"})
+	sicilian, sicilianOK := FindOpening([]string{"e4", "c5", "Nf3", "d6"})
+	checked, checkedOK := FindOpening([]string{"e4", "e5", "Nf3", "Nc6", "Bb5"})
+	_, noneOK := FindOpening([]string{"a3"})
+
+	// Assert
+	require.True(t, najdorfOK)
+	require.Equal(t, "B90", najdorf.ECO)
+	require.True(t, sicilianOK)
+	require.Equal(t, "Sicilian Defense", sicilian.Name)
+	require.True(t, checkedOK)
+	require.Equal(t, "Ruy Lopez", checked.Name)
+	require.False(t, noneOK)
+	require.Equal(t, 10, OpeningPlies)
+}
+
+func Test_NewOpeningRecord_Requires_A_Known_Opening(t *testing.T) {
+	// Arrange
+	game := FinishedGame{WhiteID: uuid.New(), BlackID: uuid.New(), Result: chess.Draw, Moves: []string{"d4", "d5", "c4+"}}
+	unknown := FinishedGame{WhiteID: game.WhiteID, BlackID: game.BlackID, Result: chess.Draw}
+
+	// Act
+	record, ok := NewOpeningRecord(game, chess.Black)
+	_, unknownOK := NewOpeningRecord(unknown, chess.Black)
+
+	// Assert
+	require.True(t, ok)
+	require.Equal(t, OpeningRecord{PlayerID: game.BlackID, Color: "black", ECO: "D06", Name: "Queen's Gambit", Draws: 1}, record)
+	require.False(t, unknownOK)
+}
+
+func Test_NewStats_Totals_The_Records(t *testing.T) {
+	// Arrange
+	playerID := uuid.New()
+	records := []Record{
+		{PlayerID: playerID, TimeControl: "rapid", Color: "white", Wins: 3, Draws: 1},
+		{PlayerID: playerID, TimeControl: "blitz", Color: "white", Wins: 1, Losses: 2},
+		{PlayerID: playerID, TimeControl: "blitz", Color: "black", Draws: 2, Losses: 1},
+	}
+	streak := Streak{PlayerID: playerID, Current: 1, Longest: 3}
+
+	var openings []OpeningRecord
+	for i := range MaxFavoriteOpenings + 1 {
+		openings = append(openings, OpeningRecord{PlayerID: playerID, Color: "white", Name: string(rune('A' + i)), Wins: i})
+	}
+
+	// Act
+	stats := NewStats(records, streak, openings)
+
+	// Assert
+	require.Equal(t, Results{Games: 10, Wins: 4, Draws: 3, Losses: 3}, stats.Total)
+	require.Equal(t, Results{Games: 7, Wins: 4, Draws: 1, Losses: 2}, stats.White)
+	require.Equal(t, Results{Games: 3, Draws: 2, Losses: 1}, stats.Black)
+	require.Equal(t, []TimeControlResults{
+		{TimeControl: "blitz", Results: Results{Games: 6, Wins: 1, Draws: 2, Losses: 3}},
+		{TimeControl: "rapid", Results: Results{Games: 4, Wins: 3, Draws: 1}},
+	}, stats.ByTimeControl)
+	require.Equal(t, 1, stats.CurrentStreak)
+	require.Equal(t, 3, stats.LongestStreak)
+
+	require.Len(t, stats.FavoriteOpenings, MaxFavoriteOpenings)
+	require.Equal(t, "F", stats.FavoriteOpenings[0].Name, "the most played opening comes first")
+}
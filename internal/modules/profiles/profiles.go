@@ -0,0 +1,154 @@
+package profiles
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// RecordGame adds the game to its players' stats as part of the transaction which
+// ends the game, so each game is counted exactly once. Games without a result are
+// not counted.
+func RecordGame(ctx context.Context, tx *sql.Tx, game domain.FinishedGame) error {
+	const recordStmt = `
+		INSERT INTO
+			player_record (player_id, time_control, color, wins, draws, losses)
+		VALUES
+			(:player_id, :time_control, :color, :wins, :draws, :losses)
+		ON CONFLICT (player_id, time_control, color) DO UPDATE SET
+			wins = player_record.wins + EXCLUDED.wins,
+			draws = player_record.draws + EXCLUDED.draws,
+			losses = player_record.losses + EXCLUDED.losses;`
+	const openingStmt = `
+		INSERT INTO
+			player_opening_record (player_id, color, eco, name, wins, draws, losses)
+		VALUES
+			(:player_id, :color, :eco, :name, :wins, :draws, :losses)
+		ON CONFLICT (player_id, color, eco, name) DO UPDATE SET
+			wins = player_opening_record.wins + EXCLUDED.wins,
+			draws = player_opening_record.draws + EXCLUDED.draws,
+			losses = player_opening_record.losses + EXCLUDED.losses;`
+	for _, color := range []chess.Color{chess.White, chess.Black} {
+		record, ok := domain.NewRecord(game, color)
+		if !ok {
+			return nil
+		}
+
+		if _, err := tql.Exec(ctx, tx, recordStmt, record); err != nil {
+			return err
+		}
+
+		if opening, ok := domain.NewOpeningRecord(game, color); ok {
+			if _, err := tql.Exec(ctx, tx, openingStmt, opening); err != nil {
+				return err
+			}
+		}
+	}
+
+	return recordStreaks(ctx, tx, game)
+}
+
+func recordStreaks(ctx context.Context, tx *sql.Tx, game domain.FinishedGame) error {
+	const insertStmt = `
+		INSERT INTO
+			player_streak (player_id, current_streak, longest_streak)
+		VALUES
+			($1, 0, 0)
+		ON CONFLICT (player_id) DO NOTHING;`
+	for _, playerID := range []uuid.UUID{game.WhiteID, game.BlackID} {
+		if _, err := tql.Exec(ctx, tx, insertStmt, playerID); err != nil {
+			return err
+		}
+	}
+
+	// Locked in a fixed order so games between the same players ending concurrently do not deadlock.
+	const query = `
+		SELECT
+			*
+		FROM
+			player_streak
+		WHERE
+			player_id IN ($1, $2)
+		ORDER BY
+			player_id
+		FOR UPDATE;`
+	streaks, err := tql.Query[domain.Streak](ctx, tx, query, game.WhiteID, game.BlackID)
+	if err != nil {
+		return err
+	}
+
+	const updateStmt = `
+		UPDATE
+			player_streak
+		SET
+			current_streak = :current_streak,
+			longest_streak = :longest_streak
+		WHERE
+			player_id = :player_id;`
+	for _, streak := range streaks {
+		color := chess.White
+		if streak.PlayerID == game.BlackID {
+			color = chess.Black
+		}
+
+		outcome, _ := game.Outcome(color)
+		streak.Record(outcome)
+
+		if _, err := tql.Exec(ctx, tx, updateStmt, streak); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stats loads the player's stats from the records kept as their games ended.
+func Stats(ctx context.Context, q tql.Querier, playerID uuid.UUID) (domain.Stats, error) {
+	const recordsQuery = `
+		SELECT
+			*
+		FROM
+			player_record
+		WHERE
+			player_id = $1;`
+	records, err := tql.Query[domain.Record](ctx, q, recordsQuery, playerID)
+	if err != nil {
+		return domain.Stats{}, err
+	}
+
+	const streakQuery = `
+		SELECT
+			*
+		FROM
+			player_streak
+		WHERE
+			player_id = $1;`
+	streak, err := tql.QueryFirst[domain.Streak](ctx, q, streakQuery, playerID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return domain.Stats{}, err
+	}
+
+	const openingsQuery = `
+		SELECT
+			*
+		FROM
+			player_opening_record
+		WHERE
+			player_id = $1
+		ORDER BY
+			wins + draws + losses DESC, name
+		LIMIT
+			$2;`
+	openings, err := tql.Query[domain.OpeningRecord](ctx, q, openingsQuery, playerID, domain.MaxFavoriteOpenings)
+	if err != nil {
+		return domain.Stats{}, err
+	}
+
+	return domain.NewStats(records, streak, openings), nil
+}
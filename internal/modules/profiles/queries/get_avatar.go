@@ -0,0 +1,79 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetAvatarQuery returns the file the player's avatar is stored in.
+type GetAvatarQuery struct {
+	PlayerID uuid.UUID
+}
+
+func (q GetAvatarQuery) Validate() error {
+	if q.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", q.PlayerID)
+	}
+
+	return nil
+}
+
+func HandleGetAvatar(w http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetAvatarQuery, string](
+		r.Context(),
+		GetAvatarQuery{PlayerID: playerID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	// The avatar can be replaced at any time, clients revalidate it by its modification time.
+	w.Header().Set("Cache-Control", "private, no-cache")
+	http.ServeFile(w, r, response)
+}
+
+type GetAvatarQueryHandler struct {
+	db      *sql.DB
+	avatars *profiles.AvatarStore
+}
+
+func NewGetAvatarQueryHandler(db *sql.DB, avatars *profiles.AvatarStore) *GetAvatarQueryHandler {
+	return &GetAvatarQueryHandler{db: db, avatars: avatars}
+}
+
+func (h *GetAvatarQueryHandler) Handle(ctx context.Context, request GetAvatarQuery) (string, error) {
+	const query = `
+		SELECT
+			avatar_updated_at
+		FROM
+			player_profile
+		WHERE
+			user_id = $1 AND avatar_updated_at IS NOT NULL;`
+	_, err := tql.QueryFirst[time.Time](ctx, h.db, query, request.PlayerID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return "", core.NewCommandError(404, fmt.Errorf("player '%s' has no avatar", request.PlayerID))
+	case err != nil:
+		return "", core.NewCommandError(500, err)
+	}
+
+	return h.avatars.Path(request.PlayerID), nil
+}
@@ -0,0 +1,97 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/presence"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetProfileQuery returns the player's public profile with their stats and
+// whether they are online.
+type GetProfileQuery struct {
+	PlayerID uuid.UUID
+}
+
+func (q GetProfileQuery) Validate() error {
+	if q.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", q.PlayerID)
+	}
+
+	return nil
+}
+
+func HandleGetProfile(w http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetProfileQuery, domain.PlayerProfile](
+		r.Context(),
+		GetProfileQuery{PlayerID: playerID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetProfileQueryHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewGetProfileQueryHandler(db *sql.DB, clock core.Clock) *GetProfileQueryHandler {
+	return &GetProfileQueryHandler{db: db, clock: clock}
+}
+
+func (h *GetProfileQueryHandler) Handle(ctx context.Context, request GetProfileQuery) (domain.PlayerProfile, error) {
+	const query = `
+		SELECT
+			u.id AS user_id,
+			u.username,
+			COALESCE(p.display_name, '') AS display_name,
+			COALESCE(p.bio, '') AS bio,
+			COALESCE(p.country, '') AS country,
+			p.avatar_updated_at
+		FROM
+			auth.user u
+		LEFT JOIN
+			player_profile p ON p.user_id = u.id
+		WHERE
+			u.id = $1;`
+	profile, err := tql.QueryFirst[domain.PlayerProfile](ctx, h.db, query, request.PlayerID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return domain.PlayerProfile{}, core.NewCommandError(404, fmt.Errorf("player '%s' not found", request.PlayerID))
+	case err != nil:
+		return domain.PlayerProfile{}, core.NewCommandError(500, err)
+	}
+
+	statuses, err := presence.Statuses(ctx, h.db, []uuid.UUID{profile.UserID}, h.clock.Now())
+	if err != nil {
+		return domain.PlayerProfile{}, core.NewCommandError(500, err)
+	}
+	profile.Status = statuses.Of(profile.UserID)
+
+	profile.Stats, err = profiles.Stats(ctx, h.db, profile.UserID)
+	if err != nil {
+		return domain.PlayerProfile{}, core.NewCommandError(500, err)
+	}
+
+	return profile, nil
+}
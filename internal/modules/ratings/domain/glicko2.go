@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Glicko-2 as described in http://www.glicko.net/glicko/glicko2.pdf. Each game is
+// rated on its own, as its own rating period, and the inactivity before a game
+// increases the deviation by the number of RatingPeriods elapsed since the last one.
+const (
+	DefaultRating     = 1500.0
+	DefaultDeviation  = 350.0
+	DefaultVolatility = 0.06
+
+	// MinDeviation keeps very active players' ratings from settling for good.
+	MinDeviation = 45.0
+	MaxDeviation = DefaultDeviation
+
+	// ProvisionalDeviation is the deviation above which a rating is not yet trusted.
+	ProvisionalDeviation = 110.0
+
+	RatingPeriod = 24 * time.Hour
+
+	// tau constrains the change of the volatility over time.
+	tau = 0.5
+	// glicko2Scale converts between the Glicko and the Glicko-2 scale.
+	glicko2Scale = 173.7178
+	convergence  = 0.000001
+)
+
+// Outcome is the result of a game from the perspective of one player.
+type Outcome struct {
+	OpponentRating    float64
+	OpponentDeviation float64
+	// Score is 1 for a win, 0.5 for a draw and 0 for a loss.
+	Score float64
+}
+
+// Decay increases the deviation for the rating periods of inactivity.
+func Decay(deviation float64, volatility float64, inactive time.Duration) float64 {
+	if inactive <= 0 {
+		return deviation
+	}
+
+	periods := float64(inactive) / float64(RatingPeriod)
+	phi := deviation / glicko2Scale
+	phi = math.Sqrt(phi*phi + periods*volatility*volatility)
+
+	return math.Min(phi*glicko2Scale, MaxDeviation)
+}
+
+// Rate returns the rating, deviation and volatility after the outcomes,
+// which are treated as played in a single rating period.
+func Rate(rating, deviation, volatility float64, outcomes ...Outcome) (float64, float64, float64) {
+	mu := (rating - DefaultRating) / glicko2Scale
+	phi := deviation / glicko2Scale
+
+	if len(outcomes) == 0 {
+		phi = math.Sqrt(phi*phi + volatility*volatility)
+		return rating, math.Min(phi*glicko2Scale, MaxDeviation), volatility
+	}
+
+	var vInverse, deltaSum float64
+	for _, outcome := range outcomes {
+		muJ := (outcome.OpponentRating - DefaultRating) / glicko2Scale
+		phiJ := outcome.OpponentDeviation / glicko2Scale
+
+		gJ := g(phiJ)
+		eJ := expectedScore(mu, muJ, gJ)
+
+		vInverse += gJ * gJ * eJ * (1 - eJ)
+		deltaSum += gJ * (outcome.Score - eJ)
+	}
+
+	v := 1 / vInverse
+	delta := v * deltaSum
+
+	newVolatility := nextVolatility(phi, volatility, v, delta)
+
+	phiStar := math.Sqrt(phi*phi + newVolatility*newVolatility)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*deltaSum
+
+	newDeviation := math.Max(math.Min(newPhi*glicko2Scale, MaxDeviation), MinDeviation)
+
+	return newMu*glicko2Scale + DefaultRating, newDeviation, newVolatility
+}
+
+func g(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+func expectedScore(mu, muJ, gJ float64) float64 {
+	return 1 / (1 + math.Exp(-gJ*(mu-muJ)))
+}
+
+// nextVolatility finds the new volatility with the Illinois algorithm (step 5 of the paper).
+func nextVolatility(phi, sigma, v, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	// xA and xB bracket the solution, named after A and B in the paper.
+	xA := a
+	var xB float64
+	if delta*delta > phi*phi+v {
+		xB = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		xB = a - k*tau
+	}
+
+	fA, fB := f(xA), f(xB)
+
+	for math.Abs(xB-xA) > convergence {
+		xC := xA + (xA-xB)*fA/(fB-fA)
+		fC := f(xC)
+
+		if fC*fB <= 0 {
+			xA, fA = xB, fB
+		} else {
+			fA /= 2
+		}
+
+		xB, fB = xC, fC
+	}
+
+	return math.Exp(xA / 2)
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Rate_Matches_Glicko2_Paper_Example(t *testing.T) {
+	// Act
+	rating, deviation, volatility := Rate(
+		1500, 200, 0.06,
+		Outcome{OpponentRating: 1400, OpponentDeviation: 30, Score: 1},
+		Outcome{OpponentRating: 1550, OpponentDeviation: 100, Score: 0},
+		Outcome{OpponentRating: 1700, OpponentDeviation: 300, Score: 0},
+	)
+
+	// Assert
+	require.InDelta(t, 1464.06, rating, 0.01)
+	require.InDelta(t, 151.52, deviation, 0.01)
+	require.InDelta(t, 0.05999, volatility, 0.00001)
+}
+
+func Test_Rate_Without_Games_Only_Increases_Deviation(t *testing.T) {
+	// Act
+	rating, deviation, volatility := Rate(1500, 200, 0.06)
+
+	// Assert
+	require.Equal(t, 1500.0, rating)
+	require.InDelta(t, 200.27, deviation, 0.01)
+	require.Equal(t, 0.06, volatility)
+}
+
+func Test_Rate_Keeps_Deviation_Above_Minimum(t *testing.T) {
+	// Act
+	_, deviation, _ := Rate(1500, MinDeviation, 0.01, Outcome{OpponentRating: 1500, OpponentDeviation: MinDeviation, Score: 0.5})
+
+	// Assert
+	require.Equal(t, MinDeviation, deviation)
+}
+
+func Test_Decay_Increases_Deviation_With_Inactivity(t *testing.T) {
+	require.Equal(t, 50.0, Decay(50, DefaultVolatility, 0))
+	require.Greater(t, Decay(50, DefaultVolatility, 30*RatingPeriod), Decay(50, DefaultVolatility, RatingPeriod))
+	require.Equal(t, MaxDeviation, Decay(50, DefaultVolatility, 100*365*24*time.Hour))
+}
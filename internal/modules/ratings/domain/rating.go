@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotRatable = errors.New("game cannot be rated")
+
+// Category is the time control category a rating is kept for, e.g. "blitz".
+// Variants other than standard chess have a single category named after them.
+type Category string
+
+// Rating is a player's Glicko-2 rating in a category. The deviation is the one
+// at LastPlayedAt, it grows with inactivity until the next game.
+type Rating struct {
+	PlayerID     uuid.UUID  `db:"player_id"`
+	Category     Category   `db:"category"`
+	Rating       float64    `db:"rating"`
+	Deviation    float64    `db:"deviation"`
+	Volatility   float64    `db:"volatility"`
+	Games        int        `db:"games"`
+	LastPlayedAt *time.Time `db:"last_played_at"`
+}
+
+func NewRating(playerID uuid.UUID, category Category) Rating {
+	return Rating{
+		PlayerID:   playerID,
+		Category:   category,
+		Rating:     DefaultRating,
+		Deviation:  DefaultDeviation,
+		Volatility: DefaultVolatility,
+	}
+}
+
+// DeviationAt is the deviation including the decay for inactivity until now.
+func (r Rating) DeviationAt(now time.Time) float64 {
+	if r.LastPlayedAt == nil {
+		return r.Deviation
+	}
+
+	return Decay(r.Deviation, r.Volatility, now.Sub(*r.LastPlayedAt))
+}
+
+// IsProvisional reports whether too little is known about the player's strength
+// for the rating to be trusted, either because of too few games or inactivity.
+func (r Rating) IsProvisional(now time.Time) bool {
+	return r.DeviationAt(now) > ProvisionalDeviation
+}
+
+// PlayerRating is the rating as shown to the players.
+type PlayerRating struct {
+	Category     Category
+	Rating       int
+	Deviation    int
+	Provisional  bool
+	Games        int
+	LastPlayedAt *time.Time
+}
+
+func (r Rating) PlayerRating(now time.Time) PlayerRating {
+	return PlayerRating{
+		Category:     r.Category,
+		Rating:       int(math.Round(r.Rating)),
+		Deviation:    int(math.Round(r.DeviationAt(now))),
+		Provisional:  r.IsProvisional(now),
+		Games:        r.Games,
+		LastPlayedAt: r.LastPlayedAt,
+	}
+}
+
+// RatedGame is a finished game counting towards the ratings of its players.
+type RatedGame struct {
+	GameID   uuid.UUID
+	WhiteID  uuid.UUID
+	BlackID  uuid.UUID
+	Category Category
+	// WhiteScore is 1 when white won, 0.5 for a draw and 0 when black won.
+	WhiteScore float64
+	PlayedAt   time.Time
+}
+
+// RatingChange is an entry in a player's rating history.
+type RatingChange struct {
+	PlayerID        uuid.UUID `db:"player_id"`
+	Category        Category  `db:"category"`
+	GameID          uuid.UUID `db:"game_id"`
+	OpponentID      uuid.UUID `db:"opponent_id"`
+	Score           float64   `db:"score"`
+	RatingBefore    float64   `db:"rating_before"`
+	RatingAfter     float64   `db:"rating_after"`
+	DeviationBefore float64   `db:"deviation_before"`
+	DeviationAfter  float64   `db:"deviation_after"`
+	PlayedAt        time.Time `db:"played_at"`
+}
+
+// RateGame updates the ratings of both players with the result of the game.
+// Both are rated against the opponent's rating from before the game.
+func RateGame(white Rating, black Rating, game RatedGame) (Rating, Rating, []RatingChange, error) {
+	if white.PlayerID != game.WhiteID || black.PlayerID != game.BlackID || white.PlayerID == black.PlayerID {
+		return Rating{}, Rating{}, nil, ErrNotRatable
+	}
+
+	if white.Category != game.Category || black.Category != game.Category {
+		return Rating{}, Rating{}, nil, ErrNotRatable
+	}
+
+	if game.WhiteScore != 0 && game.WhiteScore != 0.5 && game.WhiteScore != 1 {
+		return Rating{}, Rating{}, nil, ErrNotRatable
+	}
+
+	newWhite, whiteChange := rate(white, black, game.WhiteScore, game)
+	newBlack, blackChange := rate(black, white, 1-game.WhiteScore, game)
+
+	return newWhite, newBlack, []RatingChange{whiteChange, blackChange}, nil
+}
+
+func rate(player Rating, opponent Rating, score float64, game RatedGame) (Rating, RatingChange) {
+	deviation := player.DeviationAt(game.PlayedAt)
+
+	rating, newDeviation, volatility := Rate(
+		player.Rating,
+		deviation,
+		player.Volatility,
+		Outcome{
+			OpponentRating:    opponent.Rating,
+			OpponentDeviation: opponent.DeviationAt(game.PlayedAt),
+			Score:             score,
+		},
+	)
+
+	change := RatingChange{
+		PlayerID:        player.PlayerID,
+		Category:        game.Category,
+		GameID:          game.GameID,
+		OpponentID:      opponent.PlayerID,
+		Score:           score,
+		RatingBefore:    player.Rating,
+		RatingAfter:     rating,
+		DeviationBefore: deviation,
+		DeviationAfter:  newDeviation,
+		PlayedAt:        game.PlayedAt,
+	}
+
+	playedAt := game.PlayedAt
+
+	player.Rating = rating
+	player.Deviation = newDeviation
+	player.Volatility = volatility
+	player.Games++
+	player.LastPlayedAt = &playedAt
+
+	return player, change
+}
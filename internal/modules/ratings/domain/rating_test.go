@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RateGame_Moves_Ratings_Towards_Result(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	white := NewRating(uuid.New(), "blitz")
+	black := NewRating(uuid.New(), "blitz")
+
+	game := RatedGame{
+		GameID:     uuid.New(),
+		WhiteID:    white.PlayerID,
+		BlackID:    black.PlayerID,
+		Category:   "blitz",
+		WhiteScore: 1,
+		PlayedAt:   now,
+	}
+
+	// Act
+	newWhite, newBlack, changes, err := RateGame(white, black, game)
+
+	// Assert
+	require.NoError(t, err)
+	require.Greater(t, newWhite.Rating, DefaultRating)
+	require.Less(t, newBlack.Rating, DefaultRating)
+	require.InDelta(t, newWhite.Rating-DefaultRating, DefaultRating-newBlack.Rating, 0.001)
+	require.Less(t, newWhite.Deviation, DefaultDeviation)
+	require.Equal(t, 1, newWhite.Games)
+	require.Equal(t, now, *newBlack.LastPlayedAt)
+
+	require.Len(t, changes, 2)
+	require.Equal(t, black.PlayerID, changes[0].OpponentID)
+	require.Equal(t, 0.0, changes[1].Score)
+}
+
+func Test_RateGame_Returns_Error_For_Invalid_Game(t *testing.T) {
+	white := NewRating(uuid.New(), "blitz")
+	black := NewRating(uuid.New(), "blitz")
+
+	tests := []struct {
+		name string
+		game RatedGame
+	}{
+		{"wrong players", RatedGame{WhiteID: uuid.New(), BlackID: black.PlayerID, Category: "blitz", WhiteScore: 1}},
+		{"wrong category", RatedGame{WhiteID: white.PlayerID, BlackID: black.PlayerID, Category: "rapid", WhiteScore: 1}},
+		{"invalid score", RatedGame{WhiteID: white.PlayerID, BlackID: black.PlayerID, Category: "blitz", WhiteScore: 0.3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := RateGame(white, black, tt.game)
+			require.ErrorIs(t, err, ErrNotRatable)
+		})
+	}
+}
+
+func Test_Rating_Is_Provisional_Until_Deviation_Settles_And_After_Inactivity(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rating := NewRating(uuid.New(), "blitz")
+	rating.Deviation = 60
+	rating.LastPlayedAt = &now
+
+	// Assert
+	require.True(t, NewRating(uuid.New(), "blitz").IsProvisional(now))
+	require.False(t, rating.IsProvisional(now))
+	require.True(t, rating.IsProvisional(now.Add(365*RatingPeriod)))
+	require.Equal(t, 60, rating.PlayerRating(now).Deviation)
+}
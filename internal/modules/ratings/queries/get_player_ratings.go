@@ -0,0 +1,85 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetPlayerRatingsQuery returns the player's current rating
+// in each of the categories the player has played in.
+type GetPlayerRatingsQuery struct {
+	PlayerID uuid.UUID
+}
+
+func (q GetPlayerRatingsQuery) Validate() error {
+	if q.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", q.PlayerID)
+	}
+
+	return nil
+}
+
+func HandleGetPlayerRatings(w http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetPlayerRatingsQuery, []domain.PlayerRating](
+		r.Context(),
+		GetPlayerRatingsQuery{PlayerID: playerID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetPlayerRatingsQueryHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewGetPlayerRatingsQueryHandler(db *sql.DB, clock core.Clock) *GetPlayerRatingsQueryHandler {
+	return &GetPlayerRatingsQueryHandler{db, clock}
+}
+
+func (h *GetPlayerRatingsQueryHandler) Handle(
+	ctx context.Context,
+	request GetPlayerRatingsQuery,
+) ([]domain.PlayerRating, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			player_rating
+		WHERE
+			player_id = $1
+		ORDER BY
+			category;`
+	ratings, err := tql.Query[domain.Rating](ctx, h.db, query, request.PlayerID)
+	if err != nil {
+		return nil, core.NewCommandError(500, err)
+	}
+
+	now := h.clock.Now()
+
+	playerRatings := make([]domain.PlayerRating, 0, len(ratings))
+	for _, rating := range ratings {
+		playerRatings = append(playerRatings, rating.PlayerRating(now))
+	}
+
+	return playerRatings, nil
+}
@@ -0,0 +1,82 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetRatingHistoryQuery returns the rating changes of the player
+// in the category game by game, oldest first, to draw the rating graph.
+type GetRatingHistoryQuery struct {
+	PlayerID uuid.UUID
+	Category domain.Category
+}
+
+func (q GetRatingHistoryQuery) Validate() error {
+	if q.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", q.PlayerID)
+	}
+
+	if q.Category == "" {
+		return fmt.Errorf("invalid Category - '%s'", q.Category)
+	}
+
+	return nil
+}
+
+func HandleGetRatingHistory(w http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetRatingHistoryQuery, []domain.RatingChange](
+		r.Context(),
+		GetRatingHistoryQuery{PlayerID: playerID, Category: domain.Category(r.PathValue("category"))},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetRatingHistoryQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetRatingHistoryQueryHandler(db *sql.DB) *GetRatingHistoryQueryHandler {
+	return &GetRatingHistoryQueryHandler{db}
+}
+
+func (h *GetRatingHistoryQueryHandler) Handle(
+	ctx context.Context,
+	request GetRatingHistoryQuery,
+) ([]domain.RatingChange, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			rating_history
+		WHERE
+			player_id = $1 AND category = $2
+		ORDER BY
+			played_at;`
+	history, err := tql.Query[domain.RatingChange](ctx, h.db, query, request.PlayerID, request.Category)
+	if err != nil {
+		return nil, core.NewCommandError(500, err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,137 @@
+package ratings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// RecordGame updates the ratings of the game's players as part of the
+// transaction which ends the game, so each game is rated exactly once.
+func RecordGame(ctx context.Context, tx *sql.Tx, game domain.RatedGame) error {
+	const insertStmt = `
+		INSERT INTO
+			player_rating (player_id, category, rating, deviation, volatility, games, last_played_at)
+		VALUES
+			(:player_id, :category, :rating, :deviation, :volatility, :games, :last_played_at)
+		ON CONFLICT (player_id, category) DO NOTHING;`
+	for _, playerID := range []uuid.UUID{game.WhiteID, game.BlackID} {
+		if _, err := tql.Exec(ctx, tx, insertStmt, domain.NewRating(playerID, game.Category)); err != nil {
+			return err
+		}
+	}
+
+	// Locked in a fixed order so games between the same players rated concurrently do not deadlock.
+	const query = `
+		SELECT
+			*
+		FROM
+			player_rating
+		WHERE
+			category = $1 AND player_id IN ($2, $3)
+		ORDER BY
+			player_id
+		FOR UPDATE;`
+	current, err := tql.Query[domain.Rating](ctx, tx, query, game.Category, game.WhiteID, game.BlackID)
+	if err != nil {
+		return err
+	}
+
+	if len(current) != 2 {
+		return fmt.Errorf("%w - '%s'", domain.ErrNotRatable, game.GameID)
+	}
+
+	white, black := current[0], current[1]
+	if white.PlayerID != game.WhiteID {
+		white, black = black, white
+	}
+
+	white, black, changes, err := domain.RateGame(white, black, game)
+	if err != nil {
+		return err
+	}
+
+	const updateStmt = `
+		UPDATE
+			player_rating
+		SET
+			rating = :rating,
+			deviation = :deviation,
+			volatility = :volatility,
+			games = :games,
+			last_played_at = :last_played_at
+		WHERE
+			player_id = :player_id AND category = :category;`
+	for _, rating := range []domain.Rating{white, black} {
+		if _, err := tql.Exec(ctx, tx, updateStmt, rating); err != nil {
+			return err
+		}
+	}
+
+	const historyStmt = `
+		INSERT INTO
+			rating_history (
+				player_id,
+				category,
+				game_id,
+				opponent_id,
+				score,
+				rating_before,
+				rating_after,
+				deviation_before,
+				deviation_after,
+				played_at
+			)
+		VALUES
+			(
+				:player_id,
+				:category,
+				:game_id,
+				:opponent_id,
+				:score,
+				:rating_before,
+				:rating_after,
+				:deviation_before,
+				:deviation_after,
+				:played_at
+			);`
+	for _, change := range changes {
+		if _, err := tql.Exec(ctx, tx, historyStmt, change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CurrentRating returns the player's rating in the category, the
+// default rating if the player has not played a rated game in it yet.
+func CurrentRating(
+	ctx context.Context,
+	q tql.Querier,
+	playerID uuid.UUID,
+	category domain.Category,
+) (domain.Rating, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			player_rating
+		WHERE
+			player_id = $1 AND category = $2;`
+	rating, err := tql.QueryFirst[domain.Rating](ctx, q, query, playerID, category)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return domain.NewRating(playerID, category), nil
+	case err != nil:
+		return domain.Rating{}, err
+	}
+
+	return rating, nil
+}
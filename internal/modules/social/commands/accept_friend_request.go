@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// AcceptFriendRequestCommand makes the sender and the recipient of the request friends.
+// Only the recipient can accept it.
+type AcceptFriendRequestCommand struct {
+	RequestID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c AcceptFriendRequestCommand) Validate() error {
+	if c.RequestID == uuid.Nil {
+		return fmt.Errorf("invalid RequestID - '%s'", c.RequestID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleAcceptFriendRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := AcceptFriendRequestCommand{
+		RequestID: requestID,
+		UserID:    core.Session(ctx).UserID,
+	}
+
+	_, err = mediator.Send[AcceptFriendRequestCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type AcceptFriendRequestCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewAcceptFriendRequestCommandHandler(db *sql.DB, clock core.Clock) *AcceptFriendRequestCommandHandler {
+	return &AcceptFriendRequestCommandHandler{db: db, clock: clock}
+}
+
+func (h *AcceptFriendRequestCommandHandler) Handle(ctx context.Context, request AcceptFriendRequestCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, friendRequest domain.FriendRequest) error {
+		friendships, err := friendRequest.Accept(request.UserID, now)
+		if err != nil {
+			return err
+		}
+
+		if err := insertFriendships(ctx, tx, friendships); err != nil {
+			return err
+		}
+
+		return notifyFriendRequest(
+			ctx,
+			tx,
+			friendRequest.SenderID,
+			notificationsdomain.FriendRequestAcceptedNotification,
+			friendRequest,
+			friendRequest.RecipientID,
+			now,
+		)
+	}
+
+	if err := runFriendRequestAction(ctx, h.db, request.RequestID, action); err != nil {
+		return core.Unit{}, err
+	}
+
+	return core.Unit{}, nil
+}
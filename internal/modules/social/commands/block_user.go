@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// BlockUserCommand keeps the blocked user away from the user. It ends their friendship,
+// their pending friend requests and their follows, whoever started them.
+type BlockUserCommand struct {
+	BlockerID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c BlockUserCommand) Validate() error {
+	if c.BlockerID == uuid.Nil {
+		return fmt.Errorf("invalid BlockerID - '%s'", c.BlockerID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleBlockUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command, err := core.RequestBody[BlockUserCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	command.BlockerID = core.Session(ctx).UserID
+
+	_, err = mediator.Send[BlockUserCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type BlockUserCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewBlockUserCommandHandler(db *sql.DB, clock core.Clock) *BlockUserCommandHandler {
+	return &BlockUserCommandHandler{db: db, clock: clock}
+}
+
+func (h *BlockUserCommandHandler) Handle(ctx context.Context, request BlockUserCommand) (core.Unit, error) {
+	block, err := domain.NewBlock(request.BlockerID, request.UserID, h.clock.Now())
+	if err != nil {
+		return core.Unit{}, socialCommandError(err)
+	}
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		if err := findUser(ctx, tx, block.BlockedID); err != nil {
+			return err
+		}
+
+		const stmt = `
+			INSERT INTO
+				user_block (blocker_id, blocked_id, created_at)
+			VALUES
+				(:blocker_id, :blocked_id, :created_at)
+			ON CONFLICT DO NOTHING;`
+		if _, err := tql.Exec(ctx, tx, stmt, block); err != nil {
+			return err
+		}
+
+		if _, err := deleteFriendships(ctx, tx, block.BlockerID, block.BlockedID); err != nil {
+			return err
+		}
+
+		const requestsStmt = `
+			DELETE FROM
+				friend_request
+			WHERE
+				(sender_id = $1 AND recipient_id = $2) OR (sender_id = $2 AND recipient_id = $1);`
+		if _, err := tql.Exec(ctx, tx, requestsStmt, block.BlockerID, block.BlockedID); err != nil {
+			return err
+		}
+
+		const followsStmt = `
+			DELETE FROM
+				user_follow
+			WHERE
+				(follower_id = $1 AND followed_id = $2) OR (follower_id = $2 AND followed_id = $1);`
+		_, err := tql.Exec(ctx, tx, followsStmt, block.BlockerID, block.BlockedID)
+		return err
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, socialCommandError(err)
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// CancelFriendRequestCommand takes the sender's request back before it is answered.
+type CancelFriendRequestCommand struct {
+	RequestID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c CancelFriendRequestCommand) Validate() error {
+	if c.RequestID == uuid.Nil {
+		return fmt.Errorf("invalid RequestID - '%s'", c.RequestID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleCancelFriendRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := CancelFriendRequestCommand{
+		RequestID: requestID,
+		UserID:    core.Session(ctx).UserID,
+	}
+
+	_, err = mediator.Send[CancelFriendRequestCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type CancelFriendRequestCommandHandler struct {
+	db *sql.DB
+}
+
+func NewCancelFriendRequestCommandHandler(db *sql.DB) *CancelFriendRequestCommandHandler {
+	return &CancelFriendRequestCommandHandler{db}
+}
+
+func (h *CancelFriendRequestCommandHandler) Handle(ctx context.Context, request CancelFriendRequestCommand) (core.Unit, error) {
+	action := func(_ context.Context, _ *sql.Tx, friendRequest domain.FriendRequest) error {
+		return friendRequest.Cancel(request.UserID)
+	}
+
+	if err := runFriendRequestAction(ctx, h.db, request.RequestID, action); err != nil {
+		return core.Unit{}, err
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// DeclineFriendRequestCommand turns the request down. The sender is not told, they can
+// send another request later.
+type DeclineFriendRequestCommand struct {
+	RequestID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c DeclineFriendRequestCommand) Validate() error {
+	if c.RequestID == uuid.Nil {
+		return fmt.Errorf("invalid RequestID - '%s'", c.RequestID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleDeclineFriendRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := DeclineFriendRequestCommand{
+		RequestID: requestID,
+		UserID:    core.Session(ctx).UserID,
+	}
+
+	_, err = mediator.Send[DeclineFriendRequestCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type DeclineFriendRequestCommandHandler struct {
+	db *sql.DB
+}
+
+func NewDeclineFriendRequestCommandHandler(db *sql.DB) *DeclineFriendRequestCommandHandler {
+	return &DeclineFriendRequestCommandHandler{db}
+}
+
+func (h *DeclineFriendRequestCommandHandler) Handle(ctx context.Context, request DeclineFriendRequestCommand) (core.Unit, error) {
+	action := func(_ context.Context, _ *sql.Tx, friendRequest domain.FriendRequest) error {
+		return friendRequest.Answer(request.UserID)
+	}
+
+	if err := runFriendRequestAction(ctx, h.db, request.RequestID, action); err != nil {
+		return core.Unit{}, err
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// FollowUserCommand follows the games of another player. Following needs no consent,
+// but users who blocked each other cannot follow each other.
+type FollowUserCommand struct {
+	FollowerID uuid.UUID
+	UserID     uuid.UUID
+}
+
+func (c FollowUserCommand) Validate() error {
+	if c.FollowerID == uuid.Nil {
+		return fmt.Errorf("invalid FollowerID - '%s'", c.FollowerID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleFollowUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command, err := core.RequestBody[FollowUserCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	command.FollowerID = core.Session(ctx).UserID
+
+	_, err = mediator.Send[FollowUserCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type FollowUserCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewFollowUserCommandHandler(db *sql.DB, clock core.Clock) *FollowUserCommandHandler {
+	return &FollowUserCommandHandler{db: db, clock: clock}
+}
+
+func (h *FollowUserCommandHandler) Handle(ctx context.Context, request FollowUserCommand) (core.Unit, error) {
+	follow, err := domain.NewFollow(request.FollowerID, request.UserID, h.clock.Now())
+	if err != nil {
+		return core.Unit{}, socialCommandError(err)
+	}
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		if err := findUser(ctx, tx, follow.FollowedID); err != nil {
+			return err
+		}
+
+		if err := social.CheckNotBlocked(ctx, tx, follow.FollowerID, follow.FollowedID); err != nil {
+			return err
+		}
+
+		const stmt = `
+			INSERT INTO
+				user_follow (follower_id, followed_id, created_at)
+			VALUES
+				(:follower_id, :followed_id, :created_at)
+			ON CONFLICT DO NOTHING;`
+		_, err := tql.Exec(ctx, tx, stmt, follow)
+		return err
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, socialCommandError(err)
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// RemoveFriendCommand ends the friendship for both users.
+type RemoveFriendCommand struct {
+	UserID   uuid.UUID
+	FriendID uuid.UUID
+}
+
+func (c RemoveFriendCommand) Validate() error {
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	if c.FriendID == uuid.Nil {
+		return fmt.Errorf("invalid FriendID - '%s'", c.FriendID)
+	}
+
+	return nil
+}
+
+func HandleRemoveFriend(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	friendID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := RemoveFriendCommand{UserID: core.Session(ctx).UserID, FriendID: friendID}
+
+	_, err = mediator.Send[RemoveFriendCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type RemoveFriendCommandHandler struct {
+	db *sql.DB
+}
+
+func NewRemoveFriendCommandHandler(db *sql.DB) *RemoveFriendCommandHandler {
+	return &RemoveFriendCommandHandler{db}
+}
+
+func (h *RemoveFriendCommandHandler) Handle(ctx context.Context, request RemoveFriendCommand) (core.Unit, error) {
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		removed, err := deleteFriendships(ctx, tx, request.UserID, request.FriendID)
+		if err != nil {
+			return err
+		}
+
+		if !removed {
+			return domain.ErrNotFriends
+		}
+
+		return nil
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return core.Unit{}, socialCommandError(err)
+	}
+
+	return core.Unit{}, nil
+}
+
+// deleteFriendships removes the friendship of the users in both directions and
+// reports whether they were friends.
+func deleteFriendships(ctx context.Context, tx *sql.Tx, userID uuid.UUID, otherID uuid.UUID) (bool, error) {
+	const stmt = `
+		DELETE FROM
+			friendship
+		WHERE
+			(user_id = $1 AND friend_id = $2) OR (user_id = $2 AND friend_id = $1);`
+	result, err := tql.Exec(ctx, tx, stmt, userID, otherID)
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
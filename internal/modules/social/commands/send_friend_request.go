@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// SendFriendRequestCommand asks the recipient to become friends with the sender.
+// Only one request can be pending between two users, whoever sent it.
+type SendFriendRequestCommand struct {
+	SenderID    uuid.UUID
+	RecipientID uuid.UUID
+}
+
+func (c SendFriendRequestCommand) Validate() error {
+	if c.SenderID == uuid.Nil {
+		return fmt.Errorf("invalid SenderID - '%s'", c.SenderID)
+	}
+
+	if c.RecipientID == uuid.Nil {
+		return fmt.Errorf("invalid RecipientID - '%s'", c.RecipientID)
+	}
+
+	return nil
+}
+
+func HandleSendFriendRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command, err := core.RequestBody[SendFriendRequestCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	command.SenderID = core.Session(ctx).UserID
+
+	requestID, err := mediator.Send[SendFriendRequestCommand, uuid.UUID](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	location := path.Join(r.Host, "friend-requests", requestID.String())
+	core.WriteCreated(w, r, location)
+}
+
+type SendFriendRequestCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewSendFriendRequestCommandHandler(db *sql.DB, clock core.Clock) *SendFriendRequestCommandHandler {
+	return &SendFriendRequestCommandHandler{db: db, clock: clock}
+}
+
+func (h *SendFriendRequestCommandHandler) Handle(ctx context.Context, request SendFriendRequestCommand) (uuid.UUID, error) {
+	now := h.clock.Now()
+
+	friendRequest, err := domain.NewFriendRequest(request.SenderID, request.RecipientID, now)
+	if err != nil {
+		return uuid.Nil, socialCommandError(err)
+	}
+
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		if err := findUser(ctx, tx, request.RecipientID); err != nil {
+			return err
+		}
+
+		if err := social.CheckNotBlocked(ctx, tx, request.SenderID, request.RecipientID); err != nil {
+			return err
+		}
+
+		friends, err := areFriends(ctx, tx, request.SenderID, request.RecipientID)
+		if err != nil {
+			return err
+		}
+
+		if friends {
+			return domain.ErrAlreadyFriends
+		}
+
+		const stmt = `
+			INSERT INTO
+				friend_request (id, sender_id, recipient_id, created_at)
+			VALUES
+				(:id, :sender_id, :recipient_id, :created_at)
+			ON CONFLICT DO NOTHING;`
+		result, err := tql.Exec(ctx, tx, stmt, friendRequest)
+		if err != nil {
+			return err
+		}
+
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if inserted == 0 {
+			return domain.ErrFriendRequestPending
+		}
+
+		return notifyFriendRequest(
+			ctx,
+			tx,
+			friendRequest.RecipientID,
+			notificationsdomain.FriendRequestReceivedNotification,
+			friendRequest,
+			friendRequest.SenderID,
+			now,
+		)
+	}
+
+	if err := core.Tx(ctx, h.db, txFn); err != nil {
+		return uuid.Nil, socialCommandError(err)
+	}
+
+	return friendRequest.ID, nil
+}
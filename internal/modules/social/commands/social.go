@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// findUser makes sure the user exists before anything is stored for them.
+func findUser(ctx context.Context, tx *sql.Tx, userID uuid.UUID) error {
+	const query = `SELECT id FROM auth.user WHERE id = $1;`
+	_, err := tql.QueryFirst[uuid.UUID](ctx, tx, query, userID)
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		return core.NewCommandError(404, fmt.Errorf("user '%s' not found", userID))
+	}
+
+	return err
+}
+
+func areFriends(ctx context.Context, tx *sql.Tx, userID uuid.UUID, otherID uuid.UUID) (bool, error) {
+	const query = `
+		SELECT
+			count(*)
+		FROM
+			friendship
+		WHERE
+			user_id = $1 AND friend_id = $2;`
+	friendships, err := tql.QueryFirst[int](ctx, tx, query, userID, otherID)
+	return friendships > 0, err
+}
+
+func insertFriendships(ctx context.Context, tx *sql.Tx, friendships []domain.Friendship) error {
+	const stmt = `
+		INSERT INTO
+			friendship (user_id, friend_id, created_at)
+		VALUES
+			(:user_id, :friend_id, :created_at)
+		ON CONFLICT DO NOTHING;`
+	for _, friendship := range friendships {
+		if _, err := tql.Exec(ctx, tx, stmt, friendship); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runFriendRequestAction locks the friend request and runs the user's answer to it in a
+// transaction. Every answer settles the request, so it is deleted afterwards.
+func runFriendRequestAction(
+	ctx context.Context,
+	db *sql.DB,
+	requestID uuid.UUID,
+	action func(ctx context.Context, tx *sql.Tx, request domain.FriendRequest) error,
+) error {
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const query = `
+			SELECT
+				*
+			FROM
+				friend_request
+			WHERE
+				id = $1
+			FOR UPDATE;`
+		request, err := tql.QueryFirst[domain.FriendRequest](ctx, tx, query, requestID)
+		if err != nil {
+			return err
+		}
+
+		if err := action(ctx, tx, request); err != nil {
+			return err
+		}
+
+		const stmt = `DELETE FROM friend_request WHERE id = $1;`
+		_, err = tql.Exec(ctx, tx, stmt, request.ID)
+		return err
+	}
+
+	if err := core.Tx(ctx, db, txFn); err != nil {
+		return socialCommandError(err)
+	}
+
+	return nil
+}
+
+// notifyFriendRequest tells the user about the friend request, otherID is the other user of the request.
+func notifyFriendRequest(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID uuid.UUID,
+	notificationType notificationsdomain.NotificationType,
+	request domain.FriendRequest,
+	otherID uuid.UUID,
+	now time.Time,
+) error {
+	notification, err := notificationsdomain.NewUserNotification(
+		userID,
+		notificationType,
+		notificationsdomain.FriendRequestPayload{RequestID: request.ID, UserID: otherID},
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	return notifications.Notify(ctx, tx, notification)
+}
+
+// socialCommandError maps the errors of the social commands to their status codes.
+func socialCommandError(err error) error {
+	var commandErr core.CommandError
+	switch {
+	case errors.As(err, &commandErr):
+		return commandErr
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, domain.ErrNotFriends):
+		return core.NewCommandError(404, err)
+	case errors.Is(err, domain.ErrCannotBefriendSelf),
+		errors.Is(err, domain.ErrCannotFollowSelf),
+		errors.Is(err, domain.ErrCannotBlockSelf):
+		return core.NewCommandError(400, err)
+	case errors.Is(err, domain.ErrNotRecipient),
+		errors.Is(err, domain.ErrNotSender),
+		errors.Is(err, domain.ErrBlocked):
+		return core.NewCommandError(403, err)
+	case errors.Is(err, domain.ErrAlreadyFriends), errors.Is(err, domain.ErrFriendRequestPending):
+		return core.NewCommandError(409, err)
+	default:
+		return core.NewCommandError(500, err)
+	}
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+type UnblockUserCommand struct {
+	BlockerID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c UnblockUserCommand) Validate() error {
+	if c.BlockerID == uuid.Nil {
+		return fmt.Errorf("invalid BlockerID - '%s'", c.BlockerID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleUnblockUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := UnblockUserCommand{BlockerID: core.Session(ctx).UserID, UserID: userID}
+
+	_, err = mediator.Send[UnblockUserCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type UnblockUserCommandHandler struct {
+	db *sql.DB
+}
+
+func NewUnblockUserCommandHandler(db *sql.DB) *UnblockUserCommandHandler {
+	return &UnblockUserCommandHandler{db}
+}
+
+func (h *UnblockUserCommandHandler) Handle(ctx context.Context, request UnblockUserCommand) (core.Unit, error) {
+	const stmt = `
+		DELETE FROM
+			user_block
+		WHERE
+			blocker_id = $1 AND blocked_id = $2;`
+	result, err := tql.Exec(ctx, h.db, stmt, request.BlockerID, request.UserID)
+	if err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	if deleted == 0 {
+		return core.Unit{}, core.NewCommandError(404, fmt.Errorf("user '%s' is not blocked", request.UserID))
+	}
+
+	return core.Unit{}, nil
+}
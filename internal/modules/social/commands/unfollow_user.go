@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+type UnfollowUserCommand struct {
+	FollowerID uuid.UUID
+	UserID     uuid.UUID
+}
+
+func (c UnfollowUserCommand) Validate() error {
+	if c.FollowerID == uuid.Nil {
+		return fmt.Errorf("invalid FollowerID - '%s'", c.FollowerID)
+	}
+
+	if c.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", c.UserID)
+	}
+
+	return nil
+}
+
+func HandleUnfollowUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := UnfollowUserCommand{FollowerID: core.Session(ctx).UserID, UserID: userID}
+
+	_, err = mediator.Send[UnfollowUserCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type UnfollowUserCommandHandler struct {
+	db *sql.DB
+}
+
+func NewUnfollowUserCommandHandler(db *sql.DB) *UnfollowUserCommandHandler {
+	return &UnfollowUserCommandHandler{db}
+}
+
+func (h *UnfollowUserCommandHandler) Handle(ctx context.Context, request UnfollowUserCommand) (core.Unit, error) {
+	const stmt = `
+		DELETE FROM
+			user_follow
+		WHERE
+			follower_id = $1 AND followed_id = $2;`
+	result, err := tql.Exec(ctx, h.db, stmt, request.FollowerID, request.UserID)
+	if err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	if deleted == 0 {
+		return core.Unit{}, core.NewCommandError(404, fmt.Errorf("user '%s' is not followed", request.UserID))
+	}
+
+	return core.Unit{}, nil
+}
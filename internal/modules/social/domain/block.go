@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBlocked          = errors.New("one of the users has blocked the other")
+	ErrCannotBlockSelf  = errors.New("users cannot block themselves")
+	ErrCannotFollowSelf = errors.New("users cannot follow themselves")
+)
+
+// Block keeps the blocked user away from the user who blocked them. Neither can
+// invite, challenge, chat with or be paired with the other, whoever blocked whom.
+type Block struct {
+	BlockerID uuid.UUID `db:"blocker_id"`
+	BlockedID uuid.UUID `db:"blocked_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func NewBlock(blockerID uuid.UUID, blockedID uuid.UUID, now time.Time) (Block, error) {
+	if blockerID == blockedID {
+		return Block{}, ErrCannotBlockSelf
+	}
+
+	return Block{BlockerID: blockerID, BlockedID: blockedID, CreatedAt: now}, nil
+}
+
+// Blocks is a set of blocks, for checking many pairs of users at once.
+type Blocks map[[2]uuid.UUID]struct{}
+
+func NewBlocks(blocks []Block) Blocks {
+	set := make(Blocks, len(blocks))
+	for _, block := range blocks {
+		set[[2]uuid.UUID{block.BlockerID, block.BlockedID}] = struct{}{}
+	}
+	return set
+}
+
+// Between reports whether either user blocked the other.
+func (b Blocks) Between(userID uuid.UUID, otherID uuid.UUID) bool {
+	_, blocked := b[[2]uuid.UUID{userID, otherID}]
+	if blocked {
+		return true
+	}
+
+	_, blocked = b[[2]uuid.UUID{otherID, userID}]
+	return blocked
+}
+
+// Follow subscribes the follower to the followed user's activity. Unlike friendships
+// it is one-sided and needs no consent.
+type Follow struct {
+	FollowerID uuid.UUID `db:"follower_id"`
+	FollowedID uuid.UUID `db:"followed_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func NewFollow(followerID uuid.UUID, followedID uuid.UUID, now time.Time) (Follow, error) {
+	if followerID == followedID {
+		return Follow{}, ErrCannotFollowSelf
+	}
+
+	return Follow{FollowerID: followerID, FollowedID: followedID, CreatedAt: now}, nil
+}
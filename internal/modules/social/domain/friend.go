@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	presencedomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/presence/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCannotBefriendSelf   = errors.New("users cannot befriend themselves")
+	ErrAlreadyFriends       = errors.New("users are already friends")
+	ErrFriendRequestPending = errors.New("a friend request between the users is already pending")
+	ErrNotRecipient         = errors.New("only the recipient can answer the friend request")
+	ErrNotSender            = errors.New("only the sender can cancel the friend request")
+	ErrNotFriends           = errors.New("users are not friends")
+)
+
+// FriendRequest is a user's request to become friends, pending until the
+// recipient accepts or declines it or the sender cancels it.
+type FriendRequest struct {
+	ID          uuid.UUID `db:"id"`
+	SenderID    uuid.UUID `db:"sender_id"`
+	RecipientID uuid.UUID `db:"recipient_id"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func NewFriendRequest(senderID uuid.UUID, recipientID uuid.UUID, now time.Time) (FriendRequest, error) {
+	if senderID == recipientID {
+		return FriendRequest{}, ErrCannotBefriendSelf
+	}
+
+	return FriendRequest{
+		ID:          uuid.New(),
+		SenderID:    senderID,
+		RecipientID: recipientID,
+		CreatedAt:   now,
+	}, nil
+}
+
+// Answer checks the user can accept or decline the request.
+func (r FriendRequest) Answer(userID uuid.UUID) error {
+	if userID != r.RecipientID {
+		return ErrNotRecipient
+	}
+
+	return nil
+}
+
+// Cancel checks the user can take the request back.
+func (r FriendRequest) Cancel(userID uuid.UUID) error {
+	if userID != r.SenderID {
+		return ErrNotSender
+	}
+
+	return nil
+}
+
+// Accept makes the users of the request friends.
+func (r FriendRequest) Accept(userID uuid.UUID, now time.Time) ([]Friendship, error) {
+	if err := r.Answer(userID); err != nil {
+		return nil, err
+	}
+
+	return NewFriendships(r.SenderID, r.RecipientID, now), nil
+}
+
+// Friendship is stored for both friends, so each user's friends are listed the same way.
+type Friendship struct {
+	UserID    uuid.UUID `db:"user_id"`
+	FriendID  uuid.UUID `db:"friend_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func NewFriendships(userID uuid.UUID, friendID uuid.UUID, now time.Time) []Friendship {
+	return []Friendship{
+		{UserID: userID, FriendID: friendID, CreatedAt: now},
+		{UserID: friendID, FriendID: userID, CreatedAt: now},
+	}
+}
+
+// FriendStatus is one of the user's friends and whether they are around.
+type FriendStatus struct {
+	UserID   uuid.UUID `db:"user_id"`
+	Username string    `db:"username"`
+	// Since is when the users became friends.
+	Since  time.Time `db:"since"`
+	Status presencedomain.Status
+}
+
+// FriendGame is a game one of the user's friends or followed players is playing.
+type FriendGame struct {
+	PlayerID  uuid.UUID `db:"player_id"`
+	SessionID string    `db:"session_id"`
+	GameID    uuid.UUID `db:"game_id"`
+	WhiteID   uuid.UUID `db:"white_id"`
+	BlackID   uuid.UUID `db:"black_id"`
+	Ply       int       `db:"ply"`
+	StartedAt time.Time `db:"started_at"`
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FriendRequest_Accept_Befriends_Both_Users(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	senderID, recipientID := uuid.New(), uuid.New()
+	request, err := NewFriendRequest(senderID, recipientID, now)
+	require.NoError(t, err)
+
+	// Act
+	_, senderErr := request.Accept(senderID, now)
+	friendships, err := request.Accept(recipientID, now)
+
+	// Assert
+	require.ErrorIs(t, senderErr, ErrNotRecipient)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Friendship{
+		{UserID: senderID, FriendID: recipientID, CreatedAt: now},
+		{UserID: recipientID, FriendID: senderID, CreatedAt: now},
+	}, friendships)
+}
+
+func Test_FriendRequest_Only_Sender_Cancels(t *testing.T) {
+	// Arrange
+	senderID, recipientID := uuid.New(), uuid.New()
+	request, err := NewFriendRequest(senderID, recipientID, time.Now().UTC())
+	require.NoError(t, err)
+
+	// Act & Assert
+	require.NoError(t, request.Cancel(senderID))
+	require.ErrorIs(t, request.Cancel(recipientID), ErrNotSender)
+	require.ErrorIs(t, request.Answer(senderID), ErrNotRecipient)
+}
+
+func Test_Users_Cannot_Befriend_Follow_Or_Block_Themselves(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	userID := uuid.New()
+
+	// Act
+	_, friendErr := NewFriendRequest(userID, userID, now)
+	_, followErr := NewFollow(userID, userID, now)
+	_, blockErr := NewBlock(userID, userID, now)
+
+	// Assert
+	require.ErrorIs(t, friendErr, ErrCannotBefriendSelf)
+	require.ErrorIs(t, followErr, ErrCannotFollowSelf)
+	require.ErrorIs(t, blockErr, ErrCannotBlockSelf)
+}
+
+func Test_Blocks_Between_Works_Both_Ways(t *testing.T) {
+	// Arrange
+	blockerID, blockedID, otherID := uuid.New(), uuid.New(), uuid.New()
+	block, err := NewBlock(blockerID, blockedID, time.Now().UTC())
+	require.NoError(t, err)
+
+	// Act
+	blocks := NewBlocks([]Block{block})
+
+	// Assert
+	require.True(t, blocks.Between(blockerID, blockedID))
+	require.True(t, blocks.Between(blockedID, blockerID))
+	require.False(t, blocks.Between(blockerID, otherID))
+	require.False(t, Blocks(nil).Between(blockerID, blockedID))
+}
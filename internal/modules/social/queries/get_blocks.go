@@ -0,0 +1,72 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetBlocksQuery lists the users the user blocked.
+type GetBlocksQuery struct {
+	UserID uuid.UUID
+}
+
+func (q GetBlocksQuery) Validate() error {
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return nil
+}
+
+func HandleGetBlocks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	response, err := mediator.Send[GetBlocksQuery, []uuid.UUID](
+		ctx,
+		GetBlocksQuery{UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetBlocksQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetBlocksQueryHandler(db *sql.DB) *GetBlocksQueryHandler {
+	return &GetBlocksQueryHandler{db}
+}
+
+func (h *GetBlocksQueryHandler) Handle(ctx context.Context, request GetBlocksQuery) ([]uuid.UUID, error) {
+	const query = `
+		SELECT
+			blocked_id
+		FROM
+			user_block
+		WHERE
+			blocker_id = $1
+		ORDER BY
+			created_at;`
+	blocked, err := tql.Query[uuid.UUID](ctx, h.db, query, request.UserID)
+	if err != nil {
+		return nil, core.NewCommandError(500, err)
+	}
+
+	if blocked == nil {
+		blocked = []uuid.UUID{}
+	}
+
+	return blocked, nil
+}
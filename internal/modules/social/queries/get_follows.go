@@ -0,0 +1,72 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetFollowsQuery lists the players the user follows.
+type GetFollowsQuery struct {
+	UserID uuid.UUID
+}
+
+func (q GetFollowsQuery) Validate() error {
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return nil
+}
+
+func HandleGetFollows(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	response, err := mediator.Send[GetFollowsQuery, []uuid.UUID](
+		ctx,
+		GetFollowsQuery{UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetFollowsQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetFollowsQueryHandler(db *sql.DB) *GetFollowsQueryHandler {
+	return &GetFollowsQueryHandler{db}
+}
+
+func (h *GetFollowsQueryHandler) Handle(ctx context.Context, request GetFollowsQuery) ([]uuid.UUID, error) {
+	const query = `
+		SELECT
+			followed_id
+		FROM
+			user_follow
+		WHERE
+			follower_id = $1
+		ORDER BY
+			created_at;`
+	followed, err := tql.Query[uuid.UUID](ctx, h.db, query, request.UserID)
+	if err != nil {
+		return nil, core.NewCommandError(500, err)
+	}
+
+	if followed == nil {
+		followed = []uuid.UUID{}
+	}
+
+	return followed, nil
+}
@@ -0,0 +1,104 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetFriendGamesQuery lists the ongoing games of the user's friends and of the players
+// they follow, newest first. Games in private sessions are left out.
+type GetFriendGamesQuery struct {
+	UserID uuid.UUID
+}
+
+func (q GetFriendGamesQuery) Validate() error {
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return nil
+}
+
+func HandleGetFriendGames(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	response, err := mediator.Send[GetFriendGamesQuery, []domain.FriendGame](
+		ctx,
+		GetFriendGamesQuery{UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetFriendGamesQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetFriendGamesQueryHandler(db *sql.DB) *GetFriendGamesQueryHandler {
+	return &GetFriendGamesQueryHandler{db}
+}
+
+func (h *GetFriendGamesQueryHandler) Handle(ctx context.Context, request GetFriendGamesQuery) ([]domain.FriendGame, error) {
+	// A game between two of the players is listed once.
+	const query = `
+		SELECT
+			*
+		FROM
+			(
+				SELECT DISTINCT ON (g.id)
+					p.player_id,
+					g.session_id,
+					g.id AS game_id,
+					g.white_id,
+					g.black_id,
+					g.ply,
+					g.created_at AS started_at
+				FROM
+					(
+						SELECT friend_id AS player_id FROM friendship WHERE user_id = $1
+						UNION
+						SELECT followed_id AS player_id FROM user_follow WHERE follower_id = $1
+					) p
+				INNER JOIN
+					game g ON g.white_id = p.player_id OR g.black_id = p.player_id
+				INNER JOIN
+					game_session s ON s.id = g.session_id
+				WHERE
+					g.status = $2 AND s.visibility = $3
+				ORDER BY
+					g.id, p.player_id
+			) games
+		ORDER BY
+			started_at DESC, game_id;`
+	games, err := tql.Query[domain.FriendGame](
+		ctx,
+		h.db,
+		query,
+		request.UserID,
+		gamesessiondomain.GameStarted,
+		gamesessiondomain.Public,
+	)
+	if err != nil {
+		return nil, core.NewCommandError(500, err)
+	}
+
+	if games == nil {
+		games = []domain.FriendGame{}
+	}
+
+	return games, nil
+}
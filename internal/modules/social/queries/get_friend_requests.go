@@ -0,0 +1,84 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetFriendRequestsQuery lists the user's pending friend requests, oldest first.
+type GetFriendRequestsQuery struct {
+	UserID uuid.UUID
+}
+
+func (q GetFriendRequestsQuery) Validate() error {
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return nil
+}
+
+// FriendRequests are the requests the user received and the ones they sent.
+type FriendRequests struct {
+	Received []domain.FriendRequest
+	Sent     []domain.FriendRequest
+}
+
+func HandleGetFriendRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	response, err := mediator.Send[GetFriendRequestsQuery, FriendRequests](
+		ctx,
+		GetFriendRequestsQuery{UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetFriendRequestsQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetFriendRequestsQueryHandler(db *sql.DB) *GetFriendRequestsQueryHandler {
+	return &GetFriendRequestsQueryHandler{db}
+}
+
+func (h *GetFriendRequestsQueryHandler) Handle(ctx context.Context, request GetFriendRequestsQuery) (FriendRequests, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			friend_request
+		WHERE
+			sender_id = $1 OR recipient_id = $1
+		ORDER BY
+			created_at;`
+	requests, err := tql.Query[domain.FriendRequest](ctx, h.db, query, request.UserID)
+	if err != nil {
+		return FriendRequests{}, core.NewCommandError(500, err)
+	}
+
+	response := FriendRequests{Received: []domain.FriendRequest{}, Sent: []domain.FriendRequest{}}
+	for _, friendRequest := range requests {
+		if friendRequest.RecipientID == request.UserID {
+			response.Received = append(response.Received, friendRequest)
+		} else {
+			response.Sent = append(response.Sent, friendRequest)
+		}
+	}
+
+	return response, nil
+}
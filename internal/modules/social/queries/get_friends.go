@@ -0,0 +1,109 @@
+package queries
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/presence"
+	presencedomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/presence/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetFriendsQuery lists the user's friends with their presence, the ones around first.
+type GetFriendsQuery struct {
+	UserID uuid.UUID
+}
+
+func (q GetFriendsQuery) Validate() error {
+	if q.UserID == uuid.Nil {
+		return fmt.Errorf("invalid UserID - '%s'", q.UserID)
+	}
+
+	return nil
+}
+
+func HandleGetFriends(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	response, err := mediator.Send[GetFriendsQuery, []domain.FriendStatus](
+		ctx,
+		GetFriendsQuery{UserID: core.Session(ctx).UserID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetFriendsQueryHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewGetFriendsQueryHandler(db *sql.DB, clock core.Clock) *GetFriendsQueryHandler {
+	return &GetFriendsQueryHandler{db: db, clock: clock}
+}
+
+func (h *GetFriendsQueryHandler) Handle(ctx context.Context, request GetFriendsQuery) ([]domain.FriendStatus, error) {
+	const query = `
+		SELECT
+			f.friend_id AS user_id,
+			u.username,
+			f.created_at AS since
+		FROM
+			friendship f
+		INNER JOIN
+			auth.user u ON u.id = f.friend_id
+		WHERE
+			f.user_id = $1
+		ORDER BY
+			u.username;`
+	friends, err := tql.Query[domain.FriendStatus](ctx, h.db, query, request.UserID)
+	if err != nil {
+		return nil, core.NewCommandError(500, err)
+	}
+
+	friendIDs := make([]uuid.UUID, 0, len(friends))
+	for _, friend := range friends {
+		friendIDs = append(friendIDs, friend.UserID)
+	}
+
+	statuses, err := presence.Statuses(ctx, h.db, friendIDs, h.clock.Now())
+	if err != nil {
+		return nil, core.NewCommandError(500, err)
+	}
+
+	for i := range friends {
+		friends[i].Status = statuses.Of(friends[i].UserID)
+	}
+
+	slices.SortStableFunc(friends, func(a, b domain.FriendStatus) int {
+		return cmp.Compare(statusRank(a.Status), statusRank(b.Status))
+	})
+
+	if friends == nil {
+		friends = []domain.FriendStatus{}
+	}
+
+	return friends, nil
+}
+
+// statusRank lists the friends who are around first.
+func statusRank(status presencedomain.Status) int {
+	if status == presencedomain.Offline {
+		return 1
+	}
+
+	return 0
+}
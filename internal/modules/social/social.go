@@ -0,0 +1,59 @@
+package social
+
+import (
+	"context"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// CheckNotBlocked refuses an interaction between the users, such as an invitation, a
+// challenge or a chat message, when either of them blocked the other. It is what every
+// module letting users interact checks, so a block keeps the users apart everywhere.
+func CheckNotBlocked(ctx context.Context, q tql.Querier, userID uuid.UUID, otherID uuid.UUID) error {
+	blocked, err := Blocked(ctx, q, userID, otherID)
+	if err != nil {
+		return core.NewCommandError(500, err)
+	}
+
+	if blocked {
+		return core.NewCommandError(403, domain.ErrBlocked)
+	}
+
+	return nil
+}
+
+// Blocked reports whether either user blocked the other, for the interactions which
+// are dropped quietly instead of refused.
+func Blocked(ctx context.Context, q tql.Querier, userID uuid.UUID, otherID uuid.UUID) (bool, error) {
+	const query = `
+		SELECT
+			count(*)
+		FROM
+			user_block
+		WHERE
+			(blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1);`
+	blocks, err := tql.QueryFirst[int](ctx, q, query, userID, otherID)
+	return blocks > 0, err
+}
+
+// BlocksAmong loads the blocks between the users, for pairing many users at once.
+func BlocksAmong(ctx context.Context, q tql.Querier, userIDs []uuid.UUID) (domain.Blocks, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			user_block
+		WHERE
+			blocker_id = ANY($1) AND blocked_id = ANY($1);`
+	blocks, err := tql.Query[domain.Block](ctx, q, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewBlocks(blocks), nil
+}
@@ -0,0 +1,150 @@
+package tournaments
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// Berserk lets the player go berserk in their arena game as part of the transaction
+// which halves their clock. Only the games of arenas allowing it can be played berserk,
+// and only once by each player.
+func Berserk(ctx context.Context, tx *sql.Tx, gameID uuid.UUID, playerID uuid.UUID) error {
+	const query = `
+		SELECT
+			g.*
+		FROM
+			arena_game g
+		INNER JOIN
+			arena a ON a.id = g.arena_id
+		WHERE
+			g.game_id = $1 AND a.berserk = true
+		FOR UPDATE OF g;`
+	game, err := tql.QueryFirst[domain.ArenaGame](ctx, tx, query, gameID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return domain.ErrBerserkNotAllowed
+	case err != nil:
+		return err
+	}
+
+	if err := game.GoBerserk(playerID); err != nil {
+		return err
+	}
+
+	const stmt = `
+		UPDATE
+			arena_game
+		SET
+			white_berserk = :white_berserk,
+			black_berserk = :black_berserk
+		WHERE
+			game_id = :game_id;`
+	_, err = tql.Exec(ctx, tx, stmt, game)
+	return err
+}
+
+// recordArenaGame scores the arena game and puts its players back in the arena's
+// pairing pool. Aborted games and games ending after the arena do not count.
+func recordArenaGame(ctx context.Context, tx *sql.Tx, result domain.GameResult) error {
+	const query = `
+		SELECT
+			*
+		FROM
+			arena_game
+		WHERE
+			game_id = $1 AND white_points IS NULL
+		FOR UPDATE;`
+	game, err := tql.QueryFirst[domain.ArenaGame](ctx, tx, query, result.GameID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		return err
+	}
+
+	const arenaQuery = `
+		SELECT
+			*
+		FROM
+			arena
+		WHERE
+			id = $1;`
+	arena, err := tql.QueryFirst[domain.Arena](ctx, tx, arenaQuery, game.ArenaID)
+	if err != nil {
+		return err
+	}
+
+	const playersQuery = `
+		SELECT
+			*
+		FROM
+			arena_player
+		WHERE
+			arena_id = $1 AND player_id IN ($2, $3)
+		ORDER BY
+			player_id
+		FOR UPDATE;`
+	players, err := tql.Query[domain.ArenaPlayer](ctx, tx, playersQuery, game.ArenaID, game.WhiteID, game.BlackID)
+	if err != nil {
+		return err
+	}
+
+	counts := !result.Aborted && result.EndedAt.Before(arena.EndsAt)
+
+	game.Score(0, 0)
+	for i := range players {
+		player := &players[i]
+		if !counts {
+			player.Playing = false
+			continue
+		}
+
+		if player.PlayerID == game.WhiteID {
+			*game.WhitePoints = player.Record(result.WhiteScore, game.WhiteBerserk, result.Plies)
+		} else {
+			*game.BlackPoints = player.Record(result.BlackScore, game.BlackBerserk, result.Plies)
+		}
+	}
+
+	const gameStmt = `
+		UPDATE
+			arena_game
+		SET
+			white_points = :white_points,
+			black_points = :black_points
+		WHERE
+			game_id = :game_id;`
+	if _, err := tql.Exec(ctx, tx, gameStmt, game); err != nil {
+		return err
+	}
+
+	for _, player := range players {
+		const playerStmt = `
+			UPDATE
+				arena_player
+			SET
+				points = :points,
+				streak = :streak,
+				games = :games,
+				wins = :wins,
+				draws = :draws,
+				losses = :losses,
+				berserks = :berserks,
+				playing = :playing
+			WHERE
+				arena_id = :arena_id AND player_id = :player_id;`
+		if _, err := tql.Exec(ctx, tx, playerStmt, player); err != nil {
+			return err
+		}
+	}
+
+	// The leaderboard streams read the leaderboard again on each change.
+	return core.Publish(ctx, tx, domain.ArenaTopic(arena.ID), arena.ID)
+}
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// AdvanceTournamentsCommand moves the tournaments whose current round is over on to
+// the next round, or finishes them after their last round. Swiss tournaments also
+// finish early when the players left cannot be paired without meeting twice.
+// It is run periodically by a background job.
+type AdvanceTournamentsCommand struct{}
+
+type AdvanceTournamentsCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewAdvanceTournamentsCommandHandler(db *sql.DB, clock core.Clock) *AdvanceTournamentsCommandHandler {
+	return &AdvanceTournamentsCommandHandler{db: db, clock: clock}
+}
+
+func (h *AdvanceTournamentsCommandHandler) Handle(ctx context.Context, _ AdvanceTournamentsCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	const query = `
+		SELECT
+			t.id
+		FROM
+			tournament t
+		WHERE
+			t.status = $1
+			AND NOT EXISTS (
+				SELECT
+					1
+				FROM
+					tournament_pairing p
+				WHERE
+					p.tournament_id = t.id AND p.round = t.current_round AND p.white_score IS NULL
+			)
+		ORDER BY
+			t.started_at
+		LIMIT
+			100;`
+	tournamentIDs, err := tql.Query[uuid.UUID](ctx, h.db, query, domain.Running)
+	if err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	// Each tournament advances in its own transaction, so one which fails to
+	// advance does not hold back the others.
+	var errs []error
+	for _, tournamentID := range tournamentIDs {
+		txFn := func(ctx context.Context, tx *sql.Tx) error {
+			// Skip the tournament when another instance is advancing it or a player is
+			// withdrawing, it is picked up again on the next run if still due. The round
+			// is checked again, another instance may have advanced it meanwhile.
+			const lockQuery = `
+				SELECT
+					t.*
+				FROM
+					tournament t
+				WHERE
+					t.id = $1
+					AND t.status = $2
+					AND NOT EXISTS (
+						SELECT
+							1
+						FROM
+							tournament_pairing p
+						WHERE
+							p.tournament_id = t.id AND p.round = t.current_round AND p.white_score IS NULL
+					)
+				FOR UPDATE OF t SKIP LOCKED;`
+			tournament, err := tql.QueryFirst[domain.Tournament](ctx, tx, lockQuery, tournamentID, domain.Running)
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return nil
+			case err != nil:
+				return err
+			}
+
+			return advanceTournament(ctx, tx, &tournament, now)
+		}
+
+		if err := core.Tx(ctx, h.db, txFn); err != nil {
+			errs = append(errs, fmt.Errorf("tournament '%s': %w", tournamentID, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
+
+// advanceTournament starts the next round of the tournament whose current round is over.
+func advanceTournament(ctx context.Context, tx *sql.Tx, tournament *domain.Tournament, now time.Time) error {
+	players, err := getPlayers(ctx, tx, tournament.ID)
+	if err != nil {
+		return err
+	}
+
+	if tournament.IsLastRound() {
+		return finishTournament(ctx, tx, tournament, players, now)
+	}
+
+	pairings, err := getPairings(ctx, tx, tournament.ID)
+	if err != nil {
+		return err
+	}
+
+	err = startNextRound(ctx, tx, tournament, players, pairings, now)
+	if errors.Is(err, domain.ErrNoPairing) {
+		return finishTournament(ctx, tx, tournament, players, now)
+	}
+
+	return err
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// runArenaAction locks the arena and runs the player's action on it in a transaction.
+// Holding the arena's lock keeps the action from racing the arena's pairing.
+func runArenaAction(
+	ctx context.Context,
+	db *sql.DB,
+	arenaID uuid.UUID,
+	action func(ctx context.Context, tx *sql.Tx, arena *domain.Arena) error,
+) error {
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const query = `
+			SELECT
+				*
+			FROM
+				arena
+			WHERE
+				id = $1
+			FOR UPDATE;`
+		arena, err := tql.QueryFirst[domain.Arena](ctx, tx, query, arenaID)
+		if err != nil {
+			return err
+		}
+
+		return action(ctx, tx, &arena)
+	}
+
+	if err := core.Tx(ctx, db, txFn); err != nil {
+		return tournamentActionError(err)
+	}
+
+	return nil
+}
+
+func updateArena(ctx context.Context, tx *sql.Tx, arena domain.Arena) error {
+	const stmt = `
+		UPDATE
+			arena
+		SET
+			status = :status,
+			started_at = :started_at,
+			ended_at = :ended_at
+		WHERE
+			id = :id;`
+	_, err := tql.Exec(ctx, tx, stmt, arena)
+	return err
+}
+
+// publishArenaChanged wakes up the arena's leaderboard streams once the transaction commits.
+func publishArenaChanged(ctx context.Context, tx *sql.Tx, arenaID uuid.UUID) error {
+	return core.Publish(ctx, tx, domain.ArenaTopic(arenaID), arenaID)
+}
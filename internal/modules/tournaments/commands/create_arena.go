@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// CreateArenaCommand schedules an arena. It starts at StartsAt, or right away when
+// not set, and runs for the minutes.
+type CreateArenaCommand struct {
+	OrganizerID uuid.UUID
+	Name        string
+	TimeControl gamesessiondomain.TimeControl
+	Rated       bool
+	// Berserk lets players halve their clock for an extra point when they win.
+	Berserk  bool
+	StartsAt *time.Time
+	Minutes  int
+}
+
+func (c CreateArenaCommand) Validate() error {
+	if c.OrganizerID == uuid.Nil {
+		return fmt.Errorf("invalid OrganizerID - '%s'", c.OrganizerID)
+	}
+
+	if c.Name == "" {
+		return fmt.Errorf("invalid Name - '%s'", c.Name)
+	}
+
+	if c.Minutes < 1 || c.Minutes > domain.MaxArenaMinutes {
+		return fmt.Errorf("invalid Minutes - '%d'", c.Minutes)
+	}
+
+	if err := c.TimeControl.Validate(); err != nil {
+		return err
+	}
+
+	// Players are paired again as soon as their game is over, so the games have to be over quickly.
+	if !c.TimeControl.IsTimed() {
+		return fmt.Errorf("arenas need a timed TimeControl")
+	}
+
+	return nil
+}
+
+type CreateArenaResponse struct {
+	ArenaID uuid.UUID
+}
+
+func HandleCreateArena(w http.ResponseWriter, r *http.Request) {
+	command, err := core.RequestBody[CreateArenaCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+	command.OrganizerID = core.Session(r.Context()).UserID
+
+	response, err := mediator.Send[CreateArenaCommand, CreateArenaResponse](r.Context(), command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	location := path.Join(r.Host, "arenas", response.ArenaID.String())
+	core.WriteCreated(w, r, location)
+}
+
+type CreateArenaCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewCreateArenaCommandHandler(db *sql.DB, clock core.Clock) *CreateArenaCommandHandler {
+	return &CreateArenaCommandHandler{db: db, clock: clock}
+}
+
+func (h *CreateArenaCommandHandler) Handle(ctx context.Context, request CreateArenaCommand) (CreateArenaResponse, error) {
+	now := h.clock.Now()
+
+	startsAt := now
+	if request.StartsAt != nil && request.StartsAt.After(now) {
+		startsAt = *request.StartsAt
+	}
+
+	arena := domain.NewArena(
+		request.OrganizerID,
+		request.Name,
+		request.TimeControl,
+		request.Rated,
+		request.Berserk,
+		startsAt,
+		request.Minutes,
+		now,
+	)
+
+	const stmt = `
+		INSERT INTO
+			arena (
+				id,
+				organizer_id,
+				name,
+				status,
+				rated,
+				berserk,
+				time_control_base_seconds,
+				time_control_increment_seconds,
+				time_control_delay,
+				time_control_category,
+				starts_at,
+				ends_at,
+				created_at,
+				started_at,
+				ended_at
+			)
+		VALUES
+			(
+				:id,
+				:organizer_id,
+				:name,
+				:status,
+				:rated,
+				:berserk,
+				:time_control_base_seconds,
+				:time_control_increment_seconds,
+				:time_control_delay,
+				:time_control_category,
+				:starts_at,
+				:ends_at,
+				:created_at,
+				:started_at,
+				:ended_at
+			);`
+	if _, err := tql.Exec(ctx, h.db, stmt, arena); err != nil {
+		return CreateArenaResponse{}, core.NewCommandError(500, err)
+	}
+
+	return CreateArenaResponse{ArenaID: arena.ID}, nil
+}
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// CreateTournamentCommand opens a tournament for registration, the organizer starts it
+// once the players are in.
+type CreateTournamentCommand struct {
+	OrganizerID uuid.UUID
+	Name        string
+	Format      domain.Format
+	// Rounds is how many rounds a Swiss tournament is played over,
+	// round robins are played until everyone has met.
+	Rounds      int
+	TimeControl gamesessiondomain.TimeControl
+	Rated       bool
+	// MaxPlayers caps the registrations, 0 when there is no cap.
+	MaxPlayers int
+}
+
+func (c CreateTournamentCommand) Validate() error {
+	if c.OrganizerID == uuid.Nil {
+		return fmt.Errorf("invalid OrganizerID - '%s'", c.OrganizerID)
+	}
+
+	if c.Name == "" {
+		return fmt.Errorf("invalid Name - '%s'", c.Name)
+	}
+
+	if err := c.Format.Validate(); err != nil {
+		return err
+	}
+
+	switch c.Format {
+	case domain.Swiss:
+		if c.Rounds < 1 || c.Rounds > domain.MaxSwissRounds {
+			return fmt.Errorf("invalid Rounds - '%d'", c.Rounds)
+		}
+	case domain.RoundRobin:
+		if c.Rounds != 0 {
+			return fmt.Errorf("Rounds cannot be set for round robin tournaments")
+		}
+	}
+
+	if c.MaxPlayers < 0 || (c.MaxPlayers > 0 && c.MaxPlayers < domain.MinPlayers) {
+		return fmt.Errorf("invalid MaxPlayers - '%d'", c.MaxPlayers)
+	}
+
+	if err := c.TimeControl.Validate(); err != nil {
+		return err
+	}
+
+	if c.Rated && !c.TimeControl.IsTimed() && !c.TimeControl.IsCorrespondence() {
+		return fmt.Errorf("untimed games cannot be rated")
+	}
+
+	return nil
+}
+
+type CreateTournamentResponse struct {
+	TournamentID uuid.UUID
+}
+
+func HandleCreateTournament(w http.ResponseWriter, r *http.Request) {
+	command, err := core.RequestBody[CreateTournamentCommand](r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+	command.OrganizerID = core.Session(r.Context()).UserID
+
+	response, err := mediator.Send[CreateTournamentCommand, CreateTournamentResponse](r.Context(), command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	location := path.Join(r.Host, "tournaments", response.TournamentID.String())
+	core.WriteCreated(w, r, location)
+}
+
+type CreateTournamentCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewCreateTournamentCommandHandler(db *sql.DB, clock core.Clock) *CreateTournamentCommandHandler {
+	return &CreateTournamentCommandHandler{db: db, clock: clock}
+}
+
+func (h *CreateTournamentCommandHandler) Handle(
+	ctx context.Context,
+	request CreateTournamentCommand,
+) (CreateTournamentResponse, error) {
+	tournament := domain.NewTournament(
+		request.OrganizerID,
+		request.Name,
+		request.Format,
+		request.Rounds,
+		request.TimeControl,
+		request.Rated,
+		request.MaxPlayers,
+		h.clock.Now(),
+	)
+
+	const stmt = `
+		INSERT INTO
+			tournament (
+				id,
+				organizer_id,
+				name,
+				format,
+				status,
+				rounds,
+				current_round,
+				max_players,
+				rated,
+				time_control_base_seconds,
+				time_control_increment_seconds,
+				time_control_delay,
+				time_control_days_per_move,
+				time_control_category,
+				created_at,
+				started_at,
+				ended_at
+			)
+		VALUES
+			(
+				:id,
+				:organizer_id,
+				:name,
+				:format,
+				:status,
+				:rounds,
+				:current_round,
+				:max_players,
+				:rated,
+				:time_control_base_seconds,
+				:time_control_increment_seconds,
+				:time_control_delay,
+				:time_control_days_per_move,
+				:time_control_category,
+				:created_at,
+				:started_at,
+				:ended_at
+			);`
+	if _, err := tql.Exec(ctx, h.db, stmt, tournament); err != nil {
+		return CreateTournamentResponse{}, core.NewCommandError(500, err)
+	}
+
+	return CreateTournamentResponse{TournamentID: tournament.ID}, nil
+}
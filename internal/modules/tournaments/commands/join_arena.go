@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings"
+	ratingsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// JoinArenaCommand adds the player to the arena's pairing pool, or brings a player who
+// paused back into it. The player is paired as soon as the arena is running and an
+// opponent is waiting.
+type JoinArenaCommand struct {
+	ArenaID  uuid.UUID
+	PlayerID uuid.UUID
+}
+
+func (c JoinArenaCommand) Validate() error {
+	if c.ArenaID == uuid.Nil {
+		return fmt.Errorf("invalid ArenaID - '%s'", c.ArenaID)
+	}
+
+	if c.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", c.PlayerID)
+	}
+
+	return nil
+}
+
+func HandleJoinArena(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	arenaID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := JoinArenaCommand{
+		ArenaID:  arenaID,
+		PlayerID: core.Session(ctx).UserID,
+	}
+
+	_, err = mediator.Send[JoinArenaCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type JoinArenaCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewJoinArenaCommandHandler(db *sql.DB, clock core.Clock) *JoinArenaCommandHandler {
+	return &JoinArenaCommandHandler{db: db, clock: clock}
+}
+
+func (h *JoinArenaCommandHandler) Handle(ctx context.Context, request JoinArenaCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, arena *domain.Arena) error {
+		if err := arena.CanJoin(now); err != nil {
+			return err
+		}
+
+		category := ratingsdomain.Category(arena.RatingCategory())
+		rating, err := ratings.CurrentRating(ctx, tx, request.PlayerID, category)
+		if err != nil {
+			return err
+		}
+
+		player := domain.ArenaPlayer{
+			ArenaID:  arena.ID,
+			PlayerID: request.PlayerID,
+			Rating:   int(math.Round(rating.Rating)),
+			JoinedAt: now,
+		}
+
+		const stmt = `
+			INSERT INTO
+				arena_player (
+					arena_id,
+					player_id,
+					rating,
+					points,
+					streak,
+					games,
+					wins,
+					draws,
+					losses,
+					berserks,
+					color_balance,
+					last_opponent_id,
+					paused,
+					playing,
+					joined_at
+				)
+			VALUES
+				(
+					:arena_id,
+					:player_id,
+					:rating,
+					:points,
+					:streak,
+					:games,
+					:wins,
+					:draws,
+					:losses,
+					:berserks,
+					:color_balance,
+					:last_opponent_id,
+					:paused,
+					:playing,
+					:joined_at
+				)
+			ON CONFLICT (arena_id, player_id) DO UPDATE SET paused = false;`
+		if _, err := tql.Exec(ctx, tx, stmt, player); err != nil {
+			return err
+		}
+
+		return publishArenaChanged(ctx, tx, arena.ID)
+	}
+
+	if err := runArenaAction(ctx, h.db, request.ArenaID, action); err != nil {
+		return core.Unit{}, err
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,242 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	gamesessioncommands "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// PairArenasCommand starts the arenas whose start time has come, pairs the players
+// waiting for a game in the running ones and finishes those whose time is up.
+// It is run periodically by a background job.
+type PairArenasCommand struct{}
+
+type PairArenasCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewPairArenasCommandHandler(db *sql.DB, clock core.Clock) *PairArenasCommandHandler {
+	return &PairArenasCommandHandler{db: db, clock: clock}
+}
+
+func (h *PairArenasCommandHandler) Handle(ctx context.Context, _ PairArenasCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	const query = `
+		SELECT
+			id
+		FROM
+			arena
+		WHERE
+			status = $1 OR (status = $2 AND starts_at <= $3)
+		ORDER BY
+			starts_at
+		LIMIT
+			100;`
+	arenaIDs, err := tql.Query[uuid.UUID](ctx, h.db, query, domain.Running, domain.Registering, now)
+	if err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	// Each arena is paired in its own transaction, so one which fails to pair
+	// does not hold back the others.
+	var errs []error
+	for _, arenaID := range arenaIDs {
+		txFn := func(ctx context.Context, tx *sql.Tx) error {
+			// Skip the arena when another instance is pairing it or a player is joining,
+			// it is picked up again on the next run. Its players can only be paired while
+			// the arena is locked, so no player ends up in two games.
+			const lockQuery = `
+				SELECT
+					*
+				FROM
+					arena
+				WHERE
+					id = $1 AND status <> $2
+				FOR UPDATE SKIP LOCKED;`
+			arena, err := tql.QueryFirst[domain.Arena](ctx, tx, lockQuery, arenaID, domain.Finished)
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return nil
+			case err != nil:
+				return err
+			}
+
+			return pairArena(ctx, tx, &arena, now)
+		}
+
+		if err := core.Tx(ctx, h.db, txFn); err != nil {
+			errs = append(errs, fmt.Errorf("arena '%s': %w", arenaID, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return core.Unit{}, core.NewCommandError(500, err)
+	}
+
+	return core.Unit{}, nil
+}
+
+// pairArena moves the arena on and starts the games of the players waiting in it.
+func pairArena(ctx context.Context, tx *sql.Tx, arena *domain.Arena, now time.Time) error {
+	if arena.Finish(now) {
+		return finishArena(ctx, tx, *arena, now)
+	}
+
+	started := arena.Start(now)
+	if started {
+		if err := updateArena(ctx, tx, *arena); err != nil {
+			return err
+		}
+	}
+
+	if arena.Status != domain.Running {
+		return nil
+	}
+
+	const query = `
+		SELECT
+			*
+		FROM
+			arena_player
+		WHERE
+			arena_id = $1 AND paused = false AND playing = false
+		ORDER BY
+			player_id
+		FOR UPDATE;`
+	waiting, err := tql.Query[domain.ArenaPlayer](ctx, tx, query, arena.ID)
+	if err != nil {
+		return err
+	}
+
+	playerIDs := make([]uuid.UUID, 0, len(waiting))
+	for _, player := range waiting {
+		playerIDs = append(playerIDs, player.PlayerID)
+	}
+
+	blocks, err := social.BlocksAmong(ctx, tx, playerIDs)
+	if err != nil {
+		return err
+	}
+
+	pairs := domain.ArenaPairings(waiting, blocks.Between)
+	for _, pair := range pairs {
+		session := arena.Session(pair, now)
+
+		game, err := gamesessioncommands.StartPairedGame(ctx, tx, session, now)
+		if err != nil {
+			return err
+		}
+
+		const stmt = `
+			INSERT INTO
+				arena_game (
+					game_id,
+					arena_id,
+					session_id,
+					white_id,
+					black_id,
+					white_berserk,
+					black_berserk,
+					white_points,
+					black_points,
+					created_at
+				)
+			VALUES
+				(
+					:game_id,
+					:arena_id,
+					:session_id,
+					:white_id,
+					:black_id,
+					:white_berserk,
+					:black_berserk,
+					:white_points,
+					:black_points,
+					:created_at
+				);`
+		arenaGame := domain.NewArenaGame(arena.ID, pair, session.ID, game.ID, now)
+		if _, err := tql.Exec(ctx, tx, stmt, arenaGame); err != nil {
+			return err
+		}
+
+		// White is counted against the color balance, black for it.
+		const playerStmt = `
+			UPDATE
+				arena_player
+			SET
+				playing = true,
+				last_opponent_id = $3,
+				color_balance = color_balance + $4
+			WHERE
+				arena_id = $1 AND player_id = $2;`
+		if _, err := tql.Exec(ctx, tx, playerStmt, arena.ID, pair.WhiteID, pair.BlackID, 1); err != nil {
+			return err
+		}
+		if _, err := tql.Exec(ctx, tx, playerStmt, arena.ID, pair.BlackID, pair.WhiteID, -1); err != nil {
+			return err
+		}
+	}
+
+	if !started && len(pairs) == 0 {
+		return nil
+	}
+
+	return publishArenaChanged(ctx, tx, arena.ID)
+}
+
+// finishArena ends the arena and lets its players know the final leaderboard is in.
+// The games still being played no longer count.
+func finishArena(ctx context.Context, tx *sql.Tx, arena domain.Arena, now time.Time) error {
+	if err := updateArena(ctx, tx, arena); err != nil {
+		return err
+	}
+
+	const query = `
+		SELECT
+			player_id
+		FROM
+			arena_player
+		WHERE
+			arena_id = $1;`
+	playerIDs, err := tql.Query[uuid.UUID](ctx, tx, query, arena.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := publishArenaChanged(ctx, tx, arena.ID); err != nil {
+		return err
+	}
+
+	payload := notificationsdomain.ArenaPayload{ArenaID: arena.ID, Name: arena.Name}
+
+	toNotify := make([]notificationsdomain.Notification, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		notification, err := notificationsdomain.NewUserNotification(
+			playerID,
+			notificationsdomain.ArenaFinishedNotification,
+			payload,
+			now,
+		)
+		if err != nil {
+			return err
+		}
+
+		toNotify = append(toNotify, notification)
+	}
+
+	return notifications.Notify(ctx, tx, toNotify...)
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// PauseArenaCommand takes the player out of the arena's pairing pool until they join
+// again. Their points so far still count, the game they are in still has to be finished.
+type PauseArenaCommand struct {
+	ArenaID  uuid.UUID
+	PlayerID uuid.UUID
+}
+
+func (c PauseArenaCommand) Validate() error {
+	if c.ArenaID == uuid.Nil {
+		return fmt.Errorf("invalid ArenaID - '%s'", c.ArenaID)
+	}
+
+	if c.PlayerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", c.PlayerID)
+	}
+
+	return nil
+}
+
+func HandlePauseArena(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	arenaID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := PauseArenaCommand{
+		ArenaID:  arenaID,
+		PlayerID: core.Session(ctx).UserID,
+	}
+
+	_, err = mediator.Send[PauseArenaCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type PauseArenaCommandHandler struct {
+	db *sql.DB
+}
+
+func NewPauseArenaCommandHandler(db *sql.DB) *PauseArenaCommandHandler {
+	return &PauseArenaCommandHandler{db}
+}
+
+func (h *PauseArenaCommandHandler) Handle(ctx context.Context, request PauseArenaCommand) (core.Unit, error) {
+	action := func(ctx context.Context, tx *sql.Tx, arena *domain.Arena) error {
+		if arena.Status == domain.Finished {
+			return domain.ErrArenaOver
+		}
+
+		const stmt = `
+			UPDATE
+				arena_player
+			SET
+				paused = true
+			WHERE
+				arena_id = $1 AND player_id = $2;`
+		result, err := tql.Exec(ctx, tx, stmt, arena.ID, request.PlayerID)
+		if err != nil {
+			return err
+		}
+
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return domain.ErrNotRegistered
+		}
+
+		return publishArenaChanged(ctx, tx, arena.ID)
+	}
+
+	if err := runArenaAction(ctx, h.db, request.ArenaID, action); err != nil {
+		return core.Unit{}, err
+	}
+
+	return core.Unit{}, nil
+}
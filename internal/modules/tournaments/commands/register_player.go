@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings"
+	ratingsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// RegisterPlayerCommand registers the player for a tournament which has not started yet.
+// The player is seeded by their rating in the tournament's category when registering.
+type RegisterPlayerCommand struct {
+	TournamentID uuid.UUID
+	PlayerID     uuid.UUID
+}
+
+func (c RegisterPlayerCommand) Validate() error {
+	return validateTournamentAction(c.TournamentID, c.PlayerID)
+}
+
+func HandleRegisterPlayer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tournamentID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := RegisterPlayerCommand{
+		TournamentID: tournamentID,
+		PlayerID:     core.Session(ctx).UserID,
+	}
+
+	_, err = mediator.Send[RegisterPlayerCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type RegisterPlayerCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewRegisterPlayerCommandHandler(db *sql.DB, clock core.Clock) *RegisterPlayerCommandHandler {
+	return &RegisterPlayerCommandHandler{db: db, clock: clock}
+}
+
+func (h *RegisterPlayerCommandHandler) Handle(ctx context.Context, request RegisterPlayerCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, tournament *domain.Tournament) error {
+		const countQuery = `
+			SELECT
+				COUNT(*)
+			FROM
+				tournament_player
+			WHERE
+				tournament_id = $1;`
+		registered, err := tql.QueryFirst[int](ctx, tx, countQuery, tournament.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := tournament.CanRegister(registered); err != nil {
+			return err
+		}
+
+		category := ratingsdomain.Category(tournament.RatingCategory())
+		rating, err := ratings.CurrentRating(ctx, tx, request.PlayerID, category)
+		if err != nil {
+			return err
+		}
+
+		player := domain.Player{
+			TournamentID: tournament.ID,
+			PlayerID:     request.PlayerID,
+			Rating:       int(math.Round(rating.Rating)),
+			RegisteredAt: now,
+		}
+
+		const stmt = `
+			INSERT INTO
+				tournament_player (tournament_id, player_id, rating, withdrawn, registered_at)
+			VALUES
+				(:tournament_id, :player_id, :rating, :withdrawn, :registered_at)
+			ON CONFLICT (tournament_id, player_id) DO NOTHING;`
+		result, err := tql.Exec(ctx, tx, stmt, player)
+		if err != nil {
+			return err
+		}
+
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if inserted == 0 {
+			return domain.ErrAlreadyRegistered
+		}
+
+		return nil
+	}
+
+	if err := runTournamentAction(ctx, h.db, request.TournamentID, action); err != nil {
+		return core.Unit{}, err
+	}
+
+	return core.Unit{}, nil
+}
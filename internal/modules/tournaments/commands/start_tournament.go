@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// StartTournamentCommand closes the registration and starts the first round. Only the
+// organizer can start the tournament, the later rounds start on their own once the
+// games of the previous round are over.
+type StartTournamentCommand struct {
+	TournamentID uuid.UUID
+	OrganizerID  uuid.UUID
+}
+
+func (c StartTournamentCommand) Validate() error {
+	if c.TournamentID == uuid.Nil {
+		return fmt.Errorf("invalid TournamentID - '%s'", c.TournamentID)
+	}
+
+	if c.OrganizerID == uuid.Nil {
+		return fmt.Errorf("invalid OrganizerID - '%s'", c.OrganizerID)
+	}
+
+	return nil
+}
+
+func HandleStartTournament(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tournamentID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := StartTournamentCommand{
+		TournamentID: tournamentID,
+		OrganizerID:  core.Session(ctx).UserID,
+	}
+
+	_, err = mediator.Send[StartTournamentCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type StartTournamentCommandHandler struct {
+	db    *sql.DB
+	clock core.Clock
+}
+
+func NewStartTournamentCommandHandler(db *sql.DB, clock core.Clock) *StartTournamentCommandHandler {
+	return &StartTournamentCommandHandler{db: db, clock: clock}
+}
+
+func (h *StartTournamentCommandHandler) Handle(ctx context.Context, request StartTournamentCommand) (core.Unit, error) {
+	now := h.clock.Now()
+
+	action := func(ctx context.Context, tx *sql.Tx, tournament *domain.Tournament) error {
+		players, err := getPlayers(ctx, tx, tournament.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := tournament.Start(request.OrganizerID, len(players), now); err != nil {
+			return err
+		}
+
+		return startNextRound(ctx, tx, tournament, players, nil, now)
+	}
+
+	if err := runTournamentAction(ctx, h.db, request.TournamentID, action); err != nil {
+		return core.Unit{}, err
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,268 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	gamesessioncommands "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+func validateTournamentAction(tournamentID uuid.UUID, playerID uuid.UUID) error {
+	if tournamentID == uuid.Nil {
+		return fmt.Errorf("invalid TournamentID - '%s'", tournamentID)
+	}
+
+	if playerID == uuid.Nil {
+		return fmt.Errorf("invalid PlayerID - '%s'", playerID)
+	}
+
+	return nil
+}
+
+// runTournamentAction locks the tournament and runs the player's action on it in a transaction.
+func runTournamentAction(
+	ctx context.Context,
+	db *sql.DB,
+	tournamentID uuid.UUID,
+	action func(ctx context.Context, tx *sql.Tx, tournament *domain.Tournament) error,
+) error {
+	txFn := func(ctx context.Context, tx *sql.Tx) error {
+		const query = `
+			SELECT
+				*
+			FROM
+				tournament
+			WHERE
+				id = $1
+			FOR UPDATE;`
+		tournament, err := tql.QueryFirst[domain.Tournament](ctx, tx, query, tournamentID)
+		if err != nil {
+			return err
+		}
+
+		return action(ctx, tx, &tournament)
+	}
+
+	if err := core.Tx(ctx, db, txFn); err != nil {
+		return tournamentActionError(err)
+	}
+
+	return nil
+}
+
+// tournamentActionError maps the errors of the tournament actions to their status codes.
+func tournamentActionError(err error) error {
+	var commandErr core.CommandError
+	switch {
+	case errors.As(err, &commandErr):
+		return commandErr
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, domain.ErrNotRegistered):
+		return core.NewCommandError(404, err)
+	case errors.Is(err, domain.ErrNotOrganizer):
+		return core.NewCommandError(403, err)
+	case errors.Is(err, domain.ErrRegistrationClosed),
+		errors.Is(err, domain.ErrTournamentFull),
+		errors.Is(err, domain.ErrAlreadyRegistered),
+		errors.Is(err, domain.ErrNotEnoughPlayers),
+		errors.Is(err, domain.ErrTournamentNotRunning),
+		errors.Is(err, domain.ErrTournamentFinished),
+		errors.Is(err, domain.ErrArenaOver):
+		return core.NewCommandError(409, err)
+	default:
+		return core.NewCommandError(500, err)
+	}
+}
+
+func updateTournament(ctx context.Context, tx *sql.Tx, tournament domain.Tournament) error {
+	const stmt = `
+		UPDATE
+			tournament
+		SET
+			status = :status,
+			rounds = :rounds,
+			current_round = :current_round,
+			started_at = :started_at,
+			ended_at = :ended_at
+		WHERE
+			id = :id;`
+	_, err := tql.Exec(ctx, tx, stmt, tournament)
+	return err
+}
+
+func getPlayers(ctx context.Context, tx *sql.Tx, tournamentID uuid.UUID) ([]domain.Player, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			tournament_player
+		WHERE
+			tournament_id = $1;`
+	return tql.Query[domain.Player](ctx, tx, query, tournamentID)
+}
+
+func getPairings(ctx context.Context, tx *sql.Tx, tournamentID uuid.UUID) ([]domain.Pairing, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			tournament_pairing
+		WHERE
+			tournament_id = $1
+		ORDER BY
+			round, board;`
+	return tql.Query[domain.Pairing](ctx, tx, query, tournamentID)
+}
+
+// startNextRound pairs the tournament's next round and starts the games of its boards.
+// The players who withdrew forfeit their games in round robins, Swiss tournaments no
+// longer pair them at all.
+func startNextRound(
+	ctx context.Context,
+	tx *sql.Tx,
+	tournament *domain.Tournament,
+	players []domain.Player,
+	pairings []domain.Pairing,
+	now time.Time,
+) error {
+	round := tournament.CurrentRound + 1
+
+	var (
+		pairs     []domain.Pair
+		withdrawn []uuid.UUID
+		err       error
+	)
+	switch tournament.Format {
+	case domain.RoundRobin:
+		seeded := domain.Seeded(players)
+		ids := make([]uuid.UUID, 0, len(seeded))
+		for _, player := range seeded {
+			ids = append(ids, player.PlayerID)
+			if player.Withdrawn {
+				withdrawn = append(withdrawn, player.PlayerID)
+			}
+		}
+		pairs, err = domain.RoundRobinPairings(ids, round)
+	case domain.Swiss:
+		pairs, err = domain.SwissPairings(domain.SwissPlayers(players, pairings))
+	default:
+		err = tournament.Format.Validate()
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, pairing := range domain.NewRoundPairings(*tournament, round, pairs, withdrawn, now) {
+		if pairing.NeedsGame() {
+			session := tournament.Session(pairing, now)
+
+			game, err := gamesessioncommands.StartPairedGame(ctx, tx, session, now)
+			if err != nil {
+				return err
+			}
+
+			pairing.SessionID = &session.ID
+			pairing.GameID = &game.ID
+		}
+
+		const stmt = `
+			INSERT INTO
+				tournament_pairing (
+					id,
+					tournament_id,
+					round,
+					board,
+					white_id,
+					black_id,
+					session_id,
+					game_id,
+					white_score,
+					black_score,
+					created_at
+				)
+			VALUES
+				(
+					:id,
+					:tournament_id,
+					:round,
+					:board,
+					:white_id,
+					:black_id,
+					:session_id,
+					:game_id,
+					:white_score,
+					:black_score,
+					:created_at
+				);`
+		if _, err := tql.Exec(ctx, tx, stmt, pairing); err != nil {
+			return err
+		}
+	}
+
+	tournament.CurrentRound = round
+	if err := updateTournament(ctx, tx, *tournament); err != nil {
+		return err
+	}
+
+	return notifyPlayers(ctx, tx, *tournament, players, notificationsdomain.TournamentRoundStartedNotification, now)
+}
+
+// finishTournament ends the tournament and lets its players know the final standings are in.
+func finishTournament(
+	ctx context.Context,
+	tx *sql.Tx,
+	tournament *domain.Tournament,
+	players []domain.Player,
+	now time.Time,
+) error {
+	if err := tournament.Finish(now); err != nil {
+		return err
+	}
+
+	if err := updateTournament(ctx, tx, *tournament); err != nil {
+		return err
+	}
+
+	return notifyPlayers(ctx, tx, *tournament, players, notificationsdomain.TournamentFinishedNotification, now)
+}
+
+// notifyPlayers tells the players still in the tournament about its progress.
+func notifyPlayers(
+	ctx context.Context,
+	tx *sql.Tx,
+	tournament domain.Tournament,
+	players []domain.Player,
+	notificationType notificationsdomain.NotificationType,
+	now time.Time,
+) error {
+	payload := notificationsdomain.TournamentPayload{
+		TournamentID: tournament.ID,
+		Name:         tournament.Name,
+		Round:        tournament.CurrentRound,
+	}
+
+	toNotify := make([]notificationsdomain.Notification, 0, len(players))
+	for _, player := range players {
+		if player.Withdrawn {
+			continue
+		}
+
+		notification, err := notificationsdomain.NewUserNotification(player.PlayerID, notificationType, payload, now)
+		if err != nil {
+			return err
+		}
+
+		toNotify = append(toNotify, notification)
+	}
+
+	return notifications.Notify(ctx, tx, toNotify...)
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// WithdrawPlayerCommand takes the player out of the tournament. Before the start the
+// registration is simply dropped, later the player is no longer paired and forfeits
+// the rounds left, while their results so far still count. The game the player is in
+// still has to be finished.
+type WithdrawPlayerCommand struct {
+	TournamentID uuid.UUID
+	PlayerID     uuid.UUID
+}
+
+func (c WithdrawPlayerCommand) Validate() error {
+	return validateTournamentAction(c.TournamentID, c.PlayerID)
+}
+
+func HandleWithdrawPlayer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tournamentID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	command := WithdrawPlayerCommand{
+		TournamentID: tournamentID,
+		PlayerID:     core.Session(ctx).UserID,
+	}
+
+	_, err = mediator.Send[WithdrawPlayerCommand, core.Unit](ctx, command)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, nil)
+}
+
+type WithdrawPlayerCommandHandler struct {
+	db *sql.DB
+}
+
+func NewWithdrawPlayerCommandHandler(db *sql.DB) *WithdrawPlayerCommandHandler {
+	return &WithdrawPlayerCommandHandler{db}
+}
+
+func (h *WithdrawPlayerCommandHandler) Handle(ctx context.Context, request WithdrawPlayerCommand) (core.Unit, error) {
+	action := func(ctx context.Context, tx *sql.Tx, tournament *domain.Tournament) error {
+		var stmt string
+		switch tournament.Status {
+		case domain.Registering:
+			stmt = `
+				DELETE FROM
+					tournament_player
+				WHERE
+					tournament_id = $1 AND player_id = $2;`
+		case domain.Running:
+			stmt = `
+				UPDATE
+					tournament_player
+				SET
+					withdrawn = true
+				WHERE
+					tournament_id = $1 AND player_id = $2 AND withdrawn = false;`
+		default:
+			return domain.ErrTournamentFinished
+		}
+
+		result, err := tql.Exec(ctx, tx, stmt, tournament.ID, request.PlayerID)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return domain.ErrNotRegistered
+		}
+
+		return nil
+	}
+
+	if err := runTournamentAction(ctx, h.db, request.TournamentID, action); err != nil {
+		return core.Unit{}, err
+	}
+
+	return core.Unit{}, nil
+}
@@ -0,0 +1,384 @@
+package domain
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxArenaMinutes caps how long an arena runs.
+	MaxArenaMinutes = 12 * 60
+	// WinPoints and DrawPoints are what an arena game scores, losses score nothing.
+	WinPoints  = 2
+	DrawPoints = 1
+	// FireStreak is how many wins in a row put a player on fire, doubling the points
+	// of their games until they fail to win.
+	FireStreak = 2
+	// MinBerserkPlies is how long a game won berserk has to last for the extra point,
+	// so berserking and losing on purpose in a few moves does not pay off for a friend.
+	MinBerserkPlies = 14
+)
+
+var (
+	ErrArenaOver         = errors.New("arena is over")
+	ErrBerserkNotAllowed = errors.New("berserk is not allowed in this game")
+	ErrAlreadyBerserk    = errors.New("player already went berserk")
+)
+
+// Arena is a tournament played for a fixed time in which players are paired again as
+// soon as their game is over. Players join and pause whenever they like, the most
+// points when the time is up win.
+type Arena struct {
+	ID          uuid.UUID `db:"id"`
+	OrganizerID uuid.UUID `db:"organizer_id"`
+	Name        string    `db:"name"`
+	Status      Status    `db:"status"`
+	Rated       bool      `db:"rated"`
+	// Berserk lets players halve their clock for an extra point when they win.
+	Berserk bool `db:"berserk"`
+
+	TimeControlBaseSeconds      int                                   `db:"time_control_base_seconds"`
+	TimeControlIncrementSeconds int                                   `db:"time_control_increment_seconds"`
+	TimeControlDelay            gamesessiondomain.DelayMode           `db:"time_control_delay"`
+	TimeControlCategory         gamesessiondomain.TimeControlCategory `db:"time_control_category"`
+
+	StartsAt  time.Time  `db:"starts_at"`
+	EndsAt    time.Time  `db:"ends_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	StartedAt *time.Time `db:"started_at"`
+	EndedAt   *time.Time `db:"ended_at"`
+}
+
+// NewArena schedules an arena running for the minutes from startsAt. Players can
+// join as soon as it is created, they are paired once it starts.
+func NewArena(
+	organizerID uuid.UUID,
+	name string,
+	timeControl gamesessiondomain.TimeControl,
+	rated bool,
+	berserk bool,
+	startsAt time.Time,
+	minutes int,
+	now time.Time,
+) Arena {
+	if timeControl.Delay == "" {
+		timeControl.Delay = gamesessiondomain.FischerDelay
+	}
+
+	return Arena{
+		ID:                          uuid.New(),
+		OrganizerID:                 organizerID,
+		Name:                        name,
+		Status:                      Registering,
+		Rated:                       rated,
+		Berserk:                     berserk,
+		TimeControlBaseSeconds:      timeControl.BaseSeconds,
+		TimeControlIncrementSeconds: timeControl.IncrementSeconds,
+		TimeControlDelay:            timeControl.Delay,
+		TimeControlCategory:         timeControl.Category(),
+		StartsAt:                    startsAt,
+		EndsAt:                      startsAt.Add(time.Duration(minutes) * time.Minute),
+		CreatedAt:                   now,
+	}
+}
+
+func (a Arena) TimeControl() gamesessiondomain.TimeControl {
+	return gamesessiondomain.TimeControl{
+		BaseSeconds:      a.TimeControlBaseSeconds,
+		IncrementSeconds: a.TimeControlIncrementSeconds,
+		Delay:            a.TimeControlDelay,
+	}
+}
+
+// RatingCategory is the category the players are ranked by and their games are rated in.
+func (a Arena) RatingCategory() string {
+	return gamesessiondomain.RatingCategory(gamesessiondomain.Standard, a.TimeControl())
+}
+
+// CanJoin checks a player can still join the arena.
+func (a Arena) CanJoin(now time.Time) error {
+	if a.Status == Finished || !now.Before(a.EndsAt) {
+		return ErrArenaOver
+	}
+
+	return nil
+}
+
+// Start opens the arena for pairing once its start time has come, reporting whether it did.
+func (a *Arena) Start(now time.Time) bool {
+	if a.Status != Registering || now.Before(a.StartsAt) {
+		return false
+	}
+
+	a.Status = Running
+	a.StartedAt = &now
+	return true
+}
+
+// Finish ends the arena once its time is up, reporting whether it did. Games still
+// being played are finished but no longer count.
+func (a *Arena) Finish(now time.Time) bool {
+	if a.Status == Finished || now.Before(a.EndsAt) {
+		return false
+	}
+
+	a.Status = Finished
+	a.EndedAt = &now
+	return true
+}
+
+// ArenaTopic is the pub/sub topic the arena's leaderboard changes are published to.
+func ArenaTopic(arenaID uuid.UUID) string {
+	return fmt.Sprintf("arena:%s", arenaID)
+}
+
+// Session sets up the session the game of the pair is played in.
+func (a Arena) Session(pair Pair, now time.Time) gamesessiondomain.Session {
+	timeControl := a.TimeControl()
+
+	return gamesessiondomain.Session{
+		ID:                          uuid.NewString(),
+		OwnerID:                     a.OrganizerID,
+		Player1ID:                   pair.WhiteID,
+		Player2ID:                   pair.BlackID,
+		Name:                        a.Name,
+		Rated:                       a.Rated,
+		Visibility:                  gamesessiondomain.Public,
+		Variant:                     gamesessiondomain.Standard,
+		InitialFEN:                  chess.StartingFEN,
+		TimeControlBaseSeconds:      timeControl.BaseSeconds,
+		TimeControlIncrementSeconds: timeControl.IncrementSeconds,
+		TimeControlDelay:            timeControl.Delay,
+		TimeControlCategory:         timeControl.Category(),
+		CreatedAt:                   now,
+	}
+}
+
+// ArenaPlayer is a player who joined an arena, with their score so far.
+type ArenaPlayer struct {
+	ArenaID  uuid.UUID `db:"arena_id"`
+	PlayerID uuid.UUID `db:"player_id"`
+	// Rating ranks players with the same points, it is the player's rating when joining.
+	Rating int `db:"rating"`
+	Points int `db:"points"`
+	// Streak is the number of games the player won in a row.
+	Streak   int `db:"streak"`
+	Games    int `db:"games"`
+	Wins     int `db:"wins"`
+	Draws    int `db:"draws"`
+	Losses   int `db:"losses"`
+	Berserks int `db:"berserks"`
+	// ColorBalance is how many more games the player had white than black.
+	ColorBalance int `db:"color_balance"`
+	// LastOpponentID is who the player was paired with last, they are not paired again right away.
+	LastOpponentID *uuid.UUID `db:"last_opponent_id"`
+	// Paused players are not paired until they join again.
+	Paused bool `db:"paused"`
+	// Playing is true while the player's arena game is being played.
+	Playing  bool      `db:"playing"`
+	JoinedAt time.Time `db:"joined_at"`
+}
+
+// OnFire reports whether the player's next game scores double.
+func (p ArenaPlayer) OnFire() bool {
+	return p.Streak >= FireStreak
+}
+
+// Record scores the player's finished game, a score of 1 being a win and 0.5 a draw,
+// and returns the points it was worth.
+func (p *ArenaPlayer) Record(score float64, berserk bool, plies int) int {
+	points := 0
+	switch score {
+	case 1:
+		points = WinPoints
+	case 0.5:
+		points = DrawPoints
+	}
+
+	if p.OnFire() {
+		points *= 2
+	}
+
+	if score == 1 && berserk && plies >= MinBerserkPlies {
+		points++
+	}
+
+	switch score {
+	case 1:
+		p.Wins++
+		p.Streak++
+	case 0.5:
+		p.Draws++
+		p.Streak = 0
+	default:
+		p.Losses++
+		p.Streak = 0
+	}
+
+	if berserk {
+		p.Berserks++
+	}
+
+	p.Games++
+	p.Points += points
+	p.Playing = false
+	return points
+}
+
+// ArenaPairings pairs the players waiting for a game, each with the player closest
+// to them in the ranking who was not their last opponent. Players who cannot be
+// paired keep waiting for the next players to finish their games, as do players
+// blocked by their neighbours. White goes to the player who had it less often.
+func ArenaPairings(waiting []ArenaPlayer, blocked func(playerID, otherID uuid.UUID) bool) []Pair {
+	ranked := slices.Clone(waiting)
+	slices.SortStableFunc(ranked, compareArenaPlayers)
+
+	paired := make([]bool, len(ranked))
+	pairs := make([]Pair, 0, len(ranked)/2)
+	for i, player := range ranked {
+		if paired[i] {
+			continue
+		}
+
+		for j := i + 1; j < len(ranked); j++ {
+			opponent := ranked[j]
+			if paired[j] || player.lastPlayed(opponent.PlayerID) || opponent.lastPlayed(player.PlayerID) {
+				continue
+			}
+
+			if blocked(player.PlayerID, opponent.PlayerID) {
+				continue
+			}
+
+			paired[i], paired[j] = true, true
+			if opponent.ColorBalance < player.ColorBalance {
+				pairs = append(pairs, Pair{WhiteID: opponent.PlayerID, BlackID: player.PlayerID})
+			} else {
+				pairs = append(pairs, Pair{WhiteID: player.PlayerID, BlackID: opponent.PlayerID})
+			}
+			break
+		}
+	}
+
+	return pairs
+}
+
+func (p ArenaPlayer) lastPlayed(playerID uuid.UUID) bool {
+	return p.LastOpponentID != nil && *p.LastOpponentID == playerID
+}
+
+// ArenaStanding is a player's place on an arena's leaderboard.
+type ArenaStanding struct {
+	Rank     int
+	PlayerID uuid.UUID
+	Rating   int
+	Points   int
+	Games    int
+	Wins     int
+	Draws    int
+	Losses   int
+	Berserks int
+	OnFire   bool
+	Paused   bool
+}
+
+// Leaderboard ranks the players of the arena by their points, players with the same
+// points by their wins and then their rating.
+func Leaderboard(players []ArenaPlayer) []ArenaStanding {
+	ranked := slices.Clone(players)
+	slices.SortStableFunc(ranked, compareArenaPlayers)
+
+	standings := make([]ArenaStanding, 0, len(ranked))
+	for i, player := range ranked {
+		standings = append(standings, ArenaStanding{
+			Rank:     i + 1,
+			PlayerID: player.PlayerID,
+			Rating:   player.Rating,
+			Points:   player.Points,
+			Games:    player.Games,
+			Wins:     player.Wins,
+			Draws:    player.Draws,
+			Losses:   player.Losses,
+			Berserks: player.Berserks,
+			OnFire:   player.OnFire(),
+			Paused:   player.Paused,
+		})
+	}
+
+	return standings
+}
+
+func compareArenaPlayers(a, b ArenaPlayer) int {
+	return cmp.Or(
+		cmp.Compare(b.Points, a.Points),
+		cmp.Compare(b.Wins, a.Wins),
+		cmp.Compare(b.Rating, a.Rating),
+		a.JoinedAt.Compare(b.JoinedAt),
+		slices.Compare(a.PlayerID[:], b.PlayerID[:]),
+	)
+}
+
+// ArenaGame is a game played in an arena. The points are set once the game is over.
+type ArenaGame struct {
+	GameID       uuid.UUID `db:"game_id"`
+	ArenaID      uuid.UUID `db:"arena_id"`
+	SessionID    string    `db:"session_id"`
+	WhiteID      uuid.UUID `db:"white_id"`
+	BlackID      uuid.UUID `db:"black_id"`
+	WhiteBerserk bool      `db:"white_berserk"`
+	BlackBerserk bool      `db:"black_berserk"`
+	WhitePoints  *int      `db:"white_points"`
+	BlackPoints  *int      `db:"black_points"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// NewArenaGame records the game started for the pair.
+func NewArenaGame(arenaID uuid.UUID, pair Pair, sessionID string, gameID uuid.UUID, now time.Time) ArenaGame {
+	return ArenaGame{
+		GameID:    gameID,
+		ArenaID:   arenaID,
+		SessionID: sessionID,
+		WhiteID:   pair.WhiteID,
+		BlackID:   pair.BlackID,
+		CreatedAt: now,
+	}
+}
+
+// GoBerserk marks the player as berserk in the game, once.
+func (g *ArenaGame) GoBerserk(playerID uuid.UUID) error {
+	var berserk *bool
+	switch playerID {
+	case g.WhiteID:
+		berserk = &g.WhiteBerserk
+	case g.BlackID:
+		berserk = &g.BlackBerserk
+	default:
+		return fmt.Errorf("player '%s' is not playing the game: %w", playerID, ErrBerserkNotAllowed)
+	}
+
+	if *berserk {
+		return ErrAlreadyBerserk
+	}
+
+	*berserk = true
+	return nil
+}
+
+// IsScored reports whether the game's points are in.
+func (g ArenaGame) IsScored() bool {
+	return g.WhitePoints != nil
+}
+
+// Score sets the game's points, once the players' results are recorded.
+func (g *ArenaGame) Score(whitePoints, blackPoints int) {
+	g.WhitePoints = &whitePoints
+	g.BlackPoints = &blackPoints
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func testArenaPlayers(n int) []ArenaPlayer {
+	joinedAt := time.Now().UTC()
+
+	players := make([]ArenaPlayer, 0, n)
+	for i := range n {
+		players = append(players, ArenaPlayer{
+			PlayerID: uuid.New(),
+			Rating:   2000 - i*10,
+			JoinedAt: joinedAt,
+		})
+	}
+	return players
+}
+
+func noBlocks(uuid.UUID, uuid.UUID) bool {
+	return false
+}
+
+func Test_Arena_Lifecycle(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	arena := NewArena(uuid.New(), "Blitz", gamesessiondomain.TimeControl{BaseSeconds: 180}, true, true, now.Add(time.Minute), 30, now)
+
+	// Act & Assert
+	require.Equal(t, now.Add(31*time.Minute), arena.EndsAt)
+	require.Equal(t, gamesessiondomain.FischerDelay, arena.TimeControlDelay)
+	require.NoError(t, arena.CanJoin(now))
+
+	require.False(t, arena.Start(now))
+	require.True(t, arena.Start(now.Add(time.Minute)))
+	require.Equal(t, Running, arena.Status)
+	require.False(t, arena.Start(now.Add(2*time.Minute)))
+
+	require.False(t, arena.Finish(now.Add(30*time.Minute)))
+	require.True(t, arena.Finish(now.Add(31*time.Minute)))
+	require.Equal(t, Finished, arena.Status)
+	require.ErrorIs(t, arena.CanJoin(now), ErrArenaOver)
+}
+
+func Test_ArenaPlayer_Record(t *testing.T) {
+	tests := map[string]struct {
+		player         ArenaPlayer
+		score          float64
+		berserk        bool
+		plies          int
+		expected       int
+		expectedStreak int
+	}{
+		"win":                       {score: 1, expected: WinPoints, expectedStreak: 1},
+		"draw":                      {score: 0.5, expected: DrawPoints},
+		"loss":                      {score: 0, expected: 0},
+		"win on fire":               {player: ArenaPlayer{Streak: 2}, score: 1, expected: 2 * WinPoints, expectedStreak: 3},
+		"draw on fire":              {player: ArenaPlayer{Streak: 2}, score: 0.5, expected: 2 * DrawPoints},
+		"loss on fire":              {player: ArenaPlayer{Streak: 5}, score: 0, expected: 0},
+		"berserk win":               {score: 1, berserk: true, plies: MinBerserkPlies, expected: WinPoints + 1, expectedStreak: 1},
+		"berserk win on fire":       {player: ArenaPlayer{Streak: 2}, score: 1, berserk: true, plies: 40, expected: 2*WinPoints + 1, expectedStreak: 3},
+		"berserk win too short":     {score: 1, berserk: true, plies: MinBerserkPlies - 1, expected: WinPoints, expectedStreak: 1},
+		"berserk draw has no bonus": {score: 0.5, berserk: true, plies: 40, expected: DrawPoints},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			player := test.player
+			player.Playing = true
+			points := player.Record(test.score, test.berserk, test.plies)
+
+			require.Equal(t, test.expected, points)
+			require.Equal(t, test.player.Points+test.expected, player.Points)
+			require.Equal(t, test.expectedStreak, player.Streak)
+			require.Equal(t, 1, player.Games)
+			require.Equal(t, 1, player.Wins+player.Draws+player.Losses)
+			require.Equal(t, test.berserk, player.Berserks == 1)
+			require.False(t, player.Playing)
+		})
+	}
+}
+
+func Test_ArenaPairings_Pairs_Neighbours_In_The_Ranking(t *testing.T) {
+	// Arrange
+	players := testArenaPlayers(5)
+	players[4].Points = 10
+	players[2].Points = 6
+
+	// Act
+	pairs := ArenaPairings(players, noBlocks)
+
+	// Assert
+	require.Equal(t, []Pair{
+		{WhiteID: players[4].PlayerID, BlackID: players[2].PlayerID},
+		{WhiteID: players[0].PlayerID, BlackID: players[1].PlayerID},
+	}, pairs)
+}
+
+func Test_ArenaPairings_Avoids_The_Last_Opponent(t *testing.T) {
+	// Arrange
+	players := testArenaPlayers(3)
+	players[0].LastOpponentID = &players[1].PlayerID
+	players[1].LastOpponentID = &players[0].PlayerID
+
+	// Act
+	pairs := ArenaPairings(players, noBlocks)
+	rematches := ArenaPairings(players[:2], noBlocks)
+
+	// Assert
+	require.Equal(t, []Pair{{WhiteID: players[0].PlayerID, BlackID: players[2].PlayerID}}, pairs)
+	require.Empty(t, rematches)
+}
+
+func Test_ArenaPairings_Balances_Colors(t *testing.T) {
+	// Arrange
+	players := testArenaPlayers(2)
+	players[0].ColorBalance = 1
+	players[1].ColorBalance = -1
+
+	// Act
+	pairs := ArenaPairings(players, noBlocks)
+
+	// Assert
+	require.Equal(t, []Pair{{WhiteID: players[1].PlayerID, BlackID: players[0].PlayerID}}, pairs)
+}
+
+func Test_ArenaPairings_Skips_Blocked_Players(t *testing.T) {
+	// Arrange
+	players := testArenaPlayers(3)
+	blocked := func(playerID, otherID uuid.UUID) bool {
+		return playerID == players[0].PlayerID && otherID == players[1].PlayerID
+	}
+
+	// Act
+	pairs := ArenaPairings(players, blocked)
+
+	// Assert
+	require.Equal(t, []Pair{{WhiteID: players[0].PlayerID, BlackID: players[2].PlayerID}}, pairs)
+}
+
+func Test_Leaderboard(t *testing.T) {
+	// Arrange
+	players := testArenaPlayers(3)
+	players[0].Points, players[0].Wins = 4, 1
+	players[1].Points, players[1].Wins = 4, 2
+	players[2].Points, players[2].Streak = 6, FireStreak
+
+	// Act
+	leaderboard := Leaderboard(players)
+
+	// Assert
+	require.Len(t, leaderboard, 3)
+	for i, playerID := range []uuid.UUID{players[2].PlayerID, players[1].PlayerID, players[0].PlayerID} {
+		require.Equal(t, i+1, leaderboard[i].Rank)
+		require.Equal(t, playerID, leaderboard[i].PlayerID)
+	}
+	require.True(t, leaderboard[0].OnFire)
+	require.False(t, leaderboard[1].OnFire)
+}
+
+func Test_ArenaGame_GoBerserk_Once(t *testing.T) {
+	// Arrange
+	pair := Pair{WhiteID: uuid.New(), BlackID: uuid.New()}
+	game := NewArenaGame(uuid.New(), pair, uuid.NewString(), uuid.New(), time.Now().UTC())
+
+	// Act & Assert
+	require.NoError(t, game.GoBerserk(pair.BlackID))
+	require.True(t, game.BlackBerserk)
+	require.False(t, game.WhiteBerserk)
+	require.ErrorIs(t, game.GoBerserk(pair.BlackID), ErrAlreadyBerserk)
+	require.ErrorIs(t, game.GoBerserk(uuid.New()), ErrBerserkNotAllowed)
+}
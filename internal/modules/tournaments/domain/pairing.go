@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pair is two players paired for a round, BlackID is uuid.Nil for a bye.
+type Pair struct {
+	WhiteID uuid.UUID
+	BlackID uuid.UUID
+}
+
+func (p Pair) IsBye() bool {
+	return p.BlackID == uuid.Nil
+}
+
+// Pairing is a board of a tournament round. Pairings with a game get their
+// result once the game is over, byes and forfeits are scored when paired.
+type Pairing struct {
+	ID           uuid.UUID `db:"id"`
+	TournamentID uuid.UUID `db:"tournament_id"`
+	Round        int       `db:"round"`
+	Board        int       `db:"board"`
+	WhiteID      uuid.UUID `db:"white_id"`
+	// BlackID is nil for the player sitting out the round.
+	BlackID   *uuid.UUID `db:"black_id"`
+	SessionID *string    `db:"session_id"`
+	GameID    *uuid.UUID `db:"game_id"`
+	// WhiteScore and BlackScore are set once the pairing is decided.
+	WhiteScore *float64  `db:"white_score"`
+	BlackScore *float64  `db:"black_score"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// GameResult is the result of a tournament game, aborted games score nothing for either player.
+type GameResult struct {
+	GameID     uuid.UUID `db:"game_id"`
+	WhiteScore float64   `db:"white_score"`
+	BlackScore float64   `db:"black_score"`
+	Aborted    bool      `db:"aborted"`
+	// Plies is how long the game lasted, arena games won berserk need to last long enough.
+	Plies   int       `db:"plies"`
+	EndedAt time.Time `db:"ended_at"`
+}
+
+// NewRoundPairings turns the pairs of the round into the tournament's boards. Players who
+// withdrew forfeit their games, so only the pairings still to be played need a game.
+func NewRoundPairings(tournament Tournament, round int, pairs []Pair, withdrawn []uuid.UUID, now time.Time) []Pairing {
+	isWithdrawn := make(map[uuid.UUID]bool, len(withdrawn))
+	for _, playerID := range withdrawn {
+		isWithdrawn[playerID] = true
+	}
+
+	pairings := make([]Pairing, 0, len(pairs))
+	for i, pair := range pairs {
+		pairing := Pairing{
+			ID:           uuid.New(),
+			TournamentID: tournament.ID,
+			Round:        round,
+			Board:        i + 1,
+			WhiteID:      pair.WhiteID,
+			CreatedAt:    now,
+		}
+
+		switch {
+		case pair.IsBye():
+			pairing.score(tournament.Format.ByePoints(), 0)
+		case isWithdrawn[pair.WhiteID] && isWithdrawn[pair.BlackID]:
+			pairing.BlackID = &pair.BlackID
+			pairing.score(0, 0)
+		case isWithdrawn[pair.WhiteID]:
+			pairing.BlackID = &pair.BlackID
+			pairing.score(0, 1)
+		case isWithdrawn[pair.BlackID]:
+			pairing.BlackID = &pair.BlackID
+			pairing.score(1, 0)
+		default:
+			pairing.BlackID = &pair.BlackID
+		}
+
+		pairings = append(pairings, pairing)
+	}
+
+	return pairings
+}
+
+func (p *Pairing) score(white float64, black float64) {
+	p.WhiteScore = &white
+	p.BlackScore = &black
+}
+
+func (p Pairing) IsBye() bool {
+	return p.BlackID == nil
+}
+
+// IsDecided reports whether the pairing has its result.
+func (p Pairing) IsDecided() bool {
+	return p.WhiteScore != nil
+}
+
+// IsPlayed reports whether the pairing was decided over the board,
+// byes and forfeits do not count towards the tie-breaks.
+func (p Pairing) IsPlayed() bool {
+	return p.GameID != nil && p.IsDecided()
+}
+
+// NeedsGame reports whether the pairing is still to be played.
+func (p Pairing) NeedsGame() bool {
+	return !p.IsBye() && !p.IsDecided()
+}
+
+func (p Pairing) players() []uuid.UUID {
+	if p.BlackID == nil {
+		return []uuid.UUID{p.WhiteID}
+	}
+	return []uuid.UUID{p.WhiteID, *p.BlackID}
+}
+
+// Result returns what the player scored in the pairing and their opponent,
+// uuid.Nil for a bye. It reports false when the player is not in the pairing
+// or the pairing is not decided yet.
+func (p Pairing) Result(playerID uuid.UUID) (score float64, opponentID uuid.UUID, ok bool) {
+	if !p.IsDecided() {
+		return 0, uuid.Nil, false
+	}
+
+	switch {
+	case playerID == p.WhiteID:
+		if p.BlackID != nil {
+			opponentID = *p.BlackID
+		}
+		return *p.WhiteScore, opponentID, true
+	case p.BlackID != nil && playerID == *p.BlackID:
+		return *p.BlackScore, p.WhiteID, true
+	default:
+		return 0, uuid.Nil, false
+	}
+}
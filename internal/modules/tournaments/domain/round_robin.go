@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// RoundRobinRounds is how many rounds it takes for each of the players to meet every
+// other player once. With an odd number of players each player also sits out a round.
+func RoundRobinRounds(players int) int {
+	if players%2 == 1 {
+		return players
+	}
+	return players - 1
+}
+
+// RoundRobinPairings pairs the seeded players for the round following the Berger tables,
+// the schedule used by FIDE. Each player's colors alternate as much as possible and
+// the difference between their whites and blacks is never more than one.
+//
+// The players have to be passed in the same order every round.
+func RoundRobinPairings(seeded []uuid.UUID, round int) ([]Pair, error) {
+	rounds := RoundRobinRounds(len(seeded))
+	if round < 1 || round > rounds {
+		return nil, fmt.Errorf("invalid round - '%d'", round)
+	}
+
+	// With an odd number of players, whoever meets the last, missing player has a bye.
+	n := len(seeded)
+	if n%2 == 1 {
+		n++
+	}
+
+	// Players are numbered from 1 as in the tables. Players i and j meet in round
+	// i+j-1 and player i meets the last player in round 2i-1, counting rounds modulo n-1.
+	pairs := make([]numberedPair, 0, n/2)
+	for i := 1; i < n; i++ {
+		if (2*i-2)%(n-1)+1 == round {
+			if i <= n/2 {
+				pairs = append(pairs, numberedPair{white: i, black: n})
+			} else {
+				pairs = append(pairs, numberedPair{white: n, black: i})
+			}
+		}
+
+		for j := i + 1; j < n; j++ {
+			if (i+j-2)%(n-1)+1 != round {
+				continue
+			}
+
+			// The lower number plays white when the sum is odd, the higher one when it is even.
+			if (i+j)%2 == 1 {
+				pairs = append(pairs, numberedPair{white: i, black: j})
+			} else {
+				pairs = append(pairs, numberedPair{white: j, black: i})
+			}
+		}
+	}
+
+	// The boards are ordered by the best seed playing on them.
+	slices.SortFunc(pairs, func(a, b numberedPair) int {
+		return cmp.Compare(min(a.white, a.black), min(b.white, b.black))
+	})
+
+	player := func(number int) uuid.UUID {
+		if number > len(seeded) {
+			return uuid.Nil
+		}
+		return seeded[number-1]
+	}
+
+	result := make([]Pair, 0, len(pairs))
+	var bye *Pair
+	for _, pair := range pairs {
+		white, black := player(pair.white), player(pair.black)
+
+		switch {
+		case black == uuid.Nil:
+			bye = &Pair{WhiteID: white}
+		case white == uuid.Nil:
+			bye = &Pair{WhiteID: black}
+		default:
+			result = append(result, Pair{WhiteID: white, BlackID: black})
+		}
+	}
+
+	// The bye is listed on the last board.
+	if bye != nil {
+		result = append(result, *bye)
+	}
+
+	return result, nil
+}
+
+type numberedPair struct {
+	white int
+	black int
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func testPlayers(n int) []uuid.UUID {
+	players := make([]uuid.UUID, n)
+	for i := range players {
+		players[i] = uuid.New()
+	}
+	return players
+}
+
+func Test_RoundRobinRounds(t *testing.T) {
+	require.Equal(t, 1, RoundRobinRounds(2))
+	require.Equal(t, 3, RoundRobinRounds(3))
+	require.Equal(t, 3, RoundRobinRounds(4))
+	require.Equal(t, 9, RoundRobinRounds(9))
+	require.Equal(t, 9, RoundRobinRounds(10))
+}
+
+func Test_RoundRobinPairings_Follow_Berger_Tables(t *testing.T) {
+	// Arrange
+	players := testPlayers(6)
+	p := func(number int) uuid.UUID { return players[number-1] }
+
+	// The FIDE Berger table for 5 or 6 players.
+	expected := [][]Pair{
+		{{p(1), p(6)}, {p(2), p(5)}, {p(3), p(4)}},
+		{{p(1), p(2)}, {p(5), p(3)}, {p(6), p(4)}},
+		{{p(3), p(1)}, {p(2), p(6)}, {p(4), p(5)}},
+		{{p(1), p(4)}, {p(2), p(3)}, {p(6), p(5)}},
+		{{p(5), p(1)}, {p(4), p(2)}, {p(3), p(6)}},
+	}
+
+	for round, pairs := range expected {
+		// Act
+		actual, err := RoundRobinPairings(players, round+1)
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, pairs, actual, "round %d", round+1)
+	}
+}
+
+func Test_RoundRobinPairings_Pair_Everyone_Once_With_Balanced_Colors(t *testing.T) {
+	for n := 2; n <= 16; n++ {
+		// Arrange
+		players := testPlayers(n)
+
+		met := make(map[[2]uuid.UUID]int)
+		whites := make(map[uuid.UUID]int)
+		blacks := make(map[uuid.UUID]int)
+		byes := make(map[uuid.UUID]int)
+
+		for round := 1; round <= RoundRobinRounds(n); round++ {
+			// Act
+			pairs, err := RoundRobinPairings(players, round)
+			require.NoError(t, err)
+
+			// Assert
+			seen := make(map[uuid.UUID]bool)
+			for _, pair := range pairs {
+				require.False(t, seen[pair.WhiteID], "%d players, round %d", n, round)
+				seen[pair.WhiteID] = true
+
+				if pair.IsBye() {
+					byes[pair.WhiteID]++
+					continue
+				}
+
+				require.False(t, seen[pair.BlackID], "%d players, round %d", n, round)
+				seen[pair.BlackID] = true
+
+				key := [2]uuid.UUID{pair.WhiteID, pair.BlackID}
+				if pair.BlackID.String() < pair.WhiteID.String() {
+					key = [2]uuid.UUID{pair.BlackID, pair.WhiteID}
+				}
+				met[key]++
+
+				whites[pair.WhiteID]++
+				blacks[pair.BlackID]++
+			}
+			require.Len(t, seen, n, "%d players, round %d", n, round)
+		}
+
+		require.Len(t, met, n*(n-1)/2, "%d players", n)
+		for key, times := range met {
+			require.Equal(t, 1, times, "%d players, %v", n, key)
+		}
+
+		for _, player := range players {
+			require.LessOrEqual(t, abs(whites[player]-blacks[player]), 1, "%d players", n)
+
+			if n%2 == 1 {
+				require.Equal(t, 1, byes[player], "%d players", n)
+			}
+		}
+	}
+}
+
+func Test_RoundRobinPairings_Returns_Error_For_Invalid_Round(t *testing.T) {
+	players := testPlayers(4)
+
+	_, err := RoundRobinPairings(players, 0)
+	require.Error(t, err)
+
+	_, err = RoundRobinPairings(players, 4)
+	require.Error(t, err)
+}
+
+func abs(n int) int {
+	return max(n, -n)
+}
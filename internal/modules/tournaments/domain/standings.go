@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Standing is a player's place in the tournament.
+type Standing struct {
+	Rank     int
+	PlayerID uuid.UUID
+	Rating   int
+	Points   float64
+	// Buchholz is the sum of the points of the opponents played.
+	Buchholz float64
+	// SonnebornBerger is the sum of the points of the opponents beaten
+	// and half the points of the opponents drawn.
+	SonnebornBerger float64
+	Games           int
+	Wins            int
+	Draws           int
+	Losses          int
+	Withdrawn       bool
+}
+
+// Standings ranks the players by their points and breaks ties with Buchholz, then
+// Sonneborn-Berger in Swiss tournaments, the other way around in round robins where
+// everyone meets the same opponents, and last by seed. Only the games played over
+// the board count towards the tie-breaks, byes and forfeits only score points.
+func Standings(tournament Tournament, players []Player, pairings []Pairing) []Standing {
+	standings := make([]Standing, 0, len(players))
+	byPlayer := make(map[uuid.UUID]*Standing, len(players))
+
+	seeded := Seeded(players)
+	for _, player := range seeded {
+		standings = append(standings, Standing{
+			PlayerID:  player.PlayerID,
+			Rating:    player.Rating,
+			Withdrawn: player.Withdrawn,
+		})
+	}
+	for i := range standings {
+		byPlayer[standings[i].PlayerID] = &standings[i]
+	}
+
+	for _, pairing := range pairings {
+		for _, playerID := range pairing.players() {
+			standing, ok := byPlayer[playerID]
+			if !ok {
+				continue
+			}
+
+			score, _, ok := pairing.Result(playerID)
+			if !ok {
+				continue
+			}
+			standing.Points += score
+
+			if !pairing.IsPlayed() {
+				continue
+			}
+
+			standing.Games++
+			switch score {
+			case 1:
+				standing.Wins++
+			case 0.5:
+				standing.Draws++
+			default:
+				standing.Losses++
+			}
+		}
+	}
+
+	for _, pairing := range pairings {
+		if !pairing.IsPlayed() {
+			continue
+		}
+
+		for _, playerID := range pairing.players() {
+			standing, ok := byPlayer[playerID]
+			if !ok {
+				continue
+			}
+
+			score, opponentID, _ := pairing.Result(playerID)
+			opponent, ok := byPlayer[opponentID]
+			if !ok {
+				continue
+			}
+
+			standing.Buchholz += opponent.Points
+			standing.SonnebornBerger += score * opponent.Points
+		}
+	}
+
+	seed := make(map[uuid.UUID]int, len(seeded))
+	for i, player := range seeded {
+		seed[player.PlayerID] = i
+	}
+
+	slices.SortStableFunc(standings, func(a, b Standing) int {
+		first, second := cmp.Compare(b.Buchholz, a.Buchholz), cmp.Compare(b.SonnebornBerger, a.SonnebornBerger)
+		if tournament.Format == RoundRobin {
+			first, second = second, first
+		}
+
+		return cmp.Or(
+			cmp.Compare(b.Points, a.Points),
+			first,
+			second,
+			cmp.Compare(seed[a.PlayerID], seed[b.PlayerID]),
+		)
+	})
+
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+
+	return standings
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func playedPairing(tournament Tournament, round int, white Player, black Player, whiteScore float64) Pairing {
+	pairings := NewRoundPairings(tournament, round, []Pair{{WhiteID: white.PlayerID, BlackID: black.PlayerID}}, nil, time.Now().UTC())
+
+	gameID := uuid.New()
+	pairings[0].GameID = &gameID
+	pairings[0].score(whiteScore, 1-whiteScore)
+
+	return pairings[0]
+}
+
+func standingIDs(standings []Standing) []uuid.UUID {
+	ids := make([]uuid.UUID, len(standings))
+	for i, standing := range standings {
+		ids[i] = standing.PlayerID
+	}
+	return ids
+}
+
+func Test_Standings_Break_Ties_By_Format(t *testing.T) {
+	// Arrange
+	tournament := Tournament{ID: uuid.New()}
+	players := testTournamentPlayers(tournament, 6)
+	a, b, c, d, e, f := players[0], players[1], players[2], players[3], players[4], players[5]
+
+	pairings := []Pairing{
+		playedPairing(tournament, 1, a, d, 1),
+		playedPairing(tournament, 1, b, e, 1),
+		playedPairing(tournament, 1, c, f, 0.5),
+		playedPairing(tournament, 2, a, b, 1),
+		playedPairing(tournament, 2, e, c, 0.5),
+		playedPairing(tournament, 2, d, f, 0.5),
+	}
+
+	tests := map[string]struct {
+		format   Format
+		expected []Player
+	}{
+		"swiss breaks ties by Buchholz first": {
+			format:   Swiss,
+			expected: []Player{a, b, c, f, d, e},
+		},
+		"round robin breaks ties by Sonneborn-Berger first": {
+			format:   RoundRobin,
+			expected: []Player{a, c, f, b, d, e},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tournament.Format = test.format
+
+			// Act
+			standings := Standings(tournament, players, pairings)
+
+			// Assert
+			expected := make([]uuid.UUID, len(test.expected))
+			for i, player := range test.expected {
+				expected[i] = player.PlayerID
+			}
+			require.Equal(t, expected, standingIDs(standings))
+
+			for i, standing := range standings {
+				require.Equal(t, i+1, standing.Rank)
+			}
+		})
+	}
+}
+
+func Test_Standings_Compute_Points_And_Tie_Breaks(t *testing.T) {
+	// Arrange
+	tournament := Tournament{ID: uuid.New(), Format: Swiss}
+	players := testTournamentPlayers(tournament, 6)
+	a, b, c, d, e, f := players[0], players[1], players[2], players[3], players[4], players[5]
+
+	pairings := []Pairing{
+		playedPairing(tournament, 1, a, d, 1),
+		playedPairing(tournament, 1, b, e, 1),
+		playedPairing(tournament, 1, c, f, 0.5),
+		playedPairing(tournament, 2, a, b, 1),
+		playedPairing(tournament, 2, e, c, 0.5),
+		playedPairing(tournament, 2, d, f, 0.5),
+	}
+
+	// Act
+	standings := Standings(tournament, players, pairings)
+
+	// Assert
+	require.Equal(t, Standing{
+		Rank:            1,
+		PlayerID:        a.PlayerID,
+		Rating:          a.Rating,
+		Points:          2,
+		Buchholz:        1.5,
+		SonnebornBerger: 1.5,
+		Games:           2,
+		Wins:            2,
+	}, standings[0])
+
+	require.Equal(t, Standing{
+		Rank:            2,
+		PlayerID:        b.PlayerID,
+		Rating:          b.Rating,
+		Points:          1,
+		Buchholz:        2.5,
+		SonnebornBerger: 0.5,
+		Games:           2,
+		Wins:            1,
+		Losses:          1,
+	}, standings[1])
+
+	require.Equal(t, Standing{
+		Rank:            3,
+		PlayerID:        c.PlayerID,
+		Rating:          c.Rating,
+		Points:          1,
+		Buchholz:        1.5,
+		SonnebornBerger: 0.75,
+		Games:           2,
+		Draws:           2,
+	}, standings[2])
+}
+
+func Test_Standings_Count_Byes_And_Forfeits_As_Points_Only(t *testing.T) {
+	// Arrange
+	tournament := Tournament{ID: uuid.New(), Format: Swiss}
+	players := testTournamentPlayers(tournament, 3)
+	a, b, c := players[0], players[1], players[2]
+	players[2].Withdrawn = true
+
+	now := time.Now().UTC()
+	pairings := []Pairing{playedPairing(tournament, 1, a, b, 1)}
+	pairings = append(pairings, NewRoundPairings(tournament, 1, []Pair{{WhiteID: c.PlayerID}}, nil, now)...)
+	pairings = append(pairings, NewRoundPairings(tournament, 2, []Pair{
+		{WhiteID: b.PlayerID, BlackID: c.PlayerID},
+		{WhiteID: a.PlayerID},
+	}, []uuid.UUID{c.PlayerID}, now)...)
+
+	// Act
+	standings := Standings(tournament, players, pairings)
+
+	// Assert
+	require.Equal(t, []Standing{
+		{
+			Rank:            1,
+			PlayerID:        a.PlayerID,
+			Rating:          a.Rating,
+			Points:          2,
+			Buchholz:        1,
+			SonnebornBerger: 1,
+			Games:           1,
+			Wins:            1,
+		},
+		{
+			Rank:     2,
+			PlayerID: b.PlayerID,
+			Rating:   b.Rating,
+			Points:   1,
+			Buchholz: 2,
+			Games:    1,
+			Losses:   1,
+		},
+		{
+			Rank:      3,
+			PlayerID:  c.PlayerID,
+			Rating:    c.Rating,
+			Points:    1,
+			Withdrawn: true,
+		},
+	}, standings)
+}
@@ -0,0 +1,295 @@
+package domain
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+)
+
+var ErrNoPairing = errors.New("no valid pairing exists for the round")
+
+// maxPairingSteps bounds the search for a pairing, so a round which cannot be paired
+// within the rules is given up on instead of searched exhaustively.
+const maxPairingSteps = 100_000
+
+// SwissPlayer is the record of a player going into a Swiss round.
+type SwissPlayer struct {
+	ID     uuid.UUID
+	Points float64
+	// Opponents are the players met so far.
+	Opponents []uuid.UUID
+	// Colors are the colors of the games played so far, in order.
+	Colors []chess.Color
+	HadBye bool
+}
+
+// SwissPlayers builds the records of the players still in the tournament from the
+// pairings so far. The players keep their seed order.
+func SwissPlayers(players []Player, pairings []Pairing) []SwissPlayer {
+	ordered := slices.Clone(pairings)
+	slices.SortStableFunc(ordered, func(a, b Pairing) int { return cmp.Compare(a.Round, b.Round) })
+
+	result := make([]SwissPlayer, 0, len(players))
+	for _, player := range Seeded(players) {
+		if player.Withdrawn {
+			continue
+		}
+
+		record := SwissPlayer{ID: player.PlayerID}
+		for _, pairing := range ordered {
+			score, opponentID, ok := pairing.Result(player.PlayerID)
+			if !ok {
+				continue
+			}
+			record.Points += score
+
+			if pairing.IsBye() {
+				record.HadBye = true
+				continue
+			}
+			record.Opponents = append(record.Opponents, opponentID)
+
+			if pairing.IsPlayed() {
+				color := chess.White
+				if opponentID == pairing.WhiteID {
+					color = chess.Black
+				}
+				record.Colors = append(record.Colors, color)
+			}
+		}
+
+		result = append(result, record)
+	}
+
+	return result
+}
+
+// SwissPairings pairs the round following the Dutch system. The players are ranked by
+// their points, ties keeping the order the players are passed in, which has to be the
+// seed order. Going down the ranking, players are paired within their score group,
+// the top half against the bottom half, and the ones left over float down to the
+// next group. Players never meet twice and, unless there is no other way, nobody
+// plays the same color three times in a row or two more games with one color than
+// with the other.
+//
+// With an odd number of players the lowest ranked player without a bye sits out.
+func SwissPairings(players []SwissPlayer) ([]Pair, error) {
+	ranked := slices.Clone(players)
+	slices.SortStableFunc(ranked, func(a, b SwissPlayer) int { return cmp.Compare(b.Points, a.Points) })
+
+	if len(ranked)%2 == 0 {
+		return pairRanked(ranked, nil)
+	}
+
+	for i := len(ranked) - 1; i >= 0; i-- {
+		if ranked[i].HadBye {
+			continue
+		}
+
+		rest := slices.Delete(slices.Clone(ranked), i, i+1)
+		if pairs, err := pairRanked(rest, &ranked[i]); err == nil {
+			return pairs, nil
+		}
+	}
+
+	return nil, ErrNoPairing
+}
+
+// pairRanked pairs the ranked players, first keeping to the color rules and then
+// without them.
+func pairRanked(ranked []SwissPlayer, bye *SwissPlayer) ([]Pair, error) {
+	for _, colorRules := range []bool{true, false} {
+		pairer := swissPairer{colorRules: colorRules}
+
+		matches, ok := pairer.pair(ranked)
+		if !ok {
+			continue
+		}
+
+		pairs := make([]Pair, 0, len(matches)+1)
+		for i, match := range matches {
+			pairs = append(pairs, allocateColors(match[0], match[1], i+1))
+		}
+
+		if bye != nil {
+			pairs = append(pairs, Pair{WhiteID: bye.ID})
+		}
+
+		return pairs, nil
+	}
+
+	return nil, ErrNoPairing
+}
+
+type swissPairer struct {
+	colorRules bool
+	steps      int
+}
+
+// pair pairs the top ranked player with the first opponent in order of preference
+// for which the rest of the players can be paired as well.
+func (s *swissPairer) pair(ranked []SwissPlayer) ([][2]SwissPlayer, bool) {
+	if len(ranked) == 0 {
+		return nil, true
+	}
+
+	s.steps++
+	if s.steps > maxPairingSteps {
+		return nil, false
+	}
+
+	top := ranked[0]
+	for _, i := range opponentOrder(ranked) {
+		if !s.canMeet(top, ranked[i]) {
+			continue
+		}
+
+		rest := make([]SwissPlayer, 0, len(ranked)-2)
+		rest = append(rest, ranked[1:i]...)
+		rest = append(rest, ranked[i+1:]...)
+
+		if matches, ok := s.pair(rest); ok {
+			return append([][2]SwissPlayer{{top, ranked[i]}}, matches...), true
+		}
+
+		if s.steps > maxPairingSteps {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+func (s *swissPairer) canMeet(a SwissPlayer, b SwissPlayer) bool {
+	if slices.Contains(a.Opponents, b.ID) {
+		return false
+	}
+
+	if !s.colorRules {
+		return true
+	}
+
+	pa, pb := a.colorPreference(), b.colorPreference()
+	return pa.strength != absolutePreference || pb.strength != absolutePreference || pa.color != pb.color
+}
+
+// opponentOrder lists the indexes of the top ranked player's possible opponents, best
+// first. The top player is the first of the upper half of their score group, and
+// meets the first of the lower half. Failing that, the rest of the lower half and then
+// the upper half from the bottom up are tried, and last the players of lower groups.
+func opponentOrder(ranked []SwissPlayer) []int {
+	group := 1
+	for group < len(ranked) && ranked[group].Points == ranked[0].Points {
+		group++
+	}
+
+	order := make([]int, 0, len(ranked)-1)
+	half := group / 2
+	for i := max(half, 1); i < group; i++ {
+		order = append(order, i)
+	}
+	for i := half - 1; i >= 1; i-- {
+		order = append(order, i)
+	}
+	for i := group; i < len(ranked); i++ {
+		order = append(order, i)
+	}
+
+	return order
+}
+
+type preferenceStrength int
+
+const (
+	noPreference preferenceStrength = iota
+	mildPreference
+	strongPreference
+	absolutePreference
+)
+
+type colorPreference struct {
+	color    chess.Color
+	strength preferenceStrength
+}
+
+// colorPreference is the color the player should get next. Players who had a color
+// twice in a row or two more times than the other must get the other color, players
+// who had a color once more should, and the others would rather alternate.
+func (p SwissPlayer) colorPreference() colorPreference {
+	n := len(p.Colors)
+	if n == 0 {
+		return colorPreference{}
+	}
+
+	difference := 0
+	for _, color := range p.Colors {
+		if color == chess.White {
+			difference++
+		} else {
+			difference--
+		}
+	}
+
+	last := p.Colors[n-1]
+	twiceInARow := n >= 2 && p.Colors[n-2] == last
+
+	switch {
+	case difference > 1 || (twiceInARow && last == chess.White):
+		return colorPreference{color: chess.Black, strength: absolutePreference}
+	case difference < -1 || (twiceInARow && last == chess.Black):
+		return colorPreference{color: chess.White, strength: absolutePreference}
+	case difference == 1:
+		return colorPreference{color: chess.Black, strength: strongPreference}
+	case difference == -1:
+		return colorPreference{color: chess.White, strength: strongPreference}
+	default:
+		return colorPreference{color: last.Other(), strength: mildPreference}
+	}
+}
+
+// allocateColors gives the players of the board their colors, a being the higher ranked.
+// Both preferences are granted when they can be, otherwise the stronger one, then the
+// one alternating from the last round the players had different colors, and last the
+// higher ranked player's. Without preferences, as in the first round, the higher
+// ranked player is white on odd boards and black on even ones.
+func allocateColors(a SwissPlayer, b SwissPlayer, board int) Pair {
+	pa, pb := a.colorPreference(), b.colorPreference()
+
+	white := func(color chess.Color) Pair {
+		if color == chess.White {
+			return Pair{WhiteID: a.ID, BlackID: b.ID}
+		}
+		return Pair{WhiteID: b.ID, BlackID: a.ID}
+	}
+
+	switch {
+	case pa.strength == noPreference && pb.strength == noPreference:
+		if board%2 == 1 {
+			return white(chess.White)
+		}
+		return white(chess.Black)
+	case pb.strength == noPreference:
+		return white(pa.color)
+	case pa.strength == noPreference:
+		return white(pb.color.Other())
+	case pa.color != pb.color:
+		return white(pa.color)
+	case pa.strength > pb.strength:
+		return white(pa.color)
+	case pb.strength > pa.strength:
+		return white(pb.color.Other())
+	}
+
+	for i := 1; i <= min(len(a.Colors), len(b.Colors)); i++ {
+		ca, cb := a.Colors[len(a.Colors)-i], b.Colors[len(b.Colors)-i]
+		if ca != cb {
+			return white(ca.Other())
+		}
+	}
+
+	return white(pa.color)
+}
@@ -0,0 +1,365 @@
+package domain
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// testTournamentPlayers registers n players seeded in the order they are returned.
+func testTournamentPlayers(tournament Tournament, n int) []Player {
+	now := time.Now().UTC()
+
+	players := make([]Player, n)
+	for i := range players {
+		players[i] = Player{
+			TournamentID: tournament.ID,
+			PlayerID:     uuid.New(),
+			Rating:       2000 - 10*i,
+			RegisteredAt: now,
+		}
+	}
+	return players
+}
+
+// playRound pairs the round and plays its games, scores picks each game's white score.
+func playRound(
+	t *testing.T,
+	tournament Tournament,
+	players []Player,
+	pairings []Pairing,
+	round int,
+	scores func(pair Pair) float64,
+) []Pairing {
+	pairs, err := SwissPairings(SwissPlayers(players, pairings))
+	require.NoError(t, err, "round %d", round)
+
+	for _, pairing := range NewRoundPairings(tournament, round, pairs, nil, time.Now().UTC()) {
+		if pairing.NeedsGame() {
+			gameID := uuid.New()
+			pairing.GameID = &gameID
+
+			white := scores(Pair{WhiteID: pairing.WhiteID, BlackID: *pairing.BlackID})
+			pairing.score(white, 1-white)
+		}
+		pairings = append(pairings, pairing)
+	}
+
+	return pairings
+}
+
+func swissRecord(id uuid.UUID, points float64, colors ...chess.Color) SwissPlayer {
+	return SwissPlayer{ID: id, Points: points, Colors: colors}
+}
+
+func Test_SwissPairings_First_Round_Pairs_Top_Half_Against_Bottom_Half(t *testing.T) {
+	// Arrange
+	ids := testPlayers(8)
+	players := make([]SwissPlayer, len(ids))
+	for i, id := range ids {
+		players[i] = SwissPlayer{ID: id}
+	}
+
+	// Act
+	pairs, err := SwissPairings(players)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []Pair{
+		{WhiteID: ids[0], BlackID: ids[4]},
+		{WhiteID: ids[5], BlackID: ids[1]},
+		{WhiteID: ids[2], BlackID: ids[6]},
+		{WhiteID: ids[7], BlackID: ids[3]},
+	}, pairs)
+}
+
+func Test_SwissPairings_Pairs_Within_Score_Groups(t *testing.T) {
+	// Arrange
+	ids := testPlayers(8)
+	players := []SwissPlayer{
+		{ID: ids[0], Points: 1, Opponents: []uuid.UUID{ids[4]}, Colors: []chess.Color{chess.White}},
+		{ID: ids[1], Points: 0, Opponents: []uuid.UUID{ids[5]}, Colors: []chess.Color{chess.Black}},
+		{ID: ids[2], Points: 1, Opponents: []uuid.UUID{ids[6]}, Colors: []chess.Color{chess.White}},
+		{ID: ids[3], Points: 0, Opponents: []uuid.UUID{ids[7]}, Colors: []chess.Color{chess.Black}},
+		{ID: ids[4], Points: 0, Opponents: []uuid.UUID{ids[0]}, Colors: []chess.Color{chess.Black}},
+		{ID: ids[5], Points: 1, Opponents: []uuid.UUID{ids[1]}, Colors: []chess.Color{chess.White}},
+		{ID: ids[6], Points: 0, Opponents: []uuid.UUID{ids[2]}, Colors: []chess.Color{chess.Black}},
+		{ID: ids[7], Points: 1, Opponents: []uuid.UUID{ids[3]}, Colors: []chess.Color{chess.White}},
+	}
+
+	// Act
+	pairs, err := SwissPairings(players)
+
+	// Assert
+	require.NoError(t, err)
+
+	// The winners, seeded 1, 3, 6 and 8, meet 1-6 and 3-8, the others 2-5 and 4-7.
+	// Both players of a board had the same color, so the higher ranked gets their
+	// preference.
+	require.Equal(t, []Pair{
+		{WhiteID: ids[5], BlackID: ids[0]},
+		{WhiteID: ids[7], BlackID: ids[2]},
+		{WhiteID: ids[1], BlackID: ids[4]},
+		{WhiteID: ids[3], BlackID: ids[6]},
+	}, pairs)
+}
+
+func Test_SwissPairings_Floats_Odd_Player_Down(t *testing.T) {
+	// Arrange
+	ids := testPlayers(6)
+	players := []SwissPlayer{
+		swissRecord(ids[0], 2),
+		swissRecord(ids[1], 2),
+		swissRecord(ids[2], 2),
+		swissRecord(ids[3], 1),
+		swissRecord(ids[4], 1),
+		swissRecord(ids[5], 1),
+	}
+
+	// Act
+	pairs, err := SwissPairings(players)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, pairs, 3)
+
+	// The lowest ranked of the top group floats down to meet the top of the next group.
+	require.ElementsMatch(t, []uuid.UUID{ids[0], ids[1]}, []uuid.UUID{pairs[0].WhiteID, pairs[0].BlackID})
+	require.ElementsMatch(t, []uuid.UUID{ids[2], ids[3]}, []uuid.UUID{pairs[1].WhiteID, pairs[1].BlackID})
+	require.ElementsMatch(t, []uuid.UUID{ids[4], ids[5]}, []uuid.UUID{pairs[2].WhiteID, pairs[2].BlackID})
+}
+
+func Test_SwissPairings_Avoids_Rematches(t *testing.T) {
+	// Arrange
+	ids := testPlayers(4)
+	players := []SwissPlayer{
+		{ID: ids[0], Points: 1, Opponents: []uuid.UUID{ids[1]}},
+		{ID: ids[1], Points: 1, Opponents: []uuid.UUID{ids[0]}},
+		{ID: ids[2], Points: 0, Opponents: []uuid.UUID{ids[3]}},
+		{ID: ids[3], Points: 0, Opponents: []uuid.UUID{ids[2]}},
+	}
+
+	// Act
+	pairs, err := SwissPairings(players)
+
+	// Assert
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{ids[0], ids[2]}, []uuid.UUID{pairs[0].WhiteID, pairs[0].BlackID})
+	require.ElementsMatch(t, []uuid.UUID{ids[1], ids[3]}, []uuid.UUID{pairs[1].WhiteID, pairs[1].BlackID})
+}
+
+func Test_SwissPairings_Gives_Bye_To_Lowest_Ranked_Player_Without_One(t *testing.T) {
+	// Arrange
+	ids := testPlayers(5)
+	players := []SwissPlayer{
+		swissRecord(ids[0], 1),
+		swissRecord(ids[1], 1),
+		swissRecord(ids[2], 0),
+		swissRecord(ids[3], 0),
+		{ID: ids[4], Points: 1, HadBye: true},
+	}
+
+	// Act
+	pairs, err := SwissPairings(players)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, pairs, 3)
+	require.Equal(t, Pair{WhiteID: ids[3]}, pairs[2])
+}
+
+func Test_SwissPairings_Returns_ErrNoPairing_When_Everyone_Met(t *testing.T) {
+	// Arrange
+	ids := testPlayers(2)
+	players := []SwissPlayer{
+		{ID: ids[0], Opponents: []uuid.UUID{ids[1]}},
+		{ID: ids[1], Opponents: []uuid.UUID{ids[0]}},
+	}
+
+	// Act
+	_, err := SwissPairings(players)
+
+	// Assert
+	require.ErrorIs(t, err, ErrNoPairing)
+}
+
+func Test_SwissPairings_Keeps_Absolute_Color_Preferences(t *testing.T) {
+	// Arrange
+	ids := testPlayers(4)
+	players := []SwissPlayer{
+		swissRecord(ids[0], 2, chess.White, chess.White),
+		swissRecord(ids[1], 2, chess.White, chess.White),
+		swissRecord(ids[2], 2, chess.Black, chess.Black),
+		swissRecord(ids[3], 2, chess.Black, chess.Black),
+	}
+
+	// Act
+	pairs, err := SwissPairings(players)
+
+	// Assert
+	require.NoError(t, err)
+
+	// The top player would meet the third, the two who had white twice cannot meet.
+	require.Equal(t, []Pair{
+		{WhiteID: ids[2], BlackID: ids[0]},
+		{WhiteID: ids[3], BlackID: ids[1]},
+	}, pairs)
+}
+
+func Test_AllocateColors(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	tests := map[string]struct {
+		a        SwissPlayer
+		b        SwissPlayer
+		board    int
+		expected Pair
+	}{
+		"no preferences on odd board": {
+			a: swissRecord(a, 0), b: swissRecord(b, 0), board: 1,
+			expected: Pair{WhiteID: a, BlackID: b},
+		},
+		"no preferences on even board": {
+			a: swissRecord(a, 0), b: swissRecord(b, 0), board: 2,
+			expected: Pair{WhiteID: b, BlackID: a},
+		},
+		"both preferences granted": {
+			a: swissRecord(a, 1, chess.White), b: swissRecord(b, 1, chess.Black), board: 1,
+			expected: Pair{WhiteID: b, BlackID: a},
+		},
+		"stronger preference wins": {
+			a: swissRecord(a, 1, chess.White, chess.Black), b: swissRecord(b, 1, chess.Black), board: 1,
+			expected: Pair{WhiteID: b, BlackID: a},
+		},
+		"absolute preference of the lower ranked wins": {
+			a: swissRecord(a, 1, chess.Black), b: swissRecord(b, 1, chess.White, chess.Black, chess.Black), board: 1,
+			expected: Pair{WhiteID: b, BlackID: a},
+		},
+		"alternates from the last different colors": {
+			a:        swissRecord(a, 2, chess.Black, chess.White, chess.White, chess.Black),
+			b:        swissRecord(b, 2, chess.White, chess.Black, chess.White, chess.Black),
+			board:    1,
+			expected: Pair{WhiteID: b, BlackID: a},
+		},
+		"higher ranked gets their preference": {
+			a: swissRecord(a, 1, chess.Black), b: swissRecord(b, 1, chess.Black), board: 1,
+			expected: Pair{WhiteID: a, BlackID: b},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expected, allocateColors(test.a, test.b, test.board))
+		})
+	}
+}
+
+func Test_SwissPairings_Full_Tournaments_Keep_To_The_Rules(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for n := 4; n <= 24; n++ {
+		for _, rounds := range []int{n / 2, min(n-1, 9)} {
+			// Arrange
+			tournament := Tournament{ID: uuid.New(), Format: Swiss}
+			players := testTournamentPlayers(tournament, n)
+
+			var pairings []Pairing
+			for round := 1; round <= rounds; round++ {
+				// Act
+				pairings = playRound(t, tournament, players, pairings, round, func(Pair) float64 {
+					return float64(random.IntN(3)) / 2
+				})
+			}
+
+			// Assert
+			met := make(map[[2]uuid.UUID]bool)
+			byes := make(map[uuid.UUID]int)
+			for _, pairing := range pairings {
+				if pairing.IsBye() {
+					byes[pairing.WhiteID]++
+					continue
+				}
+
+				key := [2]uuid.UUID{pairing.WhiteID, *pairing.BlackID}
+				if key[1].String() < key[0].String() {
+					key[0], key[1] = key[1], key[0]
+				}
+				require.False(t, met[key], "%d players, %d rounds, met twice", n, rounds)
+				met[key] = true
+			}
+
+			for _, times := range byes {
+				require.Equal(t, 1, times, "%d players", n)
+			}
+			require.Len(t, byes, rounds*(n%2), "%d players", n)
+
+			// Close to a round robin there may be no way left to keep to the color rules.
+			if 2*rounds > n {
+				continue
+			}
+
+			for _, record := range SwissPlayers(players, pairings) {
+				games := rounds
+				if record.HadBye {
+					games--
+				}
+				require.Len(t, record.Colors, games, "%d players", n)
+
+				whites := 0
+				for i, color := range record.Colors {
+					if color == chess.White {
+						whites++
+					}
+					if i >= 2 {
+						require.False(t,
+							record.Colors[i] == record.Colors[i-1] && record.Colors[i] == record.Colors[i-2],
+							"%d players, three times the same color", n,
+						)
+					}
+				}
+				require.LessOrEqual(t, abs(2*whites-len(record.Colors)), 2, "%d players", n)
+			}
+		}
+	}
+}
+
+func Test_SwissPairings_Winners_Meet(t *testing.T) {
+	// Arrange
+	tournament := Tournament{ID: uuid.New(), Format: Swiss}
+	players := testTournamentPlayers(tournament, 16)
+
+	// The higher seed always wins.
+	seed := make(map[uuid.UUID]int, len(players))
+	for i, player := range players {
+		seed[player.PlayerID] = i
+	}
+	higherSeedWins := func(pair Pair) float64 {
+		if seed[pair.WhiteID] < seed[pair.BlackID] {
+			return 1
+		}
+		return 0
+	}
+
+	var pairings []Pairing
+	for round := 1; round <= 3; round++ {
+		pairings = playRound(t, tournament, players, pairings, round, higherSeedWins)
+	}
+
+	// Act
+	pairings = playRound(t, tournament, players, pairings, 4, higherSeedWins)
+
+	// Assert
+	// The only two players who won their first three games are the top two seeds.
+	last := pairings[len(pairings)-8]
+	require.Equal(t, 4, last.Round)
+	require.ElementsMatch(t, []uuid.UUID{players[0].PlayerID, players[1].PlayerID}, last.players())
+
+	standings := Standings(tournament, players, pairings)
+	require.Equal(t, players[0].PlayerID, standings[0].PlayerID)
+	require.Equal(t, 4.0, standings[0].Points)
+}
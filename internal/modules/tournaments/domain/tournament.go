@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MinPlayers is how many players a tournament needs to start.
+	MinPlayers = 2
+	// MaxSwissRounds caps the rounds of a Swiss tournament.
+	MaxSwissRounds = 20
+)
+
+var (
+	ErrRegistrationClosed   = errors.New("tournament is no longer open for registration")
+	ErrTournamentFull       = errors.New("tournament is full")
+	ErrAlreadyRegistered    = errors.New("user is already registered for the tournament")
+	ErrNotRegistered        = errors.New("user is not registered for the tournament")
+	ErrNotOrganizer         = errors.New("only the organizer can start the tournament")
+	ErrNotEnoughPlayers     = errors.New("not enough players for the tournament's rounds")
+	ErrTournamentNotRunning = errors.New("tournament is not running")
+	ErrTournamentFinished   = errors.New("tournament is already finished")
+)
+
+// Format decides how the players of a tournament are paired.
+type Format string
+
+const (
+	// RoundRobin tournaments pair every player with every other player once.
+	RoundRobin Format = "round_robin"
+	// Swiss tournaments pair players with similar scores for a fixed number of rounds.
+	Swiss Format = "swiss"
+)
+
+func (f Format) Validate() error {
+	switch f {
+	case RoundRobin, Swiss:
+		return nil
+	default:
+		return fmt.Errorf("invalid Format - '%s'", f)
+	}
+}
+
+// ByePoints is what a player sitting out a round scores. A Swiss bye is worth a win,
+// a round robin bye is only a round without an opponent.
+func (f Format) ByePoints() float64 {
+	if f == Swiss {
+		return 1
+	}
+	return 0
+}
+
+type Status string
+
+const (
+	Registering Status = "registering"
+	Running     Status = "running"
+	Finished    Status = "finished"
+)
+
+type Tournament struct {
+	ID          uuid.UUID `db:"id"`
+	OrganizerID uuid.UUID `db:"organizer_id"`
+	Name        string    `db:"name"`
+	Format      Format    `db:"format"`
+	Status      Status    `db:"status"`
+	// Rounds is set by the organizer for Swiss tournaments and when starting for round robins.
+	Rounds       int `db:"rounds"`
+	CurrentRound int `db:"current_round"`
+	// MaxPlayers caps the registrations, 0 when there is no cap.
+	MaxPlayers int  `db:"max_players"`
+	Rated      bool `db:"rated"`
+
+	TimeControlBaseSeconds      int                                   `db:"time_control_base_seconds"`
+	TimeControlIncrementSeconds int                                   `db:"time_control_increment_seconds"`
+	TimeControlDelay            gamesessiondomain.DelayMode           `db:"time_control_delay"`
+	TimeControlDaysPerMove      int                                   `db:"time_control_days_per_move"`
+	TimeControlCategory         gamesessiondomain.TimeControlCategory `db:"time_control_category"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	StartedAt *time.Time `db:"started_at"`
+	EndedAt   *time.Time `db:"ended_at"`
+}
+
+// NewTournament opens a tournament for registration.
+func NewTournament(
+	organizerID uuid.UUID,
+	name string,
+	format Format,
+	rounds int,
+	timeControl gamesessiondomain.TimeControl,
+	rated bool,
+	maxPlayers int,
+	now time.Time,
+) Tournament {
+	if timeControl.Delay == "" {
+		timeControl.Delay = gamesessiondomain.FischerDelay
+	}
+
+	return Tournament{
+		ID:                          uuid.New(),
+		OrganizerID:                 organizerID,
+		Name:                        name,
+		Format:                      format,
+		Status:                      Registering,
+		Rounds:                      rounds,
+		MaxPlayers:                  maxPlayers,
+		Rated:                       rated,
+		TimeControlBaseSeconds:      timeControl.BaseSeconds,
+		TimeControlIncrementSeconds: timeControl.IncrementSeconds,
+		TimeControlDelay:            timeControl.Delay,
+		TimeControlDaysPerMove:      timeControl.DaysPerMove,
+		TimeControlCategory:         timeControl.Category(),
+		CreatedAt:                   now,
+	}
+}
+
+func (t Tournament) TimeControl() gamesessiondomain.TimeControl {
+	return gamesessiondomain.TimeControl{
+		BaseSeconds:      t.TimeControlBaseSeconds,
+		IncrementSeconds: t.TimeControlIncrementSeconds,
+		Delay:            t.TimeControlDelay,
+		DaysPerMove:      t.TimeControlDaysPerMove,
+	}
+}
+
+// RatingCategory is the category the players are seeded by and their games are rated in.
+func (t Tournament) RatingCategory() string {
+	return gamesessiondomain.RatingCategory(gamesessiondomain.Standard, t.TimeControl())
+}
+
+// CanRegister checks another player can register while registered players already are.
+func (t Tournament) CanRegister(registered int) error {
+	if t.Status != Registering {
+		return ErrRegistrationClosed
+	}
+
+	if t.MaxPlayers > 0 && registered >= t.MaxPlayers {
+		return ErrTournamentFull
+	}
+
+	return nil
+}
+
+// Start closes the registration of the players. Round robins are played over as many
+// rounds as it takes for everyone to meet, while Swiss tournaments need more players
+// than rounds so nobody has to meet the same opponent twice.
+func (t *Tournament) Start(organizerID uuid.UUID, players int, now time.Time) error {
+	if organizerID != t.OrganizerID {
+		return ErrNotOrganizer
+	}
+
+	if t.Status != Registering {
+		return ErrRegistrationClosed
+	}
+
+	if players < MinPlayers {
+		return ErrNotEnoughPlayers
+	}
+
+	switch t.Format {
+	case RoundRobin:
+		t.Rounds = RoundRobinRounds(players)
+	case Swiss:
+		if t.Rounds >= players {
+			return ErrNotEnoughPlayers
+		}
+	}
+
+	t.Status = Running
+	t.StartedAt = &now
+	return nil
+}
+
+// IsLastRound reports whether the current round is the tournament's last.
+func (t Tournament) IsLastRound() bool {
+	return t.CurrentRound >= t.Rounds
+}
+
+// Finish ends the tournament once its last round is over.
+func (t *Tournament) Finish(now time.Time) error {
+	if t.Status != Running {
+		return ErrTournamentNotRunning
+	}
+
+	t.Status = Finished
+	t.EndedAt = &now
+	return nil
+}
+
+// Session sets up the session the game of the pairing is played in.
+func (t Tournament) Session(pairing Pairing, now time.Time) gamesessiondomain.Session {
+	timeControl := t.TimeControl()
+
+	return gamesessiondomain.Session{
+		ID:                          uuid.NewString(),
+		OwnerID:                     t.OrganizerID,
+		Player1ID:                   pairing.WhiteID,
+		Player2ID:                   *pairing.BlackID,
+		Name:                        fmt.Sprintf("%s - Round %d", t.Name, pairing.Round),
+		Rated:                       t.Rated,
+		Visibility:                  gamesessiondomain.Public,
+		Variant:                     gamesessiondomain.Standard,
+		InitialFEN:                  chess.StartingFEN,
+		TimeControlBaseSeconds:      timeControl.BaseSeconds,
+		TimeControlIncrementSeconds: timeControl.IncrementSeconds,
+		TimeControlDelay:            timeControl.Delay,
+		TimeControlDaysPerMove:      timeControl.DaysPerMove,
+		TimeControlCategory:         timeControl.Category(),
+		CreatedAt:                   now,
+	}
+}
+
+// Player is a player registered for a tournament.
+type Player struct {
+	TournamentID uuid.UUID `db:"tournament_id"`
+	PlayerID     uuid.UUID `db:"player_id"`
+	// Rating seeds the player, it is the player's rating when registering.
+	Rating int `db:"rating"`
+	// Withdrawn players are no longer paired, their results so far still count.
+	Withdrawn    bool      `db:"withdrawn"`
+	RegisteredAt time.Time `db:"registered_at"`
+}
+
+// Seeded orders the players by their seed, highest rated first.
+func Seeded(players []Player) []Player {
+	seeded := slices.Clone(players)
+	slices.SortStableFunc(seeded, func(a, b Player) int {
+		return cmp.Or(
+			cmp.Compare(b.Rating, a.Rating),
+			a.RegisteredAt.Compare(b.RegisteredAt),
+			slices.Compare(a.PlayerID[:], b.PlayerID[:]),
+		)
+	})
+	return seeded
+}
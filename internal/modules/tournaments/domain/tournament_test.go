@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Tournament_CanRegister(t *testing.T) {
+	tests := map[string]struct {
+		tournament Tournament
+		registered int
+		expected   error
+	}{
+		"open":           {tournament: Tournament{Status: Registering}, registered: 10},
+		"open with room": {tournament: Tournament{Status: Registering, MaxPlayers: 4}, registered: 3},
+		"full":           {tournament: Tournament{Status: Registering, MaxPlayers: 4}, registered: 4, expected: ErrTournamentFull},
+		"running":        {tournament: Tournament{Status: Running}, registered: 3, expected: ErrRegistrationClosed},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, test.tournament.CanRegister(test.registered), test.expected)
+		})
+	}
+}
+
+func Test_Tournament_Start(t *testing.T) {
+	organizerID := uuid.New()
+
+	tests := map[string]struct {
+		tournament     Tournament
+		organizerID    uuid.UUID
+		players        int
+		expected       error
+		expectedRounds int
+	}{
+		"round robin plays everyone": {
+			tournament:     Tournament{OrganizerID: organizerID, Format: RoundRobin, Status: Registering},
+			organizerID:    organizerID,
+			players:        5,
+			expectedRounds: 5,
+		},
+		"swiss keeps its rounds": {
+			tournament:     Tournament{OrganizerID: organizerID, Format: Swiss, Status: Registering, Rounds: 3},
+			organizerID:    organizerID,
+			players:        4,
+			expectedRounds: 3,
+		},
+		"swiss needs more players than rounds": {
+			tournament:  Tournament{OrganizerID: organizerID, Format: Swiss, Status: Registering, Rounds: 4},
+			organizerID: organizerID,
+			players:     4,
+			expected:    ErrNotEnoughPlayers,
+		},
+		"needs two players": {
+			tournament:  Tournament{OrganizerID: organizerID, Format: RoundRobin, Status: Registering},
+			organizerID: organizerID,
+			players:     1,
+			expected:    ErrNotEnoughPlayers,
+		},
+		"only the organizer starts": {
+			tournament:  Tournament{OrganizerID: organizerID, Format: RoundRobin, Status: Registering},
+			organizerID: uuid.New(),
+			players:     4,
+			expected:    ErrNotOrganizer,
+		},
+		"starts once": {
+			tournament:  Tournament{OrganizerID: organizerID, Format: RoundRobin, Status: Running},
+			organizerID: organizerID,
+			players:     4,
+			expected:    ErrRegistrationClosed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			tournament := test.tournament
+			now := time.Now().UTC()
+
+			// Act
+			err := tournament.Start(test.organizerID, test.players, now)
+
+			// Assert
+			require.ErrorIs(t, err, test.expected)
+			if test.expected != nil {
+				require.Equal(t, test.tournament, tournament)
+				return
+			}
+
+			require.Equal(t, Running, tournament.Status)
+			require.Equal(t, test.expectedRounds, tournament.Rounds)
+			require.Equal(t, &now, tournament.StartedAt)
+		})
+	}
+}
+
+func Test_Tournament_Finish(t *testing.T) {
+	// Arrange
+	tournament := Tournament{Status: Running, Rounds: 3, CurrentRound: 3}
+	now := time.Now().UTC()
+
+	// Act
+	err := tournament.Finish(now)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, Finished, tournament.Status)
+	require.Equal(t, &now, tournament.EndedAt)
+	require.ErrorIs(t, tournament.Finish(now), ErrTournamentNotRunning)
+}
+
+func Test_NewRoundPairings_Score_Byes_And_Forfeits(t *testing.T) {
+	// Arrange
+	ids := testPlayers(7)
+	pairs := []Pair{
+		{WhiteID: ids[0], BlackID: ids[1]},
+		{WhiteID: ids[2], BlackID: ids[3]},
+		{WhiteID: ids[4], BlackID: ids[5]},
+		{WhiteID: ids[6]},
+	}
+	withdrawn := []uuid.UUID{ids[3], ids[4], ids[5]}
+
+	for format, byePoints := range map[Format]float64{Swiss: 1, RoundRobin: 0} {
+		t.Run(string(format), func(t *testing.T) {
+			tournament := Tournament{ID: uuid.New(), Format: format}
+
+			// Act
+			pairings := NewRoundPairings(tournament, 2, pairs, withdrawn, time.Now().UTC())
+
+			// Assert
+			require.Len(t, pairings, 4)
+			for i, pairing := range pairings {
+				require.Equal(t, tournament.ID, pairing.TournamentID)
+				require.Equal(t, 2, pairing.Round)
+				require.Equal(t, i+1, pairing.Board)
+			}
+
+			require.True(t, pairings[0].NeedsGame())
+
+			score, opponentID, ok := pairings[1].Result(ids[2])
+			require.True(t, ok)
+			require.Equal(t, 1.0, score)
+			require.Equal(t, ids[3], opponentID)
+			require.False(t, pairings[1].NeedsGame())
+			require.False(t, pairings[1].IsPlayed())
+
+			score, _, _ = pairings[2].Result(ids[4])
+			require.Equal(t, 0.0, score)
+			score, _, _ = pairings[2].Result(ids[5])
+			require.Equal(t, 0.0, score)
+
+			score, opponentID, ok = pairings[3].Result(ids[6])
+			require.True(t, ok)
+			require.True(t, pairings[3].IsBye())
+			require.Equal(t, byePoints, score)
+			require.Equal(t, uuid.Nil, opponentID)
+		})
+	}
+}
+
+func Test_Seeded_Orders_By_Rating_Then_Registration(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC()
+	players := []Player{
+		{PlayerID: uuid.New(), Rating: 1500, RegisteredAt: now},
+		{PlayerID: uuid.New(), Rating: 1800, RegisteredAt: now.Add(time.Minute)},
+		{PlayerID: uuid.New(), Rating: 1500, RegisteredAt: now.Add(-time.Minute)},
+		{PlayerID: uuid.New(), Rating: 1800, RegisteredAt: now},
+	}
+
+	// Act
+	seeded := Seeded(players)
+
+	// Assert
+	require.Equal(t, []Player{players[3], players[1], players[2], players[0]}, seeded)
+}
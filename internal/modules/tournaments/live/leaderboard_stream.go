@@ -0,0 +1,169 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/queries"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+const (
+	heartbeatPeriod = 15 * time.Second
+	retryMs         = 3000
+
+	// Published changes are only wake-ups, the leaderboard is read again on each,
+	// so a small buffer is enough.
+	subscriptionBuffer = 16
+
+	leaderboardEvent = "leaderboard"
+)
+
+// HandleLeaderboardStream streams the arena's leaderboard as server-sent events. The
+// whole leaderboard is sent on connecting and again whenever it changes, so clients
+// which reconnect have nothing to catch up on.
+func HandleLeaderboardStream(pubSub *core.PubSub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		arenaID, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			core.WriteInternalServerError(w, r, fmt.Errorf("streaming is not supported"))
+			return
+		}
+
+		// Subscribe before reading the leaderboard so no change made in the meantime is lost.
+		s := stream{w: w, flusher: flusher, pubSub: pubSub, arenaID: arenaID}
+		s.subscribe()
+		defer s.unsubscribe()
+
+		leaderboard, err := s.leaderboard(ctx)
+		if err != nil {
+			core.WriteCommandError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		if err := s.write(fmt.Sprintf("retry: %d\n\n", retryMs)); err != nil {
+			return
+		}
+
+		if err := s.writeLeaderboard(leaderboard); err != nil {
+			return
+		}
+
+		s.run(ctx)
+	}
+}
+
+type stream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	pubSub  *core.PubSub
+
+	arenaID uuid.UUID
+	arena   *core.Subscription
+}
+
+func (s *stream) subscribe() {
+	s.arena = s.pubSub.Subscribe(domain.ArenaTopic(s.arenaID), subscriptionBuffer)
+}
+
+func (s *stream) unsubscribe() {
+	s.pubSub.Unsubscribe(s.arena)
+}
+
+func (s *stream) run(ctx context.Context) {
+	ticker := time.NewTicker(heartbeatPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			// Also how a closed connection is detected.
+			if err := s.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+			continue
+
+		case _, ok := <-s.arena.Messages():
+			if !ok {
+				// The subscription was dropped for falling behind or for a reconnect of
+				// the listener, the leaderboard is read again anyway.
+				s.unsubscribe()
+				s.subscribe()
+			}
+		}
+
+		// Changes which came in meanwhile are covered by reading the leaderboard once.
+		s.drain()
+
+		leaderboard, err := s.leaderboard(ctx)
+		if err != nil {
+			return
+		}
+
+		if err := s.writeLeaderboard(leaderboard); err != nil {
+			return
+		}
+	}
+}
+
+func (s *stream) drain() {
+	for {
+		select {
+		case _, ok := <-s.arena.Messages():
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (s *stream) leaderboard(ctx context.Context) ([]domain.ArenaStanding, error) {
+	return mediator.Send[queries.GetArenaLeaderboardQuery, []domain.ArenaStanding](
+		ctx,
+		queries.GetArenaLeaderboardQuery{ArenaID: s.arenaID},
+	)
+}
+
+func (s *stream) writeLeaderboard(leaderboard []domain.ArenaStanding) error {
+	data, err := json.Marshal(leaderboard)
+	if err != nil {
+		return err
+	}
+
+	return s.write(fmt.Sprintf("event: %s\ndata: %s\n\n", leaderboardEvent, data))
+}
+
+func (s *stream) write(event string) error {
+	if _, err := fmt.Fprint(s.w, event); err != nil {
+		return err
+	}
+
+	s.flusher.Flush()
+	return nil
+}
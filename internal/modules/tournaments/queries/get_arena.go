@@ -0,0 +1,99 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetArenaQuery returns the arena with its leaderboard.
+type GetArenaQuery struct {
+	ArenaID uuid.UUID
+}
+
+func (q GetArenaQuery) Validate() error {
+	if q.ArenaID == uuid.Nil {
+		return fmt.Errorf("invalid ArenaID - '%s'", q.ArenaID)
+	}
+
+	return nil
+}
+
+type ArenaDetails struct {
+	Arena       domain.Arena
+	Leaderboard []domain.ArenaStanding
+}
+
+func HandleGetArena(w http.ResponseWriter, r *http.Request) {
+	arenaID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetArenaQuery, ArenaDetails](r.Context(), GetArenaQuery{ArenaID: arenaID})
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetArenaQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetArenaQueryHandler(db *sql.DB) *GetArenaQueryHandler {
+	return &GetArenaQueryHandler{db}
+}
+
+func (h *GetArenaQueryHandler) Handle(ctx context.Context, request GetArenaQuery) (ArenaDetails, error) {
+	arena, players, err := getArena(ctx, h.db, request.ArenaID)
+	if err != nil {
+		return ArenaDetails{}, err
+	}
+
+	return ArenaDetails{Arena: arena, Leaderboard: domain.Leaderboard(players)}, nil
+}
+
+// getArena loads the arena with its players.
+func getArena(ctx context.Context, q tql.Querier, arenaID uuid.UUID) (domain.Arena, []domain.ArenaPlayer, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			arena
+		WHERE
+			id = $1;`
+	arena, err := tql.QueryFirst[domain.Arena](ctx, q, query, arenaID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return domain.Arena{}, nil, core.NewCommandError(404, err)
+	case err != nil:
+		return domain.Arena{}, nil, core.NewCommandError(500, err)
+	}
+
+	const playersQuery = `
+		SELECT
+			*
+		FROM
+			arena_player
+		WHERE
+			arena_id = $1;`
+	players, err := tql.Query[domain.ArenaPlayer](ctx, q, playersQuery, arenaID)
+	if err != nil {
+		return domain.Arena{}, nil, core.NewCommandError(500, err)
+	}
+
+	return arena, players, nil
+}
@@ -0,0 +1,66 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// GetArenaLeaderboardQuery ranks the players of the arena by their points so far.
+type GetArenaLeaderboardQuery struct {
+	ArenaID uuid.UUID
+}
+
+func (q GetArenaLeaderboardQuery) Validate() error {
+	if q.ArenaID == uuid.Nil {
+		return fmt.Errorf("invalid ArenaID - '%s'", q.ArenaID)
+	}
+
+	return nil
+}
+
+func HandleGetArenaLeaderboard(w http.ResponseWriter, r *http.Request) {
+	arenaID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetArenaLeaderboardQuery, []domain.ArenaStanding](
+		r.Context(),
+		GetArenaLeaderboardQuery{ArenaID: arenaID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetArenaLeaderboardQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetArenaLeaderboardQueryHandler(db *sql.DB) *GetArenaLeaderboardQueryHandler {
+	return &GetArenaLeaderboardQueryHandler{db}
+}
+
+func (h *GetArenaLeaderboardQueryHandler) Handle(
+	ctx context.Context,
+	request GetArenaLeaderboardQuery,
+) ([]domain.ArenaStanding, error) {
+	_, players, err := getArena(ctx, h.db, request.ArenaID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.Leaderboard(players), nil
+}
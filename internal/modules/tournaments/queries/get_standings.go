@@ -0,0 +1,64 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/google/uuid"
+)
+
+// GetStandingsQuery ranks the players of the tournament by the results so far,
+// with the tie-breaks of the tournament's format.
+type GetStandingsQuery struct {
+	TournamentID uuid.UUID
+}
+
+func (q GetStandingsQuery) Validate() error {
+	if q.TournamentID == uuid.Nil {
+		return fmt.Errorf("invalid TournamentID - '%s'", q.TournamentID)
+	}
+
+	return nil
+}
+
+func HandleGetStandings(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetStandingsQuery, []domain.Standing](
+		r.Context(),
+		GetStandingsQuery{TournamentID: tournamentID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetStandingsQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetStandingsQueryHandler(db *sql.DB) *GetStandingsQueryHandler {
+	return &GetStandingsQueryHandler{db}
+}
+
+func (h *GetStandingsQueryHandler) Handle(ctx context.Context, request GetStandingsQuery) ([]domain.Standing, error) {
+	tournament, players, pairings, err := getTournament(ctx, h.db, request.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.Standings(tournament, players, pairings), nil
+}
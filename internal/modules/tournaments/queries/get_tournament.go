@@ -0,0 +1,126 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetTournamentQuery returns the tournament with its players, in seed order,
+// and the pairings of the rounds so far.
+type GetTournamentQuery struct {
+	TournamentID uuid.UUID
+}
+
+func (q GetTournamentQuery) Validate() error {
+	if q.TournamentID == uuid.Nil {
+		return fmt.Errorf("invalid TournamentID - '%s'", q.TournamentID)
+	}
+
+	return nil
+}
+
+type TournamentDetails struct {
+	Tournament domain.Tournament
+	Players    []domain.Player
+	Pairings   []domain.Pairing
+}
+
+func HandleGetTournament(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		core.WriteBadRequest(w, r, fmt.Errorf("invalid format for path param 'id'"))
+		return
+	}
+
+	response, err := mediator.Send[GetTournamentQuery, TournamentDetails](
+		r.Context(),
+		GetTournamentQuery{TournamentID: tournamentID},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetTournamentQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetTournamentQueryHandler(db *sql.DB) *GetTournamentQueryHandler {
+	return &GetTournamentQueryHandler{db}
+}
+
+func (h *GetTournamentQueryHandler) Handle(ctx context.Context, request GetTournamentQuery) (TournamentDetails, error) {
+	tournament, players, pairings, err := getTournament(ctx, h.db, request.TournamentID)
+	if err != nil {
+		return TournamentDetails{}, err
+	}
+
+	return TournamentDetails{
+		Tournament: tournament,
+		Players:    domain.Seeded(players),
+		Pairings:   pairings,
+	}, nil
+}
+
+// getTournament loads the tournament with its players and pairings.
+func getTournament(
+	ctx context.Context,
+	q tql.Querier,
+	tournamentID uuid.UUID,
+) (domain.Tournament, []domain.Player, []domain.Pairing, error) {
+	const query = `
+		SELECT
+			*
+		FROM
+			tournament
+		WHERE
+			id = $1;`
+	tournament, err := tql.QueryFirst[domain.Tournament](ctx, q, query, tournamentID)
+	switch {
+	case err != nil && errors.Is(err, sql.ErrNoRows):
+		return domain.Tournament{}, nil, nil, core.NewCommandError(404, err)
+	case err != nil:
+		return domain.Tournament{}, nil, nil, core.NewCommandError(500, err)
+	}
+
+	const playersQuery = `
+		SELECT
+			*
+		FROM
+			tournament_player
+		WHERE
+			tournament_id = $1;`
+	players, err := tql.Query[domain.Player](ctx, q, playersQuery, tournamentID)
+	if err != nil {
+		return domain.Tournament{}, nil, nil, core.NewCommandError(500, err)
+	}
+
+	const pairingsQuery = `
+		SELECT
+			*
+		FROM
+			tournament_pairing
+		WHERE
+			tournament_id = $1
+		ORDER BY
+			round, board;`
+	pairings, err := tql.Query[domain.Pairing](ctx, q, pairingsQuery, tournamentID)
+	if err != nil {
+		return domain.Tournament{}, nil, nil, core.NewCommandError(500, err)
+	}
+
+	return tournament, players, pairings, nil
+}
@@ -0,0 +1,125 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/mediator-go"
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+)
+
+// GetTournamentsQuery pages through the tournaments with the status, newest first.
+type GetTournamentsQuery struct {
+	Status domain.Status
+	Page   core.PageRequest
+}
+
+func (q GetTournamentsQuery) Validate() error {
+	switch q.Status {
+	case domain.Registering, domain.Running, domain.Finished:
+	default:
+		return fmt.Errorf("invalid Status - '%s'", q.Status)
+	}
+
+	return q.Page.Validate()
+}
+
+// tournamentCursor holds the sort keys of the last tournament on a page and the
+// status it was made for, so it is not used to continue another list.
+type tournamentCursor struct {
+	Status       domain.Status
+	CreatedAt    time.Time
+	TournamentID uuid.UUID
+}
+
+// HandleGetTournaments lists the tournaments, the ones open for registration unless
+// the 'status' query param asks for the running or finished ones.
+func HandleGetTournaments(w http.ResponseWriter, r *http.Request) {
+	page, err := core.ParsePageRequest(r)
+	if err != nil {
+		core.WriteBadRequest(w, r, err)
+		return
+	}
+
+	status := domain.Registering
+	if param := r.URL.Query().Get("status"); param != "" {
+		status = domain.Status(param)
+	}
+
+	response, err := mediator.Send[GetTournamentsQuery, core.Page[domain.Tournament]](
+		r.Context(),
+		GetTournamentsQuery{Status: status, Page: page},
+	)
+	if err != nil {
+		core.WriteCommandError(w, r, err)
+		return
+	}
+
+	core.WriteOK(w, r, response)
+}
+
+type GetTournamentsQueryHandler struct {
+	db *sql.DB
+}
+
+func NewGetTournamentsQueryHandler(db *sql.DB) *GetTournamentsQueryHandler {
+	return &GetTournamentsQueryHandler{db}
+}
+
+func (h *GetTournamentsQueryHandler) Handle(
+	ctx context.Context,
+	request GetTournamentsQuery,
+) (core.Page[domain.Tournament], error) {
+	// The first page starts after a cursor past every tournament.
+	cursor := tournamentCursor{CreatedAt: time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if request.Page.Cursor != "" {
+		decoded, err := core.DecodeCursor[tournamentCursor](request.Page.Cursor)
+		if err != nil {
+			return core.Page[domain.Tournament]{}, core.NewCommandError(400, err)
+		}
+		if decoded.Status != request.Status {
+			return core.Page[domain.Tournament]{}, core.NewCommandError(400, fmt.Errorf("cursor does not match Status '%s'", request.Status))
+		}
+		cursor = decoded
+	}
+
+	const query = `
+		SELECT
+			*
+		FROM
+			tournament
+		WHERE
+			status = $1 AND (created_at, id) < ($2, $3)
+		ORDER BY
+			created_at DESC, id DESC
+		LIMIT
+			$4;`
+	tournaments, err := tql.Query[domain.Tournament](
+		ctx,
+		h.db,
+		query,
+		request.Status,
+		cursor.CreatedAt,
+		cursor.TournamentID,
+		request.Page.Limit+1,
+	)
+	if err != nil {
+		return core.Page[domain.Tournament]{}, core.NewCommandError(500, err)
+	}
+
+	page, err := core.NewPage(tournaments, request.Page.Limit, func(t domain.Tournament) tournamentCursor {
+		return tournamentCursor{Status: request.Status, CreatedAt: t.CreatedAt, TournamentID: t.ID}
+	})
+	if err != nil {
+		return core.Page[domain.Tournament]{}, core.NewCommandError(500, err)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,30 @@
+package tournaments
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/tql"
+)
+
+// RecordGame scores the tournament pairing or the arena game the game was played for
+// as part of the transaction which ends the game. Games outside of tournaments are left
+// alone, and the round is advanced by the tournament's background job once all of it
+// is scored.
+func RecordGame(ctx context.Context, tx *sql.Tx, result domain.GameResult) error {
+	const stmt = `
+		UPDATE
+			tournament_pairing
+		SET
+			white_score = :white_score,
+			black_score = :black_score
+		WHERE
+			game_id = :game_id AND white_score IS NULL;`
+	if _, err := tql.Exec(ctx, tx, stmt, result); err != nil {
+		return err
+	}
+
+	return recordArenaGame(ctx, tx, result)
+}
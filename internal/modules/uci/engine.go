@@ -0,0 +1,300 @@
+package uci
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEngineClosed = errors.New("engine process has exited")
+	// ErrNoBestMove is returned when the engine ends a search without a move,
+	// which engines do for positions without legal moves.
+	ErrNoBestMove = errors.New("engine returned no best move")
+)
+
+// stopTimeout is how long an engine has to answer a stopped search before it is killed.
+const stopTimeout = time.Second
+
+// Limits bound an engine's search. Without Depth and MoveTime the engine searches
+// until the context is done.
+type Limits struct {
+	Depth    int
+	MoveTime time.Duration
+	// MultiPV is the number of best lines reported, 1 when not set.
+	MultiPV int
+}
+
+func (l Limits) multiPV() int {
+	return max(l.MultiPV, 1)
+}
+
+// Position is the position an engine searches, the moves in UCI notation played
+// from the FEN's position.
+type Position struct {
+	FEN   string
+	Moves []string
+	// Chess960 makes the engine read and write castling as the king taking its rook.
+	Chess960 bool
+}
+
+// Analysis is the result of a search: the move the engine would play and its best
+// lines, best first.
+type Analysis struct {
+	BestMove string
+	Lines    []Line
+}
+
+// Engine is a UCI engine running as a subprocess. It is not safe for concurrent
+// use, a search has to finish before the next one starts.
+type Engine struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan string
+	// err is set once the engine misbehaved and has to be closed.
+	err     error
+	options map[string]string
+}
+
+// Start runs the engine binary at the path and completes the UCI handshake.
+func Start(ctx context.Context, path string) (*Engine, error) {
+	cmd := exec.Command(path)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start engine '%s': %w", path, err)
+	}
+
+	e := &Engine{
+		cmd:     cmd,
+		stdin:   stdin,
+		lines:   make(chan string, 64),
+		options: make(map[string]string),
+	}
+
+	// The engine's output is read in the background, so reads can be abandoned
+	// when the context is done.
+	go func() {
+		defer close(e.lines)
+
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			e.lines <- scanner.Text()
+		}
+	}()
+
+	handshake := e.send("uci")
+	if handshake == nil {
+		handshake = e.readUntil(ctx, "uciok", nil)
+	}
+
+	if handshake != nil {
+		_ = e.Close()
+		return nil, fmt.Errorf("engine '%s' failed the UCI handshake: %w", path, handshake)
+	}
+
+	return e, nil
+}
+
+// Err is the error which broke the engine, nil while it works.
+func (e *Engine) Err() error {
+	return e.err
+}
+
+// Close asks the engine to quit and kills it if it does not.
+func (e *Engine) Close() error {
+	_ = e.send("quit")
+	_ = e.stdin.Close()
+
+	// Output nobody reads anymore would block the reader.
+	go func() {
+		for range e.lines {
+		}
+	}()
+
+	exited := make(chan struct{})
+	go func() {
+		_ = e.cmd.Wait()
+		close(exited)
+	}()
+
+	select {
+	case <-exited:
+		return nil
+	case <-time.After(stopTimeout):
+		return e.cmd.Process.Kill()
+	}
+}
+
+// NewGame tells the engine the next searches are from a different game than
+// the last ones, so it can clear what it learned from them.
+func (e *Engine) NewGame(ctx context.Context) error {
+	if err := e.send("ucinewgame"); err != nil {
+		return e.fail(err)
+	}
+	return e.ready(ctx)
+}
+
+// Analyze searches the position within the limits. When the context is done the
+// search is stopped and its result so far returned with the context's error.
+func (e *Engine) Analyze(ctx context.Context, position Position, limits Limits) (Analysis, error) {
+	if e.err != nil {
+		return Analysis{}, e.err
+	}
+
+	if err := e.setOption("UCI_Chess960", fmt.Sprint(position.Chess960)); err != nil {
+		return Analysis{}, err
+	}
+
+	if err := e.setOption("MultiPV", fmt.Sprint(limits.multiPV())); err != nil {
+		return Analysis{}, err
+	}
+
+	if err := e.ready(ctx); err != nil {
+		return Analysis{}, err
+	}
+
+	command := "position fen " + position.FEN
+	if len(position.Moves) > 0 {
+		command += " moves " + strings.Join(position.Moves, " ")
+	}
+	if err := e.send(command); err != nil {
+		return Analysis{}, e.fail(err)
+	}
+
+	command = "go"
+	if limits.Depth > 0 {
+		command += fmt.Sprintf(" depth %d", limits.Depth)
+	}
+	if limits.MoveTime > 0 {
+		command += fmt.Sprintf(" movetime %d", limits.MoveTime.Milliseconds())
+	}
+	if limits.Depth <= 0 && limits.MoveTime <= 0 {
+		// Only stopping the search or the context ends it.
+		command += " infinite"
+	}
+	if err := e.send(command); err != nil {
+		return Analysis{}, e.fail(err)
+	}
+
+	lines := make([]Line, limits.multiPV())
+	var bestMove string
+
+	collect := func(text string) {
+		if line, ok := ParseInfo(text); ok && line.MultiPV <= len(lines) {
+			lines[line.MultiPV-1] = line
+			return
+		}
+
+		if fields := strings.Fields(text); len(fields) > 1 && fields[0] == "bestmove" {
+			bestMove = fields[1]
+		}
+	}
+
+	searchErr := e.readUntil(ctx, "bestmove", collect)
+	if searchErr != nil && ctx.Err() != nil && e.err == nil {
+		// The engine still has to answer the stop, or it would answer the next search with it.
+		stopCtx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+		defer cancel()
+
+		if err := e.send("stop"); err != nil {
+			return Analysis{}, e.fail(err)
+		}
+
+		if err := e.readUntil(stopCtx, "bestmove", collect); err != nil {
+			return Analysis{}, e.fail(err)
+		}
+	} else if searchErr != nil {
+		return Analysis{}, e.fail(searchErr)
+	}
+
+	analysis := Analysis{BestMove: bestMove}
+	for _, line := range lines {
+		if len(line.PV) > 0 {
+			analysis.Lines = append(analysis.Lines, line)
+		}
+	}
+
+	if bestMove == "" || bestMove == "(none)" || bestMove == "0000" {
+		return analysis, ErrNoBestMove
+	}
+
+	return analysis, searchErr
+}
+
+// setOption changes an option of the engine, unless it already has the value.
+func (e *Engine) setOption(name, value string) error {
+	if e.options[name] == value {
+		return nil
+	}
+
+	if err := e.send(fmt.Sprintf("setoption name %s value %s", name, value)); err != nil {
+		return e.fail(err)
+	}
+
+	e.options[name] = value
+	return nil
+}
+
+// ready waits for the engine to process the commands sent to it.
+func (e *Engine) ready(ctx context.Context) error {
+	if err := e.send("isready"); err != nil {
+		return e.fail(err)
+	}
+
+	if err := e.readUntil(ctx, "readyok", nil); err != nil {
+		return e.fail(err)
+	}
+
+	return nil
+}
+
+func (e *Engine) send(command string) error {
+	_, err := io.WriteString(e.stdin, command+"\n")
+	return err
+}
+
+// readUntil reads the engine's output up to the line starting with the token,
+// passing every line read, including the last one, to fn.
+func (e *Engine) readUntil(ctx context.Context, token string, fn func(string)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case text, ok := <-e.lines:
+			if !ok {
+				return ErrEngineClosed
+			}
+
+			if fn != nil {
+				fn(text)
+			}
+
+			if text == token || strings.HasPrefix(text, token+" ") {
+				return nil
+			}
+		}
+	}
+}
+
+// fail marks the engine as broken, its state is unknown after the error.
+func (e *Engine) fail(err error) error {
+	if e.err == nil {
+		e.err = err
+	}
+	return err
+}
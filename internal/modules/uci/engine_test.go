@@ -0,0 +1,165 @@
+package uci
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+
+	"github.com/stretchr/testify/require"
+)
+
+const fakeEnginePath = "testdata/fake_engine.sh"
+
+func startFakeEngine(t *testing.T) *Engine {
+	e, err := Start(context.Background(), fakeEnginePath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = e.Close() })
+
+	return e
+}
+
+func Test_ParseInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		line Line
+		ok   bool
+	}{
+		{
+			"centipawns",
+			"info depth 20 seldepth 28 multipv 1 score cp 31 nodes 1563294 nps 1283000 hashfull 640 tbhits 0 time 1218 pv e2e4 e7e5 g1f3",
+			Line{MultiPV: 1, Depth: 20, Score: Score{Centipawns: 31}, PV: []string{"e2e4", "e7e5", "g1f3"}},
+			true,
+		},
+		{
+			"mate with multipv",
+			"info depth 5 multipv 2 score mate -3 wdl 0 0 1000 pv g8h8 a1a8",
+			Line{MultiPV: 2, Depth: 5, Score: Score{Mate: -3}, PV: []string{"g8h8", "a1a8"}},
+			true,
+		},
+		{"bound", "info depth 20 multipv 1 score cp 40 lowerbound nodes 100 pv e2e4", Line{}, false},
+		{"no pv", "info depth 1 currmove e2e4 currmovenumber 1", Line{}, false},
+		{"string", "info string NNUE evaluation enabled", Line{}, false},
+		{"not info", "bestmove e2e4 ponder e7e5", Line{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			line, ok := ParseInfo(tt.text)
+
+			// Assert
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.line, line)
+		})
+	}
+}
+
+func Test_Score_Value_Orders_Mates(t *testing.T) {
+	require.Greater(t, Score{Mate: 1}.Value(), Score{Mate: 2}.Value())
+	require.Greater(t, Score{Mate: 2}.Value(), Score{Centipawns: 5000}.Value())
+	require.Less(t, Score{Mate: -1}.Value(), Score{Mate: -2}.Value())
+	require.Equal(t, Score{Mate: -2}, Score{Mate: 2}.Negate())
+}
+
+func Test_Engine_Analyze_Reports_Lines(t *testing.T) {
+	// Arrange
+	e := startFakeEngine(t)
+
+	// Act
+	analysis, err := e.Analyze(
+		context.Background(),
+		Position{FEN: chess.StartingFEN, Moves: []string{"e2e4", "e7e5"}},
+		Limits{Depth: 10, MultiPV: 2},
+	)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "g1f3", analysis.BestMove)
+	require.Equal(t, []Line{
+		{MultiPV: 1, Depth: 10, Score: Score{Centipawns: 30}, PV: []string{"g1f3"}},
+		{MultiPV: 2, Depth: 10, Score: Score{Centipawns: -500}, PV: []string{"d1h5"}},
+	}, analysis.Lines)
+}
+
+func Test_Engine_Analyze_Only_Keeps_Requested_Lines(t *testing.T) {
+	// Arrange
+	e := startFakeEngine(t)
+
+	// Act
+	analysis, err := e.Analyze(context.Background(), Position{FEN: chess.StartingFEN}, Limits{MoveTime: time.Second})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, analysis.Lines, 1)
+	require.Equal(t, Score{Centipawns: 30}, analysis.Lines[0].Score)
+}
+
+func Test_Engine_Analyze_Stops_When_Context_Is_Done(t *testing.T) {
+	// Arrange
+	e := startFakeEngine(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	analysis, err := e.Analyze(ctx, Position{FEN: chess.StartingFEN}, Limits{})
+
+	// Assert
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, "e2e4", analysis.BestMove)
+	require.NoError(t, e.Err())
+
+	// The engine is still usable after the stop.
+	analysis, err = e.Analyze(context.Background(), Position{FEN: chess.StartingFEN, Moves: []string{"e2e4"}}, Limits{Depth: 1})
+	require.NoError(t, err)
+	require.Equal(t, "e7e5", analysis.BestMove)
+}
+
+func Test_Start_Fails_For_Missing_Binary(t *testing.T) {
+	// Act
+	_, err := Start(context.Background(), "testdata/missing")
+
+	// Assert
+	require.Error(t, err)
+}
+
+func Test_Pool_Reuses_Engines(t *testing.T) {
+	// Arrange
+	pool := NewPool(fakeEnginePath, 1, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(pool.Close)
+
+	first, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+
+	// The only engine is taken, so acquiring waits until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = pool.Acquire(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	pool.Release(first)
+
+	// Act
+	second, err := pool.Acquire(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Same(t, first, second)
+	pool.Release(second)
+}
+
+func Test_Pool_Without_Path_Is_Not_Configured(t *testing.T) {
+	// Arrange
+	pool := NewPool("", 1, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Act
+	_, err := pool.Acquire(context.Background())
+
+	// Assert
+	require.False(t, pool.Configured())
+	require.ErrorIs(t, err, ErrNotConfigured)
+}
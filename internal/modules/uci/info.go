@@ -0,0 +1,118 @@
+package uci
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Score is an engine's evaluation from the side to move's point of view, either
+// in centipawns or, when Mate is set, as a forced mate in Mate moves. A negative
+// Mate means the side to move gets mated.
+type Score struct {
+	Centipawns int
+	Mate       int
+}
+
+// MateCentipawns stands in for a mate when scores are compared in centipawns,
+// it is the value of a position in which the side to move is checkmated.
+const MateCentipawns = 100_000
+
+// IsMate reports whether the score is a forced mate.
+func (s Score) IsMate() bool {
+	return s.Mate != 0
+}
+
+// Negate turns the score around to the other side's point of view.
+func (s Score) Negate() Score {
+	return Score{Centipawns: -s.Centipawns, Mate: -s.Mate}
+}
+
+// Value is the score in centipawns, with mates counted as more than any evaluation
+// and sooner mates as more than later ones.
+func (s Score) Value() int {
+	switch {
+	case s.Mate > 0:
+		return MateCentipawns - s.Mate
+	case s.Mate < 0:
+		return -MateCentipawns - s.Mate
+	default:
+		return s.Centipawns
+	}
+}
+
+// Line is one of the principal variations an engine reports, MultiPV 1 being the best.
+type Line struct {
+	MultiPV int
+	Depth   int
+	Score   Score
+	// PV are the moves of the variation in UCI notation.
+	PV []string
+}
+
+// ParseInfo reads a line of an engine's "info" output. Only lines with a score and
+// a principal variation are reported. Scores which are only bounds, sent when the
+// engine's search window failed, are not.
+func ParseInfo(text string) (Line, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || fields[0] != "info" {
+		return Line{}, false
+	}
+
+	line := Line{MultiPV: 1}
+	var scored bool
+
+	for i := 1; i < len(fields); i++ {
+		switch fields[i] {
+		case "depth":
+			line.Depth, i = intField(fields, i)
+		case "multipv":
+			line.MultiPV, i = intField(fields, i)
+		case "score":
+			if i+2 >= len(fields) {
+				return Line{}, false
+			}
+
+			value, err := strconv.Atoi(fields[i+2])
+			if err != nil {
+				return Line{}, false
+			}
+
+			switch fields[i+1] {
+			case "cp":
+				line.Score = Score{Centipawns: value}
+			case "mate":
+				line.Score = Score{Mate: value}
+			default:
+				return Line{}, false
+			}
+
+			scored = true
+			i += 2
+		case "lowerbound", "upperbound":
+			return Line{}, false
+		case "pv":
+			// The variation runs to the end of the line.
+			line.PV = fields[i+1:]
+			i = len(fields)
+		case "string":
+			// Free text runs to the end of the line.
+			i = len(fields)
+		}
+	}
+
+	if !scored || len(line.PV) == 0 {
+		return Line{}, false
+	}
+
+	return line, true
+}
+
+// intField parses the value following the field at i, returning the index of the value.
+func intField(fields []string, i int) (int, int) {
+	if i+1 >= len(fields) {
+		return 0, i
+	}
+
+	value, _ := strconv.Atoi(fields[i+1])
+	return value, i + 1
+}
@@ -0,0 +1,101 @@
+package uci
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+var ErrNotConfigured = errors.New("no UCI engine is configured")
+
+// Pool hands out engine processes of the same binary, running at most size of them.
+// Processes are started when needed and kept running between searches. Processes
+// which broke are closed when they are released and replaced by new ones.
+type Pool struct {
+	path   string
+	logger *slog.Logger
+	// slots holds a token for every engine handed out.
+	slots chan struct{}
+
+	mu     sync.Mutex
+	idle   []*Engine
+	closed bool
+}
+
+// NewPool creates a pool of the engine binary at the path. Without a path no engine
+// is configured and Acquire returns ErrNotConfigured.
+func NewPool(path string, size int, logger *slog.Logger) *Pool {
+	return &Pool{
+		path:   path,
+		logger: logger,
+		slots:  make(chan struct{}, max(size, 1)),
+	}
+}
+
+// Configured reports whether the pool has an engine binary to run.
+func (p *Pool) Configured() bool {
+	return p.path != ""
+}
+
+// Acquire waits for a free engine. It has to be released once the caller is done with it.
+func (p *Pool) Acquire(ctx context.Context) (*Engine, error) {
+	if !p.Configured() {
+		return nil, ErrNotConfigured
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case p.slots <- struct{}{}:
+	}
+
+	p.mu.Lock()
+	if n := len(p.idle); n > 0 {
+		e := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mu.Unlock()
+		return e, nil
+	}
+	p.mu.Unlock()
+
+	e, err := Start(ctx, p.path)
+	if err != nil {
+		<-p.slots
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Release returns the engine to the pool.
+func (p *Pool) Release(e *Engine) {
+	defer func() { <-p.slots }()
+
+	p.mu.Lock()
+	if e.Err() == nil && !p.closed {
+		p.idle = append(p.idle, e)
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+
+	if err := e.Close(); err != nil {
+		p.logger.Error("failed to close UCI engine", "error", err)
+	}
+}
+
+// Close stops the idle engines. Engines still in use are stopped when they are released.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.closed = true
+	p.mu.Unlock()
+
+	for _, e := range idle {
+		if err := e.Close(); err != nil {
+			p.logger.Error("failed to close UCI engine", "error", err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+#!/bin/sh
+# A fake UCI engine for tests. Its answers only depend on how many moves were
+# played from the FEN, they follow the scholar's mate:
+# 1. e4 e5 2. Qh5 Nc6 3. Bc4 Nf6 4. Qxf7#
+moves=0
+while read -r command rest; do
+	case "$command" in
+	uci)
+		echo "id name Fake"
+		echo "option name MultiPV type spin default 1 min 1 max 500"
+		echo "uciok"
+		;;
+	isready)
+		echo "readyok"
+		;;
+	position)
+		moves=0
+		counting=0
+		for word in $rest; do
+			if [ "$counting" = 1 ]; then
+				moves=$((moves + 1))
+			fi
+			if [ "$word" = "moves" ]; then
+				counting=1
+			fi
+		done
+		;;
+	go)
+		case "$moves" in
+		0) score="cp 30"; best="e2e4"; second="d2d4" ;;
+		1) score="cp -30"; best="e7e5"; second="c7c5" ;;
+		2) score="cp 30"; best="g1f3"; second="d1h5" ;;
+		3) score="cp 0"; best="b8c6"; second="g7g6" ;;
+		4) score="cp 20"; best="f1c4"; second="g1f3" ;;
+		5) score="cp -20"; best="g7g6"; second="d8e7" ;;
+		*) score="mate 1"; best="h5f7"; second="c4f7" ;;
+		esac
+		echo "info depth 1 seldepth 1 multipv 1 score cp 10 nodes 20 pv $second"
+		echo "info depth 10 seldepth 14 multipv 1 score $score nodes 1000 nps 100000 time 10 pv $best"
+		echo "info depth 10 seldepth 14 multipv 2 score cp -500 nodes 1000 nps 100000 time 10 pv $second"
+		# Infinite searches only end when they are stopped.
+		if [ "$rest" != "infinite" ]; then
+			echo "bestmove $best"
+		fi
+		;;
+	stop)
+		echo "bestmove $best"
+		;;
+	quit)
+		exit 0
+		;;
+	esac
+done
@@ -5,23 +5,54 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/smtp"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/eskrenkovic/vertical-slice-go/internal/config"
+	analysisqueries "github.com/eskrenkovic/vertical-slice-go/internal/modules/analysis/queries"
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/auth"
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/auth/commands"
 	authcommands "github.com/eskrenkovic/vertical-slice-go/internal/modules/auth/commands"
 	authdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/auth/domain"
+	authqueries "github.com/eskrenkovic/vertical-slice-go/internal/modules/auth/queries"
+	chatcommands "github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/commands"
+	chatdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	chatqueries "github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/queries"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
 	gamesessioncommands "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
 	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	gamesessionlive "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/live"
 	gamesessionqueries "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/queries"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	notificationslive "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/live"
+	notificationsqueries "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/queries"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/presence"
+	presencedomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/presence/domain"
+	presencequeries "github.com/eskrenkovic/vertical-slice-go/internal/modules/presence/queries"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles"
+	profilescommands "github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles/commands"
+	profilesdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles/domain"
+	profilesqueries "github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles/queries"
+	ratingsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings/domain"
+	ratingsqueries "github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings/queries"
+	socialcommands "github.com/eskrenkovic/vertical-slice-go/internal/modules/social/commands"
+	socialdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+	socialqueries "github.com/eskrenkovic/vertical-slice-go/internal/modules/social/queries"
+	tournamentscommands "github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/commands"
+	tournamentsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+	tournamentslive "github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/live"
+	tournamentsqueries "github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/queries"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/uci"
 
 	"github.com/eskrenkovic/mediator-go"
 	"github.com/eskrenkovic/migrate-go"
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 )
 
@@ -35,77 +66,890 @@ var _ Server = &HTTPServer{}
 // HTTPServer acts as the composition root for an application.
 type HTTPServer struct {
 	server *http.Server
+	logger *slog.Logger
+
+	jobs       []core.Job
+	pubSub     *core.PubSub
+	botPool    *core.WorkerPool
+	engines    *uci.Pool
+	cancelJobs context.CancelFunc
 }
 
-func NewHTTPServer(config config.Config) (Server, error) {
-	baseCtx := context.Background()
+func NewHTTPServer(config config.Config) (Server, error) {
+	baseCtx := context.Background()
+
+	server := http.Server{
+		Addr: net.JoinHostPort("", "8080"),
+	}
+
+	db, err := sql.Open("postgres", config.DatabaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrate.Run(baseCtx, db, config.MigrationsPath); err != nil {
+		return nil, err
+	}
+
+	requestLoggingBehavior := core.RequestLoggingBehavior{Logger: config.Logger}
+	handlerErrorLoggingBehavior := core.HandlerErrorLoggingBehavior{Logger: config.Logger}
+	requestValidationBehavior := core.RequestValidationBehavior{}
+
+	mediator.RegisterPipelineBehavior(&requestLoggingBehavior)
+	mediator.RegisterPipelineBehavior(&handlerErrorLoggingBehavior)
+	mediator.RegisterPipelineBehavior(&requestValidationBehavior)
+
+	pubSub := core.NewPubSub(db, config.DatabaseURL, config.Logger)
+	clock := core.SystemClock{}
+	presenceTracker := presence.NewTracker(db, clock)
+	avatars := profiles.NewAvatarStore(config.Profiles.AvatarsPath)
+
+	// The computer's searches are CPU bound, half the cores are left for the requests.
+	botWorkers := max(1, runtime.NumCPU()/2)
+	botPool := core.NewWorkerPool(botWorkers, botWorkers, config.Logger)
+
+	engines := uci.NewPool(config.UCIEngine.Path, config.UCIEngine.PoolSize, config.Logger)
+
+	authHost := config.Email.Host.Host
+	parts := strings.Split(authHost, ":")
+	if len(parts) > 1 {
+		authHost = parts[0]
+	}
+
+	smtpServerAuth := smtp.PlainAuth("", config.Email.Username, config.Email.Password, authHost)
+	emailClient := core.NewEmailClient(config.Email.Host, smtpServerAuth)
+
+	// handler registration
+
+	// game-session
+
+	createGameSessionHandler := gamesessioncommands.NewCreateSessionCommandHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.CreateSessionCommand, gamesessioncommands.CreateSessionResponse](
+		createGameSessionHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	closeSessionHandler := gamesessioncommands.NewCloseSessionCommandHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.CloseSessionCommand, core.Unit](
+		closeSessionHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getOwnedSessionsHandler := gamesessionqueries.NewGetOwnedSessionsQueryHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetOwnedSessionsQuery, []gamesessiondomain.Session](
+		getOwnedSessionsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getLobbyHandler := gamesessionqueries.NewGetLobbyQueryHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetLobbyQuery, core.Page[gamesessionqueries.LobbySession]](
+		getLobbyHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	createSessionInvitationHandler := gamesessioncommands.NewCreateSessionInvitationCommandHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.CreateSessionInvitationCommand, gamesessioncommands.CreateSessionInvitationResponse](
+		createSessionInvitationHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	acceptInvitationHandler := gamesessioncommands.NewAcceptInvitationCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.AcceptInvitationCommand, gamesessioncommands.AcceptInvitationResponse](
+		acceptInvitationHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	declineInvitationHandler := gamesessioncommands.NewDeclineInvitationCommandHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.DeclineInvitationCommand, core.Unit](
+		declineInvitationHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelInvitationHandler := gamesessioncommands.NewCancelInvitationCommandHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.CancelInvitationCommand, core.Unit](
+		cancelInvitationHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	expireInvitationsHandler := gamesessioncommands.NewExpireInvitationsCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.ExpireInvitationsCommand, core.Unit](
+		expireInvitationsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sendEmailInvitationsHandler := gamesessioncommands.NewSendEmailInvitationsCommandHandler(
+		db,
+		emailClient,
+		config.Email.Sender,
+		clock,
+	)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.SendEmailInvitationsCommand, core.Unit](
+		sendEmailInvitationsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sendMoveRemindersHandler := gamesessioncommands.NewSendMoveRemindersCommandHandler(
+		db,
+		emailClient,
+		config.Email.Sender,
+		clock,
+	)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.SendMoveRemindersCommand, core.Unit](
+		sendMoveRemindersHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	startVacationHandler := gamesessioncommands.NewStartVacationCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.StartVacationCommand, gamesessiondomain.Vacation](
+		startVacationHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getVacationsHandler := gamesessionqueries.NewGetVacationsQueryHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetVacationsQuery, gamesessionqueries.GetVacationsResponse](
+		getVacationsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	offerRematchHandler := gamesessioncommands.NewOfferRematchCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.OfferRematchCommand, gamesessiondomain.RematchOffer](
+		offerRematchHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	acceptRematchHandler := gamesessioncommands.NewAcceptRematchCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.AcceptRematchCommand, gamesessioncommands.AcceptRematchResponse](
+		acceptRematchHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	declineRematchHandler := gamesessioncommands.NewDeclineRematchCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.DeclineRematchCommand, core.Unit](
+		declineRematchHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	expireRematchOffersHandler := gamesessioncommands.NewExpireRematchOffersCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.ExpireRematchOffersCommand, core.Unit](
+		expireRematchOffersHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getSeriesHandler := gamesessionqueries.NewGetSeriesQueryHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetSeriesQuery, gamesessiondomain.SeriesScore](
+		getSeriesHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	convertEmailInvitationsHandler := gamesessioncommands.NewConvertEmailInvitationsCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.ConvertEmailInvitationsCommand, core.Unit](
+		convertEmailInvitationsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	createInviteLinkHandler := gamesessioncommands.NewCreateInviteLinkCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.CreateInviteLinkCommand, gamesessioncommands.CreateInviteLinkResponse](
+		createInviteLinkHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	redeemInviteLinkHandler := gamesessioncommands.NewRedeemInviteLinkCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.RedeemInviteLinkCommand, gamesessioncommands.RedeemInviteLinkResponse](
+		redeemInviteLinkHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getInvitationHandler := gamesessionqueries.NewGetInvitationQueryHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetInvitationQuery, gamesessiondomain.SessionInvitation](
+		getInvitationHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getReceivedInvitationsHandler := gamesessionqueries.NewGetReceivedInvitationsQueryHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetReceivedInvitationsQuery, []gamesessiondomain.SessionInvitation](
+		getReceivedInvitationsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getSentInvitationsHandler := gamesessionqueries.NewGetSentInvitationsQueryHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetSentInvitationsQuery, []gamesessiondomain.SessionInvitation](
+		getSentInvitationsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	joinSessionHandler := gamesessioncommands.NewJoinSessionCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.JoinSessionCommand, gamesessioncommands.JoinSessionResponse](
+		joinSessionHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	makeMoveHandler := gamesessioncommands.NewMakeMoveCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.MakeMoveCommand, gamesessioncommands.MakeMoveResponse](
+		makeMoveHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resignHandler := gamesessioncommands.NewResignCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.ResignCommand, gamesessioncommands.GameActionResponse](
+		resignHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	berserkHandler := gamesessioncommands.NewBerserkCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.BerserkCommand, gamesessioncommands.GameActionResponse](
+		berserkHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	offerDrawHandler := gamesessioncommands.NewOfferDrawCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.OfferDrawCommand, gamesessioncommands.GameActionResponse](
+		offerDrawHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	acceptDrawHandler := gamesessioncommands.NewAcceptDrawCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.AcceptDrawCommand, gamesessioncommands.GameActionResponse](
+		acceptDrawHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	declineDrawHandler := gamesessioncommands.NewDeclineDrawCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.DeclineDrawCommand, gamesessioncommands.GameActionResponse](
+		declineDrawHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	requestTakebackHandler := gamesessioncommands.NewRequestTakebackCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.RequestTakebackCommand, gamesessioncommands.GameActionResponse](
+		requestTakebackHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	acceptTakebackHandler := gamesessioncommands.NewAcceptTakebackCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.AcceptTakebackCommand, gamesessioncommands.GameActionResponse](
+		acceptTakebackHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	declineTakebackHandler := gamesessioncommands.NewDeclineTakebackCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.DeclineTakebackCommand, gamesessioncommands.GameActionResponse](
+		declineTakebackHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claimDrawHandler := gamesessioncommands.NewClaimDrawCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.ClaimDrawCommand, gamesessioncommands.GameActionResponse](
+		claimDrawHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getGameEventsHandler := gamesessionqueries.NewGetGameEventsQueryHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetGameEventsQuery, gamesessionqueries.GetGameEventsResponse](
+		getGameEventsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	spectateHandler := gamesessioncommands.NewSpectateCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.SpectateCommand, gamesessioncommands.SpectateResponse](
+		spectateHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stopSpectatingHandler := gamesessioncommands.NewStopSpectatingCommandHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.StopSpectatingCommand, core.Unit](
+		stopSpectatingHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getGamePGNHandler := gamesessionqueries.NewGetGamePGNQueryHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetGamePGNQuery, chess.PGNGame](
+		getGamePGNHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getPlayerGamesPGNHandler := gamesessionqueries.NewGetPlayerGamesPGNQueryHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetPlayerGamesPGNQuery, core.Page[chess.PGNGame]](
+		getPlayerGamesPGNHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	importPGNHandler := gamesessioncommands.NewImportPGNCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.ImportPGNCommand, gamesessioncommands.ImportPGNResponse](
+		importPGNHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getImportedGameHandler := gamesessionqueries.NewGetImportedGameQueryHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetImportedGameQuery, gamesessiondomain.ImportedGame](
+		getImportedGameHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	processClockFlagsHandler := gamesessioncommands.NewProcessClockFlagsCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.ProcessClockFlagsCommand, core.Unit](
+		processClockFlagsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	abandonmentPolicy := gamesessiondomain.AbandonmentPolicy{
+		InactiveAfter:     config.Abandonment.InactiveAfter,
+		ClaimGrace:        config.Abandonment.ClaimGrace,
+		StaleSessionAfter: config.Abandonment.StaleSessionAfter,
+	}
+	processAbandonedGamesHandler := gamesessioncommands.NewProcessAbandonedGamesCommandHandler(db, clock, abandonmentPolicy)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.ProcessAbandonedGamesCommand, core.Unit](
+		processAbandonedGamesHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claimVictoryHandler := gamesessioncommands.NewClaimVictoryCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.ClaimVictoryCommand, gamesessioncommands.GameActionResponse](
+		claimVictoryHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	enqueueMatchmakingHandler := gamesessioncommands.NewEnqueueMatchmakingCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.EnqueueMatchmakingCommand, gamesessioncommands.EnqueueMatchmakingResponse](
+		enqueueMatchmakingHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	leaveMatchmakingHandler := gamesessioncommands.NewLeaveMatchmakingCommandHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.LeaveMatchmakingCommand, core.Unit](
+		leaveMatchmakingHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getMatchmakingTicketHandler := gamesessionqueries.NewGetMatchmakingTicketQueryHandler(db)
+	err = mediator.RegisterRequestHandler[gamesessionqueries.GetMatchmakingTicketQuery, gamesessiondomain.MatchmakingTicket](
+		getMatchmakingTicketHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	matchPlayersHandler := gamesessioncommands.NewMatchPlayersCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.MatchPlayersCommand, core.Unit](
+		matchPlayersHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	createBotGameHandler := gamesessioncommands.NewCreateBotGameCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.CreateBotGameCommand, gamesessioncommands.CreateBotGameResponse](
+		createBotGameHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	playBotMovesHandler := gamesessioncommands.NewPlayBotMovesCommandHandler(db, botPool, clock)
+	err = mediator.RegisterRequestHandler[gamesessioncommands.PlayBotMovesCommand, core.Unit](
+		playBotMovesHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// analysis
+
+	getPositionHandler := analysisqueries.NewGetPositionQueryHandler()
+	err = mediator.RegisterRequestHandler[analysisqueries.GetPositionQuery, analysisqueries.GetPositionResponse](
+		getPositionHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getGameAnalysisHandler := analysisqueries.NewGetGameAnalysisQueryHandler(db, engines)
+	err = mediator.RegisterRequestHandler[analysisqueries.GetGameAnalysisQuery, analysisqueries.GetGameAnalysisResponse](
+		getGameAnalysisHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// chat
+
+	chatFilter := chatdomain.NewWordFilter(config.Chat.BannedWords)
+	chatRateLimit := chatdomain.RateLimit{Messages: config.Chat.RateLimitMessages, Window: config.Chat.RateLimitWindow}
+
+	sendMessageHandler := chatcommands.NewSendMessageCommandHandler(db, clock, chatFilter, chatRateLimit)
+	err = mediator.RegisterRequestHandler[chatcommands.SendMessageCommand, chatdomain.Message](
+		sendMessageHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getMessagesHandler := chatqueries.NewGetMessagesQueryHandler(db)
+	err = mediator.RegisterRequestHandler[chatqueries.GetMessagesQuery, core.Page[chatdomain.Message]](
+		getMessagesHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	muteUserHandler := chatcommands.NewMuteUserCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[chatcommands.MuteUserCommand, core.Unit](
+		muteUserHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	unmuteUserHandler := chatcommands.NewUnmuteUserCommandHandler(db)
+	err = mediator.RegisterRequestHandler[chatcommands.UnmuteUserCommand, core.Unit](
+		unmuteUserHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getMutedUsersHandler := chatqueries.NewGetMutedUsersQueryHandler(db)
+	err = mediator.RegisterRequestHandler[chatqueries.GetMutedUsersQuery, []uuid.UUID](
+		getMutedUsersHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	server := http.Server{
-		Addr: net.JoinHostPort("", "8080"),
+	reportMessageHandler := chatcommands.NewReportMessageCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[chatcommands.ReportMessageCommand, chatcommands.ReportMessageResponse](
+		reportMessageHandler,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	db, err := sql.Open("postgres", config.DatabaseURL)
+	getReportQueueHandler := chatqueries.NewGetReportQueueQueryHandler(db)
+	err = mediator.RegisterRequestHandler[chatqueries.GetReportQueueQuery, core.Page[chatqueries.QueuedReport]](
+		getReportQueueHandler,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := migrate.Run(baseCtx, db, config.MigrationsPath); err != nil {
+	reviewReportHandler := chatcommands.NewReviewReportCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[chatcommands.ReviewReportCommand, core.Unit](
+		reviewReportHandler,
+	)
+	if err != nil {
 		return nil, err
 	}
 
-	requestLoggingBehavior := core.RequestLoggingBehavior{Logger: config.Logger}
-	handlerErrorLoggingBehavior := core.HandlerErrorLoggingBehavior{Logger: config.Logger}
-	requestValidationBehavior := core.RequestValidationBehavior{}
+	// notifications
 
-	mediator.RegisterPipelineBehavior(&requestLoggingBehavior)
-	mediator.RegisterPipelineBehavior(&handlerErrorLoggingBehavior)
-	mediator.RegisterPipelineBehavior(&requestValidationBehavior)
+	getNotificationsHandler := notificationsqueries.NewGetNotificationsQueryHandler(db)
+	err = mediator.RegisterRequestHandler[notificationsqueries.GetNotificationsQuery, []notificationsdomain.Notification](
+		getNotificationsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	// handler registration
+	getLatestNotificationIDHandler := notificationsqueries.NewGetLatestNotificationIDQueryHandler(db)
+	err = mediator.RegisterRequestHandler[notificationsqueries.GetLatestNotificationIDQuery, int64](
+		getLatestNotificationIDHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	// game-session
+	// ratings
 
-	createGameSessionHandler := gamesessioncommands.NewCreateSessionCommandHandler(db)
-	err = mediator.RegisterRequestHandler[gamesessioncommands.CreateSessionCommand, gamesessioncommands.CreateSessionResponse](
-		createGameSessionHandler,
+	getPlayerRatingsHandler := ratingsqueries.NewGetPlayerRatingsQueryHandler(db, clock)
+	err = mediator.RegisterRequestHandler[ratingsqueries.GetPlayerRatingsQuery, []ratingsdomain.PlayerRating](
+		getPlayerRatingsHandler,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	closeSessionHandler := gamesessioncommands.NewCloseSessionCommandHandler(db)
-	err = mediator.RegisterRequestHandler[gamesessioncommands.CloseSessionCommand, core.Unit](
-		closeSessionHandler,
+	getRatingHistoryHandler := ratingsqueries.NewGetRatingHistoryQueryHandler(db)
+	err = mediator.RegisterRequestHandler[ratingsqueries.GetRatingHistoryQuery, []ratingsdomain.RatingChange](
+		getRatingHistoryHandler,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	getOwnedSessionsHandler := gamesessionqueries.NewGetOwnedSessionsQueryHandler(db)
-	err = mediator.RegisterRequestHandler[gamesessionqueries.GetOwnedSessionsQuery, []gamesessiondomain.Session](
-		getOwnedSessionsHandler,
+	// tournaments
+
+	createTournamentHandler := tournamentscommands.NewCreateTournamentCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[tournamentscommands.CreateTournamentCommand, tournamentscommands.CreateTournamentResponse](
+		createTournamentHandler,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	createSessionInvitationHandler := gamesessioncommands.NewCreateSessionInvitationCommandHandler(db)
-	err = mediator.RegisterRequestHandler[gamesessioncommands.CreateSessionInvitationCommand, core.Unit](
-		createSessionInvitationHandler,
+	registerPlayerHandler := tournamentscommands.NewRegisterPlayerCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[tournamentscommands.RegisterPlayerCommand, core.Unit](
+		registerPlayerHandler,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	// auth
-	authHost := config.Email.Host.Host
-	parts := strings.Split(authHost, ":")
-	if len(parts) > 1 {
-		authHost = parts[0]
+	withdrawPlayerHandler := tournamentscommands.NewWithdrawPlayerCommandHandler(db)
+	err = mediator.RegisterRequestHandler[tournamentscommands.WithdrawPlayerCommand, core.Unit](
+		withdrawPlayerHandler,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	smtpServerAuth := smtp.PlainAuth("", config.Email.Username, config.Email.Password, authHost)
-	emailClient := core.NewEmailClient(config.Email.Host, smtpServerAuth)
+	startTournamentHandler := tournamentscommands.NewStartTournamentCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[tournamentscommands.StartTournamentCommand, core.Unit](
+		startTournamentHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	advanceTournamentsHandler := tournamentscommands.NewAdvanceTournamentsCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[tournamentscommands.AdvanceTournamentsCommand, core.Unit](
+		advanceTournamentsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getTournamentsHandler := tournamentsqueries.NewGetTournamentsQueryHandler(db)
+	err = mediator.RegisterRequestHandler[tournamentsqueries.GetTournamentsQuery, core.Page[tournamentsdomain.Tournament]](
+		getTournamentsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getTournamentHandler := tournamentsqueries.NewGetTournamentQueryHandler(db)
+	err = mediator.RegisterRequestHandler[tournamentsqueries.GetTournamentQuery, tournamentsqueries.TournamentDetails](
+		getTournamentHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getStandingsHandler := tournamentsqueries.NewGetStandingsQueryHandler(db)
+	err = mediator.RegisterRequestHandler[tournamentsqueries.GetStandingsQuery, []tournamentsdomain.Standing](
+		getStandingsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	createArenaHandler := tournamentscommands.NewCreateArenaCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[tournamentscommands.CreateArenaCommand, tournamentscommands.CreateArenaResponse](
+		createArenaHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	joinArenaHandler := tournamentscommands.NewJoinArenaCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[tournamentscommands.JoinArenaCommand, core.Unit](
+		joinArenaHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pauseArenaHandler := tournamentscommands.NewPauseArenaCommandHandler(db)
+	err = mediator.RegisterRequestHandler[tournamentscommands.PauseArenaCommand, core.Unit](
+		pauseArenaHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pairArenasHandler := tournamentscommands.NewPairArenasCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[tournamentscommands.PairArenasCommand, core.Unit](
+		pairArenasHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getArenaHandler := tournamentsqueries.NewGetArenaQueryHandler(db)
+	err = mediator.RegisterRequestHandler[tournamentsqueries.GetArenaQuery, tournamentsqueries.ArenaDetails](
+		getArenaHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getArenaLeaderboardHandler := tournamentsqueries.NewGetArenaLeaderboardQueryHandler(db)
+	err = mediator.RegisterRequestHandler[tournamentsqueries.GetArenaLeaderboardQuery, []tournamentsdomain.ArenaStanding](
+		getArenaLeaderboardHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// presence
+
+	getPresenceHandler := presencequeries.NewGetPresenceQueryHandler(db, clock)
+	err = mediator.RegisterRequestHandler[presencequeries.GetPresenceQuery, presencedomain.Presence](
+		getPresenceHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// profiles
+
+	getProfileHandler := profilesqueries.NewGetProfileQueryHandler(db, clock)
+	err = mediator.RegisterRequestHandler[profilesqueries.GetProfileQuery, profilesdomain.PlayerProfile](
+		getProfileHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getAvatarHandler := profilesqueries.NewGetAvatarQueryHandler(db, avatars)
+	err = mediator.RegisterRequestHandler[profilesqueries.GetAvatarQuery, string](
+		getAvatarHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	updateProfileHandler := profilescommands.NewUpdateProfileCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[profilescommands.UpdateProfileCommand, core.Unit](
+		updateProfileHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadAvatarHandler := profilescommands.NewUploadAvatarCommandHandler(db, clock, avatars)
+	err = mediator.RegisterRequestHandler[profilescommands.UploadAvatarCommand, core.Unit](
+		uploadAvatarHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	removeAvatarHandler := profilescommands.NewRemoveAvatarCommandHandler(db, clock, avatars)
+	err = mediator.RegisterRequestHandler[profilescommands.RemoveAvatarCommand, core.Unit](
+		removeAvatarHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// social
+
+	sendFriendRequestHandler := socialcommands.NewSendFriendRequestCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[socialcommands.SendFriendRequestCommand, uuid.UUID](
+		sendFriendRequestHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	acceptFriendRequestHandler := socialcommands.NewAcceptFriendRequestCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[socialcommands.AcceptFriendRequestCommand, core.Unit](
+		acceptFriendRequestHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	declineFriendRequestHandler := socialcommands.NewDeclineFriendRequestCommandHandler(db)
+	err = mediator.RegisterRequestHandler[socialcommands.DeclineFriendRequestCommand, core.Unit](
+		declineFriendRequestHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelFriendRequestHandler := socialcommands.NewCancelFriendRequestCommandHandler(db)
+	err = mediator.RegisterRequestHandler[socialcommands.CancelFriendRequestCommand, core.Unit](
+		cancelFriendRequestHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getFriendRequestsHandler := socialqueries.NewGetFriendRequestsQueryHandler(db)
+	err = mediator.RegisterRequestHandler[socialqueries.GetFriendRequestsQuery, socialqueries.FriendRequests](
+		getFriendRequestsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	removeFriendHandler := socialcommands.NewRemoveFriendCommandHandler(db)
+	err = mediator.RegisterRequestHandler[socialcommands.RemoveFriendCommand, core.Unit](
+		removeFriendHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getFriendsHandler := socialqueries.NewGetFriendsQueryHandler(db, clock)
+	err = mediator.RegisterRequestHandler[socialqueries.GetFriendsQuery, []socialdomain.FriendStatus](
+		getFriendsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getFriendGamesHandler := socialqueries.NewGetFriendGamesQueryHandler(db)
+	err = mediator.RegisterRequestHandler[socialqueries.GetFriendGamesQuery, []socialdomain.FriendGame](
+		getFriendGamesHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	followUserHandler := socialcommands.NewFollowUserCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[socialcommands.FollowUserCommand, core.Unit](
+		followUserHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	unfollowUserHandler := socialcommands.NewUnfollowUserCommandHandler(db)
+	err = mediator.RegisterRequestHandler[socialcommands.UnfollowUserCommand, core.Unit](
+		unfollowUserHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getFollowsHandler := socialqueries.NewGetFollowsQueryHandler(db)
+	err = mediator.RegisterRequestHandler[socialqueries.GetFollowsQuery, []uuid.UUID](
+		getFollowsHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	blockUserHandler := socialcommands.NewBlockUserCommandHandler(db, clock)
+	err = mediator.RegisterRequestHandler[socialcommands.BlockUserCommand, core.Unit](
+		blockUserHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	unblockUserHandler := socialcommands.NewUnblockUserCommandHandler(db)
+	err = mediator.RegisterRequestHandler[socialcommands.UnblockUserCommand, core.Unit](
+		unblockUserHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getBlocksHandler := socialqueries.NewGetBlocksQueryHandler(db)
+	err = mediator.RegisterRequestHandler[socialqueries.GetBlocksQuery, []uuid.UUID](
+		getBlocksHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// auth
 	passwordHasher := authdomain.NewPasswordHasher(sha256.New)
 
 	loginHandler := authcommands.NewLoginCommandHandler(db, *passwordHasher)
@@ -156,33 +1000,304 @@ func NewHTTPServer(config config.Config) (Server, error) {
 		return nil, err
 	}
 
+	updatePreferencesHandler := authcommands.NewUpdatePreferencesCommandHandler(db)
+	err = mediator.RegisterRequestHandler[authcommands.UpdatePreferencesCommand, authdomain.Preferences](
+		updatePreferencesHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getPreferencesHandler := authqueries.NewGetPreferencesQueryHandler(db)
+	err = mediator.RegisterRequestHandler[authqueries.GetPreferencesQuery, authdomain.Preferences](
+		getPreferencesHandler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	r := router{middleware: []httpMiddleware{
 		baseContextMiddleware(baseCtx),
 		core.CorrelationIDHTTPMiddleware,
 	}}
 
+	// The authenticated requests keep their user online while they run.
+	authenticated := func(next http.HandlerFunc) http.HandlerFunc {
+		return auth.AuthenticationMiddleware(db)(presenceTracker.Middleware(next))
+	}
+
 	// http
 
-	r.register("GET /game-sessions", gamesessionqueries.HandleGetOwnedSessions, auth.AuthenticationMiddleware(db))
-	r.register("POST /game-sessions", gamesessioncommands.HandleCreateGameSession, auth.AuthenticationMiddleware(db))
+	r.register("GET /game-sessions", gamesessionqueries.HandleGetOwnedSessions, authenticated)
+	r.register("POST /game-sessions", gamesessioncommands.HandleCreateGameSession, authenticated)
+
+	r.register("GET /lobby", gamesessionqueries.HandleGetLobby, authenticated)
+
+	r.register("POST /game-sessions/{id}/invitations", gamesessioncommands.HandleCreateSessionInvitation, authenticated)
+
+	r.register("POST /game-sessions/{id}/invite-links", gamesessioncommands.HandleCreateInviteLink, authenticated)
+	r.register("PUT /invite-links/{code}/actions/redeem", gamesessioncommands.HandleRedeemInviteLink, authenticated)
+
+	r.register("GET /invitations/received", gamesessionqueries.HandleGetReceivedInvitations, authenticated)
+	r.register("GET /invitations/sent", gamesessionqueries.HandleGetSentInvitations, authenticated)
+	r.register("GET /invitations/{id}", gamesessionqueries.HandleGetInvitation, authenticated)
+	r.register("PUT /invitations/{id}/actions/accept", gamesessioncommands.HandleAcceptInvitation, authenticated)
+	r.register("PUT /invitations/{id}/actions/decline", gamesessioncommands.HandleDeclineInvitation, authenticated)
+	r.register("PUT /invitations/{id}/actions/cancel", gamesessioncommands.HandleCancelInvitation, authenticated)
+
+	r.register("PUT /game-sessions/{id}/actions/close", gamesessioncommands.HandleCloseSession, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/join", gamesessioncommands.HandleJoinSession, authenticated)
+
+	r.register("POST /game-sessions/{id}/moves", gamesessioncommands.HandleMakeMove, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/resign", gamesessioncommands.HandleResign, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/berserk", gamesessioncommands.HandleBerserk, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/offer-draw", gamesessioncommands.HandleOfferDraw, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/accept-draw", gamesessioncommands.HandleAcceptDraw, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/decline-draw", gamesessioncommands.HandleDeclineDraw, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/request-takeback", gamesessioncommands.HandleRequestTakeback, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/accept-takeback", gamesessioncommands.HandleAcceptTakeback, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/decline-takeback", gamesessioncommands.HandleDeclineTakeback, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/claim-draw", gamesessioncommands.HandleClaimDraw, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/claim-victory", gamesessioncommands.HandleClaimVictory, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/offer-rematch", gamesessioncommands.HandleOfferRematch, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/accept-rematch", gamesessioncommands.HandleAcceptRematch, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/decline-rematch", gamesessioncommands.HandleDeclineRematch, authenticated)
+	r.register("GET /game-sessions/{id}/series", gamesessionqueries.HandleGetSeries, authenticated)
+
+	r.register("GET /correspondence/vacations", gamesessionqueries.HandleGetVacations, authenticated)
+	r.register("POST /correspondence/vacations", gamesessioncommands.HandleStartVacation, authenticated)
+	r.register("GET /game-sessions/{id}/live", gamesessionlive.HandleGameStream(pubSub), authenticated)
+	r.register("PUT /game-sessions/{id}/actions/spectate", gamesessioncommands.HandleSpectate, authenticated)
+	r.register("PUT /game-sessions/{id}/actions/stop-spectating", gamesessioncommands.HandleStopSpectating, authenticated)
+
+	r.register("GET /games/pgn", gamesessionqueries.HandleExportPlayerGamesPGN, authenticated)
+	r.register("GET /games/{id}/pgn", gamesessionqueries.HandleGetGamePGN, authenticated)
+	r.register("GET /games/{id}/analysis", analysisqueries.HandleGetGameAnalysis, authenticated)
+
+	r.register("POST /imported-games", gamesessioncommands.HandleImportPGN, authenticated)
+	r.register("GET /imported-games/{id}", gamesessionqueries.HandleGetImportedGame, authenticated)
+
+	r.register("GET /matchmaking/queue", gamesessionqueries.HandleGetMatchmakingTicket, authenticated)
+	r.register("POST /matchmaking/queue", gamesessioncommands.HandleEnqueueMatchmaking, authenticated)
+	r.register("DELETE /matchmaking/queue", gamesessioncommands.HandleLeaveMatchmaking, authenticated)
+
+	r.register("POST /bot-games", gamesessioncommands.HandleCreateBotGame, authenticated)
+
+	r.register("GET /positions", analysisqueries.HandleGetPosition, authenticated)
 
-	r.register("POST /game-sessions/{id}/invitations", gamesessioncommands.HandleCreateSessionInvitation, auth.AuthenticationMiddleware(db))
+	r.register("GET /players/{id}/ratings", ratingsqueries.HandleGetPlayerRatings, authenticated)
+	r.register("GET /players/{id}/ratings/{category}/history", ratingsqueries.HandleGetRatingHistory, authenticated)
+	r.register("GET /players/{id}/presence", presencequeries.HandleGetPresence, authenticated)
+	r.register("GET /players/{id}/profile", profilesqueries.HandleGetProfile, authenticated)
+	r.register("GET /players/{id}/avatar", profilesqueries.HandleGetAvatar, authenticated)
 
-	r.register("PUT /game-sessions/{id}/actions/close", gamesessioncommands.HandleCloseSession, auth.AuthenticationMiddleware(db))
-	r.register("PUT /game-sessions/{id}/actions/join", gamesessioncommands.HandleJoinSession, auth.AuthenticationMiddleware(db))
+	r.register("PUT /profile", profilescommands.HandleUpdateProfile, authenticated)
+	r.register("PUT /profile/avatar", profilescommands.HandleUploadAvatar, authenticated)
+	r.register("DELETE /profile/avatar", profilescommands.HandleRemoveAvatar, authenticated)
+
+	r.register("GET /tournaments", tournamentsqueries.HandleGetTournaments, authenticated)
+	r.register("POST /tournaments", tournamentscommands.HandleCreateTournament, authenticated)
+	r.register("GET /tournaments/{id}", tournamentsqueries.HandleGetTournament, authenticated)
+	r.register("GET /tournaments/{id}/standings", tournamentsqueries.HandleGetStandings, authenticated)
+	r.register("PUT /tournaments/{id}/actions/register", tournamentscommands.HandleRegisterPlayer, authenticated)
+	r.register("PUT /tournaments/{id}/actions/withdraw", tournamentscommands.HandleWithdrawPlayer, authenticated)
+	r.register("PUT /tournaments/{id}/actions/start", tournamentscommands.HandleStartTournament, authenticated)
+
+	r.register("POST /arenas", tournamentscommands.HandleCreateArena, authenticated)
+	r.register("GET /arenas/{id}", tournamentsqueries.HandleGetArena, authenticated)
+	r.register("GET /arenas/{id}/leaderboard", tournamentsqueries.HandleGetArenaLeaderboard, authenticated)
+	r.register("GET /arenas/{id}/live", tournamentslive.HandleLeaderboardStream(pubSub), authenticated)
+	r.register("PUT /arenas/{id}/actions/join", tournamentscommands.HandleJoinArena, authenticated)
+	r.register("PUT /arenas/{id}/actions/pause", tournamentscommands.HandlePauseArena, authenticated)
+
+	r.register("GET /game-sessions/{id}/chat/{channel}/messages", chatqueries.HandleGetMessages, authenticated)
+	r.register("POST /game-sessions/{id}/chat/{channel}/messages", chatcommands.HandleSendMessage, authenticated)
+	r.register("POST /chat/messages/{id}/reports", chatcommands.HandleReportMessage, authenticated)
+	r.register("GET /chat/mutes", chatqueries.HandleGetMutedUsers, authenticated)
+	r.register("POST /chat/mutes", chatcommands.HandleMuteUser, authenticated)
+	r.register("DELETE /chat/mutes/{id}", chatcommands.HandleUnmuteUser, authenticated)
+
+	r.register("GET /friend-requests", socialqueries.HandleGetFriendRequests, authenticated)
+	r.register("POST /friend-requests", socialcommands.HandleSendFriendRequest, authenticated)
+	r.register("PUT /friend-requests/{id}/actions/accept", socialcommands.HandleAcceptFriendRequest, authenticated)
+	r.register("PUT /friend-requests/{id}/actions/decline", socialcommands.HandleDeclineFriendRequest, authenticated)
+	r.register("PUT /friend-requests/{id}/actions/cancel", socialcommands.HandleCancelFriendRequest, authenticated)
+	r.register("GET /friends", socialqueries.HandleGetFriends, authenticated)
+	r.register("GET /friends/games", socialqueries.HandleGetFriendGames, authenticated)
+	r.register("DELETE /friends/{id}", socialcommands.HandleRemoveFriend, authenticated)
+	r.register("GET /follows", socialqueries.HandleGetFollows, authenticated)
+	r.register("POST /follows", socialcommands.HandleFollowUser, authenticated)
+	r.register("DELETE /follows/{id}", socialcommands.HandleUnfollowUser, authenticated)
+	r.register("GET /blocks", socialqueries.HandleGetBlocks, authenticated)
+	r.register("POST /blocks", socialcommands.HandleBlockUser, authenticated)
+	r.register("DELETE /blocks/{id}", socialcommands.HandleUnblockUser, authenticated)
+
+	r.register("GET /admin/chat-reports", chatqueries.HandleGetReportQueue, authenticated, auth.AdminMiddleware(db))
+	r.register("PUT /admin/chat-reports/{id}/actions/uphold", chatcommands.HandleUpholdReport, authenticated, auth.AdminMiddleware(db))
+	r.register("PUT /admin/chat-reports/{id}/actions/dismiss", chatcommands.HandleDismissReport, authenticated, auth.AdminMiddleware(db))
+
+	r.register("GET /notifications/live", notificationslive.HandleNotificationStream(pubSub), authenticated)
 
 	r.register("POST /auth/login", authcommands.HandleLogin)
 	r.register("POST /auth/logout", authcommands.HandleLogout)
+	r.register("GET /auth/preferences", authqueries.HandleGetPreferences, authenticated)
+	r.register("PUT /auth/preferences", authcommands.HandleUpdatePreferences, authenticated)
 
 	r.register("POST /auth/registrations", authcommands.HandleRegistration)
 	r.register("POST /auth/registrations/actions/confirm", authcommands.HandleVerifyRegistration)
 	r.register("POST /auth/registrations/actions/publish-confirmation-emails", authcommands.HandlePublishConfirmationEmails)
 	r.register("POST /auth/registrations/actions/send-activation-code", authcommands.HandleReSendConfirmationEmail)
 
-	return &HTTPServer{server: &server}, nil
+	// background jobs
+
+	jobs := []core.Job{
+		{
+			Name:     "process-clock-flags",
+			Interval: time.Second,
+			Run: func(ctx context.Context) error {
+				_, err := mediator.Send[gamesessioncommands.ProcessClockFlagsCommand, core.Unit](
+					ctx,
+					gamesessioncommands.ProcessClockFlagsCommand{},
+				)
+				return err
+			},
+		},
+		{
+			Name:     "process-abandoned-games",
+			Interval: 5 * time.Second,
+			Run: func(ctx context.Context) error {
+				_, err := mediator.Send[gamesessioncommands.ProcessAbandonedGamesCommand, core.Unit](
+					ctx,
+					gamesessioncommands.ProcessAbandonedGamesCommand{},
+				)
+				return err
+			},
+		},
+		{
+			Name:     "match-players",
+			Interval: time.Second,
+			Run: func(ctx context.Context) error {
+				_, err := mediator.Send[gamesessioncommands.MatchPlayersCommand, core.Unit](
+					ctx,
+					gamesessioncommands.MatchPlayersCommand{},
+				)
+				return err
+			},
+		},
+		{
+			Name:     "play-bot-moves",
+			Interval: 500 * time.Millisecond,
+			Run: func(ctx context.Context) error {
+				_, err := mediator.Send[gamesessioncommands.PlayBotMovesCommand, core.Unit](
+					ctx,
+					gamesessioncommands.PlayBotMovesCommand{},
+				)
+				return err
+			},
+		},
+		{
+			Name:     "expire-invitations",
+			Interval: 5 * time.Second,
+			Run: func(ctx context.Context) error {
+				_, err := mediator.Send[gamesessioncommands.ExpireInvitationsCommand, core.Unit](
+					ctx,
+					gamesessioncommands.ExpireInvitationsCommand{},
+				)
+				return err
+			},
+		},
+		{
+			Name:     "expire-rematch-offers",
+			Interval: 5 * time.Second,
+			Run: func(ctx context.Context) error {
+				_, err := mediator.Send[gamesessioncommands.ExpireRematchOffersCommand, core.Unit](
+					ctx,
+					gamesessioncommands.ExpireRematchOffersCommand{},
+				)
+				return err
+			},
+		},
+		{
+			Name:     "advance-tournaments",
+			Interval: 5 * time.Second,
+			Run: func(ctx context.Context) error {
+				_, err := mediator.Send[tournamentscommands.AdvanceTournamentsCommand, core.Unit](
+					ctx,
+					tournamentscommands.AdvanceTournamentsCommand{},
+				)
+				return err
+			},
+		},
+		{
+			Name:     "pair-arenas",
+			Interval: 2 * time.Second,
+			Run: func(ctx context.Context) error {
+				_, err := mediator.Send[tournamentscommands.PairArenasCommand, core.Unit](
+					ctx,
+					tournamentscommands.PairArenasCommand{},
+				)
+				return err
+			},
+		},
+		{
+			Name:     "send-email-invitations",
+			Interval: 10 * time.Second,
+			Run: func(ctx context.Context) error {
+				_, err := mediator.Send[gamesessioncommands.SendEmailInvitationsCommand, core.Unit](
+					ctx,
+					gamesessioncommands.SendEmailInvitationsCommand{},
+				)
+				return err
+			},
+		},
+		{
+			Name:     "send-move-reminders",
+			Interval: time.Hour,
+			Run: func(ctx context.Context) error {
+				_, err := mediator.Send[gamesessioncommands.SendMoveRemindersCommand, core.Unit](
+					ctx,
+					gamesessioncommands.SendMoveRemindersCommand{},
+				)
+				return err
+			},
+		},
+		{
+			Name:     "convert-email-invitations",
+			Interval: 5 * time.Second,
+			Run: func(ctx context.Context) error {
+				_, err := mediator.Send[gamesessioncommands.ConvertEmailInvitationsCommand, core.Unit](
+					ctx,
+					gamesessioncommands.ConvertEmailInvitationsCommand{},
+				)
+				return err
+			},
+		},
+		{
+			Name:     "refresh-presence",
+			Interval: presencedomain.RefreshInterval,
+			Run:      presenceTracker.Refresh,
+		},
+		{
+			Name:     "cleanup-pubsub-messages",
+			Interval: time.Minute,
+			Run:      pubSub.Cleanup,
+		},
+	}
+
+	return &HTTPServer{server: &server, logger: config.Logger, jobs: jobs, pubSub: pubSub, botPool: botPool, engines: engines}, nil
 }
 
 func (s *HTTPServer) Start() error {
+	jobsCtx, cancel := context.WithCancel(context.Background())
+	s.cancelJobs = cancel
+
+	for _, job := range s.jobs {
+		go job.Start(jobsCtx, s.logger)
+	}
+
+	go s.pubSub.Start(jobsCtx)
+	go s.botPool.Start(jobsCtx)
+
 	if err := s.server.ListenAndServe(); err != nil {
 		log.Fatal(err)
 	}
@@ -191,6 +1306,12 @@ func (s *HTTPServer) Start() error {
 }
 
 func (s *HTTPServer) Stop() error {
+	if s.cancelJobs != nil {
+		s.cancelJobs()
+	}
+
+	s.engines.Close()
+
 	return s.server.Close()
 }
 
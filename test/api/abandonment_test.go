@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/stretchr/testify/require"
+)
+
+// leaveGame makes the side to move look inactive since long before the abandonment threshold.
+func leaveGame(t *testing.T, sessionID string) {
+	_, err := tql.Exec(
+		context.Background(),
+		fixture.db,
+		"UPDATE game SET turn_started_at = $1 WHERE session_id = $2;",
+		time.Now().UTC().Add(-time.Hour),
+		sessionID,
+	)
+	require.NoError(t, err)
+}
+
+func sessionGame(t *testing.T, sessionID string) gamesessiondomain.Game {
+	game, err := tql.QueryFirst[gamesessiondomain.Game](
+		context.Background(),
+		fixture.db,
+		"SELECT * FROM game WHERE session_id = $1;",
+		sessionID,
+	)
+	require.NoError(t, err)
+
+	return game
+}
+
+func Test_Abandoned_Game_Is_Aborted_Before_Both_Players_Moved(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, _ := startGame(t)
+	playMoves(t, sessionID, whiteCookie, "", "e2e4")
+
+	// Act
+	leaveGame(t, sessionID)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		return sessionGame(t, sessionID).IsOver()
+	}, 15*time.Second, 250*time.Millisecond)
+
+	game := sessionGame(t, sessionID)
+	require.Equal(t, chess.NoResult, game.Result)
+	require.Equal(t, chess.Aborted, game.Termination)
+}
+
+func Test_Opponent_Claims_Abandoned_Game(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	playMoves(t, sessionID, whiteCookie, blackCookie, "e2e4", "e7e5")
+
+	gameAction(t, blackCookie, sessionID, "claim-victory", http.StatusConflict)
+
+	// Act
+	leaveGame(t, sessionID)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		return sessionGame(t, sessionID).ClaimableAt != nil
+	}, 15*time.Second, 250*time.Millisecond)
+
+	gameAction(t, whiteCookie, sessionID, "claim-victory", http.StatusConflict)
+	response := gameAction(t, blackCookie, sessionID, "claim-victory", http.StatusOK)
+	require.Equal(t, chess.BlackWins, response.Result)
+	require.Equal(t, chess.Abandonment, sessionGame(t, sessionID).Termination)
+}
+
+func Test_Stale_Sessions_Are_Closed(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	// Act
+	_, err := tql.Exec(
+		context.Background(),
+		fixture.db,
+		"UPDATE game_session SET created_at = $1 WHERE id = $2;",
+		time.Now().UTC().Add(-7*24*time.Hour),
+		sessionID,
+	)
+	require.NoError(t, err)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		session, err := tql.QueryFirst[gamesessiondomain.Session](
+			context.Background(),
+			fixture.db,
+			"SELECT * FROM game_session WHERE id = $1;",
+			sessionID,
+		)
+		require.NoError(t, err)
+		return session.Closed
+	}, 15*time.Second, 250*time.Millisecond)
+
+	sendAuthenticatedRequest[any, commands.JoinSessionResponse](
+		t,
+		login(t),
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.NotEqual(t, http.StatusOK, resp.StatusCode) },
+	)
+}
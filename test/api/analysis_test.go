@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/analysis/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/analysis/queries"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getGameAnalysis(t *testing.T, cookie string, sessionID string, expectedStatus int) queries.GetGameAnalysisResponse {
+	return sendAuthenticatedRequest[any, queries.GetGameAnalysisResponse](
+		t,
+		cookie,
+		fmt.Sprintf("%s/games/%s/analysis?lines=2", fixture.baseURL, sessionGameID(t, sessionID)),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func Test_Game_Analysis_Classifies_Moves(t *testing.T) {
+	// Arrange
+	// The test server analyses with a fake engine, which knows the scholar's mate.
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	playMoves(t, sessionID, whiteCookie, blackCookie, "e2e4", "e7e5", "d1h5", "b8c6", "f1c4", "g8f6", "h5f7")
+
+	// Act
+	analysis := getGameAnalysis(t, blackCookie, sessionID, http.StatusOK)
+
+	// Assert
+	require.Len(t, analysis.Moves, 7)
+
+	classifications := make([]domain.Classification, 0, len(analysis.Moves))
+	for _, move := range analysis.Moves {
+		classifications = append(classifications, move.Classification)
+	}
+	require.Equal(t, []domain.Classification{
+		domain.Best, domain.Best, domain.Good, domain.Best, domain.Best, domain.Blunder, domain.Best,
+	}, classifications)
+
+	blunder := analysis.Moves[5]
+	require.Equal(t, "Nf6", blunder.SAN)
+	require.Equal(t, "g6", blunder.BestMove)
+	require.Len(t, blunder.BestLines, 2)
+	require.NotNil(t, blunder.Eval.Mate)
+	require.Equal(t, 1, *blunder.Eval.Mate)
+
+	// The mate ended the game, there is nothing left to evaluate.
+	require.Nil(t, analysis.Moves[6].Eval)
+}
+
+func Test_Game_Analysis_Requires_Finished_Game(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	playMoves(t, sessionID, whiteCookie, blackCookie, "e2e4")
+
+	// Act & Assert
+	getGameAnalysis(t, whiteCookie, sessionID, http.StatusConflict)
+}
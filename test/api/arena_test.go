@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"testing"
+	"time"
+
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/commands"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createArena(t *testing.T, cookie string, command commands.CreateArenaCommand, expectedStatus int) uuid.UUID {
+	var location string
+	sendAuthenticatedRequest[commands.CreateArenaCommand, any](
+		t,
+		cookie,
+		fmt.Sprintf("%s/arenas", fixture.baseURL),
+		http.MethodPost,
+		command,
+		func(resp *http.Response) {
+			require.Equal(t, expectedStatus, resp.StatusCode)
+			location = resp.Header.Get("Location")
+		},
+	)
+
+	if expectedStatus != http.StatusCreated {
+		return uuid.Nil
+	}
+
+	arenaID, err := uuid.Parse(path.Base(location))
+	require.NoError(t, err)
+	return arenaID
+}
+
+func arenaAction(t *testing.T, cookie string, arenaID uuid.UUID, action string, expectedStatus int) {
+	sendAuthenticatedRequest[any, any](
+		t,
+		cookie,
+		fmt.Sprintf("%s/arenas/%s/actions/%s", fixture.baseURL, arenaID, action),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func getArenaLeaderboard(t *testing.T, cookie string, arenaID uuid.UUID) []domain.ArenaStanding {
+	return sendAuthenticatedRequest[any, []domain.ArenaStanding](
+		t,
+		cookie,
+		fmt.Sprintf("%s/arenas/%s/leaderboard", fixture.baseURL, arenaID),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+}
+
+// arenaGames waits for the arena to have started count games, oldest first.
+func arenaGames(t *testing.T, arenaID uuid.UUID, count int) []domain.ArenaGame {
+	var games []domain.ArenaGame
+	require.Eventually(t, func() bool {
+		var err error
+		games, err = tql.Query[domain.ArenaGame](
+			context.Background(),
+			fixture.db,
+			"SELECT * FROM arena_game WHERE arena_id = $1 ORDER BY created_at;",
+			arenaID,
+		)
+		require.NoError(t, err)
+		return len(games) >= count
+	}, 15*time.Second, 250*time.Millisecond)
+
+	return games
+}
+
+func Test_Arena_Pairs_Players_Again_Once_Their_Game_Is_Over(t *testing.T) {
+	// Arrange
+	organizerCookie := login(t)
+
+	cookies := make(map[uuid.UUID]string)
+	for range 3 {
+		cookie := login(t)
+		cookies[sessionUserID(t, cookie)] = cookie
+	}
+
+	arenaID := createArena(t, organizerCookie, commands.CreateArenaCommand{
+		Name:        uuid.NewString(),
+		TimeControl: gamesessiondomain.TimeControl{BaseSeconds: 180},
+		Berserk:     true,
+		Minutes:     30,
+	}, http.StatusCreated)
+
+	leaderboard := openEventStream(t, organizerCookie, fmt.Sprintf("%s/arenas/%s/live", fixture.baseURL, arenaID), "")
+	readNotification(t, leaderboard, "leaderboard")
+
+	// Act
+	for _, cookie := range cookies {
+		arenaAction(t, cookie, arenaID, "join", http.StatusOK)
+	}
+
+	first := arenaGames(t, arenaID, 1)[0]
+
+	response := gameAction(t, cookies[first.BlackID], first.SessionID, "berserk", http.StatusOK)
+	require.Equal(t, gamesessiondomain.GameStarted, response.Status)
+	gameAction(t, cookies[first.BlackID], first.SessionID, "berserk", http.StatusConflict)
+
+	gameAction(t, cookies[first.BlackID], first.SessionID, "resign", http.StatusOK)
+
+	// Assert
+	second := arenaGames(t, arenaID, 2)[1]
+	require.NotEqual(t, first.GameID, second.GameID)
+	require.False(t,
+		(second.WhiteID == first.WhiteID && second.BlackID == first.BlackID) ||
+			(second.WhiteID == first.BlackID && second.BlackID == first.WhiteID),
+		"last opponents are not paired again right away",
+	)
+
+	standings := getArenaLeaderboard(t, organizerCookie, arenaID)
+	require.Len(t, standings, 3)
+	require.Equal(t, first.WhiteID, standings[0].PlayerID)
+	require.Equal(t, domain.WinPoints, standings[0].Points)
+	require.Equal(t, 1, standings[0].Wins)
+
+	for {
+		event := readNotification(t, leaderboard, "leaderboard")
+
+		var streamed []domain.ArenaStanding
+		require.NoError(t, json.Unmarshal([]byte(event.Data), &streamed))
+		if len(streamed) == 3 && streamed[0].Points == domain.WinPoints {
+			require.Equal(t, first.WhiteID, streamed[0].PlayerID)
+			break
+		}
+	}
+
+	arenaAction(t, cookies[first.BlackID], arenaID, "pause", http.StatusOK)
+	arenaAction(t, organizerCookie, arenaID, "pause", http.StatusNotFound)
+
+	for _, standing := range getArenaLeaderboard(t, organizerCookie, arenaID) {
+		require.Equal(t, standing.PlayerID == first.BlackID, standing.Paused)
+	}
+}
+
+func Test_Arena_Rules(t *testing.T) {
+	// Arrange
+	organizerCookie := login(t)
+	whiteCookie := login(t)
+	blackCookie := login(t)
+
+	createArena(t, organizerCookie, commands.CreateArenaCommand{
+		Name:    uuid.NewString(),
+		Minutes: 30,
+	}, http.StatusBadRequest)
+
+	createArena(t, organizerCookie, commands.CreateArenaCommand{
+		Name:        uuid.NewString(),
+		TimeControl: gamesessiondomain.TimeControl{BaseSeconds: 180},
+		Minutes:     domain.MaxArenaMinutes + 1,
+	}, http.StatusBadRequest)
+
+	arenaID := createArena(t, organizerCookie, commands.CreateArenaCommand{
+		Name:        uuid.NewString(),
+		TimeControl: gamesessiondomain.TimeControl{BaseSeconds: 180},
+		Minutes:     30,
+	}, http.StatusCreated)
+
+	// Act
+	arenaAction(t, whiteCookie, arenaID, "join", http.StatusOK)
+	arenaAction(t, blackCookie, arenaID, "join", http.StatusOK)
+	game := arenaGames(t, arenaID, 1)[0]
+
+	// Assert
+	cookies := map[uuid.UUID]string{
+		sessionUserID(t, whiteCookie): whiteCookie,
+		sessionUserID(t, blackCookie): blackCookie,
+	}
+	gameAction(t, cookies[game.WhiteID], game.SessionID, "berserk", http.StatusConflict)
+
+	sessionID, _, casualBlackCookie := startGame(t)
+	gameAction(t, casualBlackCookie, sessionID, "berserk", http.StatusConflict)
+}
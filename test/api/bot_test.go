@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/engine"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/stretchr/testify/require"
+)
+
+func createBotGame(t *testing.T, cookie string, command commands.CreateBotGameCommand, expectedStatus int) commands.CreateBotGameResponse {
+	return sendAuthenticatedRequest[commands.CreateBotGameCommand, commands.CreateBotGameResponse](
+		t,
+		cookie,
+		fmt.Sprintf("%s/bot-games", fixture.baseURL),
+		http.MethodPost,
+		command,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func Test_Bot_Game_Computer_Answers_Move(t *testing.T) {
+	// Arrange
+	cookie := login(t)
+	game := createBotGame(t, cookie, commands.CreateBotGameCommand{Level: engine.MinLevel}, http.StatusCreated)
+
+	// Act
+	sendAuthenticatedRequest[commands.MakeMoveCommand, commands.MakeMoveResponse](
+		t,
+		cookie,
+		fmt.Sprintf("%s/game-sessions/%s/moves", fixture.baseURL, game.SessionID),
+		http.MethodPost,
+		commands.MakeMoveCommand{Move: "e2e4"},
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		ply, err := tql.QueryFirst[int](context.Background(), fixture.db, "SELECT ply FROM game WHERE id = $1;", game.GameID)
+		require.NoError(t, err)
+
+		return ply == 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	san, err := tql.QueryFirst[string](
+		context.Background(),
+		fixture.db,
+		"SELECT san FROM game_move WHERE game_id = $1 AND ply = 2;",
+		game.GameID,
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, san)
+
+	blackID, err := tql.QueryFirst[string](context.Background(), fixture.db, "SELECT black_id FROM game WHERE id = $1;", game.GameID)
+	require.NoError(t, err)
+	require.Equal(t, gamesessiondomain.BotUserID.String(), blackID)
+}
+
+func Test_Bot_Game_Validates_Level(t *testing.T) {
+	// Arrange
+	cookie := login(t)
+
+	// Act & Assert
+	createBotGame(t, cookie, commands.CreateBotGameCommand{Level: engine.MaxLevel + 1}, http.StatusBadRequest)
+}
+
+func Test_Bot_Game_Cannot_Be_Joined(t *testing.T) {
+	// Arrange
+	cookie := login(t)
+	game := createBotGame(t, cookie, commands.CreateBotGameCommand{Level: engine.MinLevel}, http.StatusCreated)
+
+	// Act & Assert
+	sendAuthenticatedRequest[any, any](
+		t,
+		login(t),
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, game.SessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusForbidden, resp.StatusCode) },
+	)
+}
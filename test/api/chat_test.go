@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	chatcommands "github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/commands"
+	chatdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	chatqueries "github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/queries"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func sendChatMessage(
+	t *testing.T,
+	sessionCookie string,
+	sessionID string,
+	channel chatdomain.Channel,
+	body string,
+	expectedStatus int,
+) chatdomain.Message {
+	return sendAuthenticatedRequest[chatcommands.SendMessageCommand, chatdomain.Message](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/game-sessions/%s/chat/%s/messages", fixture.baseURL, sessionID, channel),
+		http.MethodPost,
+		chatcommands.SendMessageCommand{Body: body},
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func getChatMessages(
+	t *testing.T,
+	sessionCookie string,
+	sessionID string,
+	channel chatdomain.Channel,
+	params url.Values,
+) core.Page[chatdomain.Message] {
+	return sendAuthenticatedRequest[any, core.Page[chatdomain.Message]](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/game-sessions/%s/chat/%s/messages?%s", fixture.baseURL, sessionID, channel, params.Encode()),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+}
+
+func chatBodies(messages []chatdomain.Message) []string {
+	bodies := make([]string, 0, len(messages))
+	for _, m := range messages {
+		bodies = append(bodies, m.Body)
+	}
+	return bodies
+}
+
+func Test_Chat_Delivers_Messages_To_Stream(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+
+	conn := dialGameStream(t, blackCookie, sessionID, 0)
+	require.Equal(t, "game_started", readStreamMessage(t, conn).Type)
+
+	// Act
+	sent := sendChatMessage(t, whiteCookie, sessionID, chatdomain.PlayersChannel, "good luck", http.StatusCreated)
+	require.NoError(t, conn.WriteJSON(map[string]string{"type": "chat", "text": "have fun"}))
+
+	// Assert
+	message := readStreamMessage(t, conn)
+	require.Equal(t, chatdomain.MessageSentEvent, message.Type)
+	require.Contains(t, string(message.Payload), sent.ID.String())
+
+	// The reply and the published message race each other.
+	require.ElementsMatch(
+		t,
+		[]string{"chat_accepted", chatdomain.MessageSentEvent},
+		[]string{readStreamMessage(t, conn).Type, readStreamMessage(t, conn).Type},
+	)
+
+	history := getChatMessages(t, whiteCookie, sessionID, chatdomain.PlayersChannel, nil)
+	require.Equal(t, []string{"have fun", "good luck"}, chatBodies(history.Items))
+}
+
+func Test_Chat_Spectators_Cannot_Use_Players_Channel(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, _ := startGame(t)
+	spectatorCookie := login(t)
+	spectate(t, spectatorCookie, sessionID, http.StatusOK)
+
+	// Act & Assert
+	sendChatMessage(t, spectatorCookie, sessionID, chatdomain.PlayersChannel, "play Nf3", http.StatusForbidden)
+	sendChatMessage(t, whiteCookie, sessionID, chatdomain.SpectatorsChannel, "hello", http.StatusForbidden)
+	sendChatMessage(t, spectatorCookie, sessionID, chatdomain.SpectatorsChannel, "nice opening", http.StatusCreated)
+
+	sendAuthenticatedRequest[any, any](
+		t,
+		whiteCookie,
+		fmt.Sprintf("%s/game-sessions/%s/chat/%s/messages", fixture.baseURL, sessionID, chatdomain.SpectatorsChannel),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusForbidden, resp.StatusCode) },
+	)
+}
+
+func Test_Chat_History_Pages_Newest_First(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	sendChatMessage(t, whiteCookie, sessionID, chatdomain.PlayersChannel, "one", http.StatusCreated)
+	sendChatMessage(t, blackCookie, sessionID, chatdomain.PlayersChannel, "two", http.StatusCreated)
+	sendChatMessage(t, whiteCookie, sessionID, chatdomain.PlayersChannel, "three", http.StatusCreated)
+
+	// Act
+	first := getChatMessages(t, blackCookie, sessionID, chatdomain.PlayersChannel, url.Values{"limit": {"2"}})
+	second := getChatMessages(
+		t,
+		blackCookie,
+		sessionID,
+		chatdomain.PlayersChannel,
+		url.Values{"limit": {"2"}, "cursor": {first.NextCursor}},
+	)
+
+	// Assert
+	require.Equal(t, []string{"three", "two"}, chatBodies(first.Items))
+	require.Equal(t, []string{"one"}, chatBodies(second.Items))
+	require.Empty(t, second.NextCursor)
+}
+
+func Test_Chat_Returns_429_When_Rate_Limited(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, _ := startGame(t)
+	for i := range 5 {
+		sendChatMessage(t, whiteCookie, sessionID, chatdomain.PlayersChannel, fmt.Sprintf("spam %d", i), http.StatusCreated)
+	}
+
+	// Act & Assert
+	sendChatMessage(t, whiteCookie, sessionID, chatdomain.PlayersChannel, "spam", http.StatusTooManyRequests)
+}
+
+func Test_Chat_Mute_Hides_Messages(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	sendChatMessage(t, whiteCookie, sessionID, chatdomain.PlayersChannel, "blunder!", http.StatusCreated)
+	whiteID := sessionUserID(t, whiteCookie)
+
+	// Act
+	sendAuthenticatedRequest[chatcommands.MuteUserCommand, any](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/chat/mutes", fixture.baseURL),
+		http.MethodPost,
+		chatcommands.MuteUserCommand{MutedUserID: whiteID},
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Assert
+	require.Empty(t, getChatMessages(t, blackCookie, sessionID, chatdomain.PlayersChannel, nil).Items)
+	require.Len(t, getChatMessages(t, whiteCookie, sessionID, chatdomain.PlayersChannel, nil).Items, 1)
+
+	muted := sendAuthenticatedRequest[any, []uuid.UUID](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/chat/mutes", fixture.baseURL),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+	require.Equal(t, []uuid.UUID{whiteID}, muted)
+}
+
+func Test_Chat_Upheld_Report_Removes_Message(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	message := sendChatMessage(t, whiteCookie, sessionID, chatdomain.PlayersChannel, "insult", http.StatusCreated)
+
+	report := sendAuthenticatedRequest[chatcommands.ReportMessageCommand, chatcommands.ReportMessageResponse](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/chat/messages/%s/reports", fixture.baseURL, message.ID),
+		http.MethodPost,
+		chatcommands.ReportMessageCommand{Reason: "abusive"},
+		func(resp *http.Response) { require.Equal(t, http.StatusCreated, resp.StatusCode) },
+	)
+
+	adminCookie := login(t)
+	_, err := fixture.db.Exec(`UPDATE auth.user SET is_admin = true WHERE id = $1`, sessionUserID(t, adminCookie))
+	require.NoError(t, err)
+
+	queue := sendAuthenticatedRequest[any, core.Page[chatqueries.QueuedReport]](
+		t,
+		adminCookie,
+		fmt.Sprintf("%s/admin/chat-reports?limit=100", fixture.baseURL),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+	require.NotEmpty(t, queue.Items)
+
+	// Act
+	reviewURL := fmt.Sprintf("%s/admin/chat-reports/%s/actions/uphold", fixture.baseURL, report.ReportID)
+	sendAuthenticatedRequest[chatcommands.ReviewReportCommand, any](
+		t,
+		blackCookie,
+		reviewURL,
+		http.MethodPut,
+		chatcommands.ReviewReportCommand{SilenceMinutes: 10},
+		func(resp *http.Response) { require.Equal(t, http.StatusForbidden, resp.StatusCode) },
+	)
+	sendAuthenticatedRequest[chatcommands.ReviewReportCommand, any](
+		t,
+		adminCookie,
+		reviewURL,
+		http.MethodPut,
+		chatcommands.ReviewReportCommand{SilenceMinutes: 10},
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Assert
+	require.Empty(t, getChatMessages(t, blackCookie, sessionID, chatdomain.PlayersChannel, nil).Items)
+	sendChatMessage(t, whiteCookie, sessionID, chatdomain.PlayersChannel, "sorry", http.StatusForbidden)
+}
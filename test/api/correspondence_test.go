@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	authdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/auth/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/queries"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/stretchr/testify/require"
+)
+
+func startCorrespondenceGame(t *testing.T, daysPerMove int) (sessionID string, whiteCookie string, blackCookie string) {
+	return startVariantGame(t, commands.CreateSessionCommand{
+		Rated:       true,
+		TimeControl: gamesessiondomain.TimeControl{DaysPerMove: daysPerMove},
+	})
+}
+
+func startVacation(t *testing.T, sessionCookie string, days int, expectedStatus int) gamesessiondomain.Vacation {
+	return sendAuthenticatedRequest[commands.StartVacationCommand, gamesessiondomain.Vacation](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/correspondence/vacations", fixture.baseURL),
+		http.MethodPost,
+		commands.StartVacationCommand{Days: days},
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func Test_Correspondence_Game_Moves_Are_Due_In_Days(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startCorrespondenceGame(t, 3)
+
+	// Act
+	playMoves(t, sessionID, whiteCookie, blackCookie, "e2e4")
+
+	// Assert
+	game := sessionGame(t, sessionID)
+	require.Equal(t, 3, game.TimeControlDaysPerMove)
+	require.Nil(t, game.FlagAt)
+	require.NotNil(t, game.MoveDueAt)
+	require.WithinDuration(t, time.Now().Add(72*time.Hour), *game.MoveDueAt, time.Minute)
+}
+
+func Test_Correspondence_Vacation_Postpones_Due_Moves(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startCorrespondenceGame(t, 1)
+	playMoves(t, sessionID, whiteCookie, blackCookie, "e2e4")
+	dueAt := *sessionGame(t, sessionID).MoveDueAt
+
+	// Act
+	vacation := startVacation(t, blackCookie, 5, http.StatusCreated)
+
+	// Assert
+	require.Equal(t, 5, vacation.Days)
+	require.Equal(t, dueAt.Add(5*24*time.Hour).Unix(), sessionGame(t, sessionID).MoveDueAt.Unix())
+
+	startVacation(t, blackCookie, 1, http.StatusConflict)
+	startVacation(t, whiteCookie, gamesessiondomain.VacationDaysPerYear+1, http.StatusBadRequest)
+
+	vacations := sendAuthenticatedRequest[any, queries.GetVacationsResponse](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/correspondence/vacations", fixture.baseURL),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+	require.True(t, vacations.OnVacation)
+	require.Equal(t, 5, vacations.UsedDays)
+	require.Len(t, vacations.Vacations, 1)
+}
+
+func Test_Correspondence_Timeout_Is_Adjudicated(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startCorrespondenceGame(t, 1)
+	playMoves(t, sessionID, whiteCookie, blackCookie, "e2e4", "e7e5")
+
+	// Act
+	_, err := tql.Exec(
+		context.Background(),
+		fixture.db,
+		"UPDATE game SET move_due_at = $1 WHERE session_id = $2;",
+		time.Now().UTC().Add(-time.Hour),
+		sessionID,
+	)
+	require.NoError(t, err)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		return sessionGame(t, sessionID).ClaimableAt != nil
+	}, 15*time.Second, 250*time.Millisecond)
+
+	response := gameAction(t, blackCookie, sessionID, "claim-victory", http.StatusOK)
+	require.Equal(t, chess.BlackWins, response.Result)
+}
+
+func Test_Preferences_Turn_Off_Move_Reminders(t *testing.T) {
+	// Arrange
+	cookie := login(t)
+	preferencesURL := fmt.Sprintf("%s/auth/preferences", fixture.baseURL)
+
+	preferences := sendAuthenticatedRequest[any, authdomain.Preferences](
+		t,
+		cookie,
+		preferencesURL,
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+	require.True(t, preferences.CorrespondenceReminders)
+
+	// Act
+	sendAuthenticatedRequest[authdomain.Preferences, authdomain.Preferences](
+		t,
+		cookie,
+		preferencesURL,
+		http.MethodPut,
+		authdomain.Preferences{CorrespondenceReminders: false},
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Assert
+	preferences = sendAuthenticatedRequest[any, authdomain.Preferences](
+		t,
+		cookie,
+		preferencesURL,
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+	require.False(t, preferences.CorrespondenceReminders)
+	require.Equal(t, sessionUserID(t, cookie), preferences.UserID)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+// startGame starts a game between two new players, white first.
+func startGame(t *testing.T) (sessionID string, whiteCookie string, blackCookie string) {
+	whiteCookie = login(t)
+	blackCookie = login(t)
+
+	sessionID = createSession(t, whiteCookie, gamesessiondomain.TimeControl{})
+
+	sendAuthenticatedRequest[any, commands.JoinSessionResponse](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	return sessionID, whiteCookie, blackCookie
+}
+
+func playMoves(t *testing.T, sessionID string, whiteCookie string, blackCookie string, moves ...string) {
+	for i, move := range moves {
+		cookie := whiteCookie
+		if i%2 == 1 {
+			cookie = blackCookie
+		}
+
+		sendAuthenticatedRequest[commands.MakeMoveCommand, commands.MakeMoveResponse](
+			t,
+			cookie,
+			fmt.Sprintf("%s/game-sessions/%s/moves", fixture.baseURL, sessionID),
+			http.MethodPost,
+			commands.MakeMoveCommand{Move: move},
+			func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+		)
+	}
+}
+
+func gameAction(t *testing.T, sessionCookie string, sessionID string, action string, expectedStatus int) commands.GameActionResponse {
+	return sendAuthenticatedRequest[any, commands.GameActionResponse](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/%s", fixture.baseURL, sessionID, action),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func Test_Resign_Ends_Game_And_Notifies_Opponent(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	events := openNotificationStream(t, whiteCookie, "")
+
+	// Act
+	response := gameAction(t, blackCookie, sessionID, "resign", http.StatusOK)
+
+	// Assert
+	require.Equal(t, gamesessiondomain.GameEnded, response.Status)
+	require.Equal(t, chess.WhiteWins, response.Result)
+	require.Equal(t, chess.Resignation, response.Termination)
+
+	readNotification(t, events, notificationsdomain.GameEndedNotification)
+
+	gameAction(t, whiteCookie, sessionID, "resign", http.StatusConflict)
+}
+
+func Test_Draw_Offer_Accepted_Ends_Game_In_Draw(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	events := openNotificationStream(t, blackCookie, "")
+
+	playMoves(t, sessionID, whiteCookie, blackCookie, "e2e4")
+
+	// Act
+	gameAction(t, whiteCookie, sessionID, "offer-draw", http.StatusOK)
+	readNotification(t, events, notificationsdomain.GameOfferReceivedNotification)
+
+	gameAction(t, whiteCookie, sessionID, "accept-draw", http.StatusForbidden)
+	response := gameAction(t, blackCookie, sessionID, "accept-draw", http.StatusOK)
+
+	// Assert
+	require.Equal(t, chess.Draw, response.Result)
+	require.Equal(t, chess.Agreement, response.Termination)
+}
+
+func Test_Draw_Offer_Expires_On_Next_Move_And_Is_Rate_Limited(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+
+	gameAction(t, whiteCookie, sessionID, "offer-draw", http.StatusOK)
+	gameAction(t, blackCookie, sessionID, "decline-draw", http.StatusOK)
+
+	// Act & Assert
+	gameAction(t, whiteCookie, sessionID, "offer-draw", http.StatusTooManyRequests)
+
+	playMoves(t, sessionID, whiteCookie, blackCookie, "e2e4")
+	gameAction(t, whiteCookie, sessionID, "offer-draw", http.StatusOK)
+
+	playMoves(t, sessionID, blackCookie, whiteCookie, "e7e5")
+	gameAction(t, blackCookie, sessionID, "accept-draw", http.StatusNotFound)
+}
+
+func Test_Takeback_Accepted_Returns_To_Position_Before_Move(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	playMoves(t, sessionID, whiteCookie, blackCookie, "e2e4", "e7e5", "g1f3")
+
+	gameAction(t, whiteCookie, sessionID, "request-takeback", http.StatusOK)
+
+	// Act
+	response := gameAction(t, blackCookie, sessionID, "accept-takeback", http.StatusOK)
+
+	// Assert
+	require.Equal(t, 2, response.Ply)
+	require.Equal(t, "rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 0 2", response.FEN)
+
+	playMoves(t, sessionID, whiteCookie, blackCookie, "b1c3")
+}
+
+func Test_ClaimDraw_On_Threefold_Repetition(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	playMoves(t, sessionID, whiteCookie, blackCookie, "g1f3", "g8f6", "f3g1", "f6g8")
+
+	gameAction(t, whiteCookie, sessionID, "claim-draw", http.StatusConflict)
+
+	playMoves(t, sessionID, whiteCookie, blackCookie, "g1f3", "g8f6", "f3g1", "f6g8")
+
+	// Act
+	response := gameAction(t, whiteCookie, sessionID, "claim-draw", http.StatusOK)
+
+	// Assert
+	require.Equal(t, chess.Draw, response.Result)
+	require.Equal(t, chess.ThreefoldRepetition, response.Termination)
+}
+
+func Test_Game_Actions_Return_403_For_Non_Players(t *testing.T) {
+	sessionID, _, _ := startGame(t)
+	spectatorCookie := login(t)
+
+	gameAction(t, spectatorCookie, sessionID, "resign", http.StatusForbidden)
+	gameAction(t, spectatorCookie, sessionID, "offer-draw", http.StatusForbidden)
+}
@@ -2,15 +2,21 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/auth/domain"
 	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
 
+	"github.com/eskrenkovic/tql"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -20,8 +26,7 @@ func Test_CreateSessionCommand_Creates_New_Session_For_User(t *testing.T) {
 	sessionCookie := login(t)
 
 	createGameSessionCommand := commands.CreateSessionCommand{
-		OwnerID: uuid.New(),
-		Name:    uuid.New().String(),
+		Name: uuid.New().String(),
 	}
 
 	payload, err := json.Marshal(createGameSessionCommand)
@@ -54,41 +59,40 @@ func Test_CreateSessionCommand_Creates_New_Session_For_User(t *testing.T) {
 	require.NotEmpty(t, location)
 }
 
-func Test_CreateSessionCommand_Creates_Returns_400_When_OwnerID_Invalid(t *testing.T) {
+func Test_CreateSessionCommand_Creates_Session_Owned_By_Logged_In_User(t *testing.T) {
 	// Arrange
 	sessionCookie := login(t)
+	ownerID := sessionUserID(t, sessionCookie)
 
 	createGameSessionCommand := commands.CreateSessionCommand{
-		OwnerID: uuid.Nil,
+		OwnerID: uuid.New(),
 		Name:    uuid.New().String(),
 	}
 
-	payload, err := json.Marshal(createGameSessionCommand)
-	require.NoError(t, err)
-
-	r, err := http.NewRequest(
-		http.MethodPost,
+	// Act
+	var location string
+	sendAuthenticatedRequest[commands.CreateSessionCommand, any](
+		t,
+		sessionCookie,
 		fmt.Sprintf("%s%s", fixture.baseURL, "/game-sessions"),
-		bytes.NewReader(payload),
+		http.MethodPost,
+		createGameSessionCommand,
+		func(resp *http.Response) {
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+			location = resp.Header.Get("Location")
+		},
 	)
-	require.NoError(t, err)
-
-	r.AddCookie(&http.Cookie{
-		Name:  "chess-session",
-		Value: sessionCookie,
-	})
-
-	// Act
-	resp, err := fixture.client.Do(r)
 
 	// Assert
+	session, err := tql.QueryFirst[gamesessiondomain.Session](
+		context.Background(),
+		fixture.db,
+		"SELECT * FROM game_session WHERE id = $1;",
+		path.Base(location),
+	)
 	require.NoError(t, err)
-	defer resp.Body.Close()
-
-	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
-
-	location := resp.Header.Get("Location")
-	require.Empty(t, location)
+	require.Equal(t, ownerID, session.OwnerID)
+	require.Equal(t, ownerID, session.Player1ID)
 }
 
 func Test_CreateSessionCommand_Creates_Returns_400_When_Name_Empty(t *testing.T) {
@@ -96,8 +100,7 @@ func Test_CreateSessionCommand_Creates_Returns_400_When_Name_Empty(t *testing.T)
 	sessionCookie := login(t)
 
 	createGameSessionCommand := commands.CreateSessionCommand{
-		OwnerID: uuid.New(),
-		Name:    "",
+		Name: "",
 	}
 
 	payload, err := json.Marshal(createGameSessionCommand)
@@ -134,7 +137,7 @@ func Test_GetOwnedSessions_Returns_Empty_List_If_No_Active_Owned_Sessions(t *tes
 
 	r, err := http.NewRequest(
 		http.MethodGet,
-		fmt.Sprintf("%s%s?ownerId=%s", fixture.baseURL, "/game-sessions", uuid.New().String()),
+		fmt.Sprintf("%s%s", fixture.baseURL, "/game-sessions"),
 		nil,
 	)
 	require.NoError(t, err)
@@ -168,14 +171,13 @@ func Test_GetOwnedSessions_Returns_Sessions_Owned_By_User(t *testing.T) {
 	sessionCookie := login(t)
 
 	count := 5
-	ownerID := uuid.New()
+	ownerID := sessionUserID(t, sessionCookie)
 
 	for i := 0; i < count; i++ {
 		// Arrange
 
 		createGameSessionCommand := commands.CreateSessionCommand{
-			OwnerID: ownerID,
-			Name:    uuid.New().String(),
+			Name: uuid.New().String(),
 		}
 
 		payload, err := json.Marshal(createGameSessionCommand)
@@ -228,3 +230,624 @@ func Test_GetOwnedSessions_Returns_Sessions_Owned_By_User(t *testing.T) {
 
 	require.Equal(t, count, len(response))
 }
+
+func Test_CreateSessionCommand_Returns_400_When_TimeControl_Invalid(t *testing.T) {
+	// Arrange
+	sessionCookie := login(t)
+
+	createGameSessionCommand := commands.CreateSessionCommand{
+		Name:        uuid.New().String(),
+		TimeControl: gamesessiondomain.TimeControl{BaseSeconds: -1},
+	}
+
+	// Act
+	sendAuthenticatedRequest[commands.CreateSessionCommand, any](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s%s", fixture.baseURL, "/game-sessions"),
+		http.MethodPost,
+		createGameSessionCommand,
+		// Assert
+		func(resp *http.Response) { require.Equal(t, http.StatusBadRequest, resp.StatusCode) },
+	)
+}
+
+func Test_MakeMove_Starts_Clock_After_First_Moves(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	opponentCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{BaseSeconds: 180, IncrementSeconds: 2})
+
+	sendAuthenticatedRequest[any, commands.JoinSessionResponse](
+		t,
+		opponentCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	movesURL := fmt.Sprintf("%s/game-sessions/%s/moves", fixture.baseURL, sessionID)
+
+	sendAuthenticatedRequest[commands.MakeMoveCommand, commands.MakeMoveResponse](
+		t, ownerCookie, movesURL, http.MethodPost, commands.MakeMoveCommand{Move: "e2e4"},
+	)
+	sendAuthenticatedRequest[commands.MakeMoveCommand, commands.MakeMoveResponse](
+		t, opponentCookie, movesURL, http.MethodPost, commands.MakeMoveCommand{Move: "e7e5"},
+	)
+
+	// Act
+	response := sendAuthenticatedRequest[commands.MakeMoveCommand, commands.MakeMoveResponse](
+		t,
+		ownerCookie,
+		movesURL,
+		http.MethodPost,
+		commands.MakeMoveCommand{Move: "g1f3"},
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Assert
+	require.Equal(t, 3, response.Ply)
+	require.Equal(t, "Nf3", response.SAN)
+	require.Equal(t, gamesessiondomain.GameStarted, response.Status)
+	require.LessOrEqual(t, response.WhiteRemainingMs, int64(182_000))
+	require.Equal(t, int64(180_000), response.BlackRemainingMs)
+}
+
+func Test_MakeMove_Returns_400_When_Not_Players_Turn(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	opponentCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	sendAuthenticatedRequest[any, commands.JoinSessionResponse](
+		t,
+		opponentCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+	)
+
+	// Act
+	sendAuthenticatedRequest[commands.MakeMoveCommand, any](
+		t,
+		opponentCookie,
+		fmt.Sprintf("%s/game-sessions/%s/moves", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.MakeMoveCommand{Move: "e7e5"},
+		// Assert
+		func(resp *http.Response) { require.Equal(t, http.StatusBadRequest, resp.StatusCode) },
+	)
+}
+
+func createSession(t *testing.T, sessionCookie string, timeControl gamesessiondomain.TimeControl) string {
+	createGameSessionCommand := commands.CreateSessionCommand{
+		Name:        uuid.New().String(),
+		TimeControl: timeControl,
+	}
+
+	var location string
+	sendAuthenticatedRequest[commands.CreateSessionCommand, any](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s%s", fixture.baseURL, "/game-sessions"),
+		http.MethodPost,
+		createGameSessionCommand,
+		func(resp *http.Response) {
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+			location = resp.Header.Get("Location")
+		},
+	)
+
+	return path.Base(location)
+}
+
+func invite(t *testing.T, sessionCookie string, sessionID string, inviteeID uuid.UUID, opts ...responseAssertion) uuid.UUID {
+	var location string
+	if len(opts) == 0 {
+		opts = append(opts, func(resp *http.Response) {
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+			location = resp.Header.Get("Location")
+		})
+	}
+
+	sendAuthenticatedRequest[commands.CreateSessionInvitationCommand, any](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/game-sessions/%s/invitations", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.CreateSessionInvitationCommand{InviteeID: inviteeID},
+		opts...,
+	)
+
+	if location == "" {
+		return uuid.Nil
+	}
+
+	return uuid.MustParse(path.Base(location))
+}
+
+func getInvitation(t *testing.T, sessionCookie string, invitationID uuid.UUID) gamesessiondomain.SessionInvitation {
+	return sendAuthenticatedRequest[any, gamesessiondomain.SessionInvitation](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/invitations/%s", fixture.baseURL, invitationID),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+}
+
+func invitationAction(t *testing.T, sessionCookie string, invitationID uuid.UUID, action string, expectedStatus int) {
+	sendAuthenticatedRequest[any, any](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/invitations/%s/actions/%s", fixture.baseURL, invitationID, action),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func Test_CreateSessionInvitation_Sends_Invitation_As_Logged_In_User(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	inviteeCookie := login(t)
+	ownerID, inviteeID := sessionUserID(t, ownerCookie), sessionUserID(t, inviteeCookie)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	// Act
+	invitationID := invite(t, ownerCookie, sessionID, inviteeID)
+
+	// Assert
+	invitation := getInvitation(t, inviteeCookie, invitationID)
+	require.Equal(t, ownerID, invitation.InviterID)
+	require.Equal(t, gamesessiondomain.InvitationPending, invitation.Status)
+
+	received := sendAuthenticatedRequest[any, []gamesessiondomain.SessionInvitation](
+		t, inviteeCookie, fmt.Sprintf("%s/invitations/received", fixture.baseURL), http.MethodGet, nil,
+	)
+	require.Len(t, received, 1)
+	require.Equal(t, invitationID, received[0].ID)
+
+	sent := sendAuthenticatedRequest[any, []gamesessiondomain.SessionInvitation](
+		t, ownerCookie, fmt.Sprintf("%s/invitations/sent", fixture.baseURL), http.MethodGet, nil,
+	)
+	require.Len(t, sent, 1)
+	require.Equal(t, invitationID, sent[0].ID)
+
+	invite(
+		t,
+		ownerCookie,
+		sessionID,
+		inviteeID,
+		func(resp *http.Response) { require.Equal(t, http.StatusConflict, resp.StatusCode) },
+	)
+}
+
+func Test_CreateSessionInvitation_Returns_403_When_Not_Session_Owner(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	otherCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	// Act
+	invite(
+		t,
+		otherCookie,
+		sessionID,
+		sessionUserID(t, login(t)),
+		// Assert
+		func(resp *http.Response) { require.Equal(t, http.StatusForbidden, resp.StatusCode) },
+	)
+}
+
+func Test_AcceptInvitation_Seats_Invitee_And_Starts_Game(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	inviteeCookie := login(t)
+	otherCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+	invitationID := invite(t, ownerCookie, sessionID, sessionUserID(t, inviteeCookie))
+	otherInvitationID := invite(t, ownerCookie, sessionID, sessionUserID(t, otherCookie))
+
+	invitationAction(t, ownerCookie, invitationID, "accept", http.StatusForbidden)
+
+	// Act
+	response := sendAuthenticatedRequest[any, commands.AcceptInvitationResponse](
+		t,
+		inviteeCookie,
+		fmt.Sprintf("%s/invitations/%s/actions/accept", fixture.baseURL, invitationID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Assert
+	require.NotEqual(t, uuid.Nil, response.GameID)
+	require.Equal(t, gamesessiondomain.InvitationAccepted, getInvitation(t, inviteeCookie, invitationID).Status)
+
+	session, err := tql.QueryFirst[gamesessiondomain.Session](
+		context.Background(),
+		fixture.db,
+		"SELECT * FROM game_session WHERE id = $1;",
+		sessionID,
+	)
+	require.NoError(t, err)
+	require.True(t, session.Active)
+	require.Equal(t, sessionUserID(t, inviteeCookie), session.Player2ID)
+	require.Equal(t, response.GameID, session.GameID)
+
+	// The other invitations to the session are withdrawn.
+	require.Equal(t, gamesessiondomain.InvitationCancelled, getInvitation(t, otherCookie, otherInvitationID).Status)
+	invitationAction(t, otherCookie, otherInvitationID, "accept", http.StatusConflict)
+}
+
+func Test_DeclineInvitation_Declines_Pending_Invitation(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	inviteeCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+	invitationID := invite(t, ownerCookie, sessionID, sessionUserID(t, inviteeCookie))
+
+	invitationAction(t, ownerCookie, invitationID, "decline", http.StatusForbidden)
+
+	// Act
+	invitationAction(t, inviteeCookie, invitationID, "decline", http.StatusOK)
+
+	// Assert
+	invitation := getInvitation(t, ownerCookie, invitationID)
+	require.Equal(t, gamesessiondomain.InvitationDeclined, invitation.Status)
+	require.NotNil(t, invitation.RespondedAt)
+
+	invitationAction(t, inviteeCookie, invitationID, "accept", http.StatusConflict)
+}
+
+func Test_CancelInvitation_Cancels_Pending_Invitation(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	inviteeCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+	invitationID := invite(t, ownerCookie, sessionID, sessionUserID(t, inviteeCookie))
+
+	invitationAction(t, inviteeCookie, invitationID, "cancel", http.StatusForbidden)
+
+	// Act
+	invitationAction(t, ownerCookie, invitationID, "cancel", http.StatusOK)
+
+	// Assert
+	require.Equal(t, gamesessiondomain.InvitationCancelled, getInvitation(t, inviteeCookie, invitationID).Status)
+
+	received := sendAuthenticatedRequest[any, []gamesessiondomain.SessionInvitation](
+		t, inviteeCookie, fmt.Sprintf("%s/invitations/received", fixture.baseURL), http.MethodGet, nil,
+	)
+	require.Empty(t, received)
+
+	invitationAction(t, inviteeCookie, invitationID, "accept", http.StatusConflict)
+}
+
+func Test_CloseSession_Withdraws_Pending_Invitations(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	inviteeCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+	invitationID := invite(t, ownerCookie, sessionID, sessionUserID(t, inviteeCookie))
+
+	// Act
+	sendAuthenticatedRequest[any, any](
+		t,
+		ownerCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/close", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Assert
+	require.Equal(t, gamesessiondomain.InvitationCancelled, getInvitation(t, inviteeCookie, invitationID).Status)
+
+	sendAuthenticatedRequest[any, any](
+		t,
+		inviteeCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusConflict, resp.StatusCode) },
+	)
+}
+
+func Test_Invitations_Expire_When_Not_Answered_In_Time(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	inviteeCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+	invitationID := invite(t, ownerCookie, sessionID, sessionUserID(t, inviteeCookie))
+
+	// Act
+	_, err := tql.Exec(
+		context.Background(),
+		fixture.db,
+		"UPDATE session_invitation SET expires_at = $1 WHERE id = $2;",
+		time.Now().UTC().Add(-time.Minute),
+		invitationID,
+	)
+	require.NoError(t, err)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		return getInvitation(t, inviteeCookie, invitationID).Status == gamesessiondomain.InvitationExpired
+	}, 15*time.Second, 250*time.Millisecond)
+
+	invitationAction(t, inviteeCookie, invitationID, "accept", http.StatusConflict)
+}
+
+func Test_CreateSessionInvitation_Invites_By_Username(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	inviteeCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	username, err := tql.QueryFirst[string](
+		context.Background(),
+		fixture.db,
+		"SELECT username FROM auth.user WHERE id = $1;",
+		sessionUserID(t, inviteeCookie),
+	)
+	require.NoError(t, err)
+
+	var location string
+
+	// Act
+	sendAuthenticatedRequest[commands.CreateSessionInvitationCommand, any](
+		t,
+		ownerCookie,
+		fmt.Sprintf("%s/game-sessions/%s/invitations", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.CreateSessionInvitationCommand{InviteeUsername: username},
+		func(resp *http.Response) {
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+			location = resp.Header.Get("Location")
+		},
+	)
+
+	// Assert
+	invitation := getInvitation(t, inviteeCookie, uuid.MustParse(path.Base(location)))
+	require.Equal(t, sessionUserID(t, inviteeCookie), invitation.InviteeID)
+}
+
+func Test_CreateSessionInvitation_Returns_404_When_Username_Unknown(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	// Act
+	sendAuthenticatedRequest[commands.CreateSessionInvitationCommand, any](
+		t,
+		ownerCookie,
+		fmt.Sprintf("%s/game-sessions/%s/invitations", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.CreateSessionInvitationCommand{InviteeUsername: uuid.NewString()},
+		// Assert
+		func(resp *http.Response) { require.Equal(t, http.StatusNotFound, resp.StatusCode) },
+	)
+}
+
+func Test_CreateSessionInvitation_Returns_400_When_Several_Invitees_Given(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	// Act
+	sendAuthenticatedRequest[commands.CreateSessionInvitationCommand, any](
+		t,
+		ownerCookie,
+		fmt.Sprintf("%s/game-sessions/%s/invitations", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.CreateSessionInvitationCommand{InviteeID: uuid.New(), InviteeUsername: uuid.NewString()},
+		// Assert
+		func(resp *http.Response) { require.Equal(t, http.StatusBadRequest, resp.StatusCode) },
+	)
+}
+
+func Test_CreateSessionInvitation_By_Email_Becomes_Invitation_After_Registration(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	email := fmt.Sprintf("%s@tests.com", uuid.NewString())
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	response := sendAuthenticatedRequest[commands.CreateSessionInvitationCommand, commands.CreateSessionInvitationResponse](
+		t,
+		ownerCookie,
+		fmt.Sprintf("%s/game-sessions/%s/invitations", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.CreateSessionInvitationCommand{InviteeEmail: strings.ToUpper(email)},
+		func(resp *http.Response) { require.Equal(t, http.StatusAccepted, resp.StatusCode) },
+	)
+	require.NotEqual(t, uuid.Nil, response.EmailInvitationID)
+
+	// Act
+	inviteeCookie := loginWithEmail(t, email)
+
+	// Assert
+	var received []gamesessiondomain.SessionInvitation
+	require.Eventually(t, func() bool {
+		received = sendAuthenticatedRequest[any, []gamesessiondomain.SessionInvitation](
+			t, inviteeCookie, fmt.Sprintf("%s/invitations/received", fixture.baseURL), http.MethodGet, nil,
+		)
+		return len(received) == 1
+	}, 15*time.Second, 250*time.Millisecond)
+
+	require.Equal(t, sessionID, received[0].SessionID)
+	require.Equal(t, sessionUserID(t, ownerCookie), received[0].InviterID)
+
+	emailInvitation, err := tql.QueryFirst[gamesessiondomain.EmailInvitation](
+		context.Background(),
+		fixture.db,
+		"SELECT * FROM email_invitation WHERE id = $1;",
+		response.EmailInvitationID,
+	)
+	require.NoError(t, err)
+	require.Equal(t, gamesessiondomain.InvitationConverted, emailInvitation.Status)
+	require.Equal(t, received[0].ID, emailInvitation.InvitationID.UUID)
+}
+
+func inviteByEmail(t *testing.T, sessionCookie string, sessionID string, email string, expectedStatus int) commands.CreateSessionInvitationResponse {
+	return sendAuthenticatedRequest[commands.CreateSessionInvitationCommand, commands.CreateSessionInvitationResponse](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/game-sessions/%s/invitations", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.CreateSessionInvitationCommand{InviteeEmail: email},
+		func(resp *http.Response) {
+			require.Equal(t, expectedStatus, resp.StatusCode)
+			require.Empty(t, resp.Header.Get("Location"))
+		},
+	)
+}
+
+func Test_CreateSessionInvitation_By_Email_Does_Not_Reveal_Accounts(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	registeredEmail := fmt.Sprintf("%s@tests.com", uuid.NewString())
+	inviteeCookie := loginWithEmail(t, registeredEmail)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	// Act
+	unknown := inviteByEmail(t, ownerCookie, sessionID, fmt.Sprintf("%s@tests.com", uuid.NewString()), http.StatusAccepted)
+	registered := inviteByEmail(t, ownerCookie, sessionID, registeredEmail, http.StatusAccepted)
+
+	// Assert
+	for _, response := range []commands.CreateSessionInvitationResponse{unknown, registered} {
+		require.Equal(t, uuid.Nil, response.InvitationID)
+		require.NotEqual(t, uuid.Nil, response.EmailInvitationID)
+	}
+
+	require.Eventually(t, func() bool {
+		received := sendAuthenticatedRequest[any, []gamesessiondomain.SessionInvitation](
+			t, inviteeCookie, fmt.Sprintf("%s/invitations/received", fixture.baseURL), http.MethodGet, nil,
+		)
+		return len(received) == 1
+	}, 15*time.Second, 250*time.Millisecond)
+}
+
+func Test_CreateSessionInvitation_By_Email_Is_Rate_Limited(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	for range gamesessiondomain.MaxEmailInvitations {
+		inviteByEmail(t, ownerCookie, sessionID, fmt.Sprintf("%s@tests.com", uuid.NewString()), http.StatusAccepted)
+	}
+
+	// Act & Assert
+	inviteByEmail(t, ownerCookie, sessionID, fmt.Sprintf("%s@tests.com", uuid.NewString()), http.StatusTooManyRequests)
+	invite(t, ownerCookie, sessionID, sessionUserID(t, login(t)))
+}
+
+func createInviteLink(t *testing.T, sessionCookie string, sessionID string, command commands.CreateInviteLinkCommand) string {
+	var location string
+	sendAuthenticatedRequest[commands.CreateInviteLinkCommand, any](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/game-sessions/%s/invite-links", fixture.baseURL, sessionID),
+		http.MethodPost,
+		command,
+		func(resp *http.Response) {
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+			location = resp.Header.Get("Location")
+		},
+	)
+
+	return path.Base(location)
+}
+
+func redeemInviteLink(t *testing.T, sessionCookie string, code string, expectedStatus int) commands.RedeemInviteLinkResponse {
+	return sendAuthenticatedRequest[any, commands.RedeemInviteLinkResponse](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/invite-links/%s/actions/redeem", fixture.baseURL, code),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func Test_RedeemInviteLink_Joins_Session(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	playerCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+	code := createInviteLink(t, ownerCookie, sessionID, commands.CreateInviteLinkCommand{MaxUses: 2})
+	require.Len(t, code, gamesessiondomain.JoinCodeLength)
+
+	redeemInviteLink(t, ownerCookie, code, http.StatusBadRequest)
+
+	// Act
+	response := redeemInviteLink(t, playerCookie, strings.ToLower(code), http.StatusOK)
+
+	// Assert
+	require.NotEqual(t, uuid.Nil, response.GameID)
+
+	session, err := tql.QueryFirst[gamesessiondomain.Session](
+		context.Background(),
+		fixture.db,
+		"SELECT * FROM game_session WHERE id = $1;",
+		sessionID,
+	)
+	require.NoError(t, err)
+	require.True(t, session.Active)
+	require.Equal(t, sessionUserID(t, playerCookie), session.Player2ID)
+
+	// The link has a use left, but the session is full.
+	redeemInviteLink(t, login(t), code, http.StatusConflict)
+}
+
+func Test_CreateInviteLink_Returns_403_When_Not_Session_Owner(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	// Act
+	sendAuthenticatedRequest[commands.CreateInviteLinkCommand, any](
+		t,
+		login(t),
+		fmt.Sprintf("%s/game-sessions/%s/invite-links", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.CreateInviteLinkCommand{},
+		// Assert
+		func(resp *http.Response) { require.Equal(t, http.StatusForbidden, resp.StatusCode) },
+	)
+}
+
+func Test_RedeemInviteLink_Returns_410_When_Expired(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+	code := createInviteLink(t, ownerCookie, sessionID, commands.CreateInviteLinkCommand{TTLSeconds: 60})
+
+	_, err := tql.Exec(
+		context.Background(),
+		fixture.db,
+		"UPDATE invite_link SET expires_at = $1 WHERE code = $2;",
+		time.Now().UTC().Add(-time.Second),
+		code,
+	)
+	require.NoError(t, err)
+
+	// Act
+	redeemInviteLink(t, login(t), code, http.StatusGone)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+type streamMessage struct {
+	Type    string          `json:"type"`
+	Version int             `json:"version"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+func dialGameStream(t *testing.T, sessionCookie string, sessionID string, version int) *websocket.Conn {
+	url := fmt.Sprintf(
+		"%s/game-sessions/%s/live?version=%d",
+		strings.Replace(fixture.baseURL, "http", "ws", 1),
+		sessionID,
+		version,
+	)
+
+	header := http.Header{}
+	header.Add("Cookie", fmt.Sprintf("chess-session=%s", sessionCookie))
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func readStreamMessage(t *testing.T, conn *websocket.Conn) streamMessage {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+
+	var message streamMessage
+	require.NoError(t, conn.ReadJSON(&message))
+
+	return message
+}
+
+func Test_GameStream_Pushes_Moves_To_Spectators(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	opponentCookie := login(t)
+	spectatorCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	sendAuthenticatedRequest[any, commands.JoinSessionResponse](
+		t,
+		opponentCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+	)
+
+	conn := dialGameStream(t, spectatorCookie, sessionID, 0)
+	require.Equal(t, string(gamesessiondomain.GameStartedEvent), readStreamMessage(t, conn).Type)
+
+	// Act
+	sendAuthenticatedRequest[commands.MakeMoveCommand, commands.MakeMoveResponse](
+		t,
+		ownerCookie,
+		fmt.Sprintf("%s/game-sessions/%s/moves", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.MakeMoveCommand{Move: "e2e4"},
+	)
+
+	// Assert
+	message := readStreamMessage(t, conn)
+	require.Equal(t, string(gamesessiondomain.MoveMadeEvent), message.Type)
+	require.Equal(t, 2, message.Version)
+
+	var payload gamesessiondomain.MoveMadePayload
+	require.NoError(t, json.Unmarshal(message.Payload, &payload))
+	require.Equal(t, "e4", payload.SAN)
+}
+
+func Test_GameStream_Accepts_Moves_And_Resumes_From_Version(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	opponentCookie := login(t)
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	sendAuthenticatedRequest[any, commands.JoinSessionResponse](
+		t,
+		opponentCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+	)
+
+	conn := dialGameStream(t, ownerCookie, sessionID, 1)
+
+	// Act
+	require.NoError(t, conn.WriteJSON(map[string]string{"type": "move", "move": "d2d4"}))
+
+	// Assert
+	messages := []streamMessage{readStreamMessage(t, conn), readStreamMessage(t, conn)}
+	types := []string{messages[0].Type, messages[1].Type}
+	require.ElementsMatch(t, []string{"move_accepted", string(gamesessiondomain.MoveMadeEvent)}, types)
+
+	resumed := dialGameStream(t, opponentCookie, sessionID, 1)
+	message := readStreamMessage(t, resumed)
+	require.Equal(t, string(gamesessiondomain.MoveMadeEvent), message.Type)
+	require.Equal(t, 2, message.Version)
+}
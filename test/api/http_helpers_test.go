@@ -65,9 +65,13 @@ func sendRequest[TReq any, TResp any](
 }
 
 func login(t *testing.T) string {
+	return loginWithEmail(t, fmt.Sprintf("%s@tests.com", uuid.NewString()))
+}
+
+func loginWithEmail(t *testing.T, email string) string {
 	// Arrange
 	registerUserCommand := commands.RegisterCommand{
-		Email:    fmt.Sprintf("%s@tests.com", uuid.NewString()),
+		Email:    email,
 		Username: uuid.New().String(),
 		Password: uuid.New().String(),
 	}
@@ -138,3 +142,57 @@ func login(t *testing.T) string {
 
 	return cookie
 }
+
+func sessionUserID(t *testing.T, sessionCookie string) uuid.UUID {
+	userID, err := tql.QueryFirst[uuid.UUID](
+		context.Background(),
+		fixture.db,
+		"SELECT user_id FROM auth.session WHERE id = $1;",
+		sessionCookie,
+	)
+	require.NoError(t, err)
+
+	return userID
+}
+
+func sendAuthenticatedRequest[TReq any, TResp any](
+	t *testing.T,
+	sessionCookie string,
+	url string,
+	method string,
+	req TReq,
+	opts ...responseAssertion,
+) TResp {
+	var resp TResp
+
+	payload, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	httpReq, err := http.NewRequest(method, url, bytes.NewReader(payload))
+	require.NoError(t, err)
+
+	httpReq.AddCookie(&http.Cookie{
+		Name:  "chess-session",
+		Value: sessionCookie,
+	})
+
+	httpResp, err := fixture.client.Do(httpReq)
+	require.NoError(t, err)
+
+	defer func() {
+		_ = httpResp.Body.Close()
+	}()
+
+	for _, opt := range opts {
+		opt(httpResp)
+	}
+
+	responsePayload, err := io.ReadAll(httpResp.Body)
+	require.NoError(t, err)
+
+	if httpResp.StatusCode < 300 && len(responsePayload) > 0 {
+		require.NoError(t, json.Unmarshal(responsePayload, &resp))
+	}
+
+	return resp
+}
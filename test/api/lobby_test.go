@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/queries"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createSessionWithCommand(t *testing.T, sessionCookie string, command commands.CreateSessionCommand) string {
+	command.Name = uuid.New().String()
+
+	var location string
+	sendAuthenticatedRequest[commands.CreateSessionCommand, any](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s%s", fixture.baseURL, "/game-sessions"),
+		http.MethodPost,
+		command,
+		func(resp *http.Response) {
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+			location = resp.Header.Get("Location")
+		},
+	)
+
+	return path.Base(location)
+}
+
+func getLobbyPage(t *testing.T, sessionCookie string, params url.Values, opts ...responseAssertion) core.Page[queries.LobbySession] {
+	if len(opts) == 0 {
+		opts = append(opts, func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) })
+	}
+
+	return sendAuthenticatedRequest[any, core.Page[queries.LobbySession]](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/lobby?%s", fixture.baseURL, params.Encode()),
+		http.MethodGet,
+		nil,
+		opts...,
+	)
+}
+
+// getLobby walks every page of the lobby, the lobby is shared by all the tests.
+func getLobby(t *testing.T, sessionCookie string, params url.Values) []queries.LobbySession {
+	var sessions []queries.LobbySession
+
+	for {
+		page := getLobbyPage(t, sessionCookie, params)
+		sessions = append(sessions, page.Items...)
+
+		if page.NextCursor == "" {
+			return sessions
+		}
+
+		params.Set("cursor", page.NextCursor)
+	}
+}
+
+func lobbySessionIDs(sessions []queries.LobbySession) []string {
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.SessionID)
+	}
+
+	return ids
+}
+
+func Test_Lobby_Lists_Public_Sessions_Only(t *testing.T) {
+	// Arrange
+	sessionCookie := login(t)
+	timeControl := uniqueTimeControl()
+
+	publicID := createSessionWithCommand(t, sessionCookie, commands.CreateSessionCommand{TimeControl: timeControl})
+	privateID := createSessionWithCommand(t, sessionCookie, commands.CreateSessionCommand{
+		TimeControl: timeControl,
+		Visibility:  gamesessiondomain.Private,
+	})
+
+	// Act
+	sessions := getLobby(t, sessionCookie, url.Values{"category": {string(timeControl.Category())}})
+
+	// Assert
+	ids := lobbySessionIDs(sessions)
+	require.Contains(t, ids, publicID)
+	require.NotContains(t, ids, privateID)
+}
+
+func Test_Lobby_Filters_By_Rated_And_Variant(t *testing.T) {
+	// Arrange
+	sessionCookie := login(t)
+	timeControl := gamesessiondomain.TimeControl{BaseSeconds: 300, IncrementSeconds: 3, Delay: gamesessiondomain.FischerDelay}
+
+	ratedID := createSessionWithCommand(t, sessionCookie, commands.CreateSessionCommand{TimeControl: timeControl, Rated: true})
+	casualID := createSessionWithCommand(t, sessionCookie, commands.CreateSessionCommand{TimeControl: timeControl})
+
+	// Act
+	sessions := getLobby(t, sessionCookie, url.Values{
+		"category": {string(gamesessiondomain.Blitz)},
+		"rated":    {"true"},
+		"variant":  {string(gamesessiondomain.Standard)},
+		"limit":    {"100"},
+	})
+
+	// Assert
+	ids := lobbySessionIDs(sessions)
+	require.Contains(t, ids, ratedID)
+	require.NotContains(t, ids, casualID)
+
+	for _, session := range sessions {
+		require.True(t, session.Rated)
+		require.Equal(t, gamesessiondomain.Blitz, session.TimeControlCategory)
+	}
+}
+
+func Test_Lobby_Filters_By_Owner_Rating(t *testing.T) {
+	// Arrange
+	sessionCookie := login(t)
+	sessionID := createSessionWithCommand(t, sessionCookie, commands.CreateSessionCommand{TimeControl: uniqueTimeControl()})
+
+	// Act
+	inRange := getLobby(t, sessionCookie, url.Values{"minRating": {"1400"}, "maxRating": {"1600"}, "sort": {"rating"}})
+	outOfRange := getLobby(t, sessionCookie, url.Values{"minRating": {"1600"}, "sort": {"rating"}})
+
+	// Assert
+	require.Contains(t, lobbySessionIDs(inRange), sessionID)
+	require.NotContains(t, lobbySessionIDs(outOfRange), sessionID)
+}
+
+func Test_Lobby_Pages_Through_Sessions_Newest_First(t *testing.T) {
+	// Arrange
+	sessionCookie := login(t)
+	timeControl := uniqueTimeControl()
+
+	var created []string
+	for range 3 {
+		created = append(created, createSessionWithCommand(t, sessionCookie, commands.CreateSessionCommand{TimeControl: timeControl}))
+	}
+
+	// Act
+	sessions := getLobby(t, sessionCookie, url.Values{"category": {string(timeControl.Category())}, "limit": {"1"}})
+
+	// Assert
+	var listed []string
+	seen := map[string]bool{}
+	for _, session := range sessions {
+		require.False(t, seen[session.SessionID], "session '%s' listed twice", session.SessionID)
+		seen[session.SessionID] = true
+
+		if session.TimeControlBaseSeconds == timeControl.BaseSeconds &&
+			session.TimeControlIncrementSeconds == timeControl.IncrementSeconds {
+			listed = append(listed, session.SessionID)
+		}
+	}
+
+	require.Equal(t, []string{created[2], created[1], created[0]}, listed)
+}
+
+func Test_Lobby_Returns_400_When_Cursor_Is_From_Another_Sort(t *testing.T) {
+	// Arrange
+	sessionCookie := login(t)
+	for range 2 {
+		createSessionWithCommand(t, sessionCookie, commands.CreateSessionCommand{TimeControl: uniqueTimeControl()})
+	}
+
+	page := getLobbyPage(t, sessionCookie, url.Values{"limit": {"1"}})
+	require.NotEmpty(t, page.NextCursor)
+
+	// Act & Assert
+	getLobbyPage(
+		t,
+		sessionCookie,
+		url.Values{"limit": {"1"}, "sort": {"rating"}, "cursor": {page.NextCursor}},
+		func(resp *http.Response) { require.Equal(t, http.StatusBadRequest, resp.StatusCode) },
+	)
+}
+
+func Test_JoinSession_Returns_403_When_Session_Is_Private(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	playerCookie := login(t)
+
+	sessionID := createSessionWithCommand(t, ownerCookie, commands.CreateSessionCommand{Visibility: gamesessiondomain.Private})
+
+	// Act & Assert
+	sendAuthenticatedRequest[any, any](
+		t,
+		playerCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusForbidden, resp.StatusCode) },
+	)
+}
+
+func Test_GetOwnedSessions_Returns_403_For_Another_Owner(t *testing.T) {
+	// Arrange
+	sessionCookie := login(t)
+
+	// Act & Assert
+	sendAuthenticatedRequest[any, any](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/game-sessions?ownerId=%s", fixture.baseURL, uuid.New()),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusForbidden, resp.StatusCode) },
+	)
+}
@@ -24,9 +24,10 @@ import (
 )
 
 type IntegrationTestFixture struct {
-	client  *http.Client
-	baseURL string
-	db      *sql.DB
+	client      *http.Client
+	baseURL     string
+	db          *sql.DB
+	databaseURL string
 }
 
 var fixture = IntegrationTestFixture{}
@@ -70,6 +71,7 @@ func TestMain(m *testing.M) {
 	}
 
 	conf.Logger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+	conf.UCIEngine.Path = path.Join(rootPath, "internal", "modules", "uci", "testdata", "fake_engine.sh")
 
 	pgPort := nat.Port(fmt.Sprintf("%d", 5432))
 	mailhogPort := nat.Port(fmt.Sprintf("%d", 8025))
@@ -137,6 +139,7 @@ func initFixture(config config.Config) error {
 	}
 
 	fixture.db = db
+	fixture.databaseURL = config.DatabaseURL
 
 	return nil
 }
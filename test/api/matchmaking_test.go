@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// uniqueTimeControl keeps the tickets of a test from being matched with the tickets of other tests.
+func uniqueTimeControl() gamesessiondomain.TimeControl {
+	return gamesessiondomain.TimeControl{
+		BaseSeconds:      60 + rand.IntN(3*60*60-60),
+		IncrementSeconds: rand.IntN(60),
+		Delay:            gamesessiondomain.FischerDelay,
+	}
+}
+
+func enqueue(t *testing.T, sessionCookie string, timeControl gamesessiondomain.TimeControl, opts ...responseAssertion) {
+	if len(opts) == 0 {
+		opts = append(opts, func(resp *http.Response) { require.Equal(t, http.StatusCreated, resp.StatusCode) })
+	}
+
+	sendAuthenticatedRequest[commands.EnqueueMatchmakingCommand, any](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/matchmaking/queue", fixture.baseURL),
+		http.MethodPost,
+		commands.EnqueueMatchmakingCommand{TimeControl: timeControl, RatingRange: 100},
+		opts...,
+	)
+}
+
+func getTicket(t *testing.T, playerID uuid.UUID) gamesessiondomain.MatchmakingTicket {
+	ticket, err := tql.QueryFirst[gamesessiondomain.MatchmakingTicket](
+		context.Background(),
+		fixture.db,
+		"SELECT * FROM matchmaking_ticket WHERE player_id = $1;",
+		playerID,
+	)
+	require.NoError(t, err)
+
+	return ticket
+}
+
+func Test_Matchmaking_Matches_Players_And_Starts_Game(t *testing.T) {
+	// Arrange
+	firstCookie := login(t)
+	secondCookie := login(t)
+	timeControl := uniqueTimeControl()
+
+	events := openNotificationStream(t, secondCookie, "")
+
+	// Act
+	enqueue(t, firstCookie, timeControl)
+	enqueue(t, secondCookie, timeControl)
+
+	// Assert
+	event := readNotification(t, events, notificationsdomain.GameStartedNotification)
+	require.NotEmpty(t, event.Data)
+
+	first := getTicket(t, sessionUserID(t, firstCookie))
+	second := getTicket(t, sessionUserID(t, secondCookie))
+
+	require.Equal(t, gamesessiondomain.TicketMatched, first.Status)
+	require.Equal(t, gamesessiondomain.TicketMatched, second.Status)
+	require.Equal(t, *first.SessionID, *second.SessionID)
+
+	session, err := tql.QueryFirst[gamesessiondomain.Session](
+		context.Background(),
+		fixture.db,
+		"SELECT * FROM game_session WHERE id = $1;",
+		*first.SessionID,
+	)
+	require.NoError(t, err)
+	require.True(t, session.Active)
+	require.ElementsMatch(t, []uuid.UUID{first.PlayerID, second.PlayerID}, []uuid.UUID{session.Player1ID, session.Player2ID})
+	require.Equal(t, timeControl, session.TimeControl())
+}
+
+func Test_Matchmaking_Returns_409_When_Already_Queued(t *testing.T) {
+	// Arrange
+	cookie := login(t)
+	timeControl := uniqueTimeControl()
+
+	enqueue(t, cookie, timeControl)
+
+	// Act
+	enqueue(
+		t,
+		cookie,
+		timeControl,
+		// Assert
+		func(resp *http.Response) { require.Equal(t, http.StatusConflict, resp.StatusCode) },
+	)
+}
+
+func Test_Matchmaking_Leave_Removes_Ticket(t *testing.T) {
+	// Arrange
+	cookie := login(t)
+	enqueue(t, cookie, uniqueTimeControl())
+
+	leave := func(expectedStatus int) {
+		sendAuthenticatedRequest[any, any](
+			t,
+			cookie,
+			fmt.Sprintf("%s/matchmaking/queue", fixture.baseURL),
+			http.MethodDelete,
+			nil,
+			func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+		)
+	}
+
+	// Act
+	leave(http.StatusOK)
+
+	// Assert
+	leave(http.StatusNotFound)
+}
+
+func Test_MatchPlayers_Widens_Rating_Range_Over_Time(t *testing.T) {
+	// Arrange
+	// The tickets are created far in the future so the matcher run by the
+	// server does not widen their ranges, only the handlers below do.
+	clock := &core.FixedClock{Time: time.Date(2100, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	enqueueHandler := commands.NewEnqueueMatchmakingCommandHandler(fixture.db, clock)
+	matchHandler := commands.NewMatchPlayersCommandHandler(fixture.db, clock)
+
+	timeControl := uniqueTimeControl()
+	firstID, secondID := sessionUserID(t, login(t)), sessionUserID(t, login(t))
+
+	_, err := enqueueHandler.Handle(
+		context.Background(),
+		commands.EnqueueMatchmakingCommand{PlayerID: firstID, TimeControl: timeControl, RatingRange: 100},
+	)
+	require.NoError(t, err)
+
+	// The second ticket is stored directly, for its player to be rated differently from the start.
+	ticket, err := gamesessiondomain.NewMatchmakingTicket(secondID, 1700, 100, timeControl, false, clock.Now())
+	require.NoError(t, err)
+
+	_, err = tql.Exec(
+		context.Background(),
+		fixture.db,
+		`INSERT INTO matchmaking_ticket (
+			id, player_id, rating, rating_range, rated, time_control_base_seconds, time_control_increment_seconds,
+			time_control_delay, status, session_id, created_at, matched_at
+		)
+		VALUES (
+			:id, :player_id, :rating, :rating_range, :rated, :time_control_base_seconds, :time_control_increment_seconds,
+			:time_control_delay, :status, :session_id, :created_at, :matched_at
+		);`,
+		ticket,
+	)
+	require.NoError(t, err)
+
+	// Act
+	clock.Advance(gamesessiondomain.RatingRangeWideningInterval)
+	_, err = matchHandler.Handle(context.Background(), commands.MatchPlayersCommand{})
+	require.NoError(t, err)
+
+	before := getTicket(t, firstID)
+
+	// The matcher of the server might hold the lock and skip a run.
+	clock.Advance(gamesessiondomain.RatingRangeWideningInterval)
+	require.Eventually(t, func() bool {
+		_, err := matchHandler.Handle(context.Background(), commands.MatchPlayersCommand{})
+		require.NoError(t, err)
+
+		return getTicket(t, firstID).Status == gamesessiondomain.TicketMatched
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// Assert
+	require.Equal(t, gamesessiondomain.TicketWaiting, before.Status)
+	require.Equal(t, gamesessiondomain.TicketMatched, getTicket(t, secondID).Status)
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+type serverSentEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// openNotificationStream returns the events read from the stream, the comments
+// and the retry field are skipped.
+func openNotificationStream(t *testing.T, sessionCookie string, lastEventID string) <-chan serverSentEvent {
+	return openEventStream(t, sessionCookie, fmt.Sprintf("%s/notifications/live", fixture.baseURL), lastEventID)
+}
+
+// openEventStream returns the events read from the server-sent event stream at the url.
+func openEventStream(t *testing.T, sessionCookie string, url string, lastEventID string) <-chan serverSentEvent {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	req.Header.Add("Cookie", fmt.Sprintf("chess-session=%s", sessionCookie))
+	if lastEventID != "" {
+		req.Header.Add("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan serverSentEvent)
+
+	go func() {
+		defer close(events)
+
+		var event serverSentEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			switch {
+			case line == "":
+				if event.Event != "" {
+					events <- event
+				}
+				event = serverSentEvent{}
+			case strings.HasPrefix(line, "id: "):
+				event.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				event.Event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.Data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+
+	return events
+}
+
+// readNotification returns the next event of the given type, skipping the others,
+// since lobby notifications from concurrently running tests are delivered to everyone.
+func readNotification(t *testing.T, events <-chan serverSentEvent, notificationType notificationsdomain.NotificationType) serverSentEvent {
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "stream closed")
+			if event.Event == string(notificationType) {
+				return event
+			}
+		case <-timeout:
+			require.FailNow(t, fmt.Sprintf("no '%s' notification received", notificationType))
+		}
+	}
+}
+
+func Test_NotificationStream_Streams_Lobby_And_Game_Started_Notifications(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	opponentCookie := login(t)
+
+	events := openNotificationStream(t, ownerCookie, "")
+
+	// Act
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	sendAuthenticatedRequest[any, commands.JoinSessionResponse](
+		t,
+		opponentCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+	)
+
+	// Assert
+	var opened notificationsdomain.LobbySessionOpenedPayload
+	for opened.SessionID != sessionID {
+		event := readNotification(t, events, notificationsdomain.LobbySessionOpenedNotification)
+		require.NoError(t, json.Unmarshal([]byte(event.Data), &opened))
+	}
+
+	event := readNotification(t, events, notificationsdomain.GameStartedNotification)
+
+	var started notificationsdomain.GameStartedPayload
+	require.NoError(t, json.Unmarshal([]byte(event.Data), &started))
+	require.Equal(t, sessionID, started.SessionID)
+	require.Equal(t, sessionUserID(t, opponentCookie), started.BlackID)
+}
+
+func Test_NotificationStream_Resumes_From_Last_Event_ID(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	opponentCookie := login(t)
+
+	events := openNotificationStream(t, opponentCookie, "")
+
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	var opened notificationsdomain.LobbySessionOpenedPayload
+	var lastEventID string
+	for opened.SessionID != sessionID {
+		event := readNotification(t, events, notificationsdomain.LobbySessionOpenedNotification)
+		require.NoError(t, json.Unmarshal([]byte(event.Data), &opened))
+		lastEventID = event.ID
+	}
+
+	// Act
+	sendAuthenticatedRequest[commands.CreateSessionInvitationCommand, any](
+		t,
+		ownerCookie,
+		fmt.Sprintf("%s/game-sessions/%s/invitations", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.CreateSessionInvitationCommand{
+			InviterID: sessionUserID(t, ownerCookie),
+			InviteeID: sessionUserID(t, opponentCookie),
+		},
+	)
+
+	// Assert
+	resumed := openNotificationStream(t, opponentCookie, lastEventID)
+
+	event := readNotification(t, resumed, notificationsdomain.InvitationReceivedNotification)
+
+	var invitation notificationsdomain.InvitationReceivedPayload
+	require.NoError(t, json.Unmarshal([]byte(event.Data), &invitation))
+	require.Equal(t, sessionID, invitation.SessionID)
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func sessionGameID(t *testing.T, sessionID string) uuid.UUID {
+	gameID, err := tql.QueryFirst[uuid.UUID](
+		context.Background(),
+		fixture.db,
+		"SELECT game_id FROM game_session WHERE id = $1;",
+		sessionID,
+	)
+	require.NoError(t, err)
+
+	return gameID
+}
+
+// getPGN downloads a PGN export, which is not JSON.
+func getPGN(t *testing.T, sessionCookie string, url string, expectedStatus int) string {
+	httpReq, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	httpReq.AddCookie(&http.Cookie{Name: "chess-session", Value: sessionCookie})
+
+	httpResp, err := fixture.client.Do(httpReq)
+	require.NoError(t, err)
+
+	defer func() {
+		_ = httpResp.Body.Close()
+	}()
+
+	require.Equal(t, expectedStatus, httpResp.StatusCode)
+
+	body, err := io.ReadAll(httpResp.Body)
+	require.NoError(t, err)
+
+	if expectedStatus == http.StatusOK {
+		require.Equal(t, "application/x-chess-pgn", httpResp.Header.Get("Content-Type"))
+	}
+
+	return string(body)
+}
+
+func Test_GetGamePGN_Exports_Finished_Game(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	gameID := sessionGameID(t, sessionID)
+	playMoves(t, sessionID, whiteCookie, blackCookie, "f2f3", "e7e5", "g2g4")
+
+	url := fmt.Sprintf("%s/games/%s/pgn", fixture.baseURL, gameID)
+	getPGN(t, whiteCookie, url, http.StatusConflict)
+
+	playMoves(t, sessionID, blackCookie, whiteCookie, "d8h4")
+
+	// Act
+	pgn := getPGN(t, login(t), url, http.StatusOK)
+
+	// Assert
+	games, err := chess.ParsePGN(pgn)
+	require.NoError(t, err)
+	require.Len(t, games, 1)
+
+	game := games[0]
+	require.Equal(t, chess.BlackWins, game.Result)
+	require.Equal(t, gamesessiondomain.PGNSite, game.Tag("Site"))
+	require.NotEmpty(t, game.Tag("White"))
+	require.NotEmpty(t, game.Tag("Black"))
+
+	require.NoError(t, game.Resolve())
+	require.Equal(t, []string{"f2f3", "e7e5", "g2g4", "d8h4"}, game.MainLine())
+}
+
+func Test_GetGamePGN_Returns_404_For_Private_Game_Of_Others(t *testing.T) {
+	// Arrange
+	whiteCookie := login(t)
+	sessionID := createSessionWithCommand(t, whiteCookie, commands.CreateSessionCommand{Visibility: gamesessiondomain.Private})
+
+	blackCookie := login(t)
+
+	// Private sessions are joined through invite links.
+	link := sendAuthenticatedRequest[commands.CreateInviteLinkCommand, commands.CreateInviteLinkResponse](
+		t,
+		whiteCookie,
+		fmt.Sprintf("%s/game-sessions/%s/invite-links", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.CreateInviteLinkCommand{},
+		func(resp *http.Response) { require.Equal(t, http.StatusCreated, resp.StatusCode) },
+	)
+	sendAuthenticatedRequest[any, commands.RedeemInviteLinkResponse](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/invite-links/%s/actions/redeem", fixture.baseURL, link.Code),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	gameAction(t, blackCookie, sessionID, "resign", http.StatusOK)
+	url := fmt.Sprintf("%s/games/%s/pgn", fixture.baseURL, sessionGameID(t, sessionID))
+
+	// Act & Assert
+	getPGN(t, login(t), url, http.StatusNotFound)
+	getPGN(t, blackCookie, url, http.StatusOK)
+}
+
+func Test_ExportPlayerGamesPGN_Streams_All_Finished_Games(t *testing.T) {
+	// Arrange
+	playerCookie := login(t)
+
+	for i := range 4 {
+		opponentCookie := login(t)
+		sessionID := createSession(t, playerCookie, gamesessiondomain.TimeControl{})
+		sendAuthenticatedRequest[any, commands.JoinSessionResponse](
+			t,
+			opponentCookie,
+			fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+			http.MethodPut,
+			nil,
+			func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+		)
+		playMoves(t, sessionID, playerCookie, opponentCookie, "e2e4")
+
+		// The last game is still being played and is left out.
+		if i < 3 {
+			gameAction(t, opponentCookie, sessionID, "resign", http.StatusOK)
+		}
+	}
+
+	// Act
+	pgn := getPGN(t, playerCookie, fmt.Sprintf("%s/games/pgn", fixture.baseURL), http.StatusOK)
+
+	// Assert
+	games, err := chess.ParsePGN(pgn)
+	require.NoError(t, err)
+	require.Len(t, games, 3)
+
+	for _, game := range games {
+		require.Equal(t, chess.WhiteWins, game.Result)
+		require.NoError(t, game.Resolve())
+	}
+}
+
+func Test_ImportPGN_Stores_Games_For_Owner(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	pgn := `[Event "Opera game"]
+[White "Morphy"]
+[Black "Duke of Brunswick and Count Isouard"]
+[Result "1-0"]
+
+1. e4 e5 2. Nf3 d6 3. d4 Bg4 $6 {This is a weak move already.} 4. dxe5 Bxf3
+5. Qxf3 dxe5 6. Bc4 Nf6 7. Qb3 Qe7 8. Nc3 c6 9. Bg5 b5 (9... Qc7) 10. Nxb5 cxb5
+11. Bxb5+ Nbd7 12. O-O-O Rd8 13. Rxd7 Rxd7 14. Rd1 Qe6 15. Bxd7+ Nxd7 16. Qb8+
+Nxb8 17. Rd8# 1-0`
+
+	// Act
+	response := sendAuthenticatedRequest[commands.ImportPGNCommand, commands.ImportPGNResponse](
+		t,
+		ownerCookie,
+		fmt.Sprintf("%s/imported-games", fixture.baseURL),
+		http.MethodPost,
+		commands.ImportPGNCommand{PGN: pgn},
+		func(resp *http.Response) { require.Equal(t, http.StatusCreated, resp.StatusCode) },
+	)
+
+	// Assert
+	require.Len(t, response.GameIDs, 1)
+	url := fmt.Sprintf("%s/imported-games/%s", fixture.baseURL, response.GameIDs[0])
+
+	game := sendAuthenticatedRequest[any, gamesessiondomain.ImportedGame](
+		t,
+		ownerCookie,
+		url,
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+	require.Equal(t, "Opera game", game.Event)
+	require.Equal(t, chess.WhiteWins, game.Result)
+	require.Contains(t, game.PGN, "(9... Qc7)")
+
+	sendAuthenticatedRequest[any, any](
+		t,
+		login(t),
+		url,
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusNotFound, resp.StatusCode) },
+	)
+}
+
+func Test_ImportPGN_Returns_400_For_Illegal_Moves(t *testing.T) {
+	sendAuthenticatedRequest[commands.ImportPGNCommand, any](
+		t,
+		login(t),
+		fmt.Sprintf("%s/imported-games", fixture.baseURL),
+		http.MethodPost,
+		commands.ImportPGNCommand{PGN: "1. e4 e5 2. Ke3 *"},
+		func(resp *http.Response) { require.Equal(t, http.StatusBadRequest, resp.StatusCode) },
+	)
+}
+
+func Test_ImportPGN_Returns_413_For_Too_Large_Body(t *testing.T) {
+	sendAuthenticatedRequest[commands.ImportPGNCommand, any](
+		t,
+		login(t),
+		fmt.Sprintf("%s/imported-games", fixture.baseURL),
+		http.MethodPost,
+		commands.ImportPGNCommand{PGN: strings.Repeat("\n", gamesessiondomain.MaxImportedPGNBytes+1)},
+		func(resp *http.Response) { require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode) },
+	)
+}
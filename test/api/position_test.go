@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/analysis/queries"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func positionURL(fen string) string {
+	return fmt.Sprintf("%s/positions?%s", fixture.baseURL, url.Values{"fen": {fen}}.Encode())
+}
+
+func Test_GetPosition_Describes_Position(t *testing.T) {
+	// Act
+	response := sendAuthenticatedRequest[any, queries.GetPositionResponse](
+		t,
+		login(t),
+		positionURL("rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3"),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Assert
+	require.Equal(t, "white", response.SideToMove)
+	require.True(t, response.InCheck)
+	require.True(t, response.Checkmate)
+	require.Equal(t, chess.BlackWins, response.Result)
+	require.Empty(t, response.LegalMoves)
+	require.Equal(t, queries.PositionMaterial{White: 39, Black: 39, Balance: 0}, response.Material)
+}
+
+func Test_GetPosition_Lists_Legal_Moves(t *testing.T) {
+	response := sendAuthenticatedRequest[any, queries.GetPositionResponse](
+		t,
+		login(t),
+		positionURL(chess.StartingFEN),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	require.Len(t, response.LegalMoves, 20)
+	require.Contains(t, response.LegalMoves, queries.PositionMove{UCI: "g1f3", SAN: "Nf3"})
+}
+
+func Test_GetPosition_Returns_400_Listing_Every_Problem(t *testing.T) {
+	// Arrange
+	httpReq, err := http.NewRequest(http.MethodGet, positionURL("kk6/8/8/8/8/8/8/KP6 x - - 0 1"), nil)
+	require.NoError(t, err)
+	httpReq.AddCookie(&http.Cookie{Name: "chess-session", Value: login(t)})
+
+	// Act
+	httpResp, err := fixture.client.Do(httpReq)
+	require.NoError(t, err)
+	defer func() {
+		_ = httpResp.Body.Close()
+	}()
+
+	// Assert
+	require.Equal(t, http.StatusBadRequest, httpResp.StatusCode)
+
+	body, err := io.ReadAll(httpResp.Body)
+	require.NoError(t, err)
+
+	var response struct {
+		Payload struct {
+			ValidationErrors []string
+		}
+	}
+	require.NoError(t, json.Unmarshal(body, &response))
+	require.Equal(t, []string{"invalid FEN side to move - 'x'"}, response.Payload.ValidationErrors)
+}
+
+func Test_CreateSession_From_Custom_Position(t *testing.T) {
+	// Arrange
+	whiteCookie := login(t)
+	blackCookie := login(t)
+	fen := "4k3/8/8/8/8/8/4P3/4K3 w - - 0 1"
+
+	sessionID := createSessionWithCommand(t, whiteCookie, commands.CreateSessionCommand{InitialFEN: fen})
+
+	sendAuthenticatedRequest[any, commands.JoinSessionResponse](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Act
+	response := sendAuthenticatedRequest[commands.MakeMoveCommand, commands.MakeMoveResponse](
+		t,
+		whiteCookie,
+		fmt.Sprintf("%s/game-sessions/%s/moves", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.MakeMoveCommand{Move: "e2e4"},
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Assert
+	require.Equal(t, "4k3/8/8/8/4P3/8/8/4K3 b - - 0 1", response.FEN)
+}
+
+func Test_CreateSession_Returns_400_For_Invalid_Custom_Position(t *testing.T) {
+	sessionCookie := login(t)
+
+	for _, command := range []commands.CreateSessionCommand{
+		{InitialFEN: "4k3/8/8/8/8/8/8/8 w - - 0 1"},
+		{InitialFEN: "7k/5Q2/6K1/8/8/8/8/8 b - - 0 1"},
+		{
+			InitialFEN:  "4k3/8/8/8/8/8/4P3/4K3 w - - 0 1",
+			Rated:       true,
+			TimeControl: gamesessiondomain.TimeControl{BaseSeconds: 300},
+		},
+	} {
+		command.Name = "teaching position"
+
+		sendAuthenticatedRequest[commands.CreateSessionCommand, any](
+			t,
+			sessionCookie,
+			fmt.Sprintf("%s/game-sessions", fixture.baseURL),
+			http.MethodPost,
+			command,
+			func(resp *http.Response) { require.Equal(t, http.StatusBadRequest, resp.StatusCode) },
+		)
+	}
+}
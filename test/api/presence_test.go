@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/presence/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func getPresence(t *testing.T, cookie string, playerID uuid.UUID) domain.Presence {
+	return sendAuthenticatedRequest[any, domain.Presence](
+		t,
+		cookie,
+		fmt.Sprintf("%s/players/%s/presence", fixture.baseURL, playerID),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+}
+
+func reportPresence(t *testing.T, userID uuid.UUID, expiresAt time.Time) {
+	_, err := tql.Exec(
+		context.Background(),
+		fixture.db,
+		"INSERT INTO user_presence (user_id, instance_id, expires_at) VALUES ($1, $2, $3);",
+		userID,
+		uuid.New(),
+		expiresAt,
+	)
+	require.NoError(t, err)
+}
+
+func Test_Presence_Follows_Requests_And_Games(t *testing.T) {
+	// Arrange
+	observerCookie := login(t)
+	idleID := sessionUserID(t, login(t))
+
+	// Act
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	whiteID := sessionUserID(t, whiteCookie)
+
+	// Assert
+	require.Equal(t, domain.Offline, getPresence(t, observerCookie, idleID).Status, "logging in is not activity")
+
+	require.Eventually(t, func() bool {
+		return getPresence(t, observerCookie, whiteID).Status == domain.Playing
+	}, 15*time.Second, 250*time.Millisecond)
+
+	gameAction(t, blackCookie, sessionID, "resign", http.StatusOK)
+
+	require.Eventually(t, func() bool {
+		return getPresence(t, observerCookie, whiteID).Status == domain.Online
+	}, 15*time.Second, 250*time.Millisecond)
+
+}
+
+func Test_Presence_Expires_With_The_Reports(t *testing.T) {
+	// Arrange
+	observerCookie := login(t)
+	userID := sessionUserID(t, login(t))
+
+	// Act
+	reportPresence(t, userID, time.Now().UTC().Add(-time.Second))
+	expired := getPresence(t, observerCookie, userID)
+
+	reportPresence(t, userID, time.Now().UTC().Add(domain.TTL))
+	reported := getPresence(t, observerCookie, userID)
+
+	// Assert
+	require.Equal(t, domain.Offline, expired.Status, "an instance which stopped reporting does not keep users online")
+	require.Equal(t, domain.Online, reported.Status, "any instance's report keeps users online")
+}
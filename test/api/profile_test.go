@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles/commands"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/profiles/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func getProfile(t *testing.T, cookie string, playerID uuid.UUID) domain.PlayerProfile {
+	return sendAuthenticatedRequest[any, domain.PlayerProfile](
+		t,
+		cookie,
+		fmt.Sprintf("%s/players/%s/profile", fixture.baseURL, playerID),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+}
+
+// sendImage sends the image as the raw request body, which is not JSON.
+func sendImage(t *testing.T, cookie string, url string, method string, body []byte) *http.Response {
+	httpReq, err := http.NewRequest(method, url, bytes.NewReader(body))
+	require.NoError(t, err)
+
+	httpReq.AddCookie(&http.Cookie{Name: "chess-session", Value: cookie})
+
+	httpResp, err := fixture.client.Do(httpReq)
+	require.NoError(t, err)
+
+	return httpResp
+}
+
+func Test_Profile_Counts_Finished_Games(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	blackID := sessionUserID(t, blackCookie)
+
+	// Act
+	playMoves(t, sessionID, whiteCookie, blackCookie, "e2e4", "c7c5")
+	gameAction(t, whiteCookie, sessionID, "resign", http.StatusOK)
+
+	sendAuthenticatedRequest[commands.UpdateProfileCommand, any](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/profile", fixture.baseURL),
+		http.MethodPut,
+		commands.UpdateProfileCommand{DisplayName: " Black ", Bio: "Sicilian player", Country: "hr"},
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+	sendAuthenticatedRequest[commands.UpdateProfileCommand, any](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/profile", fixture.baseURL),
+		http.MethodPut,
+		commands.UpdateProfileCommand{Country: "Croatia"},
+		func(resp *http.Response) { require.Equal(t, http.StatusBadRequest, resp.StatusCode) },
+	)
+
+	profile := getProfile(t, whiteCookie, blackID)
+
+	// Assert
+	require.Equal(t, blackID, profile.UserID)
+	require.NotEmpty(t, profile.Username)
+	require.Equal(t, "Black", profile.DisplayName)
+	require.Equal(t, "HR", profile.Country)
+	require.Nil(t, profile.AvatarUpdatedAt)
+
+	require.Equal(t, domain.Results{Games: 1, Wins: 1}, profile.Stats.Total)
+	require.Equal(t, domain.Results{Games: 1, Wins: 1}, profile.Stats.Black)
+	require.Equal(t, domain.Results{}, profile.Stats.White)
+	require.Equal(t, []domain.TimeControlResults{
+		{TimeControl: "untimed", Results: domain.Results{Games: 1, Wins: 1}},
+	}, profile.Stats.ByTimeControl)
+	require.Equal(t, 1, profile.Stats.LongestStreak)
+
+	require.Len(t, profile.Stats.FavoriteOpenings, 1)
+	require.Equal(t, "Sicilian Defense", profile.Stats.FavoriteOpenings[0].Name)
+	require.Equal(t, "black", profile.Stats.FavoriteOpenings[0].Color)
+}
+
+func Test_Profile_Avatar_Upload_Resizes_The_Image(t *testing.T) {
+	// Arrange
+	cookie := login(t)
+	userID := sessionUserID(t, cookie)
+	avatarURL := fmt.Sprintf("%s/players/%s/avatar", fixture.baseURL, userID)
+	uploadURL := fmt.Sprintf("%s/profile/avatar", fixture.baseURL)
+
+	img := image.NewNRGBA(image.Rect(0, 0, 600, 400))
+	for y := range 400 {
+		for x := range 600 {
+			img.Set(x, y, color.NRGBA{G: 255, A: 255})
+		}
+	}
+
+	var upload bytes.Buffer
+	require.NoError(t, png.Encode(&upload, img))
+
+	resp := sendImage(t, cookie, avatarURL, http.MethodGet, nil)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Act
+	resp = sendImage(t, cookie, uploadURL, http.MethodPut, []byte("not an image"))
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = sendImage(t, cookie, uploadURL, http.MethodPut, upload.Bytes())
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = sendImage(t, login(t), avatarURL, http.MethodGet, nil)
+	avatar, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	require.NoError(t, err)
+
+	// Assert
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+
+	config, err := png.DecodeConfig(bytes.NewReader(avatar))
+	require.NoError(t, err)
+	require.Equal(t, domain.AvatarSize, config.Width)
+	require.Equal(t, domain.AvatarSize, config.Height)
+
+	require.NotNil(t, getProfile(t, cookie, userID).AvatarUpdatedAt)
+
+	resp = sendImage(t, cookie, uploadURL, http.MethodDelete, nil)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = sendImage(t, cookie, avatarURL, http.MethodGet, nil)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type pubSubTestMessage struct {
+	Text string
+}
+
+func startPubSub(t *testing.T) *core.PubSub {
+	pubSub := core.NewPubSub(fixture.db, fixture.databaseURL, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go pubSub.Start(ctx)
+
+	return pubSub
+}
+
+func publish(t *testing.T, topic string, message pubSubTestMessage) {
+	err := core.Tx(context.Background(), fixture.db, func(ctx context.Context, tx *sql.Tx) error {
+		return core.Publish(ctx, tx, topic, message)
+	})
+	require.NoError(t, err)
+}
+
+// receive publishes until the listener, which connects in the background, delivers a message.
+func receive(t *testing.T, subscription *core.Subscription, topic string, message pubSubTestMessage) pubSubTestMessage {
+	timeout := time.After(5 * time.Second)
+
+	for {
+		publish(t, topic, message)
+
+		select {
+		case payload := <-subscription.Messages():
+			var received pubSubTestMessage
+			require.NoError(t, json.Unmarshal(payload, &received))
+			return received
+		case <-time.After(100 * time.Millisecond):
+		case <-timeout:
+			require.FailNow(t, "no message received")
+		}
+	}
+}
+
+func Test_PubSub_Delivers_Message_On_Commit(t *testing.T) {
+	// Arrange
+	pubSub := startPubSub(t)
+	topic := uuid.NewString()
+
+	subscription := pubSub.Subscribe(topic, 64)
+	t.Cleanup(func() { pubSub.Unsubscribe(subscription) })
+
+	// Act
+	received := receive(t, subscription, topic, pubSubTestMessage{Text: "hello"})
+
+	// Assert
+	require.Equal(t, "hello", received.Text)
+}
+
+func Test_PubSub_Delivers_Message_Larger_Than_Notify_Limit(t *testing.T) {
+	// Arrange
+	pubSub := startPubSub(t)
+	topic := uuid.NewString()
+
+	subscription := pubSub.Subscribe(topic, 64)
+	t.Cleanup(func() { pubSub.Unsubscribe(subscription) })
+
+	text := strings.Repeat("a", 20_000)
+
+	// Act
+	received := receive(t, subscription, topic, pubSubTestMessage{Text: text})
+
+	// Assert
+	require.Equal(t, text, received.Text)
+}
+
+func Test_PubSub_Does_Not_Deliver_Rolled_Back_Message(t *testing.T) {
+	// Arrange
+	pubSub := startPubSub(t)
+	topic := uuid.NewString()
+
+	subscription := pubSub.Subscribe(topic, 64)
+	t.Cleanup(func() { pubSub.Unsubscribe(subscription) })
+
+	// Wait for the listener to be connected.
+	receive(t, subscription, topic, pubSubTestMessage{Text: "ready"})
+
+	// Act
+	err := core.Tx(context.Background(), fixture.db, func(ctx context.Context, tx *sql.Tx) error {
+		if err := core.Publish(ctx, tx, topic, pubSubTestMessage{Text: "rolled back"}); err != nil {
+			return err
+		}
+		return context.Canceled
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	// Assert
+	timeout := time.After(500 * time.Millisecond)
+	for {
+		select {
+		case payload := <-subscription.Messages():
+			// Messages published while waiting for the listener can still arrive.
+			require.NotContains(t, string(payload), "rolled back")
+		case <-timeout:
+			return
+		}
+	}
+}
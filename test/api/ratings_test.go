@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	ratingsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/ratings/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createRatedSession(t *testing.T, sessionCookie string, timeControl gamesessiondomain.TimeControl, opts ...responseAssertion) string {
+	var location string
+	if len(opts) == 0 {
+		opts = append(opts, func(resp *http.Response) {
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+			location = resp.Header.Get("Location")
+		})
+	}
+
+	sendAuthenticatedRequest[commands.CreateSessionCommand, any](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s%s", fixture.baseURL, "/game-sessions"),
+		http.MethodPost,
+		commands.CreateSessionCommand{
+			Name:        uuid.New().String(),
+			TimeControl: timeControl,
+			Rated:       true,
+		},
+		opts...,
+	)
+
+	return path.Base(location)
+}
+
+func getPlayerRatings(t *testing.T, sessionCookie string, playerID uuid.UUID) []ratingsdomain.PlayerRating {
+	return sendAuthenticatedRequest[any, []ratingsdomain.PlayerRating](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/players/%s/ratings", fixture.baseURL, playerID),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+}
+
+func Test_Rated_Game_Updates_Ratings_Of_Both_Players(t *testing.T) {
+	// Arrange
+	whiteCookie := login(t)
+	blackCookie := login(t)
+	whiteID, blackID := sessionUserID(t, whiteCookie), sessionUserID(t, blackCookie)
+
+	sessionID := createRatedSession(t, whiteCookie, gamesessiondomain.TimeControl{BaseSeconds: 180, IncrementSeconds: 2})
+
+	sendAuthenticatedRequest[any, commands.JoinSessionResponse](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	movesURL := fmt.Sprintf("%s/game-sessions/%s/moves", fixture.baseURL, sessionID)
+
+	// Act
+	for i, move := range []string{"f2f3", "e7e5", "g2g4", "d8h4"} {
+		cookie := whiteCookie
+		if i%2 == 1 {
+			cookie = blackCookie
+		}
+
+		sendAuthenticatedRequest[commands.MakeMoveCommand, commands.MakeMoveResponse](
+			t,
+			cookie,
+			movesURL,
+			http.MethodPost,
+			commands.MakeMoveCommand{Move: move},
+			func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+		)
+	}
+
+	// Assert
+	whiteRatings := getPlayerRatings(t, whiteCookie, whiteID)
+	blackRatings := getPlayerRatings(t, whiteCookie, blackID)
+
+	require.Len(t, whiteRatings, 1)
+	require.Len(t, blackRatings, 1)
+
+	require.Equal(t, ratingsdomain.Category(gamesessiondomain.Blitz), whiteRatings[0].Category)
+	require.Less(t, whiteRatings[0].Rating, int(ratingsdomain.DefaultRating))
+	require.Greater(t, blackRatings[0].Rating, int(ratingsdomain.DefaultRating))
+	require.Equal(t, 1, whiteRatings[0].Games)
+	require.True(t, whiteRatings[0].Provisional)
+
+	history := sendAuthenticatedRequest[any, []ratingsdomain.RatingChange](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/players/%s/ratings/%s/history", fixture.baseURL, blackID, gamesessiondomain.Blitz),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	require.Len(t, history, 1)
+	require.Equal(t, whiteID, history[0].OpponentID)
+	require.Equal(t, 1.0, history[0].Score)
+}
+
+func Test_CreateSession_Returns_400_When_Rated_Game_Is_Untimed(t *testing.T) {
+	// Arrange
+	cookie := login(t)
+
+	// Act
+	createRatedSession(
+		t,
+		cookie,
+		gamesessiondomain.TimeControl{},
+		// Assert
+		func(resp *http.Response) { require.Equal(t, http.StatusBadRequest, resp.StatusCode) },
+	)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/stretchr/testify/require"
+)
+
+func offerRematch(t *testing.T, sessionCookie string, sessionID string, expectedStatus int) gamesessiondomain.RematchOffer {
+	return sendAuthenticatedRequest[any, gamesessiondomain.RematchOffer](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/offer-rematch", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func acceptRematch(t *testing.T, sessionCookie string, sessionID string, expectedStatus int) commands.AcceptRematchResponse {
+	return sendAuthenticatedRequest[any, commands.AcceptRematchResponse](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/accept-rematch", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func getSeries(t *testing.T, sessionCookie string, sessionID string, expectedStatus int) gamesessiondomain.SeriesScore {
+	return sendAuthenticatedRequest[any, gamesessiondomain.SeriesScore](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/game-sessions/%s/series", fixture.baseURL, sessionID),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func Test_Rematch_Swaps_Colors_And_Tracks_Series_Score(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	whiteID, blackID := sessionUserID(t, whiteCookie), sessionUserID(t, blackCookie)
+
+	offerRematch(t, whiteCookie, sessionID, http.StatusConflict)
+	gameAction(t, blackCookie, sessionID, "resign", http.StatusOK)
+
+	events := openNotificationStream(t, blackCookie, "")
+
+	// Act
+	offer := offerRematch(t, whiteCookie, sessionID, http.StatusCreated)
+	offerRematch(t, blackCookie, sessionID, http.StatusConflict)
+	acceptRematch(t, whiteCookie, sessionID, http.StatusForbidden)
+	rematch := acceptRematch(t, blackCookie, sessionID, http.StatusCreated)
+
+	// Assert
+	event := readNotification(t, events, notificationsdomain.RematchOfferedNotification)
+	var offered notificationsdomain.RematchOfferPayload
+	require.NoError(t, json.Unmarshal([]byte(event.Data), &offered))
+	require.Equal(t, offer.ID, offered.OfferID)
+
+	game := sessionGame(t, rematch.SessionID)
+	require.Equal(t, blackID, game.WhiteID)
+	require.Equal(t, whiteID, game.BlackID)
+	require.Equal(t, rematch.GameID, game.ID)
+
+	acceptRematch(t, blackCookie, sessionID, http.StatusNotFound)
+	offerRematch(t, blackCookie, sessionID, http.StatusConflict)
+
+	playMoves(t, rematch.SessionID, blackCookie, whiteCookie, "f2f3", "e7e5", "g2g4", "d8h4")
+
+	score := getSeries(t, whiteCookie, rematch.SessionID, http.StatusOK)
+	require.Equal(t, rematch.SeriesID, score.SeriesID)
+	require.Equal(t, whiteID, score.Player1ID)
+	require.Equal(t, 2.0, score.Player1Points)
+	require.Equal(t, 0.0, score.Player2Points)
+	require.Equal(t, 2, score.Games)
+
+	acceptRematch(t, whiteCookie, rematch.SessionID, http.StatusNotFound)
+
+	offerRematch(t, blackCookie, rematch.SessionID, http.StatusCreated)
+	next := acceptRematch(t, whiteCookie, rematch.SessionID, http.StatusCreated)
+	require.Equal(t, rematch.SeriesID, next.SeriesID)
+	require.Equal(t, whiteID, sessionGame(t, next.SessionID).WhiteID)
+}
+
+func Test_Declined_Rematch_Notifies_Offerer(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	gameAction(t, whiteCookie, sessionID, "resign", http.StatusOK)
+
+	events := openNotificationStream(t, whiteCookie, "")
+	offerRematch(t, whiteCookie, sessionID, http.StatusCreated)
+
+	// Act
+	gameAction(t, whiteCookie, sessionID, "decline-rematch", http.StatusForbidden)
+	gameAction(t, blackCookie, sessionID, "decline-rematch", http.StatusOK)
+
+	// Assert
+	event := readNotification(t, events, notificationsdomain.RematchAnsweredNotification)
+	var answered notificationsdomain.RematchOfferPayload
+	require.NoError(t, json.Unmarshal([]byte(event.Data), &answered))
+	require.Equal(t, string(gamesessiondomain.OfferDeclined), answered.Status)
+
+	getSeries(t, whiteCookie, sessionID, http.StatusNotFound)
+}
+
+func Test_Unanswered_Rematch_Offer_Expires(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	gameAction(t, blackCookie, sessionID, "resign", http.StatusOK)
+	require.Equal(t, chess.WhiteWins, sessionGame(t, sessionID).Result)
+
+	events := openNotificationStream(t, blackCookie, "")
+	offer := offerRematch(t, whiteCookie, sessionID, http.StatusCreated)
+
+	// Act
+	_, err := tql.Exec(
+		context.Background(),
+		fixture.db,
+		"UPDATE rematch_offer SET expires_at = $1 WHERE id = $2;",
+		time.Now().UTC().Add(-time.Second),
+		offer.ID,
+	)
+	require.NoError(t, err)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		status, err := tql.QueryFirst[string](
+			context.Background(),
+			fixture.db,
+			"SELECT status FROM rematch_offer WHERE id = $1;",
+			offer.ID,
+		)
+		require.NoError(t, err)
+		return status == string(gamesessiondomain.OfferExpired)
+	}, 15*time.Second, 250*time.Millisecond)
+
+	event := readNotification(t, events, notificationsdomain.RematchAnsweredNotification)
+	var expired notificationsdomain.RematchOfferPayload
+	require.NoError(t, json.Unmarshal([]byte(event.Data), &expired))
+	require.Equal(t, offer.ID, expired.OfferID)
+	require.Equal(t, string(gamesessiondomain.OfferExpired), expired.Status)
+
+	acceptRematch(t, blackCookie, sessionID, http.StatusNotFound)
+}
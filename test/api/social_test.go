@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"testing"
+	"time"
+
+	chatdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/chat/domain"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	presencedomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/presence/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/commands"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/social/queries"
+
+	"github.com/eskrenkovic/tql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func sendFriendRequest(t *testing.T, cookie string, recipientID uuid.UUID, expectedStatus int) uuid.UUID {
+	var location string
+	sendAuthenticatedRequest[commands.SendFriendRequestCommand, any](
+		t,
+		cookie,
+		fmt.Sprintf("%s/friend-requests", fixture.baseURL),
+		http.MethodPost,
+		commands.SendFriendRequestCommand{RecipientID: recipientID},
+		func(resp *http.Response) {
+			require.Equal(t, expectedStatus, resp.StatusCode)
+			location = resp.Header.Get("Location")
+		},
+	)
+
+	if expectedStatus != http.StatusCreated {
+		return uuid.Nil
+	}
+
+	return uuid.MustParse(path.Base(location))
+}
+
+func friendRequestAction(t *testing.T, cookie string, requestID uuid.UUID, action string, expectedStatus int) {
+	sendAuthenticatedRequest[any, any](
+		t,
+		cookie,
+		fmt.Sprintf("%s/friend-requests/%s/actions/%s", fixture.baseURL, requestID, action),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func getFriends(t *testing.T, cookie string) []domain.FriendStatus {
+	return sendAuthenticatedRequest[any, []domain.FriendStatus](
+		t,
+		cookie,
+		fmt.Sprintf("%s/friends", fixture.baseURL),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+}
+
+func blockUser(t *testing.T, cookie string, userID uuid.UUID, expectedStatus int) {
+	sendAuthenticatedRequest[commands.BlockUserCommand, any](
+		t,
+		cookie,
+		fmt.Sprintf("%s/blocks", fixture.baseURL),
+		http.MethodPost,
+		commands.BlockUserCommand{UserID: userID},
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func Test_Friend_Requests_Befriend_Users(t *testing.T) {
+	// Arrange
+	senderCookie := login(t)
+	recipientCookie := login(t)
+	senderID := sessionUserID(t, senderCookie)
+	recipientID := sessionUserID(t, recipientCookie)
+
+	events := openNotificationStream(t, recipientCookie, "")
+
+	// Act
+	requestID := sendFriendRequest(t, senderCookie, recipientID, http.StatusCreated)
+	sendFriendRequest(t, recipientCookie, senderID, http.StatusConflict)
+	sendFriendRequest(t, senderCookie, senderID, http.StatusBadRequest)
+
+	event := readNotification(t, events, notificationsdomain.FriendRequestReceivedNotification)
+
+	requests := sendAuthenticatedRequest[any, queries.FriendRequests](
+		t,
+		recipientCookie,
+		fmt.Sprintf("%s/friend-requests", fixture.baseURL),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	friendRequestAction(t, senderCookie, requestID, "accept", http.StatusForbidden)
+	friendRequestAction(t, recipientCookie, requestID, "accept", http.StatusOK)
+	friendRequestAction(t, recipientCookie, requestID, "accept", http.StatusNotFound)
+
+	// Assert
+	var payload notificationsdomain.FriendRequestPayload
+	require.NoError(t, json.Unmarshal([]byte(event.Data), &payload))
+	require.Equal(t, requestID, payload.RequestID)
+	require.Equal(t, senderID, payload.UserID)
+
+	require.Len(t, requests.Received, 1)
+	require.Equal(t, requestID, requests.Received[0].ID)
+	require.Empty(t, requests.Sent)
+
+	require.Eventually(t, func() bool {
+		friends := getFriends(t, senderCookie)
+		require.Len(t, friends, 1)
+		require.Equal(t, recipientID, friends[0].UserID)
+		return friends[0].Status == presencedomain.Online
+	}, 15*time.Second, 250*time.Millisecond, "the recipient made requests")
+
+	sendFriendRequest(t, recipientCookie, senderID, http.StatusConflict)
+}
+
+func Test_Friends_Games_Lists_Ongoing_Public_Games(t *testing.T) {
+	// Arrange
+	userCookie := login(t)
+	sessionID, whiteCookie, _ := startGame(t)
+	whiteID := sessionUserID(t, whiteCookie)
+
+	sendAuthenticatedRequest[commands.FollowUserCommand, any](
+		t,
+		userCookie,
+		fmt.Sprintf("%s/follows", fixture.baseURL),
+		http.MethodPost,
+		commands.FollowUserCommand{UserID: whiteID},
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Act
+	games := sendAuthenticatedRequest[any, []domain.FriendGame](
+		t,
+		userCookie,
+		fmt.Sprintf("%s/friends/games", fixture.baseURL),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Assert
+	require.Len(t, games, 1)
+	require.Equal(t, whiteID, games[0].PlayerID)
+	require.Equal(t, sessionID, games[0].SessionID)
+	require.Equal(t, whiteID, games[0].WhiteID)
+}
+
+func Test_Blocked_Users_Cannot_Invite_Join_Or_Chat(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startGame(t)
+	whiteID := sessionUserID(t, whiteCookie)
+	blackID := sessionUserID(t, blackCookie)
+
+	requestID := sendFriendRequest(t, whiteCookie, blackID, http.StatusCreated)
+
+	// Act
+	blockUser(t, blackCookie, whiteID, http.StatusOK)
+	blockUser(t, blackCookie, blackID, http.StatusBadRequest)
+
+	// Assert
+	friendRequestAction(t, blackCookie, requestID, "accept", http.StatusNotFound)
+	sendFriendRequest(t, whiteCookie, blackID, http.StatusForbidden)
+
+	sendChatMessage(t, whiteCookie, sessionID, chatdomain.PlayersChannel, "hello", http.StatusForbidden)
+
+	newSessionID := createSession(t, whiteCookie, gamesessiondomain.TimeControl{})
+	invite(t, whiteCookie, newSessionID, blackID, func(resp *http.Response) {
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	sendAuthenticatedRequest[any, any](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, newSessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusForbidden, resp.StatusCode) },
+	)
+
+	sendAuthenticatedRequest[any, any](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/blocks/%s", fixture.baseURL, whiteID),
+		http.MethodDelete,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+	sendChatMessage(t, whiteCookie, sessionID, chatdomain.PlayersChannel, "hello", http.StatusCreated)
+}
+
+func Test_Email_Invitations_Between_Blocked_Users_Are_Withdrawn(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	sessionID := createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	email := fmt.Sprintf("%s@tests.com", uuid.NewString())
+	inviteeCookie := loginWithEmail(t, email)
+	blockUser(t, inviteeCookie, sessionUserID(t, ownerCookie), http.StatusOK)
+
+	// Act
+	response := inviteByEmail(t, ownerCookie, sessionID, email, http.StatusAccepted)
+
+	// Assert
+	require.Eventually(t, func() bool {
+		emailInvitation, err := tql.QueryFirst[gamesessiondomain.EmailInvitation](
+			context.Background(),
+			fixture.db,
+			"SELECT * FROM email_invitation WHERE id = $1;",
+			response.EmailInvitationID,
+		)
+		require.NoError(t, err)
+		return emailInvitation.Status == gamesessiondomain.InvitationCancelled
+	}, 15*time.Second, 250*time.Millisecond, "the conversion withdraws the email invitation")
+
+	received := sendAuthenticatedRequest[any, []gamesessiondomain.SessionInvitation](
+		t, inviteeCookie, fmt.Sprintf("%s/invitations/received", fixture.baseURL), http.MethodGet, nil,
+	)
+	require.Empty(t, received)
+}
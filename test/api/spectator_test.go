@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/core"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func spectate(t *testing.T, sessionCookie string, sessionID string, expectedStatus int) commands.SpectateResponse {
+	return sendAuthenticatedRequest[any, commands.SpectateResponse](
+		t,
+		sessionCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/spectate", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func Test_Spectate_Counts_Spectators_In_Lobby(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, _ := startGame(t)
+	spectatorCookie := login(t)
+
+	// Act
+	response := spectate(t, spectatorCookie, sessionID, http.StatusOK)
+	spectate(t, login(t), sessionID, http.StatusOK)
+
+	// Assert
+	require.Equal(t, sessionGameID(t, sessionID), response.GameID)
+	require.Equal(t, 1, response.Spectators)
+
+	lobby := getLobby(t, whiteCookie, url.Values{"status": {"playing"}})
+	require.Contains(t, lobbySessionIDs(lobby), sessionID)
+	for _, session := range lobby {
+		if session.SessionID == sessionID {
+			require.Equal(t, 2, session.SpectatorCount)
+		}
+	}
+
+	sendAuthenticatedRequest[any, any](
+		t,
+		spectatorCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/stop-spectating", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+	require.Equal(t, 2, spectate(t, login(t), sessionID, http.StatusOK).Spectators)
+}
+
+func Test_Spectate_Returns_409_For_Players(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, _ := startGame(t)
+
+	// Act & Assert
+	spectate(t, whiteCookie, sessionID, http.StatusConflict)
+}
+
+func Test_Spectate_Returns_403_For_Private_Sessions(t *testing.T) {
+	// Arrange
+	sessionID, _, _ := startVariantGame(t, commands.CreateSessionCommand{Visibility: gamesessiondomain.Private})
+
+	// Act & Assert
+	spectate(t, login(t), sessionID, http.StatusForbidden)
+
+	sendAuthenticatedRequest[any, any](
+		t,
+		login(t),
+		fmt.Sprintf("%s/game-sessions/%s/live", fixture.baseURL, sessionID),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusForbidden, resp.StatusCode) },
+	)
+}
+
+func Test_Lobby_Returns_400_When_Cursor_Is_From_Another_Status(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+	createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+	createSession(t, ownerCookie, gamesessiondomain.TimeControl{})
+
+	page := getLobbyPage(t, ownerCookie, url.Values{"limit": {"1"}})
+	require.NotEmpty(t, page.NextCursor)
+
+	// Act & Assert
+	getLobbyPage(
+		t,
+		ownerCookie,
+		url.Values{"status": {"playing"}, "cursor": {page.NextCursor}},
+		func(resp *http.Response) { require.Equal(t, http.StatusBadRequest, resp.StatusCode) },
+	)
+}
+
+func Test_GameStream_Holds_Back_Moves_From_Spectators(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, blackCookie := startVariantGame(t, commands.CreateSessionCommand{
+		SpectatorDelay: gamesessiondomain.SpectatorDelay{Moves: 1},
+	})
+	playMoves(t, sessionID, whiteCookie, blackCookie, "e2e4")
+
+	conn := dialGameStream(t, login(t), sessionID, 0)
+	require.Equal(t, string(gamesessiondomain.GameStartedEvent), readStreamMessage(t, conn).Type)
+
+	// Act
+	// Replies are written in order with the events, e4 would come first if it was not held back.
+	require.NoError(t, conn.WriteJSON(map[string]string{"type": "move", "move": "e7e5"}))
+
+	// Assert
+	rejected := readStreamMessage(t, conn)
+	require.Equal(t, "move_rejected", rejected.Type)
+
+	playMoves(t, sessionID, blackCookie, whiteCookie, "e7e5")
+
+	message := readStreamMessage(t, conn)
+	require.Equal(t, string(gamesessiondomain.MoveMadeEvent), message.Type)
+	require.Equal(t, 2, message.Version)
+
+	// The end of the game shows the rest of it.
+	gameAction(t, whiteCookie, sessionID, "resign", http.StatusOK)
+	require.Equal(t, 3, readStreamMessage(t, conn).Version)
+	require.Equal(t, string(gamesessiondomain.GameOverEvent), readStreamMessage(t, conn).Type)
+}
+
+func Test_CreateSession_Returns_400_When_SpectatorDelay_Invalid(t *testing.T) {
+	// Arrange
+	ownerCookie := login(t)
+
+	command := commands.CreateSessionCommand{
+		Name:           "delayed",
+		SpectatorDelay: gamesessiondomain.SpectatorDelay{Seconds: gamesessiondomain.MaxSpectatorDelaySeconds + 1},
+	}
+
+	// Act & Assert
+	sendAuthenticatedRequest[commands.CreateSessionCommand, core.CommandError](
+		t,
+		ownerCookie,
+		fmt.Sprintf("%s/game-sessions", fixture.baseURL),
+		http.MethodPost,
+		command,
+		func(resp *http.Response) { require.Equal(t, http.StatusBadRequest, resp.StatusCode) },
+	)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"testing"
+	"time"
+
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+	notificationsdomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/notifications/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/commands"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/domain"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/tournaments/queries"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createTournament(t *testing.T, cookie string, command commands.CreateTournamentCommand, expectedStatus int) uuid.UUID {
+	var location string
+	sendAuthenticatedRequest[commands.CreateTournamentCommand, any](
+		t,
+		cookie,
+		fmt.Sprintf("%s/tournaments", fixture.baseURL),
+		http.MethodPost,
+		command,
+		func(resp *http.Response) {
+			require.Equal(t, expectedStatus, resp.StatusCode)
+			location = resp.Header.Get("Location")
+		},
+	)
+
+	if expectedStatus != http.StatusCreated {
+		return uuid.Nil
+	}
+
+	tournamentID, err := uuid.Parse(path.Base(location))
+	require.NoError(t, err)
+	return tournamentID
+}
+
+func tournamentAction(t *testing.T, cookie string, tournamentID uuid.UUID, action string, expectedStatus int) {
+	sendAuthenticatedRequest[any, any](
+		t,
+		cookie,
+		fmt.Sprintf("%s/tournaments/%s/actions/%s", fixture.baseURL, tournamentID, action),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, expectedStatus, resp.StatusCode) },
+	)
+}
+
+func getTournament(t *testing.T, cookie string, tournamentID uuid.UUID) queries.TournamentDetails {
+	return sendAuthenticatedRequest[any, queries.TournamentDetails](
+		t,
+		cookie,
+		fmt.Sprintf("%s/tournaments/%s", fixture.baseURL, tournamentID),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+}
+
+func getStandings(t *testing.T, cookie string, tournamentID uuid.UUID) []domain.Standing {
+	return sendAuthenticatedRequest[any, []domain.Standing](
+		t,
+		cookie,
+		fmt.Sprintf("%s/tournaments/%s/standings", fixture.baseURL, tournamentID),
+		http.MethodGet,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+}
+
+// roundPairings waits for the round to be paired.
+func roundPairings(t *testing.T, cookie string, tournamentID uuid.UUID, round int) []domain.Pairing {
+	var pairings []domain.Pairing
+	require.Eventually(t, func() bool {
+		pairings = pairings[:0]
+		for _, pairing := range getTournament(t, cookie, tournamentID).Pairings {
+			if pairing.Round == round {
+				pairings = append(pairings, pairing)
+			}
+		}
+		return len(pairings) > 0
+	}, 15*time.Second, 250*time.Millisecond)
+
+	return pairings
+}
+
+func Test_Round_Robin_Tournament_Is_Played_To_The_End(t *testing.T) {
+	// Arrange
+	cookies := make(map[uuid.UUID]string)
+	for range 3 {
+		cookie := login(t)
+		cookies[sessionUserID(t, cookie)] = cookie
+	}
+
+	var organizerCookie string
+	for _, cookie := range cookies {
+		organizerCookie = cookie
+		break
+	}
+
+	tournamentID := createTournament(t, organizerCookie, commands.CreateTournamentCommand{
+		Name:        uuid.NewString(),
+		Format:      domain.RoundRobin,
+		TimeControl: gamesessiondomain.TimeControl{},
+	}, http.StatusCreated)
+
+	for _, cookie := range cookies {
+		tournamentAction(t, cookie, tournamentID, "register", http.StatusOK)
+	}
+
+	events := openNotificationStream(t, organizerCookie, "")
+
+	// Act
+	tournamentAction(t, organizerCookie, tournamentID, "start", http.StatusOK)
+
+	for round := 1; round <= 3; round++ {
+		pairings := roundPairings(t, organizerCookie, tournamentID, round)
+		require.Len(t, pairings, 2, "round %d", round)
+
+		for _, pairing := range pairings {
+			if pairing.IsBye() {
+				continue
+			}
+
+			// Black resigns every game, so white scores a point.
+			gameAction(t, cookies[*pairing.BlackID], *pairing.SessionID, "resign", http.StatusOK)
+		}
+	}
+
+	// Assert
+	readNotification(t, events, notificationsdomain.TournamentRoundStartedNotification)
+
+	require.Eventually(t, func() bool {
+		return getTournament(t, organizerCookie, tournamentID).Tournament.Status == domain.Finished
+	}, 15*time.Second, 250*time.Millisecond)
+
+	readNotification(t, events, notificationsdomain.TournamentFinishedNotification)
+
+	details := getTournament(t, organizerCookie, tournamentID)
+	require.Equal(t, 3, details.Tournament.Rounds)
+	require.Len(t, details.Players, 3)
+	require.Len(t, details.Pairings, 6)
+
+	standings := getStandings(t, organizerCookie, tournamentID)
+	require.Len(t, standings, 3)
+
+	points := 0.0
+	for i, standing := range standings {
+		require.Equal(t, i+1, standing.Rank)
+		require.Equal(t, 2, standing.Games)
+		points += standing.Points
+	}
+	require.Equal(t, 3.0, points)
+}
+
+func Test_Tournament_Registration_Rules(t *testing.T) {
+	// Arrange
+	organizerCookie := login(t)
+	playerCookie := login(t)
+
+	createTournament(t, organizerCookie, commands.CreateTournamentCommand{
+		Name:   uuid.NewString(),
+		Format: domain.Swiss,
+		Rounds: domain.MaxSwissRounds + 1,
+	}, http.StatusBadRequest)
+
+	tournamentID := createTournament(t, organizerCookie, commands.CreateTournamentCommand{
+		Name:       uuid.NewString(),
+		Format:     domain.Swiss,
+		Rounds:     1,
+		MaxPlayers: 2,
+	}, http.StatusCreated)
+
+	// Act & Assert
+	tournamentAction(t, organizerCookie, tournamentID, "register", http.StatusOK)
+	tournamentAction(t, organizerCookie, tournamentID, "register", http.StatusConflict)
+	tournamentAction(t, organizerCookie, tournamentID, "start", http.StatusConflict)
+
+	tournamentAction(t, playerCookie, tournamentID, "withdraw", http.StatusNotFound)
+	tournamentAction(t, playerCookie, tournamentID, "register", http.StatusOK)
+	tournamentAction(t, login(t), tournamentID, "register", http.StatusConflict)
+
+	tournamentAction(t, playerCookie, tournamentID, "start", http.StatusForbidden)
+	tournamentAction(t, organizerCookie, tournamentID, "start", http.StatusOK)
+	tournamentAction(t, organizerCookie, tournamentID, "start", http.StatusConflict)
+
+	pairings := roundPairings(t, organizerCookie, tournamentID, 1)
+	require.Len(t, pairings, 1)
+	require.NotNil(t, pairings[0].SessionID)
+
+	tournamentAction(t, playerCookie, tournamentID, "withdraw", http.StatusOK)
+	tournamentAction(t, playerCookie, tournamentID, "withdraw", http.StatusNotFound)
+
+	details := getTournament(t, organizerCookie, tournamentID)
+	require.Equal(t, domain.Running, details.Tournament.Status)
+	for _, player := range details.Players {
+		require.Equal(t, player.PlayerID == sessionUserID(t, playerCookie), player.Withdrawn)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/chess"
+	"github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/commands"
+	gamesessiondomain "github.com/eskrenkovic/vertical-slice-go/internal/modules/game-session/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+// startVariantGame starts a game of the session created by the command between two new players.
+func startVariantGame(t *testing.T, command commands.CreateSessionCommand) (sessionID string, whiteCookie string, blackCookie string) {
+	whiteCookie = login(t)
+	blackCookie = login(t)
+
+	sessionID = createSessionWithCommand(t, whiteCookie, command)
+
+	sendAuthenticatedRequest[any, commands.JoinSessionResponse](
+		t,
+		blackCookie,
+		fmt.Sprintf("%s/game-sessions/%s/actions/join", fixture.baseURL, sessionID),
+		http.MethodPut,
+		nil,
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	return sessionID, whiteCookie, blackCookie
+}
+
+func Test_Chess960_Game_Castles_And_Exports_Variant(t *testing.T) {
+	// Arrange
+	index := 0
+	sessionID, whiteCookie, blackCookie := startVariantGame(t, commands.CreateSessionCommand{
+		Variant:       gamesessiondomain.Chess960,
+		Chess960Index: &index,
+	})
+
+	playMoves(t, sessionID, whiteCookie, blackCookie, "f2f4", "f7f5", "f1f3", "f8f6")
+
+	// Act
+	response := sendAuthenticatedRequest[commands.MakeMoveCommand, commands.MakeMoveResponse](
+		t,
+		whiteCookie,
+		fmt.Sprintf("%s/game-sessions/%s/moves", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.MakeMoveCommand{Move: "g1h1"},
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	gameAction(t, blackCookie, sessionID, "resign", http.StatusOK)
+
+	pgn := getPGN(t, whiteCookie, fmt.Sprintf("%s/games/%s/pgn", fixture.baseURL, sessionGameID(t, sessionID)), http.StatusOK)
+
+	// Assert
+	require.Equal(t, "O-O", response.SAN)
+	require.Equal(t, "bbqnn1kr/ppppp1pp/5r2/5p2/5P2/5R2/PPPPP1PP/BBQNNRK1 b k - 3 3", response.FEN)
+	require.Contains(t, pgn, `[Variant "Chess960"]`)
+	require.Contains(t, pgn, `[FEN "bbqnnrkr/pppppppp/8/8/8/8/PPPPPPPP/BBQNNRKR w KQkq - 0 1"]`)
+}
+
+func Test_ThreeCheck_Game_Ends_On_Third_Check(t *testing.T) {
+	// Arrange
+	sessionID, whiteCookie, _ := startVariantGame(t, commands.CreateSessionCommand{
+		Variant:    gamesessiondomain.ThreeCheck,
+		InitialFEN: "4k3/8/8/8/8/8/8/R3K2R w - - 0 1 +2+0",
+	})
+
+	// Act
+	response := sendAuthenticatedRequest[commands.MakeMoveCommand, commands.MakeMoveResponse](
+		t,
+		whiteCookie,
+		fmt.Sprintf("%s/game-sessions/%s/moves", fixture.baseURL, sessionID),
+		http.MethodPost,
+		commands.MakeMoveCommand{Move: "h1h8"},
+		func(resp *http.Response) { require.Equal(t, http.StatusOK, resp.StatusCode) },
+	)
+
+	// Assert
+	require.Equal(t, gamesessiondomain.GameEnded, response.Status)
+	require.Equal(t, chess.WhiteWins, response.Result)
+}
+
+func Test_CreateSession_Returns_400_For_Invalid_Variant_Settings(t *testing.T) {
+	sessionCookie := login(t)
+	index := 518
+	outOfRange := chess.Chess960Positions
+
+	for _, command := range []commands.CreateSessionCommand{
+		{Variant: "crazyhouse"},
+		{Chess960Index: &index},
+		{Variant: gamesessiondomain.Chess960, Chess960Index: &outOfRange},
+		{Variant: gamesessiondomain.KingOfTheHill, InitialFEN: "4k3/8/8/8/3K4/8/8/8 w - - 0 1"},
+	} {
+		command.Name = "variant"
+
+		sendAuthenticatedRequest[commands.CreateSessionCommand, any](
+			t,
+			sessionCookie,
+			fmt.Sprintf("%s/game-sessions", fixture.baseURL),
+			http.MethodPost,
+			command,
+			func(resp *http.Response) { require.Equal(t, http.StatusBadRequest, resp.StatusCode) },
+		)
+	}
+}